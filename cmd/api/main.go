@@ -1,18 +1,34 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
 	"github.com/AnggaKay/ojek-kampus-backend/internal/handler"
 	"github.com/AnggaKay/ojek-kampus-backend/internal/middleware"
 	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
 	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/cancellation"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/clock"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/config"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/dispatch"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/jobqueue"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/mailer"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/payment"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/push"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/realtime"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/schedule"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/storage"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/webhook"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/whatsapp"
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
@@ -48,14 +64,91 @@ func main() {
 	fileStorage := storage.NewLocalStorage(constants.UploadDirectory)
 	logger.Log.Info().Str("upload_dir", constants.UploadDirectory).Msg("File storage initialized")
 
-	// Initialize WhatsApp client
-	whatsappClient := whatsapp.NewWhatsAppClient(
-		cfg.WhatsApp.InstanceID,
-		cfg.WhatsApp.APIToken,
-		cfg.WhatsApp.BaseURL,
-		cfg.WhatsApp.SenderNumber,
-	)
-	logger.Log.Info().Msg("WhatsApp client initialized")
+	// Initialize WhatsApp provider. The fake provider (DEV_ROUTES only) keeps sent messages
+	// in memory, readable by admins under /api/dev/whatsapp; incoming messages and delivery
+	// updates are posted to the webhook by hand.
+	var whatsappProvider whatsapp.Provider
+	var whatsappFake *whatsapp.FakeProvider
+	if cfg.WhatsApp.Provider == "ultramsg" {
+		whatsappClient := whatsapp.NewWhatsAppClient(
+			cfg.WhatsApp.InstanceID,
+			cfg.WhatsApp.APIToken,
+			cfg.WhatsApp.BaseURL,
+			cfg.WhatsApp.SenderNumber,
+		)
+		whatsappProvider = whatsapp.NewUltramsgProvider(whatsappClient, cfg.WhatsApp.WebhookToken)
+		if cfg.WhatsApp.WebhookToken == "" {
+			logger.Log.Warn().Msg("WHATSAPP_WEBHOOK_TOKEN is not set: the WhatsApp webhook rejects all calls, so delivery updates and relayed replies are not received")
+		}
+	} else {
+		webhookToken := cfg.WhatsApp.WebhookToken
+		if webhookToken == "" {
+			webhookToken = "dev-webhook-token"
+		}
+		number := cfg.WhatsApp.SenderNumber
+		if number == "" {
+			number = "+6280000000000"
+		}
+		whatsappFake = whatsapp.NewFakeProvider(number, webhookToken)
+		whatsappProvider = whatsappFake
+	}
+	logger.Log.Info().Str("provider", whatsappProvider.Name()).Msg("WhatsApp provider initialized")
+
+	// Initialize mailer. The fake transport runs an in-process SMTP server, so emails go
+	// through the real SMTP client and can be read back by admins under /api/dev/mailbox
+	// (DEV_ROUTES only).
+	var emailMailer mailer.Mailer = mailer.NewLogMailer()
+	var mailFake *mailer.FakeServer
+	switch cfg.Mail.Transport {
+	case "smtp", "fake":
+		host, port := cfg.Mail.SMTPHost, cfg.Mail.SMTPPort
+		if cfg.Mail.Transport == "fake" {
+			mailFake = mailer.NewFakeServer()
+			if err := mailFake.Start(cfg.Mail.FakeSMTPAddr); err != nil {
+				logger.Log.Fatal().Err(err).Str("addr", cfg.Mail.FakeSMTPAddr).Msg("Failed to start fake SMTP server")
+			}
+			defer mailFake.Stop()
+
+			fakeHost, fakePort, _ := net.SplitHostPort(mailFake.Addr())
+			host = fakeHost
+			port, _ = strconv.Atoi(fakePort)
+		}
+		smtpMailer, err := mailer.NewSMTPMailer(host, port, cfg.Mail.SMTPUsername, cfg.Mail.SMTPPassword, cfg.Mail.From)
+		if err != nil {
+			logger.Log.Fatal().Err(err).Msg("Failed to initialize SMTP mailer")
+		}
+		emailMailer = smtpMailer
+	}
+	logger.Log.Info().Str("transport", cfg.Mail.Transport).Msg("Mailer initialized")
+
+	// Initialize push notifier (FCM when credentials are configured)
+	var pushNotifier push.Notifier = push.NewLogNotifier()
+	if cfg.Push.FCMCredentialsFile != "" {
+		fcmNotifier, err := push.NewFCMNotifierFromFile(cfg.Push.FCMCredentialsFile)
+		if err != nil {
+			logger.Log.Fatal().Err(err).Msg("Failed to initialize FCM notifier")
+		}
+		pushNotifier = fcmNotifier
+		logger.Log.Info().Msg("FCM push notifier initialized")
+	}
+
+	// Initialize payment gateway. The mock gateway (DEV_ROUTES only) speaks the same API
+	// in-process, served under /api/dev/payment-gateway, and notifies this server's webhook
+	// endpoint; admins settle charges with POST /api/dev/payments/:reference/pay.
+	var paymentProvider payment.Provider
+	var paymentMock *payment.MockServer
+	if cfg.Payment.Provider == "midtrans" {
+		paymentProvider = payment.NewMidtransProvider(cfg.Payment.ServerKey, cfg.Payment.BaseURL)
+	} else {
+		serverKey := cfg.Payment.ServerKey
+		if serverKey == "" {
+			serverKey = "mock-server-key"
+		}
+		localURL := "http://localhost:" + cfg.Server.Port
+		paymentMock = payment.NewMockServer(serverKey, localURL+"/api/payments/notifications")
+		paymentProvider = payment.NewMidtransProvider(serverKey, localURL+"/api/dev/payment-gateway")
+	}
+	logger.Log.Info().Str("provider", cfg.Payment.Provider).Msg("Payment gateway initialized")
 
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(db)
@@ -63,11 +156,162 @@ func main() {
 	passengerRepo := repository.NewPassengerRepository(db)
 	driverRepo := repository.NewDriverRepository(db)
 	otpRepo := repository.NewOTPRepository(db)
+	driverProfileChangeRepo := repository.NewDriverProfileChangeRepository(db)
+	emailChangeRepo := repository.NewEmailChangeRepository(db)
+	deviceTokenRepo := repository.NewDeviceTokenRepository(db)
+	orderRepo := repository.NewOrderRepository(db)
+	dispatchOfferRepo := repository.NewDispatchOfferRepository(db)
+	ratingRepo := repository.NewRatingRepository(db)
+	payoutRepo := repository.NewPayoutRepository(db)
+	paymentChargeRepo := repository.NewPaymentChargeRepository(db)
+	promoRepo := repository.NewPromoRepository(db)
+	campusDomainRepo := repository.NewCampusDomainRepository(db)
+	documentExpiryRepo := repository.NewDriverDocumentExpiryRepository(db)
+	zoneRepo := repository.NewZoneRepository(db)
+	pickupPointRepo := repository.NewPickupPointRepository(db)
+	cancellationRepo := repository.NewCancellationRepository(db)
+	tripRouteRepo := repository.NewTripRouteRepository(db)
+	trustedContactRepo := repository.NewTrustedContactRepository(db)
+	sosIncidentRepo := repository.NewSOSIncidentRepository(db)
+	trackingLinkRepo := repository.NewTrackingLinkRepository(db)
+	chatRepo := repository.NewChatRepository(db)
+	relaySessionRepo := repository.NewRelaySessionRepository(db)
+	whatsappMessageRepo := repository.NewWhatsAppMessageRepository(db)
+
+	// Initialize dispatch engine (defaults overridable via DISPATCH_* env)
+	dispatchConfig := dispatch.DefaultConfig()
+	if cfg.Dispatch.OfferTimeoutSeconds > 0 {
+		dispatchConfig.OfferTimeout = time.Duration(cfg.Dispatch.OfferTimeoutSeconds) * time.Second
+	}
+	if cfg.Dispatch.MaxRadiusKm > 0 {
+		dispatchConfig.MaxRadiusKm = cfg.Dispatch.MaxRadiusKm
+	}
+	if cfg.Dispatch.MaxAttempts > 0 {
+		dispatchConfig.MaxAttempts = cfg.Dispatch.MaxAttempts
+	}
+	// Scheduled ride policy (defaults overridable via SCHEDULED_* env)
+	schedulePolicy := schedule.DefaultPolicy()
+	if cfg.Schedule.MinLeadMinutes > 0 {
+		schedulePolicy.MinLead = time.Duration(cfg.Schedule.MinLeadMinutes) * time.Minute
+	}
+	if cfg.Schedule.MaxLeadDays > 0 {
+		schedulePolicy.MaxLead = time.Duration(cfg.Schedule.MaxLeadDays) * 24 * time.Hour
+	}
+	if cfg.Schedule.DispatchLeadMinutes > 0 {
+		schedulePolicy.DispatchLead = time.Duration(cfg.Schedule.DispatchLeadMinutes) * time.Minute
+	}
+	if cfg.Schedule.ReminderLeadMinutes > 0 {
+		schedulePolicy.ReminderLead = time.Duration(cfg.Schedule.ReminderLeadMinutes) * time.Minute
+	}
+	if cfg.Schedule.FreeCancelMinutes > 0 {
+		schedulePolicy.FreeCancelLead = time.Duration(cfg.Schedule.FreeCancelMinutes) * time.Minute
+	}
+	if cfg.Schedule.MaxPending > 0 {
+		schedulePolicy.MaxPending = cfg.Schedule.MaxPending
+	}
+	// Cancellation policy (defaults overridable via CANCEL_* env)
+	cancelPolicy := cancellation.DefaultPolicy()
+	if cfg.Cancel.FreeWindowMinutes > 0 {
+		cancelPolicy.FreeWindow = time.Duration(cfg.Cancel.FreeWindowMinutes) * time.Minute
+	}
+	if cfg.Cancel.AssignedFee > 0 {
+		cancelPolicy.AssignedFee = int64(cfg.Cancel.AssignedFee)
+	}
+	if cfg.Cancel.ArrivedFee > 0 {
+		cancelPolicy.ArrivedFee = int64(cfg.Cancel.ArrivedFee)
+	}
+	if cfg.Cancel.NoShowWaitMinutes > 0 {
+		cancelPolicy.NoShowWait = time.Duration(cfg.Cancel.NoShowWaitMinutes) * time.Minute
+	}
+	if cfg.Cancel.RateWindowDays > 0 {
+		cancelPolicy.RateWindow = time.Duration(cfg.Cancel.RateWindowDays) * 24 * time.Hour
+	}
+	if cfg.Cancel.MinOrders > 0 {
+		cancelPolicy.MinOrders = cfg.Cancel.MinOrders
+	}
+	if cfg.Cancel.PassengerMaxRate > 0 {
+		cancelPolicy.PassengerMaxRate = cfg.Cancel.PassengerMaxRate
+	}
+	if cfg.Cancel.DriverMaxRate > 0 {
+		cancelPolicy.DriverMaxRate = cfg.Cancel.DriverMaxRate
+	}
+	if cfg.Cancel.PassengerCooldownMinutes > 0 {
+		cancelPolicy.PassengerCooldown = time.Duration(cfg.Cancel.PassengerCooldownMinutes) * time.Minute
+	}
+	if cfg.Cancel.DriverCooldownMinutes > 0 {
+		cancelPolicy.DriverCooldown = time.Duration(cfg.Cancel.DriverCooldownMinutes) * time.Minute
+	}
+	// Initialize realtime broker (LISTEN/NOTIFY fan-out across replicas)
+	realtimeBroker := realtime.NewPostgresBroker(db, realtime.NewHub(), constants.RealtimeEventRetention, constants.RealtimeEventMaxRows)
+	realtimeBroker.Start(context.Background())
+	defer realtimeBroker.Stop()
+
+	systemClock := clock.Real{}
+	dispatchEngine := dispatch.NewEngine(dispatchConfig, systemClock)
 
 	// Initialize services
+	campusService := service.NewCampusService(db, campusDomainRepo, userRepo, cfg.Campus.RequiredForOrders, cfg.Campus.RequiredForDrivers)
+	geofenceService := service.NewGeofenceService(zoneRepo, pickupPointRepo, driverRepo)
 	authService := service.NewAuthService(userRepo, passengerRepo, driverRepo, refreshTokenRepo)
-	driverService := service.NewDriverService(userRepo, driverRepo, refreshTokenRepo, fileStorage)
-	otpService := service.NewOTPService(otpRepo, whatsappClient)
+	whatsAppService := service.NewWhatsAppService(db, systemClock, whatsappProvider, whatsappMessageRepo)
+	otpService := service.NewOTPService(db, otpRepo, whatsappMessageRepo, whatsAppService)
+	passengerService := service.NewPassengerService(passengerRepo, deviceTokenRepo, fileStorage)
+	notificationService := service.NewNotificationService(db, userRepo, deviceTokenRepo, pushNotifier, whatsAppService)
+	accountService := service.NewAccountService(db, userRepo, emailChangeRepo, campusDomainRepo, emailMailer, cfg.JWT.Secret, cfg.Mail.LinkBaseURL)
+	webhookService := service.NewWebhookService(cfg.Jobs.WebhookURLs, webhook.NewSender(cfg.Jobs.WebhookSecret))
+	jobService := service.NewJobService(db)
+	walletService := service.NewWalletService(db, systemClock, userRepo, payoutRepo)
+	promoService := service.NewPromoService(systemClock, promoRepo, userRepo, passengerRepo)
+	tripRouteService := service.NewTripRouteService(db, tripRouteRepo, orderRepo)
+	cancellationService := service.NewCancellationService(db, systemClock, cancelPolicy, schedulePolicy, cancellationRepo, orderRepo, dispatchOfferRepo, driverRepo, passengerRepo, notificationService, walletService, promoService, realtimeBroker)
+	driverService := service.NewDriverService(db, userRepo, driverRepo, driverProfileChangeRepo, refreshTokenRepo, orderRepo, fileStorage, campusService, geofenceService, cancellationService, tripRouteService, realtimeBroker)
+	scheduledRideService := service.NewScheduledRideService(db, systemClock, schedulePolicy, orderRepo, driverRepo, dispatchOfferRepo, userRepo, notificationService, promoService, campusService, cancellationService, realtimeBroker)
+	orderService := service.NewOrderService(db, orderRepo, driverRepo, passengerRepo, userRepo, notificationService, walletService, promoService, campusService, geofenceService, scheduledRideService, cancellationService, tripRouteService, realtimeBroker)
+	realtimeService := service.NewRealtimeService(orderRepo)
+	dispatchService := service.NewDispatchService(db, dispatchEngine, systemClock, orderRepo, dispatchOfferRepo, driverRepo, userRepo, notificationService, promoService, realtimeBroker)
+	ratingService := service.NewRatingService(db, systemClock, orderRepo, ratingRepo)
+	driverReviewService := service.NewDriverReviewService(db, systemClock, driverRepo, documentExpiryRepo, notificationService)
+	safetyService := service.NewSafetyService(db, systemClock, cfg.Safety.SecurityPhones, trustedContactRepo, sosIncidentRepo, orderRepo, driverRepo, userRepo, otpService, notificationService)
+	trackingService := service.NewTrackingService(db, systemClock, cfg.Mail.LinkBaseURL, trackingLinkRepo, orderRepo, driverRepo, userRepo)
+	chatService := service.NewChatService(db, systemClock, time.Duration(cfg.Chat.RetentionDays)*24*time.Hour, chatRepo, orderRepo, realtimeBroker)
+	relayService := service.NewRelayService(db, systemClock, whatsAppService, relaySessionRepo, orderRepo, userRepo)
+	paymentService := service.NewPaymentService(db, systemClock, paymentProvider, paymentChargeRepo, userRepo, walletService, notificationService)
+
+	// Initialize background job worker and outbox relay
+	jobWorker := jobqueue.NewWorker(db, jobqueue.WorkerConfig{
+		Concurrency: cfg.Jobs.Workers,
+		Retention:   constants.JobRetention,
+	})
+	jobWorker.Register(constants.JobTypeSendOTP, otpService.HandleSendOTPJob)
+	jobWorker.Register(constants.JobTypeRedeliverOTP, otpService.HandleRedeliverOTPJob)
+	jobWorker.Register(constants.JobTypePushFanOut, notificationService.HandleFanOutJob)
+	jobWorker.Register(constants.JobTypePushSend, notificationService.HandleSendJob)
+	jobWorker.Register(constants.JobTypeWebhookDeliver, webhookService.HandleDeliverJob)
+	jobWorker.Register(constants.JobTypeDispatchOrder, dispatchService.HandleDispatchJob)
+	jobWorker.Register(constants.JobTypeDispatchOfferTimeout, dispatchService.HandleOfferTimeoutJob)
+	jobWorker.Register(constants.JobTypePaymentCheckStatus, paymentService.HandleCheckStatusJob)
+	jobWorker.Register(constants.JobTypeWhatsAppNotify, notificationService.HandleWhatsAppJob)
+	jobWorker.Register(constants.JobTypeWhatsAppSend, notificationService.HandleWhatsAppSendJob)
+	jobWorker.Register(constants.JobTypeDocumentExpiryScan, driverReviewService.HandleExpiryScanJob)
+	jobWorker.Register(constants.JobTypeScheduledDispatch, scheduledRideService.HandleDispatchJob)
+	jobWorker.Register(constants.JobTypeScheduledReminder, scheduledRideService.HandleReminderJob)
+	jobWorker.Register(constants.JobTypeChatPurge, chatService.HandlePurgeJob)
+	jobWorker.Register(constants.JobTypeRelayForward, relayService.HandleForwardJob)
+	jobWorker.Start(context.Background())
+	defer jobWorker.Stop()
+
+	// Daily scans reschedule themselves; queue today's in case the chain was broken
+	if err := driverReviewService.ScheduleExpiryScan(context.Background()); err != nil {
+		logger.Log.Error().Err(err).Msg("Failed to schedule document expiry scan")
+	}
+	if err := chatService.SchedulePurge(context.Background()); err != nil {
+		logger.Log.Error().Err(err).Msg("Failed to schedule chat retention purge")
+	}
+
+	outboxRelay := jobqueue.NewRelay(db, time.Second, 100)
+	outboxRelay.OnAll(webhookService.RouteEvent)
+	outboxRelay.Start(context.Background())
+	defer outboxRelay.Stop()
 
 	// Initialize handlers
 	healthHandler := handler.NewHealthHandler()
@@ -75,6 +319,29 @@ func main() {
 	driverHandler := handler.NewDriverHandler(driverService)
 	documentHandler := handler.NewDocumentHandler(constants.UploadDirectory)
 	otpHandler := handler.NewOTPHandler(otpService)
+	passengerHandler := handler.NewPassengerHandler(passengerService)
+	accountHandler := handler.NewAccountHandler(accountService)
+	notificationHandler := handler.NewNotificationHandler(notificationService)
+	jobHandler := handler.NewJobHandler(jobService)
+	providerHandler := handler.NewProviderHandler()
+	devHandler := handler.NewDevHandler(paymentMock)
+	orderHandler := handler.NewOrderHandler(orderService, dispatchService)
+	scheduledRideHandler := handler.NewScheduledRideHandler(scheduledRideService)
+	cancellationHandler := handler.NewCancellationHandler(cancellationService)
+	tripRouteHandler := handler.NewTripRouteHandler(tripRouteService)
+	safetyHandler := handler.NewSafetyHandler(safetyService)
+	trackingHandler := handler.NewTrackingHandler(trackingService)
+	chatHandler := handler.NewChatHandler(chatService)
+	relayHandler := handler.NewRelayHandler(relayService)
+	whatsAppHandler := handler.NewWhatsAppHandler(whatsAppService)
+	realtimeHandler := handler.NewRealtimeHandler(realtimeService, realtimeBroker)
+	ratingHandler := handler.NewRatingHandler(ratingService)
+	walletHandler := handler.NewWalletHandler(walletService)
+	paymentHandler := handler.NewPaymentHandler(paymentService)
+	promoHandler := handler.NewPromoHandler(promoService)
+	campusHandler := handler.NewCampusHandler(campusService)
+	driverReviewHandler := handler.NewDriverReviewHandler(driverReviewService)
+	geofenceHandler := handler.NewGeofenceHandler(geofenceService)
 
 	// Initialize Echo
 	e := echo.New()
@@ -83,10 +350,15 @@ func main() {
 	// Set custom validator
 	e.Validator = middleware.NewValidator()
 
+	// Render all handler/middleware errors as dto.Response
+	e.HTTPErrorHandler = middleware.ErrorHandler
+
 	// Global middlewares
-	e.Use(echoMiddleware.Logger())
+	e.Use(echoMiddleware.RequestID())
+	e.Use(middleware.RequestLogger())
 	e.Use(echoMiddleware.Recover())
 	e.Use(middleware.CORS())
+	e.Use(middleware.Locale())
 
 	// Routes
 	// Health check
@@ -106,6 +378,7 @@ func main() {
 	auth.POST("/login", authHandler.Login)
 	auth.POST("/refresh", authHandler.RefreshToken)
 	auth.POST("/logout", authHandler.Logout)
+	auth.GET("/email/verify", accountHandler.VerifyEmailLink)
 
 	// Protected routes
 	authProtected := api.Group("/auth")
@@ -117,6 +390,175 @@ func main() {
 	documents.Use(middleware.JWTAuth())
 	documents.GET("/:type/:filename", documentHandler.GetDocument)
 
+	// Avatar routes (protected - any authenticated user)
+	avatars := api.Group("/avatars")
+	avatars.Use(middleware.JWTAuth())
+	avatars.GET("/:namespace/:owner_id/:filename", documentHandler.GetAvatar)
+
+	// Account routes (protected - all roles)
+	account := api.Group("/account")
+	account.Use(middleware.JWTAuth())
+	account.PATCH("", accountHandler.UpdateAccount)
+	account.POST("/email/verification", accountHandler.SendEmailVerification)
+	account.POST("/email/verify", accountHandler.VerifyEmailChange)
+	account.POST("/devices", notificationHandler.RegisterDevice)
+	account.DELETE("/devices", notificationHandler.UnregisterDevice)
+
+	// Realtime WebSocket (protected - token via Authorization header or access_token query)
+	api.GET("/ws", realtimeHandler.Connect, middleware.JWTAuth())
+	// SSE fallback for networks that drop WebSockets (same token rules, same event ids)
+	api.GET("/events", realtimeHandler.Stream, middleware.JWTAuth())
+
+	// Order routes (passenger or assigned driver)
+	orders := api.Group("/orders")
+	orders.Use(middleware.JWTAuth())
+	orders.GET("/:id", orderHandler.GetOrder)
+	orders.GET("/:id/events", realtimeHandler.StreamOrder)
+	orders.POST("/:id/rating", ratingHandler.RateOrder)
+	orders.GET("/:id/rating", ratingHandler.GetOrderRatings)
+	orders.GET("/:id/route", tripRouteHandler.GetRoute)
+	orders.POST("/:id/share", safetyHandler.ShareTrip)
+	orders.POST("/:id/sos", safetyHandler.RaiseSOS)
+	orders.GET("/:id/chat", chatHandler.ListMessages)
+	orders.POST("/:id/chat", chatHandler.SendMessage)
+	orders.POST("/:id/chat/read", chatHandler.MarkRead)
+	orders.POST("/:id/contact", relayHandler.OpenContact)
+
+	// Trusted contacts alerted on SOS (passengers and drivers)
+	contacts := api.Group("/trusted-contacts")
+	contacts.Use(middleware.JWTAuth(), middleware.RoleGuard(string(entity.RolePassenger), string(entity.RoleDriver)))
+	contacts.GET("", safetyHandler.ListContacts)
+	contacts.POST("", safetyHandler.AddContact)
+	contacts.POST("/:id/resend-otp", safetyHandler.ResendContactOTP)
+	contacts.POST("/:id/verify", safetyHandler.VerifyContact)
+	contacts.DELETE("/:id", safetyHandler.DeleteContact)
+
+	// Wallet routes (passenger wallet or driver earnings)
+	wallet := api.Group("/wallet")
+	wallet.Use(middleware.JWTAuth())
+	wallet.GET("", walletHandler.GetWallet)
+	wallet.GET("/entries", walletHandler.GetStatement)
+
+	// Trip tracking links (public - the token is the credential, rate limited per IP)
+	api.GET("/track/:token", trackingHandler.GetTrackedTrip, middleware.RateLimit(120, time.Minute))
+
+	// Payment gateway webhook (public - authenticated by the notification signature)
+	api.POST("/payments/notifications", paymentHandler.Notification)
+
+	// WhatsApp provider webhook (public - authenticated by the webhook token)
+	api.POST("/whatsapp/webhook", whatsAppHandler.Webhook)
+
+	// Fake provider inspection routes (admin, only with DEV_ROUTES=true)
+	if cfg.Server.DevRoutes {
+		dev := api.Group("/dev")
+		dev.Use(middleware.JWTAuth(), middleware.RoleGuard(string(entity.RoleAdmin)))
+		if whatsappFake != nil {
+			dev.GET("/whatsapp", echo.WrapHandler(whatsappFake))
+		}
+		if mailFake != nil {
+			dev.GET("/mailbox", echo.WrapHandler(mailFake))
+		}
+		if paymentMock != nil {
+			dev.POST("/payments/:reference/pay", devHandler.PayCharge)
+			// The gateway API itself is called by this server's payment provider and is
+			// authenticated with the server key instead
+			api.Any("/dev/payment-gateway/*", echo.WrapHandler(http.StripPrefix("/api/dev/payment-gateway", paymentMock)))
+		}
+	}
+
+	// Rating tags for the caller's role
+	api.GET("/ratings/tags", ratingHandler.GetRatingTags, middleware.JWTAuth())
+
+	// Cancellation reason codes for the caller's role
+	api.GET("/cancellation-reasons", cancellationHandler.GetReasons, middleware.JWTAuth())
+
+	// Chat quick replies for the caller's role
+	api.GET("/chat/quick-replies", chatHandler.GetQuickReplies, middleware.JWTAuth())
+
+	// Official pickup points riders can choose from
+	api.GET("/pickup-points", geofenceHandler.ListPickupPoints, middleware.JWTAuth())
+
+	// Passenger self-service routes
+	passenger := api.Group("/passenger")
+	passenger.Use(middleware.JWTAuth(), middleware.RoleGuard(string(entity.RolePassenger)))
+	passenger.GET("/profile", passengerHandler.GetProfile)
+	passenger.PATCH("/profile", passengerHandler.UpdateProfile)
+	passenger.PUT("/profile/picture", passengerHandler.UploadProfilePicture)
+	passenger.POST("/orders/estimate", orderHandler.EstimateFare)
+	passenger.POST("/orders", orderHandler.CreateOrder)
+	passenger.GET("/orders/scheduled", scheduledRideHandler.ListPassengerRides)
+	passenger.POST("/orders/:id/cancel", cancellationHandler.CancelByPassenger)
+	passenger.POST("/orders/:id/tracking-links", trackingHandler.CreateLink)
+	passenger.GET("/orders/:id/tracking-links", trackingHandler.ListLinks)
+	passenger.DELETE("/orders/:id/tracking-links/:link_id", trackingHandler.RevokeLink)
+	passenger.POST("/wallet/topups", paymentHandler.CreateTopUp)
+	passenger.GET("/wallet/topups", paymentHandler.ListTopUps)
+	passenger.GET("/wallet/topups/:id", paymentHandler.GetTopUp)
+
+	// Driver self-service routes
+	driver := api.Group("/driver")
+	driver.Use(middleware.JWTAuth(), middleware.RoleGuard(string(entity.RoleDriver)))
+	driver.PATCH("/profile", driverHandler.UpdateProfile)
+	driver.PUT("/profile/picture", driverHandler.UploadProfilePicture)
+	driver.PUT("/location", driverHandler.UpdateLocation)
+	driver.PUT("/status", driverHandler.UpdateStatus)
+	driver.GET("/offers/current", orderHandler.GetCurrentOffer)
+	driver.POST("/offers/:id/accept", orderHandler.AcceptOffer)
+	driver.POST("/offers/:id/decline", orderHandler.DeclineOffer)
+	driver.POST("/orders/:id/arrive", orderHandler.ArriveAtPickup)
+	driver.POST("/orders/:id/start", orderHandler.StartTrip)
+	driver.POST("/orders/:id/complete", orderHandler.CompleteTrip)
+	driver.POST("/orders/:id/cancel", cancellationHandler.CancelByDriver)
+	driver.POST("/payouts", walletHandler.RequestPayout)
+	driver.GET("/payouts", walletHandler.ListDriverPayouts)
+	driver.GET("/scheduled-orders", scheduledRideHandler.ListOpenRides)
+	driver.POST("/scheduled-orders/:id/accept", scheduledRideHandler.PreAccept)
+	driver.POST("/scheduled-orders/:id/withdraw", scheduledRideHandler.Withdraw)
+	driver.GET("/agenda", scheduledRideHandler.ListAgenda)
+
+	// Admin routes
+	admin := api.Group("/admin")
+	admin.Use(middleware.JWTAuth(), middleware.RoleGuard(string(entity.RoleAdmin)))
+	admin.POST("/drivers/:id/review", driverReviewHandler.ReviewDriver)
+	admin.GET("/drivers/document-expiries", driverReviewHandler.ListExpiringDocuments)
+	admin.GET("/drivers/outside-area", geofenceHandler.ListDriversOutsideArea)
+	admin.GET("/drivers/:id/profile-changes", driverHandler.GetProfileChanges)
+	admin.GET("/drivers/flagged", ratingHandler.ListFlaggedDrivers)
+	admin.DELETE("/drivers/:id/rating-flag", ratingHandler.ClearDriverFlag)
+	admin.GET("/jobs/dead", jobHandler.ListDeadJobs)
+	admin.POST("/jobs/:id/retry", jobHandler.RetryJob)
+	admin.GET("/providers/metrics", providerHandler.GetMetrics)
+	admin.GET("/orders/:id/offers", orderHandler.ListOrderOffers)
+	admin.POST("/orders/:id/route/rebuild", tripRouteHandler.RebuildRoute)
+	admin.GET("/orders/:id/chat", chatHandler.ListMessages)
+	admin.POST("/wallets/:user_id/topup", walletHandler.TopUp)
+	admin.GET("/payouts", walletHandler.ListPayouts)
+	admin.POST("/payouts/:id/approve", walletHandler.ApprovePayout)
+	admin.POST("/payouts/:id/reject", walletHandler.RejectPayout)
+	admin.GET("/ledger/reconciliation", walletHandler.Reconcile)
+	admin.POST("/campus-domains", campusHandler.AddDomain)
+	admin.GET("/campus-domains", campusHandler.ListDomains)
+	admin.DELETE("/campus-domains/:id", campusHandler.RemoveDomain)
+	admin.POST("/promos", promoHandler.CreatePromo)
+	admin.GET("/promos", promoHandler.ListPromos)
+	admin.GET("/promos/:id", promoHandler.GetPromo)
+	admin.PATCH("/promos/:id", promoHandler.UpdatePromo)
+	admin.POST("/zones", geofenceHandler.CreateZone)
+	admin.GET("/zones", geofenceHandler.ListZones)
+	admin.GET("/zones/:id", geofenceHandler.GetZone)
+	admin.PATCH("/zones/:id", geofenceHandler.UpdateZone)
+	admin.DELETE("/zones/:id", geofenceHandler.DeleteZone)
+	admin.POST("/pickup-points", geofenceHandler.CreatePickupPoint)
+	admin.GET("/pickup-points", geofenceHandler.ListAllPickupPoints)
+	admin.PATCH("/pickup-points/:id", geofenceHandler.UpdatePickupPoint)
+	admin.DELETE("/pickup-points/:id", geofenceHandler.DeletePickupPoint)
+	admin.GET("/cooldowns", cancellationHandler.ListCooldowns)
+	admin.DELETE("/users/:id/cooldown", cancellationHandler.LiftCooldown)
+	admin.GET("/incidents", safetyHandler.ListIncidents)
+	admin.GET("/incidents/:id", safetyHandler.GetIncident)
+	admin.POST("/incidents/:id/acknowledge", safetyHandler.AcknowledgeIncident)
+	admin.POST("/incidents/:id/close", safetyHandler.CloseIncident)
+
 	// Start server
 	logger.Log.Info().Str("port", cfg.Server.Port).Msg("Server starting")
 	fmt.Printf("\n🚀 Server starting on port %s...\n", cfg.Server.Port)
@@ -130,8 +572,120 @@ func main() {
 	fmt.Println("   POST /api/auth/login")
 	fmt.Println("   POST /api/auth/refresh")
 	fmt.Println("   POST /api/auth/logout")
+	fmt.Println("   GET  /api/auth/email/verify?token= (emailed link)")
 	fmt.Println("   GET  /api/auth/me (protected)")
 	fmt.Println("   GET  /api/documents/:type/:filename (protected)")
+	fmt.Println("   GET  /api/avatars/:namespace/:owner_id/:filename (protected)")
+	fmt.Println("   PATCH /api/account (protected)")
+	fmt.Println("   POST /api/account/email/verification (protected)")
+	fmt.Println("   POST /api/account/email/verify (protected)")
+	fmt.Println("   POST /api/account/devices (protected)")
+	fmt.Println("   DELETE /api/account/devices (protected)")
+	fmt.Println("   GET  /api/ws (protected, WebSocket)")
+	fmt.Println("   GET  /api/events (protected, SSE)")
+	fmt.Println("   GET  /api/orders/:id (protected)")
+	fmt.Println("   GET  /api/orders/:id/events (protected, SSE)")
+	fmt.Println("   POST /api/orders/:id/rating (protected)")
+	fmt.Println("   GET  /api/orders/:id/rating (protected)")
+	fmt.Println("   GET  /api/orders/:id/route (protected, ?format=geojson|polyline)")
+	fmt.Println("   POST /api/orders/:id/share (protected, to verified trusted contacts)")
+	fmt.Println("   POST /api/orders/:id/sos (protected, during a ride)")
+	fmt.Println("   GET  /api/orders/:id/chat (protected, ?after_id=)")
+	fmt.Println("   POST /api/orders/:id/chat (protected, while a driver is assigned)")
+	fmt.Println("   POST /api/orders/:id/chat/read (protected)")
+	fmt.Println("   POST /api/orders/:id/contact (protected, masked WhatsApp relay during a ride)")
+	fmt.Println("   GET  /api/trusted-contacts (passenger/driver)")
+	fmt.Println("   POST /api/trusted-contacts (passenger/driver, sends OTP to the contact)")
+	fmt.Println("   POST /api/trusted-contacts/:id/resend-otp (passenger/driver)")
+	fmt.Println("   POST /api/trusted-contacts/:id/verify (passenger/driver)")
+	fmt.Println("   DELETE /api/trusted-contacts/:id (passenger/driver)")
+	fmt.Println("   GET  /api/ratings/tags (protected)")
+	fmt.Println("   GET  /api/cancellation-reasons (protected)")
+	fmt.Println("   GET  /api/chat/quick-replies (protected)")
+	fmt.Println("   GET  /api/pickup-points (protected)")
+	fmt.Println("   GET  /api/wallet (protected)")
+	fmt.Println("   GET  /api/wallet/entries (protected)")
+	fmt.Println("   GET  /api/track/:token (public, shared trip tracking)")
+	fmt.Println("   POST /api/payments/notifications (payment gateway webhook)")
+	if paymentMock != nil {
+		fmt.Println("   POST /api/dev/payments/:reference/pay (admin, settle a mock gateway charge)")
+	}
+	if mailFake != nil {
+		fmt.Println("   GET  /api/dev/mailbox?to= (admin, fake SMTP inbox)")
+	}
+	fmt.Println("   POST /api/whatsapp/webhook (WhatsApp provider webhook, X-Webhook-Token or ?token=: incoming messages, delivery updates)")
+	if whatsappFake != nil {
+		fmt.Println("   GET  /api/dev/whatsapp?to= (admin, fake WhatsApp outbox)")
+	}
+	fmt.Println("   GET  /api/passenger/profile (passenger)")
+	fmt.Println("   PATCH /api/passenger/profile (passenger)")
+	fmt.Println("   PUT  /api/passenger/profile/picture (passenger, multipart/form-data)")
+	fmt.Println("   POST /api/passenger/orders/estimate (passenger)")
+	fmt.Println("   POST /api/passenger/orders (passenger, scheduled_at books ahead)")
+	fmt.Println("   GET  /api/passenger/orders/scheduled (passenger)")
+	fmt.Println("   POST /api/passenger/orders/:id/cancel (passenger, reason_code required)")
+	fmt.Println("   POST /api/passenger/orders/:id/tracking-links (passenger, during a ride)")
+	fmt.Println("   GET  /api/passenger/orders/:id/tracking-links (passenger)")
+	fmt.Println("   DELETE /api/passenger/orders/:id/tracking-links/:link_id (passenger)")
+	fmt.Println("   POST /api/passenger/wallet/topups (passenger)")
+	fmt.Println("   GET  /api/passenger/wallet/topups (passenger)")
+	fmt.Println("   GET  /api/passenger/wallet/topups/:id (passenger)")
+	fmt.Println("   PATCH /api/driver/profile (driver)")
+	fmt.Println("   PUT  /api/driver/profile/picture (driver, multipart/form-data)")
+	fmt.Println("   PUT  /api/driver/location (driver)")
+	fmt.Println("   PUT  /api/driver/status (driver)")
+	fmt.Println("   GET  /api/driver/offers/current (driver)")
+	fmt.Println("   POST /api/driver/offers/:id/accept (driver)")
+	fmt.Println("   POST /api/driver/offers/:id/decline (driver)")
+	fmt.Println("   POST /api/driver/orders/:id/arrive (driver)")
+	fmt.Println("   POST /api/driver/orders/:id/start (driver)")
+	fmt.Println("   POST /api/driver/orders/:id/complete (driver)")
+	fmt.Println("   POST /api/driver/orders/:id/cancel (driver, PASSENGER_NO_SHOW after waiting)")
+	fmt.Println("   POST /api/driver/payouts (driver)")
+	fmt.Println("   GET  /api/driver/payouts (driver)")
+	fmt.Println("   GET  /api/driver/scheduled-orders (driver)")
+	fmt.Println("   POST /api/driver/scheduled-orders/:id/accept (driver)")
+	fmt.Println("   POST /api/driver/scheduled-orders/:id/withdraw (driver)")
+	fmt.Println("   GET  /api/driver/agenda (driver)")
+	fmt.Println("   POST /api/admin/drivers/:id/review (admin)")
+	fmt.Println("   GET  /api/admin/drivers/document-expiries?days= (admin)")
+	fmt.Println("   GET  /api/admin/drivers/outside-area (admin)")
+	fmt.Println("   GET  /api/admin/drivers/:id/profile-changes (admin)")
+	fmt.Println("   GET  /api/admin/drivers/flagged (admin)")
+	fmt.Println("   DELETE /api/admin/drivers/:id/rating-flag (admin)")
+	fmt.Println("   GET  /api/admin/jobs/dead (admin)")
+	fmt.Println("   POST /api/admin/jobs/:id/retry (admin)")
+	fmt.Println("   GET  /api/admin/providers/metrics (admin, outbound provider latency/errors/circuit)")
+	fmt.Println("   GET  /api/admin/orders/:id/offers (admin)")
+	fmt.Println("   POST /api/admin/orders/:id/route/rebuild (admin)")
+	fmt.Println("   GET  /api/admin/orders/:id/chat (admin, dispute handling)")
+	fmt.Println("   POST /api/admin/wallets/:user_id/topup (admin)")
+	fmt.Println("   GET  /api/admin/payouts (admin)")
+	fmt.Println("   POST /api/admin/payouts/:id/approve (admin)")
+	fmt.Println("   POST /api/admin/payouts/:id/reject (admin)")
+	fmt.Println("   GET  /api/admin/ledger/reconciliation (admin)")
+	fmt.Println("   POST /api/admin/campus-domains (admin)")
+	fmt.Println("   GET  /api/admin/campus-domains (admin)")
+	fmt.Println("   DELETE /api/admin/campus-domains/:id (admin)")
+	fmt.Println("   POST /api/admin/promos (admin)")
+	fmt.Println("   GET  /api/admin/promos (admin)")
+	fmt.Println("   GET  /api/admin/promos/:id (admin)")
+	fmt.Println("   PATCH /api/admin/promos/:id (admin)")
+	fmt.Println("   POST /api/admin/zones (admin)")
+	fmt.Println("   GET  /api/admin/zones?kind= (admin)")
+	fmt.Println("   GET  /api/admin/zones/:id (admin)")
+	fmt.Println("   PATCH /api/admin/zones/:id (admin)")
+	fmt.Println("   DELETE /api/admin/zones/:id (admin)")
+	fmt.Println("   POST /api/admin/pickup-points (admin)")
+	fmt.Println("   GET  /api/admin/pickup-points (admin)")
+	fmt.Println("   PATCH /api/admin/pickup-points/:id (admin)")
+	fmt.Println("   DELETE /api/admin/pickup-points/:id (admin)")
+	fmt.Println("   GET  /api/admin/cooldowns (admin)")
+	fmt.Println("   DELETE /api/admin/users/:id/cooldown (admin)")
+	fmt.Println("   GET  /api/admin/incidents?status= (admin)")
+	fmt.Println("   GET  /api/admin/incidents/:id (admin)")
+	fmt.Println("   POST /api/admin/incidents/:id/acknowledge (admin)")
+	fmt.Println("   POST /api/admin/incidents/:id/close (admin)")
 	fmt.Println()
 
 	if err := e.Start(":" + cfg.Server.Port); err != nil {
@@ -0,0 +1,30 @@
+package dto
+
+// ============================================================================
+// Account Request DTOs (shared by all roles)
+// ============================================================================
+
+// UpdateAccountRequest represents account-level update request
+// Locale sets the language used for API messages, WhatsApp, email and push notifications
+// Changing email does not take effect until the code sent to the new address is verified
+type UpdateAccountRequest struct {
+	FullName *string `json:"full_name,omitempty" validate:"omitempty,min=3,max=100"`
+	Email    *string `json:"email,omitempty" validate:"omitempty,email"`
+	Locale   *string `json:"preferred_locale,omitempty" validate:"omitempty,oneof=id en"`
+}
+
+// VerifyEmailChangeRequest represents email change verification request
+type VerifyEmailChangeRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+// ============================================================================
+// Account Response DTOs
+// ============================================================================
+
+// AccountResponse represents account data after update
+type AccountResponse struct {
+	User                     *UserResponse `json:"user"`
+	PendingEmail             *string       `json:"pending_email,omitempty"`
+	EmailVerificationExpires *int          `json:"email_verification_expires_in,omitempty"` // seconds
+}
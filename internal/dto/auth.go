@@ -20,15 +20,20 @@ type RefreshTokenRequest struct {
 // Common Response DTOs
 // ============================================================================
 
-// UserResponse represents basic user information
+// UserResponse represents basic user information. It carries the raw phone number, so it
+// is only returned to the user themselves; riders reach each other through the WhatsApp
+// relay (RelayContactResponse).
 type UserResponse struct {
-	ID            int     `json:"id"`
-	PhoneNumber   string  `json:"phone_number"`
-	Email         *string `json:"email,omitempty"`
-	FullName      string  `json:"full_name"`
-	Role          string  `json:"role"`   // Will be converted from entity.UserRole to string
-	Status        string  `json:"status"` // Will be converted from entity.UserStatus to string
-	PhoneVerified bool    `json:"phone_verified"`
+	ID             int     `json:"id"`
+	PhoneNumber    string  `json:"phone_number"`
+	Email          *string `json:"email,omitempty"`
+	FullName       string  `json:"full_name"`
+	Role           string  `json:"role"`   // Will be converted from entity.UserRole to string
+	Status         string  `json:"status"` // Will be converted from entity.UserStatus to string
+	PhoneVerified  bool    `json:"phone_verified"`
+	EmailVerified  bool    `json:"email_verified"`
+	CampusEligible bool    `json:"campus_eligible"`
+	Locale         string  `json:"preferred_locale"`
 }
 
 // TokenResponse represents token refresh response
@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// ============================================================================
+// Campus Domain Request DTOs
+// ============================================================================
+
+// CreateCampusDomainRequest allow-lists a campus email domain (admin).
+// Pattern is an exact domain ("ui.ac.id") or a subdomain wildcard ("*.ac.id").
+type CreateCampusDomainRequest struct {
+	Pattern string `json:"pattern" validate:"required,min=3,max=100"`
+	Name    string `json:"name" validate:"required,max=100"`
+}
+
+// ============================================================================
+// Campus Domain Response DTOs
+// ============================================================================
+
+// CampusDomainResponse represents an allow-listed campus domain
+type CampusDomainResponse struct {
+	ID        int       `json:"id"`
+	Pattern   string    `json:"pattern"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
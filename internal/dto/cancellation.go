@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+// ============================================================================
+// Cancellation Request DTOs
+// ============================================================================
+
+// CancelOrderRequest cancels an order with one of the caller's reason codes
+type CancelOrderRequest struct {
+	ReasonCode string  `json:"reason_code" validate:"required,max=30"`
+	Note       *string `json:"note,omitempty" validate:"omitempty,max=255"` // required for OTHER
+}
+
+// ============================================================================
+// Cancellation Response DTOs
+// ============================================================================
+
+// CancellationResponse shows the order after a cancellation and what it cost
+type CancellationResponse struct {
+	Order        *OrderResponse `json:"order"`
+	ReasonCode   string         `json:"reason_code"`
+	Fee          int            `json:"fee"`                      // paid by the passenger to the driver
+	FeeSettledBy string         `json:"fee_settled_by,omitempty"` // WALLET or DEBT
+	Counted      bool           `json:"counted"`                  // counts toward the caller's cancellation rate
+}
+
+// CancelReasonsResponse lists the reason codes a user can give when cancelling
+type CancelReasonsResponse struct {
+	Reasons []string `json:"reasons"`
+}
+
+// CooldownResponse represents a user paused for cancelling too often (admin view)
+type CooldownResponse struct {
+	UserID        int       `json:"user_id"`
+	FullName      string    `json:"full_name"`
+	PhoneNumber   string    `json:"phone_number"`
+	Role          string    `json:"role"`
+	Until         time.Time `json:"until"`
+	Cancellations int       `json:"cancellations"`
+	Orders        int       `json:"orders"`
+	CreatedAt     time.Time `json:"created_at"`
+}
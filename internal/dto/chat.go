@@ -0,0 +1,62 @@
+package dto
+
+import "time"
+
+// ============================================================================
+// Chat Request DTOs
+// ============================================================================
+
+// SendChatMessageRequest sends a chat message: either free text or a quick reply code
+type SendChatMessageRequest struct {
+	Body       string `json:"body,omitempty" validate:"omitempty,max=500"`
+	QuickReply string `json:"quick_reply,omitempty" validate:"omitempty,max=40"`
+}
+
+// MarkChatReadRequest marks the messages received up to LastMessageID as read
+type MarkChatReadRequest struct {
+	LastMessageID int64 `json:"last_message_id" validate:"required,min=1"`
+}
+
+// ============================================================================
+// Chat Response DTOs
+// ============================================================================
+
+// ChatMessageResponse represents a chat message; ReadAt is the read receipt
+type ChatMessageResponse struct {
+	ID         int64      `json:"id"`
+	OrderID    int        `json:"order_id"`
+	SenderID   int        `json:"sender_id"`
+	SenderRole string     `json:"sender_role"`
+	Body       string     `json:"body"`
+	QuickReply *string    `json:"quick_reply,omitempty"`
+	Masked     bool       `json:"masked"`
+	ReadAt     *time.Time `json:"read_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ChatResponse is a page of an order's chat. Open is false once the order has ended.
+type ChatResponse struct {
+	OrderID  int                    `json:"order_id"`
+	Open     bool                   `json:"open"`
+	Messages []*ChatMessageResponse `json:"messages"`
+}
+
+// ChatReadResponse is the read receipt sent to the other participant
+type ChatReadResponse struct {
+	OrderID       int       `json:"order_id"`
+	ReaderID      int       `json:"reader_id"`
+	LastMessageID int64     `json:"last_message_id"`
+	Updated       int64     `json:"updated"`
+	ReadAt        time.Time `json:"read_at"`
+}
+
+// QuickReplyResponse is a quick reply in the caller's language
+type QuickReplyResponse struct {
+	Code string `json:"code"`
+	Text string `json:"text"`
+}
+
+// QuickRepliesResponse lists the quick replies available to the caller's role
+type QuickRepliesResponse struct {
+	QuickReplies []QuickReplyResponse `json:"quick_replies"`
+}
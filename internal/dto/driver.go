@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // ============================================================================
 // Driver Request DTOs
 // ============================================================================
@@ -18,11 +20,13 @@ type RegisterDriverRequest struct {
 }
 
 // UpdateDriverProfileRequest represents driver profile update request
+// Accepts JSON or multipart form (multipart is required when changing vehicle_plate,
+// since a new STNK document must be uploaded alongside it)
 type UpdateDriverProfileRequest struct {
-	VehiclePlate *string `json:"vehicle_plate,omitempty"`
-	VehicleBrand *string `json:"vehicle_brand,omitempty"`
-	VehicleModel *string `json:"vehicle_model,omitempty"`
-	VehicleColor *string `json:"vehicle_color,omitempty"`
+	VehiclePlate *string `json:"vehicle_plate,omitempty" form:"vehicle_plate" validate:"omitempty,min=3,max=15"`
+	VehicleBrand *string `json:"vehicle_brand,omitempty" form:"vehicle_brand" validate:"omitempty,max=50"`
+	VehicleModel *string `json:"vehicle_model,omitempty" form:"vehicle_model" validate:"omitempty,max=50"`
+	VehicleColor *string `json:"vehicle_color,omitempty" form:"vehicle_color" validate:"omitempty,max=30"`
 }
 
 // ============================================================================
@@ -33,6 +37,7 @@ type UpdateDriverProfileRequest struct {
 type DriverProfileResponse struct {
 	ID                   int        `json:"id"`
 	UserID               int        `json:"user_id"`
+	ProfilePicture       *string    `json:"profile_picture,omitempty"`
 	VehicleType          string     `json:"vehicle_type"`
 	VehiclePlate         string     `json:"vehicle_plate"`
 	VehicleBrand         *string    `json:"vehicle_brand,omitempty"`
@@ -42,6 +47,7 @@ type DriverProfileResponse struct {
 	VerificationStatus   string     `json:"verification_status"`
 	RejectionReason      *string    `json:"rejection_reason,omitempty"`
 	IsActive             bool       `json:"is_active"`
+	IsOnline             bool       `json:"is_online"`
 	TotalCompletedOrders int        `json:"total_completed_orders"`
 	RatingAvg            float64    `json:"rating_avg"`
 	Documents            *Documents `json:"documents,omitempty"`
@@ -55,3 +61,13 @@ type DriverAuthResponse struct {
 	RefreshToken  string                 `json:"refresh_token"`
 	ExpiresIn     int                    `json:"expires_in"`
 }
+
+// DriverProfileChangeResponse represents a single entry of driver profile change history
+type DriverProfileChangeResponse struct {
+	ID        int       `json:"id"`
+	Field     string    `json:"field"`
+	OldValue  *string   `json:"old_value"`
+	NewValue  *string   `json:"new_value"`
+	ChangedBy int       `json:"changed_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
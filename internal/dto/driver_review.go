@@ -0,0 +1,53 @@
+package dto
+
+import "time"
+
+// ============================================================================
+// Driver Review Request DTOs
+// ============================================================================
+
+// ReviewDriverRequest approves or rejects a driver's documents (admin). Expiry dates are
+// the last valid day (YYYY-MM-DD); approval requires one for every expiring document.
+type ReviewDriverRequest struct {
+	Approve         bool    `json:"approve"`
+	Notes           *string `json:"notes,omitempty" validate:"omitempty,max=500"`
+	RejectionReason *string `json:"rejection_reason,omitempty" validate:"omitempty,max=255"`
+	SIMExpiresOn    *string `json:"sim_expires_on,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	STNKExpiresOn   *string `json:"stnk_expires_on,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	KTMExpiresOn    *string `json:"ktm_expires_on,omitempty" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// ============================================================================
+// Driver Review Response DTOs
+// ============================================================================
+
+// DocumentExpiryResponse represents the recorded expiry of one driver document
+type DocumentExpiryResponse struct {
+	DocumentType string     `json:"document_type"`
+	ExpiresOn    string     `json:"expires_on"`
+	DaysLeft     int        `json:"days_left"` // negative once lapsed
+	ExpiredAt    *time.Time `json:"expired_at,omitempty"`
+	RecordedAt   time.Time  `json:"recorded_at"`
+}
+
+// DriverReviewResponse represents a reviewed driver with the recorded document expiries
+type DriverReviewResponse struct {
+	Profile          *DriverProfileResponse    `json:"profile"`
+	DocumentExpiries []*DocumentExpiryResponse `json:"document_expiries"`
+}
+
+// ExpiringDocumentResponse represents a driver document nearing or past expiry (admin view)
+type ExpiringDocumentResponse struct {
+	DriverProfileID  int        `json:"driver_profile_id"`
+	UserID           int        `json:"user_id"`
+	FullName         string     `json:"full_name"`
+	PhoneNumber      string     `json:"phone_number"`
+	VehiclePlate     string     `json:"vehicle_plate"`
+	DocumentType     string     `json:"document_type"`
+	ExpiresOn        string     `json:"expires_on"`
+	DaysLeft         int        `json:"days_left"`
+	LastReminderDays *int       `json:"last_reminder_days,omitempty"`
+	ExpiredAt        *time.Time `json:"expired_at,omitempty"`
+	IsVerified       bool       `json:"is_verified"`
+	IsOnline         bool       `json:"is_online"`
+}
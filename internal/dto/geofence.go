@@ -0,0 +1,87 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ============================================================================
+// Geofence Request DTOs
+// ============================================================================
+
+// CreateZoneRequest defines a geofence zone (admin). Geometry is a GeoJSON Polygon,
+// MultiPolygon, Feature or FeatureCollection; Surcharge (Rupiah) applies to PRICING zones.
+type CreateZoneRequest struct {
+	Name      string          `json:"name" validate:"required,max=100"`
+	Kind      string          `json:"kind" validate:"required,oneof=SERVICE_AREA PRICING NO_GO"`
+	Geometry  json.RawMessage `json:"geometry" validate:"required"`
+	Surcharge int             `json:"surcharge,omitempty" validate:"min=0,max=100000"`
+	IsActive  *bool           `json:"is_active,omitempty"` // default true
+}
+
+// UpdateZoneRequest changes a zone (admin). The kind cannot change once the zone exists.
+type UpdateZoneRequest struct {
+	Name      *string         `json:"name,omitempty" validate:"omitempty,max=100"`
+	Geometry  json.RawMessage `json:"geometry,omitempty"`
+	Surcharge *int            `json:"surcharge,omitempty" validate:"omitempty,min=0,max=100000"`
+	IsActive  *bool           `json:"is_active,omitempty"`
+}
+
+// CreatePickupPointRequest adds an official pickup or drop point (admin)
+type CreatePickupPointRequest struct {
+	Name        string  `json:"name" validate:"required,max=100"`
+	Kind        string  `json:"kind" validate:"required,oneof=GATE FACULTY OTHER"`
+	Lat         float64 `json:"lat" validate:"required,latitude"`
+	Long        float64 `json:"long" validate:"required,longitude"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=255"`
+	IsActive    *bool   `json:"is_active,omitempty"` // default true
+}
+
+// UpdatePickupPointRequest changes a pickup point (admin)
+type UpdatePickupPointRequest struct {
+	Name        *string  `json:"name,omitempty" validate:"omitempty,max=100"`
+	Kind        *string  `json:"kind,omitempty" validate:"omitempty,oneof=GATE FACULTY OTHER"`
+	Lat         *float64 `json:"lat,omitempty" validate:"omitempty,latitude"`
+	Long        *float64 `json:"long,omitempty" validate:"omitempty,longitude"`
+	Description *string  `json:"description,omitempty" validate:"omitempty,max=255"`
+	IsActive    *bool    `json:"is_active,omitempty"`
+}
+
+// ============================================================================
+// Geofence Response DTOs
+// ============================================================================
+
+// ZoneResponse represents a geofence zone; Geometry is a GeoJSON MultiPolygon
+type ZoneResponse struct {
+	ID        int             `json:"id"`
+	Name      string          `json:"name"`
+	Kind      string          `json:"kind"`
+	Geometry  json.RawMessage `json:"geometry"`
+	Surcharge int             `json:"surcharge"`
+	IsActive  bool            `json:"is_active"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// PickupPointResponse represents an official pickup or drop point
+type PickupPointResponse struct {
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	Kind        string  `json:"kind"`
+	Lat         float64 `json:"lat"`
+	Long        float64 `json:"long"`
+	Description *string `json:"description,omitempty"`
+	IsActive    bool    `json:"is_active"`
+}
+
+// OutOfAreaDriverResponse represents a driver operating outside the service area (admin view)
+type OutOfAreaDriverResponse struct {
+	UserID       int       `json:"user_id"`
+	FullName     string    `json:"full_name"`
+	PhoneNumber  string    `json:"phone_number"`
+	VehiclePlate string    `json:"vehicle_plate"`
+	IsOnline     bool      `json:"is_online"`
+	Lat          *float64  `json:"lat,omitempty"`
+	Long         *float64  `json:"long,omitempty"`
+	Since        time.Time `json:"since"`
+}
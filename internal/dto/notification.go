@@ -0,0 +1,12 @@
+package dto
+
+// RegisterDeviceRequest represents push device registration request
+type RegisterDeviceRequest struct {
+	FCMToken string `json:"fcm_token" validate:"required,max=4096"`
+	Platform string `json:"platform" validate:"omitempty,oneof=ANDROID IOS WEB"`
+}
+
+// UnregisterDeviceRequest represents push device removal request
+type UnregisterDeviceRequest struct {
+	FCMToken string `json:"fcm_token" validate:"required,max=4096"`
+}
@@ -0,0 +1,127 @@
+package dto
+
+import "time"
+
+// ============================================================================
+// Order Request DTOs
+// ============================================================================
+
+// CreateOrderRequest represents a passenger's ride request
+type CreateOrderRequest struct {
+	PickupLat      float64    `json:"pickup_lat" validate:"required,latitude"`
+	PickupLong     float64    `json:"pickup_long" validate:"required,longitude"`
+	PickupAddress  string     `json:"pickup_address" validate:"required,max=255"`
+	DropoffLat     float64    `json:"dropoff_lat" validate:"required,latitude"`
+	DropoffLong    float64    `json:"dropoff_long" validate:"required,longitude"`
+	DropoffAddress string     `json:"dropoff_address" validate:"required,max=255"`
+	Notes          *string    `json:"notes,omitempty" validate:"omitempty,max=255"`
+	PaymentMethod  string     `json:"payment_method,omitempty" validate:"omitempty,oneof=CASH WALLET"` // default CASH
+	PromoCode      string     `json:"promo_code,omitempty" validate:"omitempty,max=30"`
+	ScheduledAt    *time.Time `json:"scheduled_at,omitempty"` // later pickup (RFC 3339); omit to ride now
+}
+
+// EstimateFareRequest asks for the fare of a trip, optionally with a promo code applied
+type EstimateFareRequest struct {
+	PickupLat      float64 `json:"pickup_lat" validate:"required,latitude"`
+	PickupLong     float64 `json:"pickup_long" validate:"required,longitude"`
+	PickupAddress  string  `json:"pickup_address,omitempty" validate:"omitempty,max=255"`
+	DropoffLat     float64 `json:"dropoff_lat" validate:"required,latitude"`
+	DropoffLong    float64 `json:"dropoff_long" validate:"required,longitude"`
+	DropoffAddress string  `json:"dropoff_address,omitempty" validate:"omitempty,max=255"`
+	PromoCode      string  `json:"promo_code,omitempty" validate:"omitempty,max=30"`
+}
+
+// UpdateDriverLocationRequest represents a driver's location ping
+type UpdateDriverLocationRequest struct {
+	Lat  float64 `json:"lat" validate:"required,latitude"`
+	Long float64 `json:"long" validate:"required,longitude"`
+}
+
+// UpdateDriverStatusRequest toggles whether the driver receives order offers
+type UpdateDriverStatusRequest struct {
+	Online *bool `json:"online" validate:"required"`
+}
+
+// ============================================================================
+// Order Response DTOs
+// ============================================================================
+
+// LocationResponse represents a point with its address
+type LocationResponse struct {
+	Lat     float64 `json:"lat"`
+	Long    float64 `json:"long"`
+	Address string  `json:"address"`
+}
+
+// OrderResponse represents order data
+type OrderResponse struct {
+	ID            int              `json:"id"`
+	Status        string           `json:"status"`
+	PassengerID   int              `json:"passenger_id"`
+	DriverID      *int             `json:"driver_id,omitempty"`
+	Pickup        LocationResponse `json:"pickup"`
+	Dropoff       LocationResponse `json:"dropoff"`
+	DistanceKm    float64          `json:"distance_km"`
+	Fare          int              `json:"fare"`
+	ZoneSurcharge int              `json:"zone_surcharge"` // included in fare
+	PromoCode     *string          `json:"promo_code,omitempty"`
+	Discount      int              `json:"discount"`
+	AmountDue     int              `json:"amount_due"` // fare - discount
+	PaymentMethod string           `json:"payment_method"`
+	Notes         *string          `json:"notes,omitempty"`
+	ScheduledAt   *time.Time       `json:"scheduled_at,omitempty"` // booked pickup time
+	AcceptedAt    *time.Time       `json:"accepted_at,omitempty"`
+	ArrivedAt     *time.Time       `json:"arrived_at,omitempty"`
+	StartedAt     *time.Time       `json:"started_at,omitempty"`
+	CompletedAt   *time.Time       `json:"completed_at,omitempty"`
+	ExpiredAt     *time.Time       `json:"expired_at,omitempty"`
+	CancelledAt   *time.Time       `json:"cancelled_at,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
+
+// FareEstimateResponse represents the quoted fare of a trip
+// with the pickup and dropoff as they will be used, snapped onto official points when near one
+type FareEstimateResponse struct {
+	Pickup        LocationResponse      `json:"pickup"`
+	Dropoff       LocationResponse      `json:"dropoff"`
+	DistanceKm    float64               `json:"distance_km"`
+	Fare          int                   `json:"fare"`
+	ZoneSurcharge int                   `json:"zone_surcharge"` // included in fare
+	Discount      int                   `json:"discount"`
+	AmountDue     int                   `json:"amount_due"`
+	Promo         *AppliedPromoResponse `json:"promo,omitempty"`
+}
+
+// DispatchOfferResponse represents an open offer as seen by the driver
+type DispatchOfferResponse struct {
+	ID         int            `json:"id"`
+	DistanceKm float64        `json:"distance_km"` // driver to pickup
+	ExpiresAt  time.Time      `json:"expires_at"`
+	ExpiresIn  int            `json:"expires_in"` // seconds
+	Order      *OrderResponse `json:"order"`
+}
+
+// DispatchOfferLogResponse represents a recorded offer with its ranking inputs (admin view)
+type DispatchOfferLogResponse struct {
+	ID             int        `json:"id"`
+	DriverID       int        `json:"driver_id"`
+	Attempt        int        `json:"attempt"`
+	Status         string     `json:"status"`
+	RadiusKm       float64    `json:"radius_km"`
+	DistanceKm     float64    `json:"distance_km"`
+	Rating         float64    `json:"rating"`
+	AcceptanceRate float64    `json:"acceptance_rate"`
+	Score          float64    `json:"score"`
+	OfferedAt      time.Time  `json:"offered_at"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	RespondedAt    *time.Time `json:"responded_at,omitempty"`
+}
+
+// DriverStatusResponse represents the driver's availability for dispatch
+type DriverStatusResponse struct {
+	IsOnline           bool       `json:"is_online"`
+	CurrentLat         *float64   `json:"current_lat,omitempty"`
+	CurrentLong        *float64   `json:"current_long,omitempty"`
+	LastLocationUpdate *time.Time `json:"last_location_update,omitempty"`
+	OutsideServiceArea bool       `json:"outside_service_area"`
+}
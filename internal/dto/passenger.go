@@ -13,9 +13,9 @@ type RegisterPassengerRequest struct {
 }
 
 // UpdatePassengerProfileRequest represents passenger profile update request
+// Profile picture is uploaded separately via multipart (see PUT /api/passenger/profile/picture)
 type UpdatePassengerProfileRequest struct {
-	ProfilePicture *string `json:"profile_picture,omitempty"`
-	FCMToken       *string `json:"fcm_token,omitempty"`
+	FCMToken *string `json:"fcm_token,omitempty" validate:"omitempty,max=4096"`
 }
 
 // ============================================================================
@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+// ============================================================================
+// Payment Request DTOs
+// ============================================================================
+
+// CreateTopUpChargeRequest starts a wallet top-up through the payment gateway
+type CreateTopUpChargeRequest struct {
+	Amount  int64  `json:"amount" validate:"required,min=10000,max=5000000"`
+	Channel string `json:"channel" validate:"required,oneof=QRIS VA"`
+	Bank    string `json:"bank,omitempty" validate:"required_if=Channel VA,omitempty,oneof=bca bni bri permata"`
+}
+
+// ============================================================================
+// Payment Response DTOs
+// ============================================================================
+
+// PaymentChargeResponse represents a top-up charge and how to pay it
+type PaymentChargeResponse struct {
+	ID         int64      `json:"id"`
+	Reference  string     `json:"reference"`
+	Channel    string     `json:"channel"`
+	Bank       *string    `json:"bank,omitempty"`
+	Amount     int64      `json:"amount"`
+	Status     string     `json:"status"`
+	QRString   *string    `json:"qr_string,omitempty"`
+	QRImageURL *string    `json:"qr_image_url,omitempty"`
+	VANumber   *string    `json:"va_number,omitempty"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	PaidAt     *time.Time `json:"paid_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
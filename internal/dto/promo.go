@@ -0,0 +1,81 @@
+package dto
+
+import "time"
+
+// ============================================================================
+// Promo Request DTOs
+// ============================================================================
+
+// CreatePromoRequest defines a promo campaign (admin). DiscountValue is a percentage for
+// PERCENT promos and Rupiah for FLAT promos; MaxDiscount caps PERCENT promos.
+type CreatePromoRequest struct {
+	Code          string    `json:"code" validate:"required,alphanum,min=4,max=30"`
+	Name          string    `json:"name" validate:"required,max=100"`
+	Description   *string   `json:"description,omitempty" validate:"omitempty,max=255"`
+	DiscountType  string    `json:"discount_type" validate:"required,oneof=PERCENT FLAT"`
+	DiscountValue int       `json:"discount_value" validate:"required,min=1"`
+	MaxDiscount   *int      `json:"max_discount,omitempty" validate:"omitempty,min=1"`
+	MinFare       int       `json:"min_fare" validate:"min=0"`
+	UsageLimit    *int      `json:"usage_limit,omitempty" validate:"omitempty,min=1"`
+	PerUserLimit  int       `json:"per_user_limit,omitempty" validate:"omitempty,min=1"` // default 1
+	EligibleRoles []string  `json:"eligible_roles,omitempty" validate:"omitempty,dive,oneof=PASSENGER DRIVER ADMIN"`
+	NewUsersOnly  bool      `json:"new_users_only"`
+	CampusOnly    bool      `json:"campus_only"` // verified campus email required
+	EmailDomains  []string  `json:"email_domains,omitempty" validate:"omitempty,dive,min=3,max=100"`
+	StartsAt      time.Time `json:"starts_at" validate:"required"`
+	EndsAt        time.Time `json:"ends_at" validate:"required"`
+	IsActive      *bool     `json:"is_active,omitempty"` // default true
+}
+
+// UpdatePromoRequest changes a promo's campaign settings (admin). The code and the
+// discount rule cannot change once the promo exists.
+type UpdatePromoRequest struct {
+	Name          *string    `json:"name,omitempty" validate:"omitempty,max=100"`
+	Description   *string    `json:"description,omitempty" validate:"omitempty,max=255"`
+	UsageLimit    *int       `json:"usage_limit,omitempty" validate:"omitempty,min=1"`
+	PerUserLimit  *int       `json:"per_user_limit,omitempty" validate:"omitempty,min=1"`
+	EligibleRoles *[]string  `json:"eligible_roles,omitempty" validate:"omitempty,dive,oneof=PASSENGER DRIVER ADMIN"`
+	NewUsersOnly  *bool      `json:"new_users_only,omitempty"`
+	CampusOnly    *bool      `json:"campus_only,omitempty"`
+	EmailDomains  *[]string  `json:"email_domains,omitempty" validate:"omitempty,dive,min=3,max=100"`
+	StartsAt      *time.Time `json:"starts_at,omitempty"`
+	EndsAt        *time.Time `json:"ends_at,omitempty"`
+	IsActive      *bool      `json:"is_active,omitempty"`
+}
+
+// ============================================================================
+// Promo Response DTOs
+// ============================================================================
+
+// PromoResponse represents a promo with its usage (admin view)
+type PromoResponse struct {
+	ID            int       `json:"id"`
+	Code          string    `json:"code"`
+	Name          string    `json:"name"`
+	Description   *string   `json:"description,omitempty"`
+	DiscountType  string    `json:"discount_type"`
+	DiscountValue int       `json:"discount_value"`
+	MaxDiscount   *int      `json:"max_discount,omitempty"`
+	MinFare       int       `json:"min_fare"`
+	UsageLimit    *int      `json:"usage_limit,omitempty"`
+	PerUserLimit  int       `json:"per_user_limit"`
+	UsedCount     int       `json:"used_count"`
+	EligibleRoles []string  `json:"eligible_roles"`
+	NewUsersOnly  bool      `json:"new_users_only"`
+	CampusOnly    bool      `json:"campus_only"`
+	EmailDomains  []string  `json:"email_domains"`
+	StartsAt      time.Time `json:"starts_at"`
+	EndsAt        time.Time `json:"ends_at"`
+	IsActive      bool      `json:"is_active"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// AppliedPromoResponse represents a promo applied to a fare estimate
+type AppliedPromoResponse struct {
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description,omitempty"`
+	Discount    int       `json:"discount"`
+	EndsAt      time.Time `json:"ends_at"`
+}
@@ -0,0 +1,53 @@
+package dto
+
+import "time"
+
+// ============================================================================
+// Rating Request DTOs
+// ============================================================================
+
+// CreateRatingRequest rates the other participant of a completed order
+type CreateRatingRequest struct {
+	Stars   int      `json:"stars" validate:"required,min=1,max=5"`
+	Tags    []string `json:"tags,omitempty" validate:"omitempty,max=5,unique,dive,required,max=30"`
+	Comment *string  `json:"comment,omitempty" validate:"omitempty,max=500"`
+}
+
+// ============================================================================
+// Rating Response DTOs
+// ============================================================================
+
+// RatingResponse represents a submitted rating
+type RatingResponse struct {
+	ID        int       `json:"id"`
+	OrderID   int       `json:"order_id"`
+	RaterRole string    `json:"rater_role"`
+	Stars     int       `json:"stars"`
+	Tags      []string  `json:"tags"`
+	Comment   *string   `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// OrderRatingsResponse shows what each side of an order has rated
+type OrderRatingsResponse struct {
+	OrderID      int             `json:"order_id"`
+	RateableTill *time.Time      `json:"rateable_till,omitempty"`
+	Given        *RatingResponse `json:"given,omitempty"`    // by the requesting user
+	Received     *RatingResponse `json:"received,omitempty"` // by the other participant
+}
+
+// RatingTagsResponse lists the tags a user can attach to a rating
+type RatingTagsResponse struct {
+	Tags []string `json:"tags"`
+}
+
+// FlaggedDriverResponse represents a driver awaiting rating review (admin view)
+type FlaggedDriverResponse struct {
+	UserID       int       `json:"user_id"`
+	FullName     string    `json:"full_name"`
+	PhoneNumber  string    `json:"phone_number"`
+	VehiclePlate string    `json:"vehicle_plate"`
+	RatingAvg    float64   `json:"rating_avg"`
+	RatingCount  int       `json:"rating_count"`
+	FlaggedAt    time.Time `json:"flagged_at"`
+}
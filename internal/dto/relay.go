@@ -0,0 +1,21 @@
+package dto
+
+// ============================================================================
+// WhatsApp Relay Response DTOs
+// ============================================================================
+
+// RelayContactResponse tells a rider how to reach the other party of their order without
+// either seeing the other's phone number: messages sent to RelayNumber are passed on
+// while the trip lasts
+type RelayContactResponse struct {
+	OrderID     int                      `json:"order_id"`
+	RelayNumber string                   `json:"relay_number"`
+	WhatsAppURL string                   `json:"whatsapp_url"`
+	Counterpart RelayCounterpartResponse `json:"counterpart"`
+}
+
+// RelayCounterpartResponse names the other party by first name only
+type RelayCounterpartResponse struct {
+	FirstName string `json:"first_name"`
+	Role      string `json:"role"`
+}
@@ -8,9 +8,10 @@ type Response struct {
 }
 
 type ErrorDetail struct {
-	Code    string            `json:"code"`
-	Message string            `json:"message"`
-	Fields  map[string]string `json:"fields,omitempty"` // Validation errors
+	Code      string            `json:"code"`
+	Message   string            `json:"message"`
+	Fields    map[string]string `json:"fields,omitempty"` // Validation errors
+	RequestID string            `json:"request_id,omitempty"`
 }
 
 func SuccessResponse(message string, data interface{}) Response {
@@ -0,0 +1,101 @@
+package dto
+
+import "time"
+
+// ============================================================================
+// Safety Request DTOs
+// ============================================================================
+
+// AddTrustedContactRequest adds a trusted contact; an OTP is sent to their WhatsApp
+type AddTrustedContactRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	PhoneNumber string `json:"phone_number" validate:"required,min=10,max=15"`
+}
+
+// VerifyTrustedContactRequest confirms a contact with the OTP they received
+type VerifyTrustedContactRequest struct {
+	OTPCode string `json:"otp_code" validate:"required,len=6,numeric"`
+}
+
+// RaiseSOSRequest raises an emergency on an order. Lat and Long are the reporter's
+// current position; without them the driver's last known location is used.
+type RaiseSOSRequest struct {
+	Lat  *float64 `json:"lat,omitempty" validate:"omitempty,latitude"`
+	Long *float64 `json:"long,omitempty" validate:"omitempty,longitude"`
+	Note *string  `json:"note,omitempty" validate:"omitempty,max=255"`
+}
+
+// CloseIncidentRequest ends the handling of an incident (admin only)
+type CloseIncidentRequest struct {
+	Outcome string `json:"outcome" validate:"required,oneof=RESOLVED DISMISSED"`
+	Note    string `json:"note" validate:"required,max=500"`
+}
+
+// ============================================================================
+// Safety Response DTOs
+// ============================================================================
+
+// TrustedContactResponse represents a trusted contact; only verified contacts get alerts
+type TrustedContactResponse struct {
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	PhoneNumber string     `json:"phone_number"`
+	Verified    bool       `json:"verified"`
+	VerifiedAt  *time.Time `json:"verified_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// ShareTripResponse reports how many trusted contacts a trip was shared with
+type ShareTripResponse struct {
+	OrderID          int `json:"order_id"`
+	ContactsNotified int `json:"contacts_notified"`
+}
+
+// SOSResponse is the reporter's view of their incident
+type SOSResponse struct {
+	ID              int       `json:"id"`
+	OrderID         int       `json:"order_id"`
+	Status          string    `json:"status"`
+	Lat             float64   `json:"lat"`
+	Long            float64   `json:"long"`
+	ContactsAlerted int       `json:"contacts_alerted"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// TripSnapshotResponse is the trip as it was when an SOS was raised (admin view)
+type TripSnapshotResponse struct {
+	PassengerID    int      `json:"passenger_id"`
+	PassengerName  string   `json:"passenger_name"`
+	PassengerPhone string   `json:"passenger_phone"`
+	DriverID       int      `json:"driver_id"`
+	DriverName     string   `json:"driver_name"`
+	DriverPhone    string   `json:"driver_phone"`
+	VehiclePlate   string   `json:"vehicle_plate"`
+	Vehicle        string   `json:"vehicle"`
+	PickupAddress  string   `json:"pickup_address"`
+	DropoffAddress string   `json:"dropoff_address"`
+	DriverLat      *float64 `json:"driver_lat,omitempty"`
+	DriverLong     *float64 `json:"driver_long,omitempty"`
+}
+
+// IncidentResponse represents an SOS incident with its handling (admin view)
+type IncidentResponse struct {
+	ID              int                  `json:"id"`
+	OrderID         int                  `json:"order_id"`
+	ReportedBy      int                  `json:"reported_by"`
+	Role            string               `json:"role"`
+	Lat             float64              `json:"lat"`
+	Long            float64              `json:"long"`
+	OrderStatus     string               `json:"order_status"` // when raised
+	Trip            TripSnapshotResponse `json:"trip"`
+	Note            *string              `json:"note,omitempty"`
+	Status          string               `json:"status"`
+	ContactsAlerted int                  `json:"contacts_alerted"`
+	AcknowledgedBy  *int                 `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt  *time.Time           `json:"acknowledged_at,omitempty"`
+	ResolvedBy      *int                 `json:"resolved_by,omitempty"`
+	ResolvedAt      *time.Time           `json:"resolved_at,omitempty"`
+	ResolutionNote  *string              `json:"resolution_note,omitempty"`
+	CreatedAt       time.Time            `json:"created_at"`
+	UpdatedAt       time.Time            `json:"updated_at"`
+}
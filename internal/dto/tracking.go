@@ -0,0 +1,51 @@
+package dto
+
+import "time"
+
+// ============================================================================
+// Trip Tracking Response DTOs
+// ============================================================================
+
+// TrackingLinkResponse represents a shareable tracking link. Token and URL are only
+// returned when the link is created; afterwards the link can be listed and revoked.
+type TrackingLinkResponse struct {
+	ID        int        `json:"id"`
+	OrderID   int        `json:"order_id"`
+	Token     string     `json:"token,omitempty"`
+	URL       string     `json:"url,omitempty"`
+	Active    bool       `json:"active"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TrackedTripResponse is what anyone holding a tracking link sees. It names the driver
+// by first name only and carries no phone numbers, addresses or passenger details.
+type TrackedTripResponse struct {
+	Status   string                `json:"status"`
+	Driver   TrackedDriverResponse `json:"driver"`
+	Position *TrackedPosition      `json:"position,omitempty"`
+	ETA      *TrackedETA           `json:"eta,omitempty"`
+}
+
+// TrackedDriverResponse identifies the driver and vehicle to look out for
+type TrackedDriverResponse struct {
+	FirstName    string  `json:"first_name"`
+	VehiclePlate string  `json:"vehicle_plate"`
+	VehicleColor *string `json:"vehicle_color,omitempty"`
+	Vehicle      string  `json:"vehicle"`
+}
+
+// TrackedPosition is the driver's last reported location
+type TrackedPosition struct {
+	Lat       float64    `json:"lat"`
+	Long      float64    `json:"long"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// TrackedETA estimates when the driver reaches the pickup (before the trip starts) or
+// the dropoff (during the trip)
+type TrackedETA struct {
+	Destination string `json:"destination"` // PICKUP or DROPOFF
+	Minutes     int    `json:"minutes"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ============================================================================
+// Trip Route Response DTOs
+// ============================================================================
+
+// TripRouteResponse is the route a trip actually took. Depending on the requested format
+// it carries Geometry, a GeoJSON LineString, or Polyline, an encoded polyline. Live marks
+// a route built from the pings so far while the trip is still running.
+type TripRouteResponse struct {
+	OrderID          int             `json:"order_id"`
+	Format           string          `json:"format"`
+	Geometry         json.RawMessage `json:"geometry,omitempty"`
+	Polyline         string          `json:"polyline,omitempty"`
+	DistanceKm       float64         `json:"distance_km"`        // measured along the cleaned trace
+	DurationSeconds  int             `json:"duration_seconds"`   // first to last kept ping
+	QuotedDistanceKm float64         `json:"quoted_distance_km"` // straight-line distance the order was priced on
+	QuotedFare       int             `json:"quoted_fare"`
+	MeasuredFare     int             `json:"measured_fare"` // fare recomputed from DistanceKm, zone surcharge included
+	RawPoints        int             `json:"raw_points"`
+	KeptPoints       int             `json:"kept_points"`
+	SimplifiedPoints int             `json:"simplified_points"`
+	StartedAt        time.Time       `json:"started_at"`
+	EndedAt          time.Time       `json:"ended_at"`
+	Live             bool            `json:"live"`
+}
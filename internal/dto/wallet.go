@@ -0,0 +1,106 @@
+package dto
+
+import "time"
+
+// ============================================================================
+// Wallet Request DTOs
+// ============================================================================
+
+// TopUpRequest credits a passenger wallet (admin). Reference identifies the incoming
+// payment and makes the top-up idempotent.
+type TopUpRequest struct {
+	Amount    int64   `json:"amount" validate:"required,min=10000,max=5000000"`
+	Reference string  `json:"reference" validate:"required,max=100"`
+	Note      *string `json:"note,omitempty" validate:"omitempty,max=200"`
+}
+
+// CreatePayoutRequest asks to withdraw driver earnings to a bank account
+type CreatePayoutRequest struct {
+	Amount        int64  `json:"amount" validate:"required,min=20000"`
+	BankName      string `json:"bank_name" validate:"required,max=50"`
+	AccountNumber string `json:"account_number" validate:"required,numeric,min=5,max=30"`
+	AccountHolder string `json:"account_holder" validate:"required,max=100"`
+}
+
+// RejectPayoutRequest returns the held amount to the driver's earnings
+type RejectPayoutRequest struct {
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+// ============================================================================
+// Wallet Response DTOs
+// ============================================================================
+
+// WalletResponse represents the caller's balance
+type WalletResponse struct {
+	AccountType string `json:"account_type"` // PASSENGER_WALLET or DRIVER_EARNINGS
+	Balance     int64  `json:"balance"`
+	OnHold      int64  `json:"on_hold"`        // drivers: amount reserved by pending payouts
+	Debt        int64  `json:"debt,omitempty"` // passengers: cancellation fees not yet paid
+}
+
+// WalletEntryResponse represents one line of the wallet history
+type WalletEntryResponse struct {
+	ID            int64     `json:"id"`
+	TransactionID int64     `json:"transaction_id"`
+	Kind          string    `json:"kind"`
+	Amount        int64     `json:"amount"`
+	BalanceAfter  int64     `json:"balance_after"`
+	ReferenceType *string   `json:"reference_type,omitempty"`
+	ReferenceID   *string   `json:"reference_id,omitempty"`
+	Description   *string   `json:"description,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// TopUpResponse represents a credited top-up
+type TopUpResponse struct {
+	TransactionID int64     `json:"transaction_id"`
+	UserID        int       `json:"user_id"`
+	Amount        int64     `json:"amount"`
+	Balance       int64     `json:"balance"`
+	DebtRepaid    int64     `json:"debt_repaid,omitempty"` // outstanding fees taken from the top-up
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// PayoutResponse represents a payout request
+type PayoutResponse struct {
+	ID              int64      `json:"id"`
+	DriverID        int        `json:"driver_id"`
+	Amount          int64      `json:"amount"`
+	Status          string     `json:"status"`
+	BankName        string     `json:"bank_name"`
+	AccountNumber   string     `json:"account_number"`
+	AccountHolder   string     `json:"account_holder"`
+	RejectionReason *string    `json:"rejection_reason,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// ReconciliationResponse represents a ledger reconciliation report (admin)
+type ReconciliationResponse struct {
+	GeneratedAt            time.Time               `json:"generated_at"`
+	Healthy                bool                    `json:"healthy"`
+	Accounts               int                     `json:"accounts"`
+	Transactions           int                     `json:"transactions"`
+	LedgerSum              int64                   `json:"ledger_sum"`
+	TotalsByType           []LedgerTypeTotal       `json:"totals_by_type"`
+	BalanceMismatches      []LedgerAccountMismatch `json:"balance_mismatches"`
+	NegativeAccounts       []LedgerAccountMismatch `json:"negative_accounts"`
+	UnbalancedTransactions []int64                 `json:"unbalanced_transactions"`
+}
+
+// LedgerTypeTotal sums the balances of one account type
+type LedgerTypeTotal struct {
+	Type     string `json:"type"`
+	Accounts int    `json:"accounts"`
+	Balance  int64  `json:"balance"`
+}
+
+// LedgerAccountMismatch describes an account that failed reconciliation
+type LedgerAccountMismatch struct {
+	AccountID int64  `json:"account_id"`
+	Type      string `json:"type"`
+	OwnerID   *int   `json:"owner_id,omitempty"`
+	Cached    int64  `json:"cached_balance"`
+	Derived   int64  `json:"derived_balance"`
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// CampusDomain represents the campus_domains table. Pattern is either an exact email
+// domain ("ui.ac.id") or a wildcard matching every subdomain ("*.ac.id").
+type CampusDomain struct {
+	ID        int       `json:"id" db:"id"`
+	Pattern   string    `json:"pattern" db:"pattern"`
+	Name      string    `json:"name" db:"name"`
+	CreatedBy *int      `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
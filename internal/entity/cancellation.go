@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+// FeeSettlement records where a cancellation fee was taken from
+type FeeSettlement string
+
+const (
+	FeeSettledWallet FeeSettlement = "WALLET" // debited from the passenger wallet
+	FeeSettledDebt   FeeSettlement = "DEBT"   // wallet was short, owed until the next top-up
+)
+
+// Cancellation represents the cancellations table. ChargedUserID is the user the
+// cancellation counts against, nil when it was free.
+type Cancellation struct {
+	ID            int            `json:"id" db:"id"`
+	OrderID       int            `json:"order_id" db:"order_id"`
+	CancelledBy   int            `json:"cancelled_by" db:"cancelled_by"`
+	Role          UserRole       `json:"role" db:"role"`
+	ReasonCode    string         `json:"reason_code" db:"reason_code"`
+	Note          *string        `json:"note,omitempty" db:"note"`
+	OrderStatus   OrderStatus    `json:"order_status" db:"order_status"` // status when cancelled
+	Fee           int            `json:"fee" db:"fee"`
+	FeeSettledBy  *FeeSettlement `json:"fee_settled_by,omitempty" db:"fee_settled_by"`
+	ChargedUserID *int           `json:"charged_user_id,omitempty" db:"charged_user_id"`
+	CreatedAt     time.Time      `json:"created_at" db:"created_at"`
+}
+
+// UserCooldown represents the user_cooldowns table: the user cannot order (passengers)
+// or go online (drivers) until Until
+type UserCooldown struct {
+	UserID        int       `json:"user_id" db:"user_id"`
+	Role          UserRole  `json:"role" db:"role"`
+	Until         time.Time `json:"until" db:"until"`
+	Cancellations int       `json:"cancellations" db:"cancellations"` // counted cancellations in the window
+	Orders        int       `json:"orders" db:"orders"`               // orders in the window
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// IsActive reports whether the cooldown still applies at now
+func (c *UserCooldown) IsActive(now time.Time) bool {
+	return now.Before(c.Until)
+}
+
+// CooldownWithUser is a cooldown joined with its user, for the admin view
+type CooldownWithUser struct {
+	UserCooldown
+	FullName    string
+	PhoneNumber string
+}
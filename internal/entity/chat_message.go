@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// ChatMessage represents the chat_messages table. Body is stored with profanity already
+// masked; QuickReply is the template code when the message was a quick reply. ReadAt is
+// set when the other participant has read it.
+type ChatMessage struct {
+	ID         int64      `json:"id" db:"id"`
+	OrderID    int        `json:"order_id" db:"order_id"`
+	SenderID   int        `json:"sender_id" db:"sender_id"`
+	SenderRole UserRole   `json:"sender_role" db:"sender_role"`
+	Body       string     `json:"body" db:"body"`
+	QuickReply *string    `json:"quick_reply,omitempty" db:"quick_reply"`
+	Masked     bool       `json:"masked" db:"masked"`
+	ReadAt     *time.Time `json:"read_at,omitempty" db:"read_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// DevicePlatform defines the platform of a push device
+type DevicePlatform string
+
+const (
+	PlatformAndroid DevicePlatform = "ANDROID"
+	PlatformIOS     DevicePlatform = "IOS"
+	PlatformWeb     DevicePlatform = "WEB"
+)
+
+// DeviceToken represents the device_tokens table (one row per FCM registration)
+type DeviceToken struct {
+	ID         int            `json:"id" db:"id"`
+	UserID     int            `json:"user_id" db:"user_id"`
+	Token      string         `json:"-" db:"token"`
+	Platform   DevicePlatform `json:"platform" db:"platform"`
+	CreatedAt  time.Time      `json:"created_at" db:"created_at"`
+	LastSeenAt time.Time      `json:"last_seen_at" db:"last_seen_at"`
+}
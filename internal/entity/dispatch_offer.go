@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// OfferStatus defines the outcome of a dispatch offer
+type OfferStatus string
+
+const (
+	OfferStatusPending   OfferStatus = "PENDING"
+	OfferStatusAccepted  OfferStatus = "ACCEPTED"
+	OfferStatusDeclined  OfferStatus = "DECLINED"
+	OfferStatusTimeout   OfferStatus = "TIMEOUT"
+	OfferStatusCancelled OfferStatus = "CANCELLED" // order closed while the offer was open
+)
+
+// DispatchOffer represents the dispatch_offers table. The ranking inputs are
+// stored as they were at offer time so decisions can be analysed later.
+type DispatchOffer struct {
+	ID             int         `json:"id" db:"id"`
+	OrderID        int         `json:"order_id" db:"order_id"`
+	DriverID       int         `json:"driver_id" db:"driver_id"`
+	Attempt        int         `json:"attempt" db:"attempt"`
+	Status         OfferStatus `json:"status" db:"status"`
+	RadiusKm       float64     `json:"radius_km" db:"radius_km"`
+	DistanceKm     float64     `json:"distance_km" db:"distance_km"`
+	Rating         float64     `json:"rating" db:"rating"`
+	AcceptanceRate float64     `json:"acceptance_rate" db:"acceptance_rate"`
+	Score          float64     `json:"score" db:"score"`
+	OfferedAt      time.Time   `json:"offered_at" db:"offered_at"`
+	ExpiresAt      time.Time   `json:"expires_at" db:"expires_at"`
+	RespondedAt    *time.Time  `json:"responded_at,omitempty" db:"responded_at"`
+}
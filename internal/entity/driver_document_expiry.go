@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+// DriverDocumentExpiry represents the driver_document_expiries table. ExpiresOn is a
+// calendar date (the last valid day) and LastReminderDays the smallest reminder
+// threshold already sent.
+type DriverDocumentExpiry struct {
+	DriverProfileID  int        `json:"driver_profile_id" db:"driver_profile_id"`
+	DocumentType     string     `json:"document_type" db:"document_type"`
+	ExpiresOn        time.Time  `json:"expires_on" db:"expires_on"`
+	LastReminderDays *int       `json:"last_reminder_days,omitempty" db:"last_reminder_days"`
+	ExpiredAt        *time.Time `json:"expired_at,omitempty" db:"expired_at"`
+	RecordedBy       *int       `json:"recorded_by,omitempty" db:"recorded_by"`
+	RecordedAt       time.Time  `json:"recorded_at" db:"recorded_at"`
+}
+
+// DaysLeft returns the whole days from today until the document lapses; 0 means it
+// expires at the end of today and negative values mean it has lapsed
+func (e *DriverDocumentExpiry) DaysLeft(today time.Time) int {
+	y, m, d := today.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	y, m, d = e.ExpiresOn.Date()
+	end := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
+}
+
+// ExpiringDocument is a tracked document joined with its driver, for the expiry scan and
+// the admin view
+type ExpiringDocument struct {
+	DriverDocumentExpiry
+	UserID       int
+	FullName     string
+	PhoneNumber  string
+	VehiclePlate string
+	IsVerified   bool
+	IsOnline     bool
+}
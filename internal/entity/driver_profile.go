@@ -1,6 +1,9 @@
 package entity
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // DriverProfile represents driver-specific profile data
 type DriverProfile struct {
@@ -23,14 +26,50 @@ type DriverProfile struct {
 	VerifiedAt           *time.Time `db:"verified_at"`
 	RejectionReason      *string    `db:"rejection_reason"`
 	IsActive             bool       `db:"is_active"`
+	IsOnline             bool       `db:"is_online"`
 	CurrentLat           *float64   `db:"current_lat"`
 	CurrentLong          *float64   `db:"current_long"`
 	LastLocationUpdate   *time.Time `db:"last_location_update"`
 	TotalCompletedOrders int        `db:"total_completed_orders"`
 	TotalCancelledOrders int        `db:"total_cancelled_orders"`
 	RatingAvg            float64    `db:"rating_avg"`
-	CreatedAt            time.Time  `db:"created_at"`
-	UpdatedAt            time.Time  `db:"updated_at"`
+	// Set while the last location is outside the service area
+	OutsideServiceAreaSince *time.Time `db:"outside_service_area_since"`
+	CreatedAt               time.Time  `db:"created_at"`
+	UpdatedAt               time.Time  `db:"updated_at"`
+}
+
+// VehicleDescription describes the vehicle for riders from its brand, model and colour,
+// e.g. "Honda Beat Hitam", falling back to the vehicle type when none were given
+func (p *DriverProfile) VehicleDescription() string {
+	var parts []string
+	for _, detail := range []*string{p.VehicleBrand, p.VehicleModel, p.VehicleColor} {
+		if detail != nil && *detail != "" {
+			parts = append(parts, *detail)
+		}
+	}
+	if len(parts) == 0 {
+		return p.VehicleType
+	}
+	return strings.Join(parts, " ")
+}
+
+// CandidateArea bounds the dispatch candidate search
+type CandidateArea struct {
+	MinLat, MaxLat   float64
+	MinLong, MaxLong float64
+	LocationSince    time.Time // drivers whose last location is older are skipped
+	StatsSince       time.Time // window of the offer statistics
+}
+
+// DriverCandidate is an available driver with the inputs the dispatcher ranks on
+type DriverCandidate struct {
+	UserID         int
+	Lat            float64
+	Long           float64
+	RatingAvg      float64
+	OffersAccepted int
+	OffersDecided  int
 }
 
 // VehicleInfo represents vehicle information for registration
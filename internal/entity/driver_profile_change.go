@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// DriverProfileChange represents a single field change in the driver_profile_changes table
+type DriverProfileChange struct {
+	ID              int       `db:"id"`
+	DriverProfileID int       `db:"driver_profile_id"`
+	Field           string    `db:"field"`
+	OldValue        *string   `db:"old_value"`
+	NewValue        *string   `db:"new_value"`
+	ChangedBy       int       `db:"changed_by"`
+	CreatedAt       time.Time `db:"created_at"`
+}
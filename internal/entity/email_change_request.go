@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// EmailChangeRequest represents the email_change_requests table
+// A user's email is only replaced after the code sent to the new address is verified.
+// Verifying the current, unverified email uses a request for that same address.
+type EmailChangeRequest struct {
+	ID         int        `json:"id" db:"id"`
+	UserID     int        `json:"user_id" db:"user_id"`
+	NewEmail   string     `json:"new_email" db:"new_email"`
+	CodeHash   string     `json:"-" db:"code_hash"`
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"`
+	Attempts   int        `json:"attempts" db:"attempts"`
+	IsUsed     bool       `json:"is_used" db:"is_used"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty" db:"verified_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IsExpired checks if the verification code has expired
+func (r *EmailChangeRequest) IsExpired() bool {
+	return time.Now().After(r.ExpiresAt)
+}
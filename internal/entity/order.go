@@ -0,0 +1,69 @@
+package entity
+
+import "time"
+
+// OrderStatus defines the lifecycle state of a ride order
+type OrderStatus string
+
+const (
+	OrderStatusScheduled OrderStatus = "SCHEDULED" // booked in advance, dispatch has not started
+	OrderStatusSearching OrderStatus = "SEARCHING" // dispatcher is looking for a driver
+	OrderStatusAccepted  OrderStatus = "ACCEPTED"  // driver is heading to pickup
+	OrderStatusArrived   OrderStatus = "ARRIVED"   // driver is waiting at pickup
+	OrderStatusOnTrip    OrderStatus = "ON_TRIP"
+	OrderStatusCompleted OrderStatus = "COMPLETED"
+	OrderStatusCancelled OrderStatus = "CANCELLED"
+	OrderStatusExpired   OrderStatus = "EXPIRED" // no driver accepted
+)
+
+// IsActive reports whether the order is still in progress
+func (s OrderStatus) IsActive() bool {
+	switch s {
+	case OrderStatusSearching, OrderStatusAccepted, OrderStatusArrived, OrderStatusOnTrip:
+		return true
+	}
+	return false
+}
+
+// PaymentMethod defines how the passenger pays for a trip
+type PaymentMethod string
+
+const (
+	PaymentMethodCash   PaymentMethod = "CASH"
+	PaymentMethodWallet PaymentMethod = "WALLET" // debited from the passenger wallet on completion
+)
+
+// Order represents the orders table
+type Order struct {
+	ID             int           `json:"id" db:"id"`
+	PassengerID    int           `json:"passenger_id" db:"passenger_id"`
+	DriverID       *int          `json:"driver_id,omitempty" db:"driver_id"`
+	Status         OrderStatus   `json:"status" db:"status"`
+	PickupLat      float64       `json:"pickup_lat" db:"pickup_lat"`
+	PickupLong     float64       `json:"pickup_long" db:"pickup_long"`
+	PickupAddress  string        `json:"pickup_address" db:"pickup_address"`
+	DropoffLat     float64       `json:"dropoff_lat" db:"dropoff_lat"`
+	DropoffLong    float64       `json:"dropoff_long" db:"dropoff_long"`
+	DropoffAddress string        `json:"dropoff_address" db:"dropoff_address"`
+	DistanceKm     float64       `json:"distance_km" db:"distance_km"`
+	Fare           int           `json:"fare" db:"fare"`
+	ZoneSurcharge  int           `json:"zone_surcharge" db:"zone_surcharge"` // pricing zone share of Fare
+	PromoCode      *string       `json:"promo_code,omitempty" db:"promo_code"`
+	Discount       int           `json:"discount" db:"discount"`
+	PaymentMethod  PaymentMethod `json:"payment_method" db:"payment_method"`
+	Notes          *string       `json:"notes,omitempty" db:"notes"`
+	ScheduledAt    *time.Time    `json:"scheduled_at,omitempty" db:"scheduled_at"` // booked pickup time
+	AcceptedAt     *time.Time    `json:"accepted_at,omitempty" db:"accepted_at"`
+	ArrivedAt      *time.Time    `json:"arrived_at,omitempty" db:"arrived_at"`
+	StartedAt      *time.Time    `json:"started_at,omitempty" db:"started_at"`
+	CompletedAt    *time.Time    `json:"completed_at,omitempty" db:"completed_at"`
+	ExpiredAt      *time.Time    `json:"expired_at,omitempty" db:"expired_at"`
+	CancelledAt    *time.Time    `json:"cancelled_at,omitempty" db:"cancelled_at"`
+	CreatedAt      time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// AmountDue is what the passenger pays after the promo discount
+func (o *Order) AmountDue() int {
+	return o.Fare - o.Discount
+}
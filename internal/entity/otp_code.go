@@ -9,6 +9,7 @@ const (
 	OTPPurposeRegistration      OTPPurpose = "REGISTRATION"
 	OTPPurposePasswordReset     OTPPurpose = "PASSWORD_RESET"
 	OTPPurposePhoneVerification OTPPurpose = "PHONE_VERIFICATION"
+	OTPPurposeTrustedContact    OTPPurpose = "TRUSTED_CONTACT" // sent to a contact someone added for SOS alerts
 )
 
 // OTPCode represents the otp_codes table
@@ -23,6 +24,7 @@ type OTPCode struct {
 	Attempts    int        `json:"attempts" db:"attempts"`
 	IPAddress   *string    `json:"ip_address,omitempty" db:"ip_address"`
 	UserAgent   *string    `json:"user_agent,omitempty" db:"user_agent"`
+	Locale      string     `json:"locale" db:"locale"` // locale the code was requested in
 	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
 }
 
@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+// PaymentChargeStatus defines the state of a gateway charge
+type PaymentChargeStatus string
+
+const (
+	PaymentChargePending PaymentChargeStatus = "PENDING"
+	PaymentChargePaid    PaymentChargeStatus = "PAID"
+	PaymentChargeFailed  PaymentChargeStatus = "FAILED"
+	PaymentChargeExpired PaymentChargeStatus = "EXPIRED"
+)
+
+// PaymentCharge represents the payment_charges table
+type PaymentCharge struct {
+	ID                  int64               `json:"id" db:"id"`
+	UserID              int                 `json:"user_id" db:"user_id"`
+	Provider            string              `json:"provider" db:"provider"`
+	Reference           string              `json:"reference" db:"reference"`
+	ProviderRef         *string             `json:"provider_ref,omitempty" db:"provider_ref"`
+	Channel             string              `json:"channel" db:"channel"`
+	Bank                *string             `json:"bank,omitempty" db:"bank"`
+	Amount              int64               `json:"amount" db:"amount"`
+	Status              PaymentChargeStatus `json:"status" db:"status"`
+	QRString            *string             `json:"qr_string,omitempty" db:"qr_string"`
+	QRImageURL          *string             `json:"qr_image_url,omitempty" db:"qr_image_url"`
+	VANumber            *string             `json:"va_number,omitempty" db:"va_number"`
+	ExpiresAt           time.Time           `json:"expires_at" db:"expires_at"`
+	PaidAt              *time.Time          `json:"paid_at,omitempty" db:"paid_at"`
+	LedgerTransactionID *int64              `json:"ledger_transaction_id,omitempty" db:"ledger_transaction_id"`
+	LastCheckedAt       *time.Time          `json:"last_checked_at,omitempty" db:"last_checked_at"`
+	CreatedAt           time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time           `json:"updated_at" db:"updated_at"`
+}
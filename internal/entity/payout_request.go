@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// PayoutStatus defines the review state of a payout request
+type PayoutStatus string
+
+const (
+	PayoutStatusPending  PayoutStatus = "PENDING"
+	PayoutStatusPaid     PayoutStatus = "PAID"
+	PayoutStatusRejected PayoutStatus = "REJECTED"
+)
+
+// PayoutRequest represents the payout_requests table
+type PayoutRequest struct {
+	ID              int64        `json:"id" db:"id"`
+	DriverID        int          `json:"driver_id" db:"driver_id"`
+	Amount          int64        `json:"amount" db:"amount"`
+	Status          PayoutStatus `json:"status" db:"status"`
+	BankName        string       `json:"bank_name" db:"bank_name"`
+	AccountNumber   string       `json:"account_number" db:"account_number"`
+	AccountHolder   string       `json:"account_holder" db:"account_holder"`
+	RejectionReason *string      `json:"rejection_reason,omitempty" db:"rejection_reason"`
+	ReviewedBy      *int         `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt      *time.Time   `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	CreatedAt       time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at" db:"updated_at"`
+}
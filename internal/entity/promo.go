@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// DiscountType defines how a promo discount is computed
+type DiscountType string
+
+const (
+	DiscountPercent DiscountType = "PERCENT" // DiscountValue percent of the fare, capped by MaxDiscount
+	DiscountFlat    DiscountType = "FLAT"    // DiscountValue Rupiah
+)
+
+// RedemptionStatus defines the state of a promo redemption
+type RedemptionStatus string
+
+const (
+	RedemptionReserved RedemptionStatus = "RESERVED" // order placed, trip not completed yet
+	RedemptionRedeemed RedemptionStatus = "REDEEMED" // trip completed
+	RedemptionReleased RedemptionStatus = "RELEASED" // order cancelled or expired; the use is given back
+)
+
+// Promo represents the promos table
+type Promo struct {
+	ID            int          `json:"id" db:"id"`
+	Code          string       `json:"code" db:"code"`
+	Name          string       `json:"name" db:"name"`
+	Description   *string      `json:"description,omitempty" db:"description"`
+	DiscountType  DiscountType `json:"discount_type" db:"discount_type"`
+	DiscountValue int          `json:"discount_value" db:"discount_value"`
+	MaxDiscount   *int         `json:"max_discount,omitempty" db:"max_discount"`
+	MinFare       int          `json:"min_fare" db:"min_fare"`
+	UsageLimit    *int         `json:"usage_limit,omitempty" db:"usage_limit"`
+	PerUserLimit  int          `json:"per_user_limit" db:"per_user_limit"`
+	UsedCount     int          `json:"used_count" db:"used_count"`
+	EligibleRoles []string     `json:"eligible_roles" db:"eligible_roles"`
+	NewUsersOnly  bool         `json:"new_users_only" db:"new_users_only"`
+	CampusOnly    bool         `json:"campus_only" db:"campus_only"`
+	EmailDomains  []string     `json:"email_domains" db:"email_domains"`
+	StartsAt      time.Time    `json:"starts_at" db:"starts_at"`
+	EndsAt        time.Time    `json:"ends_at" db:"ends_at"`
+	IsActive      bool         `json:"is_active" db:"is_active"`
+	CreatedBy     *int         `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt     time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// IsOpen reports whether the promo can be used at the given time
+func (p *Promo) IsOpen(now time.Time) bool {
+	return p.IsActive && !now.Before(p.StartsAt) && now.Before(p.EndsAt)
+}
+
+// IsExhausted reports whether the global usage cap is reached
+func (p *Promo) IsExhausted() bool {
+	return p.UsageLimit != nil && p.UsedCount >= *p.UsageLimit
+}
+
+// DiscountFor returns the discount on a fare; it never exceeds the fare itself
+func (p *Promo) DiscountFor(fare int) int {
+	discount := p.DiscountValue
+	if p.DiscountType == DiscountPercent {
+		discount = fare * p.DiscountValue / 100
+		if p.MaxDiscount != nil && discount > *p.MaxDiscount {
+			discount = *p.MaxDiscount
+		}
+	}
+	if discount > fare {
+		discount = fare
+	}
+	return discount
+}
+
+// AllowsRole reports whether users of the role may use the promo
+func (p *Promo) AllowsRole(role UserRole) bool {
+	if len(p.EligibleRoles) == 0 {
+		return true
+	}
+	for _, eligible := range p.EligibleRoles {
+		if UserRole(eligible) == role {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsEmail reports whether the email's domain is eligible. Entries match the domain
+// exactly ("ui.ac.id") or any subdomain with a leading wildcard ("*.ac.id"). Callers pass
+// the user's verified email, so an unverified address never qualifies.
+func (p *Promo) AllowsEmail(email *string) bool {
+	if len(p.EmailDomains) == 0 {
+		return true
+	}
+	if email == nil {
+		return false
+	}
+	_, domain, ok := strings.Cut(strings.ToLower(*email), "@")
+	if !ok {
+		return false
+	}
+	for _, allowed := range p.EmailDomains {
+		allowed = strings.ToLower(allowed)
+		if suffix, wildcard := strings.CutPrefix(allowed, "*."); wildcard {
+			if strings.HasSuffix(domain, "."+suffix) {
+				return true
+			}
+		} else if domain == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// PromoRedemption represents the promo_redemptions table
+type PromoRedemption struct {
+	ID         int64            `json:"id" db:"id"`
+	PromoID    int              `json:"promo_id" db:"promo_id"`
+	UserID     int              `json:"user_id" db:"user_id"`
+	OrderID    int              `json:"order_id" db:"order_id"`
+	Discount   int              `json:"discount" db:"discount"`
+	Status     RedemptionStatus `json:"status" db:"status"`
+	ReservedAt time.Time        `json:"reserved_at" db:"reserved_at"`
+	RedeemedAt *time.Time       `json:"redeemed_at,omitempty" db:"redeemed_at"`
+	ReleasedAt *time.Time       `json:"released_at,omitempty" db:"released_at"`
+}
@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+// Rating represents the ratings table: one participant rating the other after a trip
+type Rating struct {
+	ID        int       `json:"id" db:"id"`
+	OrderID   int       `json:"order_id" db:"order_id"`
+	RaterID   int       `json:"rater_id" db:"rater_id"`
+	RateeID   int       `json:"ratee_id" db:"ratee_id"`
+	RaterRole UserRole  `json:"rater_role" db:"rater_role"` // PASSENGER or DRIVER
+	Stars     int       `json:"stars" db:"stars"`
+	Tags      []string  `json:"tags" db:"tags"`
+	Comment   *string   `json:"comment,omitempty" db:"comment"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// RatingStats is a profile's rating aggregate after a rating was applied
+type RatingStats struct {
+	Count   int
+	Sum     int
+	Average float64 // smoothed towards the prior mean while Count is small
+}
+
+// FlaggedDriver is a driver whose rating fell below the review threshold
+type FlaggedDriver struct {
+	UserID       int
+	FullName     string
+	PhoneNumber  string
+	VehiclePlate string
+	RatingAvg    float64
+	RatingCount  int
+	FlaggedAt    time.Time
+}
@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// RelaySession represents the relay_sessions table: masked WhatsApp contact between the
+// passenger and driver of an order through our sender number
+type RelaySession struct {
+	ID              int        `json:"id" db:"id"`
+	OrderID         int        `json:"order_id" db:"order_id"`
+	PassengerID     int        `json:"passenger_id" db:"passenger_id"`
+	DriverID        int        `json:"driver_id" db:"driver_id"`
+	PassengerPhone  string     `json:"-" db:"passenger_phone"`
+	DriverPhone     string     `json:"-" db:"driver_phone"`
+	MessagesRelayed int        `json:"messages_relayed" db:"messages_relayed"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	ClosedAt        *time.Time `json:"closed_at,omitempty" db:"closed_at"`
+}
+
+// Route returns who a message from phone is from and who it goes to. ok is false when
+// the phone is not part of the session.
+func (s *RelaySession) Route(phone string) (senderID int, senderRole UserRole, recipientID int, recipientPhone string, ok bool) {
+	switch phone {
+	case s.PassengerPhone:
+		return s.PassengerID, RolePassenger, s.DriverID, s.DriverPhone, true
+	case s.DriverPhone:
+		return s.DriverID, RoleDriver, s.PassengerID, s.PassengerPhone, true
+	}
+	return 0, "", 0, "", false
+}
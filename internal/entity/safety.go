@@ -0,0 +1,73 @@
+package entity
+
+import "time"
+
+// TrustedContact represents the trusted_contacts table: someone a user wants alerted in an
+// emergency. Only contacts who confirmed the OTP sent to their number receive alerts.
+type TrustedContact struct {
+	ID          int        `json:"id" db:"id"`
+	UserID      int        `json:"user_id" db:"user_id"`
+	Name        string     `json:"name" db:"name"`
+	PhoneNumber string     `json:"phone_number" db:"phone_number"`
+	VerifiedAt  *time.Time `json:"verified_at,omitempty" db:"verified_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IsVerified reports whether the contact confirmed their number
+func (c *TrustedContact) IsVerified() bool {
+	return c.VerifiedAt != nil
+}
+
+// IncidentStatus defines the handling state of an SOS incident
+type IncidentStatus string
+
+const (
+	IncidentStatusOpen         IncidentStatus = "OPEN"         // raised, nobody has picked it up yet
+	IncidentStatusAcknowledged IncidentStatus = "ACKNOWLEDGED" // an admin is handling it
+	IncidentStatusResolved     IncidentStatus = "RESOLVED"
+	IncidentStatusDismissed    IncidentStatus = "DISMISSED" // false alarm
+)
+
+// IsClosed reports whether the incident no longer needs handling
+func (s IncidentStatus) IsClosed() bool {
+	return s == IncidentStatusResolved || s == IncidentStatusDismissed
+}
+
+// TripSnapshot is what was known about the trip when an SOS was raised, kept as it was
+// even if profiles change later
+type TripSnapshot struct {
+	PassengerID    int      `json:"passenger_id"`
+	PassengerName  string   `json:"passenger_name"`
+	PassengerPhone string   `json:"passenger_phone"`
+	DriverID       int      `json:"driver_id"`
+	DriverName     string   `json:"driver_name"`
+	DriverPhone    string   `json:"driver_phone"`
+	VehiclePlate   string   `json:"vehicle_plate"`
+	Vehicle        string   `json:"vehicle"` // type, brand, model and colour as far as known
+	PickupAddress  string   `json:"pickup_address"`
+	DropoffAddress string   `json:"dropoff_address"`
+	DriverLat      *float64 `json:"driver_lat,omitempty"`
+	DriverLong     *float64 `json:"driver_long,omitempty"`
+}
+
+// SOSIncident represents the sos_incidents table
+type SOSIncident struct {
+	ID              int            `json:"id" db:"id"`
+	OrderID         int            `json:"order_id" db:"order_id"`
+	ReportedBy      int            `json:"reported_by" db:"reported_by"`
+	Role            UserRole       `json:"role" db:"role"`
+	Lat             float64        `json:"lat" db:"lat"`
+	Long            float64        `json:"long" db:"long"`
+	OrderStatus     OrderStatus    `json:"order_status" db:"order_status"` // status when raised
+	Trip            TripSnapshot   `json:"trip" db:"trip"`
+	Note            *string        `json:"note,omitempty" db:"note"`
+	Status          IncidentStatus `json:"status" db:"status"`
+	ContactsAlerted int            `json:"contacts_alerted" db:"contacts_alerted"`
+	AcknowledgedBy  *int           `json:"acknowledged_by,omitempty" db:"acknowledged_by"`
+	AcknowledgedAt  *time.Time     `json:"acknowledged_at,omitempty" db:"acknowledged_at"`
+	ResolvedBy      *int           `json:"resolved_by,omitempty" db:"resolved_by"`
+	ResolvedAt      *time.Time     `json:"resolved_at,omitempty" db:"resolved_at"`
+	ResolutionNote  *string        `json:"resolution_note,omitempty" db:"resolution_note"`
+	CreatedAt       time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// TrackingLink represents the tracking_links table: a read-only view of one order that
+// its passenger hands out. Only the token hash is stored; the token is shown once.
+type TrackingLink struct {
+	ID        int        `json:"id" db:"id"`
+	OrderID   int        `json:"order_id" db:"order_id"`
+	CreatedBy int        `json:"created_by" db:"created_by"`
+	TokenHash string     `json:"-" db:"token_hash"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IsActive reports whether the link still works at now for an order in the given status.
+// Links end with the trip, so they stop working once the order is no longer active.
+func (l *TrackingLink) IsActive(orderStatus OrderStatus, now time.Time) bool {
+	return l.RevokedAt == nil && now.Before(l.ExpiresAt) && orderStatus.IsActive()
+}
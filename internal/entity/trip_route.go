@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// TripRoute represents the trip_routes table: the cleaned trace of a trip and what was
+// measured from it. Polyline holds the simplified shape in encoded polyline format.
+type TripRoute struct {
+	OrderID          int       `json:"order_id" db:"order_id"`
+	Polyline         string    `json:"polyline" db:"polyline"`
+	DistanceKm       float64   `json:"distance_km" db:"distance_km"`
+	DurationSeconds  int       `json:"duration_seconds" db:"duration_seconds"`
+	RawPoints        int       `json:"raw_points" db:"raw_points"`               // pings received
+	KeptPoints       int       `json:"kept_points" db:"kept_points"`             // pings left after dropping GPS jumps
+	SimplifiedPoints int       `json:"simplified_points" db:"simplified_points"` // points in the polyline
+	MeasuredFare     int       `json:"measured_fare" db:"measured_fare"`         // fare for DistanceKm, zone surcharge included
+	StartedAt        time.Time `json:"started_at" db:"started_at"`               // first kept ping
+	EndedAt          time.Time `json:"ended_at" db:"ended_at"`                   // last kept ping
+	BuiltAt          time.Time `json:"built_at" db:"built_at"`
+}
@@ -1,6 +1,9 @@
 package entity
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type UserRole string
 type UserStatus string
@@ -8,6 +11,7 @@ type UserStatus string
 const (
 	RolePassenger UserRole = "PASSENGER"
 	RoleDriver    UserRole = "DRIVER"
+	RoleAdmin     UserRole = "ADMIN"
 )
 
 const (
@@ -18,15 +22,35 @@ const (
 )
 
 type User struct {
-	ID            int        `json:"id" db:"id"`
-	PhoneNumber   string     `json:"phone_number" db:"phone_number"`
-	PasswordHash  string     `json:"-" db:"password_hash"` // Hidden from JSON
-	Email         *string    `json:"email,omitempty" db:"email"`
-	FullName      string     `json:"full_name" db:"full_name"`
-	Role          UserRole   `json:"role" db:"role"`
-	Status        UserStatus `json:"status" db:"status"`
-	PhoneVerified bool       `json:"phone_verified" db:"phone_verified"`
-	LastLoginAt   *time.Time `json:"last_login_at,omitempty" db:"last_login_at"`
-	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+	ID              int        `json:"id" db:"id"`
+	PhoneNumber     string     `json:"phone_number" db:"phone_number"`
+	PasswordHash    string     `json:"-" db:"password_hash"` // Hidden from JSON
+	Email           *string    `json:"email,omitempty" db:"email"`
+	FullName        string     `json:"full_name" db:"full_name"`
+	Role            UserRole   `json:"role" db:"role"`
+	Status          UserStatus `json:"status" db:"status"`
+	PhoneVerified   bool       `json:"phone_verified" db:"phone_verified"`
+	EmailVerified   bool       `json:"email_verified" db:"email_verified"`
+	CampusEligible  bool       `json:"campus_eligible" db:"campus_eligible"`   // verified email on an allow-listed campus domain
+	PreferredLocale string     `json:"preferred_locale" db:"preferred_locale"` // "id" or "en"
+	LastLoginAt     *time.Time `json:"last_login_at,omitempty" db:"last_login_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// VerifiedEmail returns the email only once it has been verified
+func (u *User) VerifiedEmail() *string {
+	if !u.EmailVerified {
+		return nil
+	}
+	return u.Email
+}
+
+// FirstName returns the first word of the user's full name, for places that should not
+// show the whole name
+func (u *User) FirstName() string {
+	if fields := strings.Fields(u.FullName); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
 }
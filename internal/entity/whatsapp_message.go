@@ -0,0 +1,53 @@
+package entity
+
+import "time"
+
+// WhatsAppPurpose is what a WhatsApp message was sent for
+type WhatsAppPurpose string
+
+const (
+	WhatsAppPurposeOTP          WhatsAppPurpose = "OTP"          // reference is the OTP id
+	WhatsAppPurposeNotification WhatsAppPurpose = "NOTIFICATION" // reference is the user id, nil for non-users
+	WhatsAppPurposeRelay        WhatsAppPurpose = "RELAY"        // reference is the relay session id
+)
+
+// WhatsAppStatus is how far a sent WhatsApp message got
+type WhatsAppStatus string
+
+const (
+	WhatsAppStatusSent      WhatsAppStatus = "SENT"
+	WhatsAppStatusDelivered WhatsAppStatus = "DELIVERED"
+	WhatsAppStatusRead      WhatsAppStatus = "READ"
+	WhatsAppStatusFailed    WhatsAppStatus = "FAILED"
+)
+
+// WhatsAppMessage represents the whatsapp_messages table
+type WhatsAppMessage struct {
+	ID                int64           `json:"id" db:"id"`
+	Provider          string          `json:"provider" db:"provider"`
+	ProviderMessageID string          `json:"provider_message_id" db:"provider_message_id"`
+	PhoneNumber       string          `json:"phone_number" db:"phone_number"`
+	Purpose           WhatsAppPurpose `json:"purpose" db:"purpose"`
+	ReferenceID       *int            `json:"reference_id,omitempty" db:"reference_id"`
+	Status            WhatsAppStatus  `json:"status" db:"status"`
+	Error             *string         `json:"error,omitempty" db:"error"`
+	SentAt            time.Time       `json:"sent_at" db:"sent_at"`
+	DeliveredAt       *time.Time      `json:"delivered_at,omitempty" db:"delivered_at"`
+	ReadAt            *time.Time      `json:"read_at,omitempty" db:"read_at"`
+	FailedAt          *time.Time      `json:"failed_at,omitempty" db:"failed_at"`
+}
+
+// CanMoveTo reports whether a delivery update to next is news. Webhooks can arrive out of
+// order, so a message never moves back (a late "delivered" after "read" is dropped), and
+// only a message not yet delivered can fail.
+func (m *WhatsAppMessage) CanMoveTo(next WhatsAppStatus) bool {
+	switch next {
+	case WhatsAppStatusDelivered:
+		return m.Status == WhatsAppStatusSent
+	case WhatsAppStatusRead:
+		return m.Status == WhatsAppStatusSent || m.Status == WhatsAppStatusDelivered
+	case WhatsAppStatusFailed:
+		return m.Status == WhatsAppStatusSent
+	}
+	return false
+}
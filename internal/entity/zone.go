@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/geo"
+)
+
+// ZoneKind defines what a geofence zone restricts
+type ZoneKind string
+
+const (
+	ZoneKindServiceArea ZoneKind = "SERVICE_AREA" // rides must start or end inside one
+	ZoneKindPricing     ZoneKind = "PRICING"      // trips starting or ending inside pay the surcharge
+	ZoneKindNoGo        ZoneKind = "NO_GO"        // no pickups or dropoffs inside
+)
+
+// Zone represents the zones table. Area is stored as GeoJSON in the geometry column.
+type Zone struct {
+	ID        int              `json:"id" db:"id"`
+	Name      string           `json:"name" db:"name"`
+	Kind      ZoneKind         `json:"kind" db:"kind"`
+	Area      geo.MultiPolygon `json:"-" db:"geometry"`
+	Surcharge int              `json:"surcharge" db:"surcharge"`
+	IsActive  bool             `json:"is_active" db:"is_active"`
+	CreatedBy *int             `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// PickupPointKind classifies official pickup and drop points
+type PickupPointKind string
+
+const (
+	PickupPointKindGate    PickupPointKind = "GATE"
+	PickupPointKindFaculty PickupPointKind = "FACULTY"
+	PickupPointKindOther   PickupPointKind = "OTHER"
+)
+
+// PickupPoint represents the pickup_points table
+type PickupPoint struct {
+	ID          int             `json:"id" db:"id"`
+	Name        string          `json:"name" db:"name"`
+	Kind        PickupPointKind `json:"kind" db:"kind"`
+	Lat         float64         `json:"lat" db:"lat"`
+	Long        float64         `json:"long" db:"long"`
+	Description *string         `json:"description,omitempty" db:"description"`
+	IsActive    bool            `json:"is_active" db:"is_active"`
+	CreatedBy   *int            `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// TripStop is a trip's pickup or dropoff; PointID is set when it was snapped onto an
+// official pickup point
+type TripStop struct {
+	Lat     float64
+	Long    float64
+	Address string
+	PointID *int
+}
+
+// TripPlan is a trip checked against the geofence: stops snapped and the pricing zone
+// surcharge worked out
+type TripPlan struct {
+	Pickup    TripStop
+	Dropoff   TripStop
+	Surcharge int
+}
+
+// OutOfAreaDriver is a driver whose last location was outside the service area
+type OutOfAreaDriver struct {
+	UserID       int
+	FullName     string
+	PhoneNumber  string
+	VehiclePlate string
+	IsOnline     bool
+	Lat          *float64
+	Long         *float64
+	Since        time.Time
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/middleware"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/labstack/echo/v4"
+)
+
+type AccountHandler struct {
+	accountService service.AccountService
+}
+
+func NewAccountHandler(accountService service.AccountService) *AccountHandler {
+	return &AccountHandler{accountService: accountService}
+}
+
+// UpdateAccount handles full name and email changes
+// PATCH /api/account
+func (h *AccountHandler) UpdateAccount(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	var req dto.UpdateAccountRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.accountService.UpdateAccount(c.Request().Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	message := "Account updated"
+	if response.PendingEmail != nil {
+		message = "Account updated. Please verify your new email with the code we sent"
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse(message, response))
+}
+
+// SendEmailVerification sends a verification code and link to the current email
+// POST /api/account/email/verification
+func (h *AccountHandler) SendEmailVerification(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	response, err := h.accountService.SendEmailVerification(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Verification email sent", response))
+}
+
+// VerifyEmailChange confirms a pending email change
+// POST /api/account/email/verify
+func (h *AccountHandler) VerifyEmailChange(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	var req dto.VerifyEmailChangeRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.accountService.VerifyEmailChange(c.Request().Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Email verified and updated", response))
+}
+
+// VerifyEmailLink confirms a pending email from the emailed link (no login needed)
+// GET /api/auth/email/verify?token=
+func (h *AccountHandler) VerifyEmailLink(c echo.Context) error {
+	token := c.QueryParam("token")
+	if token == "" {
+		return apperror.ErrInvalidVerificationLink
+	}
+
+	response, err := h.accountService.VerifyEmailLink(c.Request().Context(), token)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Email verified", response))
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
 	"github.com/AnggaKay/ojek-kampus-backend/internal/middleware"
 	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
 	"github.com/labstack/echo/v4"
 )
 
@@ -34,7 +35,7 @@ func (h *AuthHandler) RegisterPassenger(c echo.Context) error {
 
 	result, err := h.authService.RegisterPassenger(c.Request().Context(), req)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, dto.ErrorResponse("REGISTRATION_FAILED", err.Error()))
+		return err
 	}
 
 	return c.JSON(http.StatusCreated, dto.SuccessResponse("Registration successful", result))
@@ -62,7 +63,7 @@ func (h *AuthHandler) Login(c echo.Context) error {
 
 	result, err := h.authService.Login(c.Request().Context(), req)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse("LOGIN_FAILED", err.Error()))
+		return err
 	}
 
 	return c.JSON(http.StatusOK, dto.SuccessResponse("Login successful", result))
@@ -85,7 +86,7 @@ func (h *AuthHandler) RefreshToken(c echo.Context) error {
 
 	result, err := h.authService.RefreshToken(c.Request().Context(), req.RefreshToken)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse("REFRESH_FAILED", err.Error()))
+		return err
 	}
 
 	return c.JSON(http.StatusOK, dto.SuccessResponse("Token refreshed", result))
@@ -107,7 +108,7 @@ func (h *AuthHandler) Logout(c echo.Context) error {
 	}
 
 	if err := h.authService.Logout(c.Request().Context(), req.RefreshToken); err != nil {
-		return c.JSON(http.StatusBadRequest, dto.ErrorResponse("LOGOUT_FAILED", err.Error()))
+		return err
 	}
 
 	return c.JSON(http.StatusOK, dto.SuccessResponse("Logout successful", nil))
@@ -123,7 +124,7 @@ func (h *AuthHandler) Logout(c echo.Context) error {
 func (h *AuthHandler) GetProfile(c echo.Context) error {
 	userID, ok := c.Get("user_id").(int)
 	if !ok {
-		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Invalid user context"))
+		return apperror.ErrUnauthorized
 	}
 
 	userRole := c.Get("user_role")
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/middleware"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/labstack/echo/v4"
+)
+
+type CampusHandler struct {
+	campusService service.CampusService
+}
+
+func NewCampusHandler(campusService service.CampusService) *CampusHandler {
+	return &CampusHandler{
+		campusService: campusService,
+	}
+}
+
+// AddDomain allow-lists a campus email domain (admin only)
+// POST /api/admin/campus-domains
+func (h *CampusHandler) AddDomain(c echo.Context) error {
+	adminID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	var req dto.CreateCampusDomainRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.campusService.AddDomain(c.Request().Context(), adminID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, dto.SuccessResponse("Campus domain added", response))
+}
+
+// ListDomains returns the allow-listed campus domains (admin only)
+// GET /api/admin/campus-domains
+func (h *CampusHandler) ListDomains(c echo.Context) error {
+	domains, err := h.campusService.ListDomains(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Campus domains retrieved", domains))
+}
+
+// RemoveDomain removes a campus domain from the allow-list (admin only)
+// DELETE /api/admin/campus-domains/:id
+func (h *CampusHandler) RemoveDomain(c echo.Context) error {
+	domainID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	if err := h.campusService.RemoveDomain(c.Request().Context(), domainID); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Campus domain removed", nil))
+}
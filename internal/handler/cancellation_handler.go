@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/middleware"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/labstack/echo/v4"
+)
+
+type CancellationHandler struct {
+	cancellationService service.CancellationService
+}
+
+func NewCancellationHandler(cancellationService service.CancellationService) *CancellationHandler {
+	return &CancellationHandler{
+		cancellationService: cancellationService,
+	}
+}
+
+// GetReasons returns the cancellation reason codes for the caller's role
+// GET /api/cancellation-reasons
+func (h *CancellationHandler) GetReasons(c echo.Context) error {
+	userType, _ := c.Get("user_type").(string)
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Cancellation reasons retrieved", h.cancellationService.GetReasons(userType)))
+}
+
+// CancelByPassenger cancels the passenger's order before the trip starts
+// POST /api/passenger/orders/:id/cancel
+func (h *CancellationHandler) CancelByPassenger(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	var req dto.CancelOrderRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.cancellationService.CancelByPassenger(c.Request().Context(), userID, orderID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Order cancelled", response))
+}
+
+// CancelByDriver drops an accepted order or reports a passenger no-show
+// POST /api/driver/orders/:id/cancel
+func (h *CancellationHandler) CancelByDriver(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	var req dto.CancelOrderRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.cancellationService.CancelByDriver(c.Request().Context(), userID, orderID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Order cancelled", response))
+}
+
+// ListCooldowns returns users paused for cancelling too often (admin only)
+// GET /api/admin/cooldowns?limit=&offset=
+func (h *CancellationHandler) ListCooldowns(c echo.Context) error {
+	limit, offset := parsePagination(c)
+
+	cooldowns, err := h.cancellationService.ListCooldowns(c.Request().Context(), limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Cooldowns retrieved", cooldowns))
+}
+
+// LiftCooldown ends a user's cancellation cooldown early (admin only)
+// DELETE /api/admin/users/:id/cooldown
+func (h *CancellationHandler) LiftCooldown(c echo.Context) error {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	if err := h.cancellationService.LiftCooldown(c.Request().Context(), userID); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Cooldown lifted", nil))
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/middleware"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/labstack/echo/v4"
+)
+
+type ChatHandler struct {
+	chatService service.ChatService
+}
+
+func NewChatHandler(chatService service.ChatService) *ChatHandler {
+	return &ChatHandler{
+		chatService: chatService,
+	}
+}
+
+// GetQuickReplies returns the chat quick replies for the caller's role
+// GET /api/chat/quick-replies
+func (h *ChatHandler) GetQuickReplies(c echo.Context) error {
+	userType, _ := c.Get("user_type").(string)
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Quick replies retrieved", h.chatService.GetQuickReplies(c.Request().Context(), userType)))
+}
+
+// ListMessages returns an order's chat messages after ?after_id=, oldest first
+// GET /api/orders/:id/chat
+// GET /api/admin/orders/:id/chat
+func (h *ChatHandler) ListMessages(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+	userType, _ := c.Get("user_type").(string)
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	var afterID int64
+	if v := c.QueryParam("after_id"); v != "" {
+		afterID, err = strconv.ParseInt(v, 10, 64)
+		if err != nil || afterID < 0 {
+			return apperror.ErrInvalidRequest
+		}
+	}
+	limit, _ := parsePagination(c)
+
+	chat, err := h.chatService.ListMessages(c.Request().Context(), userID, userType, orderID, afterID, limit)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Chat retrieved", chat))
+}
+
+// SendMessage sends a chat message or quick reply to the other participant
+// POST /api/orders/:id/chat
+func (h *ChatHandler) SendMessage(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	var req dto.SendChatMessageRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	message, err := h.chatService.SendMessage(c.Request().Context(), userID, orderID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, dto.SuccessResponse("Message sent", message))
+}
+
+// MarkRead marks received chat messages as read
+// POST /api/orders/:id/chat/read
+func (h *ChatHandler) MarkRead(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	var req dto.MarkChatReadRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	receipt, err := h.chatService.MarkRead(c.Request().Context(), userID, orderID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Chat marked as read", receipt))
+}
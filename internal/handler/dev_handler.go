@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/payment"
+	"github.com/labstack/echo/v4"
+)
+
+// DevHandler drives the in-process fake providers during development (admin only,
+// mounted with DEV_ROUTES=true)
+type DevHandler struct {
+	paymentMock *payment.MockServer
+}
+
+func NewDevHandler(paymentMock *payment.MockServer) *DevHandler {
+	return &DevHandler{paymentMock: paymentMock}
+}
+
+// PayCharge simulates the customer paying a pending mock gateway charge; the gateway
+// then sends the signed settlement notification to the payment webhook
+// POST /api/dev/payments/:reference/pay
+func (h *DevHandler) PayCharge(c echo.Context) error {
+	if h.paymentMock == nil {
+		return apperror.ErrRouteNotFound
+	}
+
+	reference := c.Param("reference")
+	if err := h.paymentMock.Pay(c.Request().Context(), reference); err != nil {
+		if errors.Is(err, payment.ErrChargeNotFound) {
+			return apperror.ErrPaymentChargeNotFound
+		}
+		return apperror.ErrInvalidRequest.WithDetail(err.Error())
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Charge paid", map[string]string{"reference": reference}))
+}
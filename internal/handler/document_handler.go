@@ -2,12 +2,12 @@ package handler
 
 import (
 	"fmt"
-	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
-	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
 	"github.com/labstack/echo/v4"
@@ -30,13 +30,13 @@ func (h *DocumentHandler) GetDocument(c echo.Context) error {
 	userID, ok := c.Get("user_id").(int)
 	if !ok {
 		logger.Log.Warn().Msg("Failed to get user_id from context")
-		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Invalid user context"))
+		return apperror.ErrUnauthorized
 	}
 
 	userRole, ok := c.Get("user_role").(string)
 	if !ok {
 		logger.Log.Warn().Int("user_id", userID).Msg("Failed to get user_role from context")
-		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Invalid user role"))
+		return apperror.ErrUnauthorized
 	}
 
 	// Get document type and filename from URL params
@@ -53,7 +53,7 @@ func (h *DocumentHandler) GetDocument(c echo.Context) error {
 
 	if !validDocTypes[strings.ToLower(docType)] {
 		logger.Log.Warn().Str("doc_type", docType).Msg("Invalid document type requested")
-		return c.JSON(http.StatusBadRequest, dto.ErrorResponse("INVALID_DOC_TYPE", "Invalid document type"))
+		return apperror.ErrInvalidDocumentType
 	}
 
 	// Prevent directory traversal attacks
@@ -62,7 +62,7 @@ func (h *DocumentHandler) GetDocument(c echo.Context) error {
 			Str("filename", filename).
 			Int("user_id", userID).
 			Msg("Directory traversal attempt detected")
-		return c.JSON(http.StatusBadRequest, dto.ErrorResponse("INVALID_FILENAME", "Invalid filename"))
+		return apperror.ErrInvalidFilename
 	}
 
 	// Extract driver user ID from filename or path
@@ -78,14 +78,14 @@ func (h *DocumentHandler) GetDocument(c echo.Context) error {
 			Int("user_id", userID).
 			Str("role", userRole).
 			Msg("Passenger attempted to access driver document")
-		return c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", constants.ErrUnauthorizedAccess))
+		return apperror.ErrDocumentAccessDenied
 	}
 
 	var filePath string
 
 	if userRole == "ADMIN" {
 		// Admin can access any document - search all driver directories
-		driverDir := filepath.Join(h.uploadDir, "drivers")
+		driverDir := filepath.Join(h.uploadDir, constants.StorageNamespaceDrivers)
 
 		// Walk through all driver subdirectories to find the file
 		found := false
@@ -106,18 +106,18 @@ func (h *DocumentHandler) GetDocument(c echo.Context) error {
 				Str("filename", filename).
 				Str("doc_type", docType).
 				Msg("Document not found for admin")
-			return c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", constants.ErrDocumentNotFound))
+			return apperror.ErrDocumentNotFound
 		}
 	} else if userRole == "DRIVER" {
 		// Driver can only access their own documents
 		driverIDStr := fmt.Sprintf("%d", userID)
-		filePath = filepath.Join(h.uploadDir, "drivers", driverIDStr, docType, filename)
+		filePath = filepath.Join(h.uploadDir, constants.StorageNamespaceDrivers, driverIDStr, docType, filename)
 	} else {
 		logger.Log.Warn().
 			Int("user_id", userID).
 			Str("role", userRole).
 			Msg("Unknown role attempted to access document")
-		return c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", constants.ErrUnauthorizedAccess))
+		return apperror.ErrDocumentAccessDenied
 	}
 
 	// Check if file exists
@@ -126,7 +126,7 @@ func (h *DocumentHandler) GetDocument(c echo.Context) error {
 			Str("file_path", filePath).
 			Int("user_id", userID).
 			Msg("Document file not found")
-		return c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", constants.ErrDocumentNotFound))
+		return apperror.ErrDocumentNotFound
 	}
 
 	// Determine content type based on file extension
@@ -154,3 +154,31 @@ func (h *DocumentHandler) GetDocument(c echo.Context) error {
 
 	return c.File(filePath)
 }
+
+// GetAvatar serves profile pictures of passengers and drivers to any authenticated user
+// GET /api/avatars/:namespace/:owner_id/:filename
+func (h *DocumentHandler) GetAvatar(c echo.Context) error {
+	namespace := c.Param("namespace")
+	filename := c.Param("filename")
+
+	if namespace != constants.StorageNamespacePassengers && namespace != constants.StorageNamespaceDrivers {
+		return apperror.ErrInvalidRequest.WithDetail("namespace")
+	}
+
+	ownerID, err := strconv.Atoi(c.Param("owner_id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	if strings.Contains(filename, "..") || strings.Contains(filename, "/") || strings.Contains(filename, "\\") {
+		logger.Log.Warn().Str("filename", filename).Msg("Directory traversal attempt detected")
+		return apperror.ErrInvalidFilename
+	}
+
+	filePath := filepath.Join(h.uploadDir, namespace, strconv.Itoa(ownerID), strings.ToLower(constants.DocumentTypeProfilePicture), filename)
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return apperror.ErrDocumentNotFound
+	}
+
+	return c.File(filePath)
+}
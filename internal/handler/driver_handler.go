@@ -3,10 +3,13 @@ package handler
 import (
 	"mime/multipart"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
 	"github.com/AnggaKay/ojek-kampus-backend/internal/middleware"
 	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
 	"github.com/labstack/echo/v4"
@@ -31,7 +34,7 @@ func (h *DriverHandler) RegisterDriver(c echo.Context) error {
 	err := c.Request().ParseMultipartForm(constants.MaxTotalUploadSize)
 	if err != nil {
 		logger.Log.Error().Err(err).Msg("Failed to parse multipart form")
-		return c.JSON(http.StatusBadRequest, dto.ErrorResponse("INVALID_FORM", "Failed to parse form data"))
+		return apperror.ErrInvalidRequest.Wrap(err)
 	}
 
 	// Extract form fields
@@ -55,14 +58,14 @@ func (h *DriverHandler) RegisterDriver(c echo.Context) error {
 	form := c.Request().MultipartForm
 	if form == nil || form.File == nil {
 		logger.Log.Warn().Msg("No files uploaded in driver registration")
-		return c.JSON(http.StatusBadRequest, dto.ErrorResponse("MISSING_FILES", "Document files are required"))
+		return apperror.ErrMissingFile.WithVars(map[string]string{"field": "ktp, sim, stnk, ktm"})
 	}
 
 	// Helper function to get file from form
 	getFile := func(fieldName string) (*multipart.FileHeader, error) {
 		files := form.File[fieldName]
 		if len(files) == 0 {
-			return nil, echo.NewHTTPError(http.StatusBadRequest, fieldName+" document is required")
+			return nil, apperror.ErrMissingFile.WithVars(map[string]string{"field": strings.ToUpper(fieldName)})
 		}
 		return files[0], nil
 	}
@@ -71,25 +74,25 @@ func (h *DriverHandler) RegisterDriver(c echo.Context) error {
 	ktpFile, err := getFile("ktp")
 	if err != nil {
 		logger.Log.Warn().Msg("KTP file missing in driver registration")
-		return c.JSON(http.StatusBadRequest, dto.ErrorResponse("MISSING_KTP", "KTP document is required"))
+		return err
 	}
 
 	simFile, err := getFile("sim")
 	if err != nil {
 		logger.Log.Warn().Msg("SIM file missing in driver registration")
-		return c.JSON(http.StatusBadRequest, dto.ErrorResponse("MISSING_SIM", "SIM document is required"))
+		return err
 	}
 
 	stnkFile, err := getFile("stnk")
 	if err != nil {
 		logger.Log.Warn().Msg("STNK file missing in driver registration")
-		return c.JSON(http.StatusBadRequest, dto.ErrorResponse("MISSING_STNK", "STNK document is required"))
+		return err
 	}
 
 	ktmFile, err := getFile("ktm")
 	if err != nil {
 		logger.Log.Warn().Msg("KTM file missing in driver registration")
-		return c.JSON(http.StatusBadRequest, dto.ErrorResponse("MISSING_KTM", "KTM (Student ID Card) document is required"))
+		return err
 	}
 
 	// Prepare documents map for service
@@ -105,22 +108,7 @@ func (h *DriverHandler) RegisterDriver(c echo.Context) error {
 	if err != nil {
 		logger.Log.Error().Err(err).Str("phone", req.PhoneNumber).Msg("Driver registration failed")
 
-		// Check error type for appropriate status code
-		errMsg := err.Error()
-		switch errMsg {
-		case constants.ErrPhoneAlreadyRegistered:
-			return c.JSON(http.StatusBadRequest, dto.ErrorResponse("PHONE_EXISTS", errMsg))
-		case constants.ErrEmailAlreadyRegistered:
-			return c.JSON(http.StatusBadRequest, dto.ErrorResponse("EMAIL_EXISTS", errMsg))
-		case constants.ErrVehiclePlateExists:
-			return c.JSON(http.StatusBadRequest, dto.ErrorResponse("PLATE_EXISTS", errMsg))
-		case constants.ErrFileTooLarge:
-			return c.JSON(http.StatusBadRequest, dto.ErrorResponse("FILE_TOO_LARGE", errMsg))
-		case constants.ErrInvalidFileType:
-			return c.JSON(http.StatusBadRequest, dto.ErrorResponse("INVALID_FILE_TYPE", errMsg))
-		default:
-			return c.JSON(http.StatusInternalServerError, dto.ErrorResponse("REGISTRATION_FAILED", "Failed to register driver"))
-		}
+		return err
 	}
 
 	logger.Log.Info().
@@ -130,3 +118,112 @@ func (h *DriverHandler) RegisterDriver(c echo.Context) error {
 
 	return c.JSON(http.StatusCreated, dto.SuccessResponse("Driver registration successful. Your documents are being reviewed.", response))
 }
+
+// UpdateProfile handles partial vehicle profile update by the driver
+// PATCH /api/driver/profile
+// Accepts JSON, or multipart/form-data when vehicle_plate changes (a new "stnk" file is required)
+func (h *DriverHandler) UpdateProfile(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	var req dto.UpdateDriverProfileRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	// STNK file is optional and only used when the plate changes
+	var stnkFile *multipart.FileHeader
+	if file, err := c.FormFile("stnk"); err == nil {
+		stnkFile = file
+	}
+
+	response, err := h.driverService.UpdateProfile(c.Request().Context(), userID, req, stnkFile)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Driver profile updated", response))
+}
+
+// UploadProfilePicture handles driver profile picture upload
+// PUT /api/driver/profile/picture
+func (h *DriverHandler) UploadProfilePicture(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	file, err := c.FormFile("profile_picture")
+	if err != nil {
+		return apperror.ErrMissingFile.WithVars(map[string]string{"field": "profile_picture"})
+	}
+
+	response, err := h.driverService.UpdateProfilePicture(c.Request().Context(), userID, file)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Profile picture updated", response))
+}
+
+// GetProfileChanges returns profile change history of a driver (admin only)
+// GET /api/admin/drivers/:id/profile-changes?limit=&offset=
+func (h *DriverHandler) GetProfileChanges(c echo.Context) error {
+	driverProfileID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	limit, offset := parsePagination(c)
+
+	changes, err := h.driverService.GetProfileChanges(c.Request().Context(), driverProfileID, limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Profile changes retrieved", changes))
+}
+
+// UpdateLocation stores the driver's current position
+// PUT /api/driver/location
+func (h *DriverHandler) UpdateLocation(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	var req dto.UpdateDriverLocationRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.driverService.UpdateLocation(c.Request().Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Location updated", response))
+}
+
+// UpdateStatus switches the driver online (receiving offers) or offline
+// PUT /api/driver/status
+func (h *DriverHandler) UpdateStatus(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	var req dto.UpdateDriverStatusRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.driverService.SetOnlineStatus(c.Request().Context(), userID, *req.Online)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Driver status updated", response))
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/middleware"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/labstack/echo/v4"
+)
+
+const maxDocumentExpiryWindowDays = 365
+
+type DriverReviewHandler struct {
+	driverReviewService service.DriverReviewService
+}
+
+func NewDriverReviewHandler(driverReviewService service.DriverReviewService) *DriverReviewHandler {
+	return &DriverReviewHandler{
+		driverReviewService: driverReviewService,
+	}
+}
+
+// ReviewDriver approves or rejects a driver's documents and records their expiry dates (admin only)
+// POST /api/admin/drivers/:id/review
+func (h *DriverReviewHandler) ReviewDriver(c echo.Context) error {
+	adminID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	driverProfileID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	var req dto.ReviewDriverRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.driverReviewService.ReviewDriver(c.Request().Context(), adminID, driverProfileID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Driver reviewed", response))
+}
+
+// ListExpiringDocuments returns driver documents expiring within the next days (admin only)
+// GET /api/admin/drivers/document-expiries?days=&limit=&offset=
+func (h *DriverReviewHandler) ListExpiringDocuments(c echo.Context) error {
+	days := constants.DocumentExpiryWindowDays
+	if v, err := strconv.Atoi(c.QueryParam("days")); err == nil && v >= 0 {
+		days = v
+	}
+	if days > maxDocumentExpiryWindowDays {
+		days = maxDocumentExpiryWindowDays
+	}
+	limit, offset := parsePagination(c)
+
+	documents, err := h.driverReviewService.ListExpiringDocuments(c.Request().Context(), days, limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Expiring documents retrieved", documents))
+}
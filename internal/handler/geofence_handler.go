@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/middleware"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/labstack/echo/v4"
+)
+
+type GeofenceHandler struct {
+	geofenceService service.GeofenceService
+}
+
+func NewGeofenceHandler(geofenceService service.GeofenceService) *GeofenceHandler {
+	return &GeofenceHandler{
+		geofenceService: geofenceService,
+	}
+}
+
+// CreateZone adds a service area, pricing or no-go zone from GeoJSON (admin only)
+// POST /api/admin/zones
+func (h *GeofenceHandler) CreateZone(c echo.Context) error {
+	adminID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	var req dto.CreateZoneRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.geofenceService.CreateZone(c.Request().Context(), adminID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, dto.SuccessResponse("Zone created", response))
+}
+
+// ListZones returns the geofence zones, optionally of one kind (admin only)
+// GET /api/admin/zones?kind=
+func (h *GeofenceHandler) ListZones(c echo.Context) error {
+	kind := c.QueryParam("kind")
+	switch kind {
+	case "", "SERVICE_AREA", "PRICING", "NO_GO":
+	default:
+		return apperror.ErrInvalidRequest
+	}
+
+	zones, err := h.geofenceService.ListZones(c.Request().Context(), kind)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Zones retrieved", zones))
+}
+
+// GetZone returns a zone with its GeoJSON geometry (admin only)
+// GET /api/admin/zones/:id
+func (h *GeofenceHandler) GetZone(c echo.Context) error {
+	zoneID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	zone, err := h.geofenceService.GetZone(c.Request().Context(), zoneID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Zone retrieved", zone))
+}
+
+// UpdateZone changes a zone's name, geometry, surcharge or active flag (admin only)
+// PATCH /api/admin/zones/:id
+func (h *GeofenceHandler) UpdateZone(c echo.Context) error {
+	zoneID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	var req dto.UpdateZoneRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	zone, err := h.geofenceService.UpdateZone(c.Request().Context(), zoneID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Zone updated", zone))
+}
+
+// DeleteZone removes a zone (admin only)
+// DELETE /api/admin/zones/:id
+func (h *GeofenceHandler) DeleteZone(c echo.Context) error {
+	zoneID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	if err := h.geofenceService.DeleteZone(c.Request().Context(), zoneID); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Zone deleted", nil))
+}
+
+// CreatePickupPoint adds an official pickup point such as a campus gate (admin only)
+// POST /api/admin/pickup-points
+func (h *GeofenceHandler) CreatePickupPoint(c echo.Context) error {
+	adminID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	var req dto.CreatePickupPointRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	point, err := h.geofenceService.CreatePickupPoint(c.Request().Context(), adminID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, dto.SuccessResponse("Pickup point created", point))
+}
+
+// ListAllPickupPoints returns every pickup point, inactive ones included (admin only)
+// GET /api/admin/pickup-points
+func (h *GeofenceHandler) ListAllPickupPoints(c echo.Context) error {
+	points, err := h.geofenceService.ListPickupPoints(c.Request().Context(), false)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Pickup points retrieved", points))
+}
+
+// UpdatePickupPoint changes a pickup point (admin only)
+// PATCH /api/admin/pickup-points/:id
+func (h *GeofenceHandler) UpdatePickupPoint(c echo.Context) error {
+	pointID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	var req dto.UpdatePickupPointRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	point, err := h.geofenceService.UpdatePickupPoint(c.Request().Context(), pointID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Pickup point updated", point))
+}
+
+// DeletePickupPoint removes a pickup point (admin only)
+// DELETE /api/admin/pickup-points/:id
+func (h *GeofenceHandler) DeletePickupPoint(c echo.Context) error {
+	pointID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	if err := h.geofenceService.DeletePickupPoint(c.Request().Context(), pointID); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Pickup point deleted", nil))
+}
+
+// ListDriversOutsideArea returns drivers whose last location was outside the service area (admin only)
+// GET /api/admin/drivers/outside-area?limit=&offset=
+func (h *GeofenceHandler) ListDriversOutsideArea(c echo.Context) error {
+	limit, offset := parsePagination(c)
+
+	drivers, err := h.geofenceService.ListDriversOutsideArea(c.Request().Context(), limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Drivers outside service area retrieved", drivers))
+}
+
+// ListPickupPoints returns the active pickup points riders can choose from
+// GET /api/pickup-points
+func (h *GeofenceHandler) ListPickupPoints(c echo.Context) error {
+	points, err := h.geofenceService.ListPickupPoints(c.Request().Context(), true)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Pickup points retrieved", points))
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/labstack/echo/v4"
+)
+
+type JobHandler struct {
+	jobService service.JobService
+}
+
+func NewJobHandler(jobService service.JobService) *JobHandler {
+	return &JobHandler{jobService: jobService}
+}
+
+// ListDeadJobs returns dead-lettered background jobs (admin only)
+// GET /api/admin/jobs/dead?limit=&offset=
+func (h *JobHandler) ListDeadJobs(c echo.Context) error {
+	limit, offset := parsePagination(c)
+
+	jobs, err := h.jobService.ListDeadJobs(c.Request().Context(), limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Dead jobs retrieved", jobs))
+}
+
+// RetryJob requeues a dead-lettered job (admin only)
+// POST /api/admin/jobs/:id/retry
+func (h *JobHandler) RetryJob(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	if err := h.jobService.RetryJob(c.Request().Context(), id); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Job requeued", nil))
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/middleware"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/labstack/echo/v4"
+)
+
+type NotificationHandler struct {
+	notificationService service.NotificationService
+}
+
+func NewNotificationHandler(notificationService service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService}
+}
+
+// RegisterDevice registers an FCM token for the current user (multiple devices allowed)
+// POST /api/account/devices
+func (h *NotificationHandler) RegisterDevice(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	var req dto.RegisterDeviceRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.notificationService.RegisterDevice(c.Request().Context(), userID, req); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Device registered", nil))
+}
+
+// UnregisterDevice removes an FCM token of the current user (e.g. on logout)
+// DELETE /api/account/devices
+func (h *NotificationHandler) UnregisterDevice(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	var req dto.UnregisterDeviceRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.notificationService.UnregisterDevice(c.Request().Context(), userID, req.FCMToken); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Device unregistered", nil))
+}
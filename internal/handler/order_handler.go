@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/middleware"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/labstack/echo/v4"
+)
+
+type OrderHandler struct {
+	orderService    service.OrderService
+	dispatchService service.DispatchService
+}
+
+func NewOrderHandler(orderService service.OrderService, dispatchService service.DispatchService) *OrderHandler {
+	return &OrderHandler{
+		orderService:    orderService,
+		dispatchService: dispatchService,
+	}
+}
+
+// EstimateFare quotes a trip's fare, with an optional promo code applied
+// POST /api/passenger/orders/estimate
+func (h *OrderHandler) EstimateFare(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	var req dto.EstimateFareRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.orderService.EstimateFare(c.Request().Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Fare estimated", response))
+}
+
+// CreateOrder places a ride order and starts looking for a driver, or books it for the
+// requested pickup time
+// POST /api/passenger/orders
+func (h *OrderHandler) CreateOrder(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	var req dto.CreateOrderRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.orderService.CreateOrder(c.Request().Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	if response.ScheduledAt != nil {
+		return c.JSON(http.StatusCreated, dto.SuccessResponse("Ride scheduled", response))
+	}
+	return c.JSON(http.StatusCreated, dto.SuccessResponse("Order created, looking for a driver", response))
+}
+
+// GetOrder returns an order to its passenger or driver
+// GET /api/orders/:id
+func (h *OrderHandler) GetOrder(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	response, err := h.orderService.GetOrder(c.Request().Context(), userID, orderID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Order retrieved", response))
+}
+
+// GetCurrentOffer returns the order currently offered to the driver
+// GET /api/driver/offers/current
+func (h *OrderHandler) GetCurrentOffer(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	response, err := h.dispatchService.GetPendingOffer(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Offer retrieved", response))
+}
+
+// AcceptOffer takes the offered order
+// POST /api/driver/offers/:id/accept
+func (h *OrderHandler) AcceptOffer(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	offerID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	response, err := h.dispatchService.AcceptOffer(c.Request().Context(), userID, offerID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Order accepted", response))
+}
+
+// DeclineOffer passes the offered order to the next driver
+// POST /api/driver/offers/:id/decline
+func (h *OrderHandler) DeclineOffer(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	offerID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	if err := h.dispatchService.DeclineOffer(c.Request().Context(), userID, offerID); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Offer declined", nil))
+}
+
+// ListOrderOffers returns every dispatch offer of an order with its ranking inputs (admin only)
+// GET /api/admin/orders/:id/offers
+func (h *OrderHandler) ListOrderOffers(c echo.Context) error {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	offers, err := h.dispatchService.ListOrderOffers(c.Request().Context(), orderID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Dispatch offers retrieved", offers))
+}
+
+// ArriveAtPickup tells the passenger the driver is waiting
+// POST /api/driver/orders/:id/arrive
+func (h *OrderHandler) ArriveAtPickup(c echo.Context) error {
+	return h.advanceTrip(c, h.orderService.ArriveAtPickup, "Arrival recorded")
+}
+
+// StartTrip marks that the passenger has been picked up
+// POST /api/driver/orders/:id/start
+func (h *OrderHandler) StartTrip(c echo.Context) error {
+	return h.advanceTrip(c, h.orderService.StartTrip, "Trip started")
+}
+
+// CompleteTrip marks that the passenger has been dropped off
+// POST /api/driver/orders/:id/complete
+func (h *OrderHandler) CompleteTrip(c echo.Context) error {
+	return h.advanceTrip(c, h.orderService.CompleteTrip, "Trip completed")
+}
+
+func (h *OrderHandler) advanceTrip(c echo.Context, advance func(ctx context.Context, driverID, orderID int) (*dto.OrderResponse, error), message string) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	response, err := advance(c.Request().Context(), userID, orderID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse(message, response))
+}
@@ -4,7 +4,9 @@ import (
 	"net/http"
 
 	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/middleware"
 	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
 	"github.com/labstack/echo/v4"
 )
@@ -35,22 +37,8 @@ func NewOTPHandler(otpService service.OTPService) *OTPHandler {
 // @Router /api/auth/send-otp [post]
 func (h *OTPHandler) SendOTP(c echo.Context) error {
 	var req dto.SendOTPRequest
-	if err := c.Bind(&req); err != nil {
-		logger.Log.Warn().Err(err).Msg("Invalid request body")
-		return c.JSON(http.StatusBadRequest, dto.Response{
-			Success: false,
-			Message: "Invalid request body",
-			Data:    nil,
-		})
-	}
-
-	// Validate request
-	if err := c.Validate(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, dto.Response{
-			Success: false,
-			Message: err.Error(),
-			Data:    nil,
-		})
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
 	}
 
 	// Get IP address and user agent
@@ -62,20 +50,11 @@ func (h *OTPHandler) SendOTP(c echo.Context) error {
 	if err != nil {
 		logger.Log.Error().Err(err).Msg("Failed to send OTP")
 
-		// Check for rate limit error
-		if contains(err.Error(), "please wait") {
-			return c.JSON(http.StatusTooManyRequests, dto.Response{
-				Success: false,
-				Message: err.Error(),
-				Data:    nil,
-			})
+		// Rate limit and other domain errors keep their own status
+		if _, ok := apperror.As(err); ok {
+			return err
 		}
-
-		return c.JSON(http.StatusInternalServerError, dto.Response{
-			Success: false,
-			Message: "Gagal mengirim kode OTP. Silakan coba lagi.",
-			Data:    nil,
-		})
+		return apperror.ErrOTPSendFailed.Wrap(err)
 	}
 
 	return c.JSON(http.StatusOK, dto.Response{
@@ -98,33 +77,15 @@ func (h *OTPHandler) SendOTP(c echo.Context) error {
 // @Router /api/auth/verify-otp [post]
 func (h *OTPHandler) VerifyOTP(c echo.Context) error {
 	var req dto.VerifyOTPRequest
-	if err := c.Bind(&req); err != nil {
-		logger.Log.Warn().Err(err).Msg("Invalid request body")
-		return c.JSON(http.StatusBadRequest, dto.Response{
-			Success: false,
-			Message: "Invalid request body",
-			Data:    nil,
-		})
-	}
-
-	// Validate request
-	if err := c.Validate(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, dto.Response{
-			Success: false,
-			Message: err.Error(),
-			Data:    nil,
-		})
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
 	}
 
 	// Verify OTP
 	response, err := h.otpService.VerifyOTP(c.Request().Context(), req)
 	if err != nil {
 		logger.Log.Error().Err(err).Msg("Failed to verify OTP")
-		return c.JSON(http.StatusInternalServerError, dto.Response{
-			Success: false,
-			Message: "Gagal memverifikasi kode OTP. Silakan coba lagi.",
-			Data:    nil,
-		})
+		return apperror.ErrOTPVerifyFailed.Wrap(err)
 	}
 
 	// If verification failed, return 400
@@ -157,22 +118,8 @@ func (h *OTPHandler) VerifyOTP(c echo.Context) error {
 // @Router /api/auth/resend-otp [post]
 func (h *OTPHandler) ResendOTP(c echo.Context) error {
 	var req dto.ResendOTPRequest
-	if err := c.Bind(&req); err != nil {
-		logger.Log.Warn().Err(err).Msg("Invalid request body")
-		return c.JSON(http.StatusBadRequest, dto.Response{
-			Success: false,
-			Message: "Invalid request body",
-			Data:    nil,
-		})
-	}
-
-	// Validate request
-	if err := c.Validate(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, dto.Response{
-			Success: false,
-			Message: err.Error(),
-			Data:    nil,
-		})
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
 	}
 
 	// Get IP address and user agent
@@ -184,20 +131,11 @@ func (h *OTPHandler) ResendOTP(c echo.Context) error {
 	if err != nil {
 		logger.Log.Error().Err(err).Msg("Failed to resend OTP")
 
-		// Check for rate limit error
-		if contains(err.Error(), "please wait") {
-			return c.JSON(http.StatusTooManyRequests, dto.Response{
-				Success: false,
-				Message: err.Error(),
-				Data:    nil,
-			})
+		// Rate limit and other domain errors keep their own status
+		if _, ok := apperror.As(err); ok {
+			return err
 		}
-
-		return c.JSON(http.StatusInternalServerError, dto.Response{
-			Success: false,
-			Message: "Gagal mengirim ulang kode OTP. Silakan coba lagi.",
-			Data:    nil,
-		})
+		return apperror.ErrOTPResendFailed.Wrap(err)
 	}
 
 	return c.JSON(http.StatusOK, dto.Response{
@@ -206,8 +144,3 @@ func (h *OTPHandler) ResendOTP(c echo.Context) error {
 		Data:    response,
 	})
 }
-
-// Helper function to check if string contains substring
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && s[:len(substr)] == substr || len(s) > len(substr) && contains(s[1:], substr)
-}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// parsePagination reads limit/offset query params with sane defaults
+func parsePagination(c echo.Context) (limit, offset int) {
+	limit = defaultPageLimit
+	if v, err := strconv.Atoi(c.QueryParam("limit")); err == nil && v > 0 {
+		limit = v
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+	if v, err := strconv.Atoi(c.QueryParam("offset")); err == nil && v > 0 {
+		offset = v
+	}
+	return limit, offset
+}
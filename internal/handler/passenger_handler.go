@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/middleware"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/labstack/echo/v4"
+)
+
+type PassengerHandler struct {
+	passengerService service.PassengerService
+}
+
+func NewPassengerHandler(passengerService service.PassengerService) *PassengerHandler {
+	return &PassengerHandler{passengerService: passengerService}
+}
+
+// GetProfile returns the current passenger's profile
+// GET /api/passenger/profile
+func (h *PassengerHandler) GetProfile(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	response, err := h.passengerService.GetProfile(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Passenger profile retrieved", response))
+}
+
+// UpdateProfile handles partial passenger profile update (FCM token registration)
+// PATCH /api/passenger/profile
+func (h *PassengerHandler) UpdateProfile(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	var req dto.UpdatePassengerProfileRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.passengerService.UpdateProfile(c.Request().Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Passenger profile updated", response))
+}
+
+// UploadProfilePicture handles passenger avatar upload
+// PUT /api/passenger/profile/picture
+func (h *PassengerHandler) UploadProfilePicture(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	file, err := c.FormFile("profile_picture")
+	if err != nil {
+		return apperror.ErrMissingFile.WithVars(map[string]string{"field": "profile_picture"})
+	}
+
+	response, err := h.passengerService.UpdateProfilePicture(c.Request().Context(), userID, file)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Profile picture updated", response))
+}
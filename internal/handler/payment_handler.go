@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/middleware"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/labstack/echo/v4"
+)
+
+// maxNotificationBody bounds payment gateway webhook bodies
+const maxNotificationBody = 64 * 1024
+
+type PaymentHandler struct {
+	paymentService service.PaymentService
+}
+
+func NewPaymentHandler(paymentService service.PaymentService) *PaymentHandler {
+	return &PaymentHandler{paymentService: paymentService}
+}
+
+// CreateTopUp starts a wallet top-up paid by QRIS or virtual account
+// POST /api/passenger/wallet/topups
+func (h *PaymentHandler) CreateTopUp(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	var req dto.CreateTopUpChargeRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.paymentService.CreateTopUpCharge(c.Request().Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, dto.SuccessResponse("Top-up charge created", response))
+}
+
+// ListTopUps returns the passenger's top-up charges
+// GET /api/passenger/wallet/topups?limit=&offset=
+func (h *PaymentHandler) ListTopUps(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+	limit, offset := parsePagination(c)
+
+	charges, err := h.paymentService.ListTopUpCharges(c.Request().Context(), userID, limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Top-up charges retrieved", charges))
+}
+
+// GetTopUp returns one top-up charge and its payment status
+// GET /api/passenger/wallet/topups/:id
+func (h *PaymentHandler) GetTopUp(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	chargeID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	response, err := h.paymentService.GetTopUpCharge(c.Request().Context(), userID, chargeID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Top-up charge retrieved", response))
+}
+
+// Notification receives the payment gateway webhook (public; authenticated by signature)
+// POST /api/payments/notifications
+func (h *PaymentHandler) Notification(c echo.Context) error {
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxNotificationBody))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	if err := h.paymentService.HandleNotification(c.Request().Context(), c.Request().Header, body); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Notification processed", nil))
+}
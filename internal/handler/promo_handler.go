@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/middleware"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/labstack/echo/v4"
+)
+
+type PromoHandler struct {
+	promoService service.PromoService
+}
+
+func NewPromoHandler(promoService service.PromoService) *PromoHandler {
+	return &PromoHandler{
+		promoService: promoService,
+	}
+}
+
+// CreatePromo defines a promo campaign (admin only)
+// POST /api/admin/promos
+func (h *PromoHandler) CreatePromo(c echo.Context) error {
+	adminID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	var req dto.CreatePromoRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.promoService.CreatePromo(c.Request().Context(), adminID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, dto.SuccessResponse("Promo created", response))
+}
+
+// ListPromos returns promo campaigns; ?active=true keeps only running ones (admin only)
+// GET /api/admin/promos
+func (h *PromoHandler) ListPromos(c echo.Context) error {
+	activeOnly := c.QueryParam("active") == "true"
+	limit, offset := parsePagination(c)
+
+	promos, err := h.promoService.ListPromos(c.Request().Context(), activeOnly, limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Promos retrieved", promos))
+}
+
+// GetPromo returns a promo campaign with its usage (admin only)
+// GET /api/admin/promos/:id
+func (h *PromoHandler) GetPromo(c echo.Context) error {
+	promoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	response, err := h.promoService.GetPromo(c.Request().Context(), promoID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Promo retrieved", response))
+}
+
+// UpdatePromo changes a campaign's window, caps or eligibility (admin only)
+// PATCH /api/admin/promos/:id
+func (h *PromoHandler) UpdatePromo(c echo.Context) error {
+	promoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	var req dto.UpdatePromoRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.promoService.UpdatePromo(c.Request().Context(), promoID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Promo updated", response))
+}
@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/httpclient"
+	"github.com/labstack/echo/v4"
+)
+
+// ProviderHandler exposes the health of outbound provider calls (WhatsApp, FCM, payment)
+type ProviderHandler struct{}
+
+func NewProviderHandler() *ProviderHandler {
+	return &ProviderHandler{}
+}
+
+// GetMetrics returns per-provider latency, error and circuit breaker metrics since
+// startup (admin only)
+// GET /api/admin/providers/metrics
+func (h *ProviderHandler) GetMetrics(c echo.Context) error {
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Provider metrics retrieved", httpclient.Metrics()))
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/middleware"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/labstack/echo/v4"
+)
+
+type RatingHandler struct {
+	ratingService service.RatingService
+}
+
+func NewRatingHandler(ratingService service.RatingService) *RatingHandler {
+	return &RatingHandler{ratingService: ratingService}
+}
+
+// RateOrder rates the other participant of a completed order
+// POST /api/orders/:id/rating
+func (h *RatingHandler) RateOrder(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	var req dto.CreateRatingRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.ratingService.RateOrder(c.Request().Context(), userID, orderID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, dto.SuccessResponse("Rating submitted", response))
+}
+
+// GetOrderRatings returns the ratings given and received on an order
+// GET /api/orders/:id/rating
+func (h *RatingHandler) GetOrderRatings(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	response, err := h.ratingService.GetOrderRatings(c.Request().Context(), userID, orderID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Ratings retrieved", response))
+}
+
+// GetRatingTags returns the tags the user can attach to a rating
+// GET /api/ratings/tags
+func (h *RatingHandler) GetRatingTags(c echo.Context) error {
+	userType, _ := c.Get("user_type").(string)
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Rating tags retrieved", h.ratingService.GetRatingTags(userType)))
+}
+
+// ListFlaggedDrivers returns drivers awaiting rating review (admin only)
+// GET /api/admin/drivers/flagged?limit=&offset=
+func (h *RatingHandler) ListFlaggedDrivers(c echo.Context) error {
+	limit, offset := parsePagination(c)
+
+	drivers, err := h.ratingService.ListFlaggedDrivers(c.Request().Context(), limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Flagged drivers retrieved", drivers))
+}
+
+// ClearDriverFlag closes a driver's rating review (admin only)
+// DELETE /api/admin/drivers/:id/rating-flag
+func (h *RatingHandler) ClearDriverFlag(c echo.Context) error {
+	driverID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	if err := h.ratingService.ClearDriverFlag(c.Request().Context(), driverID); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Driver rating review cleared", nil))
+}
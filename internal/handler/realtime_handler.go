@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/middleware"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/i18n"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/realtime"
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+)
+
+// headerLastEventID lets reconnecting clients resume, mirroring the SSE header
+const headerLastEventID = "Last-Event-ID"
+
+// orderAccessEvents change who takes part in an order (a driver is assigned or taken
+// off it), so order topic subscribers are re-authorized when one of them is delivered
+var orderAccessEvents = []string{constants.RealtimeOrderStatus}
+
+type RealtimeHandler struct {
+	realtimeService service.RealtimeService
+	broker          realtime.Broker
+	upgrader        websocket.Upgrader
+}
+
+func NewRealtimeHandler(realtimeService service.RealtimeService, broker realtime.Broker) *RealtimeHandler {
+	return &RealtimeHandler{
+		realtimeService: realtimeService,
+		broker:          broker,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			// Mobile apps send no Origin; browsers are authenticated by the access token
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// Connect upgrades to a WebSocket subscribed to the user's own topic.
+// Order topics are added with {"type":"subscribe","topic":"order:<id>"}.
+// GET /api/ws?access_token=&last_event_id=
+func (h *RealtimeHandler) Connect(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+	userType, _ := c.Get("user_type").(string)
+	locale := middleware.GetLocale(c)
+
+	lastEventID := parseLastEventID(c)
+
+	conn, err := h.upgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		// The upgrader already wrote the HTTP error
+		logger.Log.Warn().Err(err).Int("user_id", userID).Msg("WebSocket upgrade failed")
+		return nil
+	}
+
+	logger.Log.Info().Int("user_id", userID).Int64("last_event_id", lastEventID).Msg("WebSocket connected")
+
+	realtime.Serve(c.Request().Context(), conn, h.broker, realtime.SessionConfig{
+		Topics:      []string{realtime.UserTopic(userID)},
+		LastEventID: lastEventID,
+		Authorize: func(ctx context.Context, topic string) error {
+			return h.realtimeService.AuthorizeTopic(ctx, userID, userType, topic)
+		},
+		RecheckOn: orderAccessEvents,
+		DescribeError: func(err error) (string, string) {
+			appErr, ok := apperror.As(err)
+			if !ok {
+				appErr = apperror.Internal(err)
+			}
+			return appErr.Code, i18n.T(locale, appErr.MessageKey, appErr.Vars)
+		},
+	})
+
+	logger.Log.Info().Int("user_id", userID).Msg("WebSocket disconnected")
+	return nil
+}
+
+// Stream is the SSE fallback of Connect: the user's own topic, which carries dispatch
+// offers for drivers and order status for passengers.
+// GET /api/events?access_token=
+func (h *RealtimeHandler) Stream(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	return h.stream(c, userID, realtime.UserTopic(userID), nil)
+}
+
+// StreamOrder streams an order's status transitions and driver location over SSE
+// GET /api/orders/:id/events?access_token=
+func (h *RealtimeHandler) StreamOrder(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+	userType, _ := c.Get("user_type").(string)
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	topic := realtime.OrderTopic(orderID)
+	if err := h.realtimeService.AuthorizeTopic(c.Request().Context(), userID, userType, topic); err != nil {
+		return err
+	}
+
+	return h.stream(c, userID, topic, func(ctx context.Context, topic string) error {
+		return h.realtimeService.AuthorizeTopic(ctx, userID, userType, topic)
+	})
+}
+
+// stream serves topic over SSE; authorize, when set, re-checks the topic on orderAccessEvents
+func (h *RealtimeHandler) stream(c echo.Context, userID int, topic string, authorize func(context.Context, string) error) error {
+	lastEventID := parseLastEventID(c)
+	logger.Log.Info().Int("user_id", userID).Str("topic", topic).Int64("last_event_id", lastEventID).Msg("SSE stream opened")
+
+	err := realtime.ServeSSE(c.Request().Context(), c.Response(), h.broker, realtime.StreamConfig{
+		Topics:      []string{topic},
+		LastEventID: lastEventID,
+		Authorize:   authorize,
+		RecheckOn:   orderAccessEvents,
+		KeepAlive:   constants.SSEKeepAliveInterval,
+	})
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	logger.Log.Info().Int("user_id", userID).Str("topic", topic).Msg("SSE stream closed")
+	return nil
+}
+
+// parseLastEventID reads the resume position; EventSource sends the header on reconnect,
+// the query parameter covers the first connect
+func parseLastEventID(c echo.Context) int64 {
+	lastEventID, _ := strconv.ParseInt(c.Request().Header.Get(headerLastEventID), 10, 64)
+	if lastEventID == 0 {
+		lastEventID, _ = strconv.ParseInt(c.QueryParam("last_event_id"), 10, 64)
+	}
+	return lastEventID
+}
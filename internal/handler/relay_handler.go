@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/labstack/echo/v4"
+)
+
+type RelayHandler struct {
+	relayService service.RelayService
+}
+
+func NewRelayHandler(relayService service.RelayService) *RelayHandler {
+	return &RelayHandler{relayService: relayService}
+}
+
+// OpenContact returns the masked WhatsApp relay for reaching the other rider of an order
+// POST /api/orders/:id/contact
+func (h *RelayHandler) OpenContact(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	response, err := h.relayService.OpenSession(c.Request().Context(), userID, orderID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Relay contact retrieved", response))
+}
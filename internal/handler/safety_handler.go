@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/middleware"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/labstack/echo/v4"
+)
+
+type SafetyHandler struct {
+	safetyService service.SafetyService
+}
+
+func NewSafetyHandler(safetyService service.SafetyService) *SafetyHandler {
+	return &SafetyHandler{
+		safetyService: safetyService,
+	}
+}
+
+// ListContacts returns the caller's trusted contacts
+// GET /api/trusted-contacts
+func (h *SafetyHandler) ListContacts(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	contacts, err := h.safetyService.ListContacts(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Trusted contacts retrieved", contacts))
+}
+
+// AddContact adds a trusted contact and sends them an OTP over WhatsApp
+// POST /api/trusted-contacts
+func (h *SafetyHandler) AddContact(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	var req dto.AddTrustedContactRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.safetyService.AddContact(c.Request().Context(), userID, req, c.RealIP(), c.Request().UserAgent())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, dto.SuccessResponse("Trusted contact added. Ask them for the code sent to their WhatsApp.", response))
+}
+
+// ResendContactOTP sends a new OTP to an unverified contact
+// POST /api/trusted-contacts/:id/resend-otp
+func (h *SafetyHandler) ResendContactOTP(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	contactID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	response, err := h.safetyService.ResendContactOTP(c.Request().Context(), userID, contactID, c.RealIP(), c.Request().UserAgent())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Verification code sent", response))
+}
+
+// VerifyContact confirms a contact with the OTP they received
+// POST /api/trusted-contacts/:id/verify
+func (h *SafetyHandler) VerifyContact(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	contactID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	var req dto.VerifyTrustedContactRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.safetyService.VerifyContact(c.Request().Context(), userID, contactID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Trusted contact verified", response))
+}
+
+// DeleteContact removes a trusted contact
+// DELETE /api/trusted-contacts/:id
+func (h *SafetyHandler) DeleteContact(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	contactID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	if err := h.safetyService.DeleteContact(c.Request().Context(), userID, contactID); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Trusted contact removed", nil))
+}
+
+// ShareTrip sends the ride details to the caller's verified trusted contacts
+// POST /api/orders/:id/share
+func (h *SafetyHandler) ShareTrip(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	response, err := h.safetyService.ShareTrip(c.Request().Context(), userID, orderID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Trip shared", response))
+}
+
+// RaiseSOS raises an emergency during a ride
+// POST /api/orders/:id/sos
+func (h *SafetyHandler) RaiseSOS(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	var req dto.RaiseSOSRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.safetyService.RaiseSOS(c.Request().Context(), userID, orderID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, dto.SuccessResponse("SOS sent. Help is being alerted.", response))
+}
+
+// ListIncidents returns SOS incidents, those still being handled by default (admin only)
+// GET /api/admin/incidents?status=&limit=&offset=
+func (h *SafetyHandler) ListIncidents(c echo.Context) error {
+	status := entity.IncidentStatus(c.QueryParam("status"))
+	switch status {
+	case "", entity.IncidentStatusOpen, entity.IncidentStatusAcknowledged, entity.IncidentStatusResolved, entity.IncidentStatusDismissed:
+	default:
+		return apperror.ErrInvalidRequest
+	}
+	limit, offset := parsePagination(c)
+
+	incidents, err := h.safetyService.ListIncidents(c.Request().Context(), string(status), limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Incidents retrieved", incidents))
+}
+
+// GetIncident returns an incident with its trip snapshot (admin only)
+// GET /api/admin/incidents/:id
+func (h *SafetyHandler) GetIncident(c echo.Context) error {
+	incidentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	response, err := h.safetyService.GetIncident(c.Request().Context(), incidentID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Incident retrieved", response))
+}
+
+// AcknowledgeIncident takes an open incident into handling (admin only)
+// POST /api/admin/incidents/:id/acknowledge
+func (h *SafetyHandler) AcknowledgeIncident(c echo.Context) error {
+	adminID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	incidentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	response, err := h.safetyService.AcknowledgeIncident(c.Request().Context(), adminID, incidentID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Incident acknowledged", response))
+}
+
+// CloseIncident resolves or dismisses an incident (admin only)
+// POST /api/admin/incidents/:id/close
+func (h *SafetyHandler) CloseIncident(c echo.Context) error {
+	adminID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	incidentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	var req dto.CloseIncidentRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.safetyService.CloseIncident(c.Request().Context(), adminID, incidentID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Incident closed", response))
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/labstack/echo/v4"
+)
+
+type ScheduledRideHandler struct {
+	scheduledRideService service.ScheduledRideService
+}
+
+func NewScheduledRideHandler(scheduledRideService service.ScheduledRideService) *ScheduledRideHandler {
+	return &ScheduledRideHandler{
+		scheduledRideService: scheduledRideService,
+	}
+}
+
+// ListPassengerRides returns the passenger's upcoming scheduled rides
+// GET /api/passenger/orders/scheduled
+func (h *ScheduledRideHandler) ListPassengerRides(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	rides, err := h.scheduledRideService.ListPassengerRides(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Scheduled rides retrieved", rides))
+}
+
+// ListOpenRides returns scheduled rides drivers can pre-accept
+// GET /api/driver/scheduled-orders?limit=&offset=
+func (h *ScheduledRideHandler) ListOpenRides(c echo.Context) error {
+	limit, offset := parsePagination(c)
+
+	rides, err := h.scheduledRideService.ListOpenRides(c.Request().Context(), limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Scheduled rides retrieved", rides))
+}
+
+// ListAgenda returns the scheduled rides the driver pre-accepted
+// GET /api/driver/agenda
+func (h *ScheduledRideHandler) ListAgenda(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	rides, err := h.scheduledRideService.ListAgenda(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Agenda retrieved", rides))
+}
+
+// PreAccept commits the driver to a scheduled ride
+// POST /api/driver/scheduled-orders/:id/accept
+func (h *ScheduledRideHandler) PreAccept(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	response, err := h.scheduledRideService.PreAccept(c.Request().Context(), userID, orderID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Scheduled ride accepted", response))
+}
+
+// Withdraw gives a pre-accepted scheduled ride back to other drivers
+// POST /api/driver/scheduled-orders/:id/withdraw
+func (h *ScheduledRideHandler) Withdraw(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	response, err := h.scheduledRideService.Withdraw(c.Request().Context(), userID, orderID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Withdrawn from scheduled ride", response))
+}
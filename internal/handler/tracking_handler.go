@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/labstack/echo/v4"
+)
+
+type TrackingHandler struct {
+	trackingService service.TrackingService
+}
+
+func NewTrackingHandler(trackingService service.TrackingService) *TrackingHandler {
+	return &TrackingHandler{
+		trackingService: trackingService,
+	}
+}
+
+// CreateLink issues a shareable tracking link for the passenger's ride
+// POST /api/passenger/orders/:id/tracking-links
+func (h *TrackingHandler) CreateLink(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	response, err := h.trackingService.CreateLink(c.Request().Context(), userID, orderID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, dto.SuccessResponse("Tracking link created", response))
+}
+
+// ListLinks returns the tracking links issued for the passenger's ride
+// GET /api/passenger/orders/:id/tracking-links
+func (h *TrackingHandler) ListLinks(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	links, err := h.trackingService.ListLinks(c.Request().Context(), userID, orderID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Tracking links retrieved", links))
+}
+
+// RevokeLink switches a tracking link off
+// DELETE /api/passenger/orders/:id/tracking-links/:link_id
+func (h *TrackingHandler) RevokeLink(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+	linkID, err := strconv.Atoi(c.Param("link_id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	if err := h.trackingService.RevokeLink(c.Request().Context(), userID, orderID, linkID); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Tracking link revoked", nil))
+}
+
+// GetTrackedTrip shows the ride behind a tracking link (public - the token is the credential)
+// GET /api/track/:token
+func (h *TrackingHandler) GetTrackedTrip(c echo.Context) error {
+	trip, err := h.trackingService.GetTrackedTrip(c.Request().Context(), c.Param("token"))
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	c.Response().Header().Set("Referrer-Policy", "no-referrer")
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Trip retrieved", trip))
+}
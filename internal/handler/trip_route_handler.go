@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/labstack/echo/v4"
+)
+
+type TripRouteHandler struct {
+	tripRouteService service.TripRouteService
+}
+
+func NewTripRouteHandler(tripRouteService service.TripRouteService) *TripRouteHandler {
+	return &TripRouteHandler{
+		tripRouteService: tripRouteService,
+	}
+}
+
+// GetRoute returns the route an order's trip took, to its participants or an admin
+// GET /api/orders/:id/route?format=geojson|polyline
+func (h *TripRouteHandler) GetRoute(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+	userType, _ := c.Get("user_type").(string)
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+	format, err := parseRouteFormat(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.tripRouteService.GetRoute(c.Request().Context(), userID, userType, orderID, format)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Trip route retrieved", response))
+}
+
+// RebuildRoute measures a completed trip again from its raw pings (admin only)
+// POST /api/admin/orders/:id/route/rebuild?format=geojson|polyline
+func (h *TripRouteHandler) RebuildRoute(c echo.Context) error {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+	format, err := parseRouteFormat(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.tripRouteService.Rebuild(c.Request().Context(), orderID, format)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Trip route rebuilt", response))
+}
+
+// parseRouteFormat reads the format query parameter, GeoJSON by default
+func parseRouteFormat(c echo.Context) (string, error) {
+	switch format := c.QueryParam("format"); format {
+	case "":
+		return constants.RouteFormatGeoJSON, nil
+	case constants.RouteFormatGeoJSON, constants.RouteFormatPolyline:
+		return format, nil
+	}
+	return "", apperror.ErrInvalidRequest
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/middleware"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/labstack/echo/v4"
+)
+
+type WalletHandler struct {
+	walletService service.WalletService
+}
+
+func NewWalletHandler(walletService service.WalletService) *WalletHandler {
+	return &WalletHandler{walletService: walletService}
+}
+
+// GetWallet returns the caller's wallet (passenger) or earnings (driver) balance
+// GET /api/wallet
+func (h *WalletHandler) GetWallet(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+	userType, _ := c.Get("user_type").(string)
+
+	response, err := h.walletService.GetWallet(c.Request().Context(), userID, userType)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Wallet retrieved", response))
+}
+
+// GetStatement returns the caller's wallet history
+// GET /api/wallet/entries?limit=&offset=
+func (h *WalletHandler) GetStatement(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+	userType, _ := c.Get("user_type").(string)
+	limit, offset := parsePagination(c)
+
+	entries, err := h.walletService.GetStatement(c.Request().Context(), userID, userType, limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Wallet entries retrieved", entries))
+}
+
+// RequestPayout withdraws driver earnings to a bank account
+// POST /api/driver/payouts
+func (h *WalletHandler) RequestPayout(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	var req dto.CreatePayoutRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.walletService.RequestPayout(c.Request().Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, dto.SuccessResponse("Payout requested", response))
+}
+
+// ListDriverPayouts returns the driver's payout requests
+// GET /api/driver/payouts?limit=&offset=
+func (h *WalletHandler) ListDriverPayouts(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+	limit, offset := parsePagination(c)
+
+	payouts, err := h.walletService.ListDriverPayouts(c.Request().Context(), userID, limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Payouts retrieved", payouts))
+}
+
+// TopUp credits a passenger wallet (admin only)
+// POST /api/admin/wallets/:user_id/topup
+func (h *WalletHandler) TopUp(c echo.Context) error {
+	adminID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	var req dto.TopUpRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.walletService.TopUp(c.Request().Context(), adminID, userID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Wallet topped up", response))
+}
+
+// ListPayouts returns payout requests by status, PENDING by default (admin only)
+// GET /api/admin/payouts?status=&limit=&offset=
+func (h *WalletHandler) ListPayouts(c echo.Context) error {
+	status := entity.PayoutStatus(c.QueryParam("status"))
+	switch status {
+	case "":
+		status = entity.PayoutStatusPending
+	case entity.PayoutStatusPending, entity.PayoutStatusPaid, entity.PayoutStatusRejected:
+	default:
+		return apperror.ErrInvalidRequest
+	}
+	limit, offset := parsePagination(c)
+
+	payouts, err := h.walletService.ListPayouts(c.Request().Context(), status, limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Payouts retrieved", payouts))
+}
+
+// ApprovePayout marks a payout as transferred (admin only)
+// POST /api/admin/payouts/:id/approve
+func (h *WalletHandler) ApprovePayout(c echo.Context) error {
+	adminID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	payoutID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	response, err := h.walletService.ApprovePayout(c.Request().Context(), adminID, payoutID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Payout approved", response))
+}
+
+// RejectPayout returns the held amount to the driver (admin only)
+// POST /api/admin/payouts/:id/reject
+func (h *WalletHandler) RejectPayout(c echo.Context) error {
+	adminID, ok := c.Get("user_id").(int)
+	if !ok {
+		return apperror.ErrUnauthorized
+	}
+
+	payoutID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	var req dto.RejectPayoutRequest
+	if err := middleware.ValidateRequest(c, &req); err != nil {
+		return err
+	}
+
+	response, err := h.walletService.RejectPayout(c.Request().Context(), adminID, payoutID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Payout rejected", response))
+}
+
+// Reconcile checks the ledger invariants (admin only)
+// GET /api/admin/ledger/reconciliation
+func (h *WalletHandler) Reconcile(c echo.Context) error {
+	report, err := h.walletService.Reconcile(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Ledger reconciled", report))
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/service"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/labstack/echo/v4"
+)
+
+// maxWebhookBody bounds WhatsApp provider webhook bodies
+const maxWebhookBody = 64 * 1024
+
+// headerWebhookToken carries the webhook token for callers that can set headers; Ultramsg
+// only lets a URL be configured, so ?token= stays supported (and is redacted from logs)
+const headerWebhookToken = "X-Webhook-Token"
+
+type WhatsAppHandler struct {
+	whatsAppService service.WhatsAppService
+}
+
+func NewWhatsAppHandler(whatsAppService service.WhatsAppService) *WhatsAppHandler {
+	return &WhatsAppHandler{whatsAppService: whatsAppService}
+}
+
+// Webhook receives incoming messages and delivery updates from the WhatsApp provider
+// (public; authenticated by the X-Webhook-Token header or the token query parameter)
+// POST /api/whatsapp/webhook
+func (h *WhatsAppHandler) Webhook(c echo.Context) error {
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxWebhookBody))
+	if err != nil {
+		return apperror.ErrInvalidRequest.Wrap(err)
+	}
+
+	token := c.Request().Header.Get(headerWebhookToken)
+	if token == "" {
+		token = c.QueryParam("token")
+	}
+
+	if err := h.whatsAppService.HandleWebhook(c.Request().Context(), token, body); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse("Webhook processed", nil))
+}
@@ -0,0 +1,33 @@
+package mapper
+
+import (
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+)
+
+// ============================================================================
+// Campus Domain Mappers
+// ============================================================================
+
+// ToCampusDomainResponse converts entity.CampusDomain to dto.CampusDomainResponse
+func ToCampusDomainResponse(domain *entity.CampusDomain) *dto.CampusDomainResponse {
+	if domain == nil {
+		return nil
+	}
+
+	return &dto.CampusDomainResponse{
+		ID:        domain.ID,
+		Pattern:   domain.Pattern,
+		Name:      domain.Name,
+		CreatedAt: domain.CreatedAt,
+	}
+}
+
+// ToCampusDomainResponses converts a list of campus domains
+func ToCampusDomainResponses(domains []*entity.CampusDomain) []*dto.CampusDomainResponse {
+	responses := make([]*dto.CampusDomainResponse, 0, len(domains))
+	for _, domain := range domains {
+		responses = append(responses, ToCampusDomainResponse(domain))
+	}
+	return responses
+}
@@ -0,0 +1,43 @@
+package mapper
+
+import (
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+)
+
+// ============================================================================
+// Cancellation Mappers
+// ============================================================================
+
+// ToCancellationResponse converts a cancelled order and its record to dto.CancellationResponse.
+// counted is whether the cancellation counts against the user who made it.
+func ToCancellationResponse(order *entity.Order, cancellation *entity.Cancellation, counted bool) *dto.CancellationResponse {
+	response := &dto.CancellationResponse{
+		Order:      ToOrderResponse(order),
+		ReasonCode: cancellation.ReasonCode,
+		Fee:        cancellation.Fee,
+		Counted:    counted,
+	}
+	if cancellation.FeeSettledBy != nil {
+		response.FeeSettledBy = string(*cancellation.FeeSettledBy)
+	}
+	return response
+}
+
+// ToCooldownResponses converts active cooldowns to the admin list
+func ToCooldownResponses(cooldowns []*entity.CooldownWithUser) []*dto.CooldownResponse {
+	responses := make([]*dto.CooldownResponse, 0, len(cooldowns))
+	for _, cooldown := range cooldowns {
+		responses = append(responses, &dto.CooldownResponse{
+			UserID:        cooldown.UserID,
+			FullName:      cooldown.FullName,
+			PhoneNumber:   cooldown.PhoneNumber,
+			Role:          string(cooldown.Role),
+			Until:         cooldown.Until,
+			Cancellations: cooldown.Cancellations,
+			Orders:        cooldown.Orders,
+			CreatedAt:     cooldown.CreatedAt,
+		})
+	}
+	return responses
+}
@@ -0,0 +1,38 @@
+package mapper
+
+import (
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+)
+
+// ============================================================================
+// Chat Mappers
+// ============================================================================
+
+// ToChatMessageResponse converts entity.ChatMessage to dto.ChatMessageResponse
+func ToChatMessageResponse(message *entity.ChatMessage) *dto.ChatMessageResponse {
+	if message == nil {
+		return nil
+	}
+
+	return &dto.ChatMessageResponse{
+		ID:         message.ID,
+		OrderID:    message.OrderID,
+		SenderID:   message.SenderID,
+		SenderRole: string(message.SenderRole),
+		Body:       message.Body,
+		QuickReply: message.QuickReply,
+		Masked:     message.Masked,
+		ReadAt:     message.ReadAt,
+		CreatedAt:  message.CreatedAt,
+	}
+}
+
+// ToChatMessageResponses converts a list of chat messages
+func ToChatMessageResponses(messages []*entity.ChatMessage) []*dto.ChatMessageResponse {
+	responses := make([]*dto.ChatMessageResponse, 0, len(messages))
+	for _, message := range messages {
+		responses = append(responses, ToChatMessageResponse(message))
+	}
+	return responses
+}
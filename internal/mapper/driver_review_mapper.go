@@ -0,0 +1,50 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+)
+
+// ============================================================================
+// Driver Review Mappers
+// ============================================================================
+
+// ToDocumentExpiryResponses converts recorded document expiries, counting days from today
+func ToDocumentExpiryResponses(expiries []*entity.DriverDocumentExpiry, today time.Time) []*dto.DocumentExpiryResponse {
+	responses := make([]*dto.DocumentExpiryResponse, 0, len(expiries))
+	for _, expiry := range expiries {
+		responses = append(responses, &dto.DocumentExpiryResponse{
+			DocumentType: expiry.DocumentType,
+			ExpiresOn:    expiry.ExpiresOn.Format(constants.DocumentExpiryDateLayout),
+			DaysLeft:     expiry.DaysLeft(today),
+			ExpiredAt:    expiry.ExpiredAt,
+			RecordedAt:   expiry.RecordedAt,
+		})
+	}
+	return responses
+}
+
+// ToExpiringDocumentResponses converts documents for the admin expiry view
+func ToExpiringDocumentResponses(documents []*entity.ExpiringDocument, today time.Time) []*dto.ExpiringDocumentResponse {
+	responses := make([]*dto.ExpiringDocumentResponse, 0, len(documents))
+	for _, document := range documents {
+		responses = append(responses, &dto.ExpiringDocumentResponse{
+			DriverProfileID:  document.DriverProfileID,
+			UserID:           document.UserID,
+			FullName:         document.FullName,
+			PhoneNumber:      document.PhoneNumber,
+			VehiclePlate:     document.VehiclePlate,
+			DocumentType:     document.DocumentType,
+			ExpiresOn:        document.ExpiresOn.Format(constants.DocumentExpiryDateLayout),
+			DaysLeft:         document.DaysLeft(today),
+			LastReminderDays: document.LastReminderDays,
+			ExpiredAt:        document.ExpiredAt,
+			IsVerified:       document.IsVerified,
+			IsOnline:         document.IsOnline,
+		})
+	}
+	return responses
+}
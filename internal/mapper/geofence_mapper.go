@@ -0,0 +1,98 @@
+package mapper
+
+import (
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+)
+
+// ============================================================================
+// Geofence Mappers
+// ============================================================================
+
+// ToZoneResponse converts entity.Zone to dto.ZoneResponse
+func ToZoneResponse(zone *entity.Zone) (*dto.ZoneResponse, error) {
+	if zone == nil {
+		return nil, nil
+	}
+
+	geometry, err := zone.Area.MarshalGeoJSON()
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ZoneResponse{
+		ID:        zone.ID,
+		Name:      zone.Name,
+		Kind:      string(zone.Kind),
+		Geometry:  geometry,
+		Surcharge: zone.Surcharge,
+		IsActive:  zone.IsActive,
+		CreatedAt: zone.CreatedAt,
+		UpdatedAt: zone.UpdatedAt,
+	}, nil
+}
+
+// ToZoneResponses converts a list of zones
+func ToZoneResponses(zones []*entity.Zone) ([]*dto.ZoneResponse, error) {
+	responses := make([]*dto.ZoneResponse, 0, len(zones))
+	for _, zone := range zones {
+		response, err := ToZoneResponse(zone)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+// ToPickupPointResponse converts entity.PickupPoint to dto.PickupPointResponse
+func ToPickupPointResponse(point *entity.PickupPoint) *dto.PickupPointResponse {
+	if point == nil {
+		return nil
+	}
+
+	return &dto.PickupPointResponse{
+		ID:          point.ID,
+		Name:        point.Name,
+		Kind:        string(point.Kind),
+		Lat:         point.Lat,
+		Long:        point.Long,
+		Description: point.Description,
+		IsActive:    point.IsActive,
+	}
+}
+
+// ToPickupPointResponses converts a list of pickup points
+func ToPickupPointResponses(points []*entity.PickupPoint) []*dto.PickupPointResponse {
+	responses := make([]*dto.PickupPointResponse, 0, len(points))
+	for _, point := range points {
+		responses = append(responses, ToPickupPointResponse(point))
+	}
+	return responses
+}
+
+// ToOutOfAreaDriverResponses converts drivers flagged outside the service area
+func ToOutOfAreaDriverResponses(drivers []*entity.OutOfAreaDriver) []*dto.OutOfAreaDriverResponse {
+	responses := make([]*dto.OutOfAreaDriverResponse, 0, len(drivers))
+	for _, driver := range drivers {
+		responses = append(responses, &dto.OutOfAreaDriverResponse{
+			UserID:       driver.UserID,
+			FullName:     driver.FullName,
+			PhoneNumber:  driver.PhoneNumber,
+			VehiclePlate: driver.VehiclePlate,
+			IsOnline:     driver.IsOnline,
+			Lat:          driver.Lat,
+			Long:         driver.Long,
+			Since:        driver.Since,
+		})
+	}
+	return responses
+}
+
+// ToTripStopResponse converts a planned pickup or dropoff
+func ToTripStopResponse(stop entity.TripStop) dto.LocationResponse {
+	return dto.LocationResponse{
+		Lat:     stop.Lat,
+		Long:    stop.Long,
+		Address: stop.Address,
+	}
+}
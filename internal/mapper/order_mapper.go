@@ -0,0 +1,110 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+)
+
+// ============================================================================
+// Order Mappers
+// ============================================================================
+
+// ToOrderResponse converts entity.Order to dto.OrderResponse
+func ToOrderResponse(order *entity.Order) *dto.OrderResponse {
+	if order == nil {
+		return nil
+	}
+
+	return &dto.OrderResponse{
+		ID:          order.ID,
+		Status:      string(order.Status),
+		PassengerID: order.PassengerID,
+		DriverID:    order.DriverID,
+		Pickup: dto.LocationResponse{
+			Lat:     order.PickupLat,
+			Long:    order.PickupLong,
+			Address: order.PickupAddress,
+		},
+		Dropoff: dto.LocationResponse{
+			Lat:     order.DropoffLat,
+			Long:    order.DropoffLong,
+			Address: order.DropoffAddress,
+		},
+		DistanceKm:    order.DistanceKm,
+		Fare:          order.Fare,
+		ZoneSurcharge: order.ZoneSurcharge,
+		PromoCode:     order.PromoCode,
+		Discount:      order.Discount,
+		AmountDue:     order.AmountDue(),
+		PaymentMethod: string(order.PaymentMethod),
+		Notes:         order.Notes,
+		ScheduledAt:   order.ScheduledAt,
+		AcceptedAt:    order.AcceptedAt,
+		ArrivedAt:     order.ArrivedAt,
+		StartedAt:     order.StartedAt,
+		CompletedAt:   order.CompletedAt,
+		ExpiredAt:     order.ExpiredAt,
+		CancelledAt:   order.CancelledAt,
+		CreatedAt:     order.CreatedAt,
+	}
+}
+
+// ToOrderResponses converts a list of orders
+func ToOrderResponses(orders []*entity.Order) []*dto.OrderResponse {
+	responses := make([]*dto.OrderResponse, 0, len(orders))
+	for _, order := range orders {
+		responses = append(responses, ToOrderResponse(order))
+	}
+	return responses
+}
+
+// ToDispatchOfferResponse converts an open offer and its order to the driver's view
+func ToDispatchOfferResponse(offer *entity.DispatchOffer, order *entity.Order, now time.Time) *dto.DispatchOfferResponse {
+	expiresIn := int(offer.ExpiresAt.Sub(now).Seconds())
+	if expiresIn < 0 {
+		expiresIn = 0
+	}
+
+	return &dto.DispatchOfferResponse{
+		ID:         offer.ID,
+		DistanceKm: offer.DistanceKm,
+		ExpiresAt:  offer.ExpiresAt,
+		ExpiresIn:  expiresIn,
+		Order:      ToOrderResponse(order),
+	}
+}
+
+// ToDispatchOfferLogResponses converts recorded offers to the admin view
+func ToDispatchOfferLogResponses(offers []*entity.DispatchOffer) []*dto.DispatchOfferLogResponse {
+	responses := make([]*dto.DispatchOfferLogResponse, 0, len(offers))
+	for _, offer := range offers {
+		responses = append(responses, &dto.DispatchOfferLogResponse{
+			ID:             offer.ID,
+			DriverID:       offer.DriverID,
+			Attempt:        offer.Attempt,
+			Status:         string(offer.Status),
+			RadiusKm:       offer.RadiusKm,
+			DistanceKm:     offer.DistanceKm,
+			Rating:         offer.Rating,
+			AcceptanceRate: offer.AcceptanceRate,
+			Score:          offer.Score,
+			OfferedAt:      offer.OfferedAt,
+			ExpiresAt:      offer.ExpiresAt,
+			RespondedAt:    offer.RespondedAt,
+		})
+	}
+	return responses
+}
+
+// ToDriverStatusResponse converts the availability fields of a driver profile
+func ToDriverStatusResponse(profile *entity.DriverProfile) *dto.DriverStatusResponse {
+	return &dto.DriverStatusResponse{
+		IsOnline:           profile.IsOnline,
+		CurrentLat:         profile.CurrentLat,
+		CurrentLong:        profile.CurrentLong,
+		LastLocationUpdate: profile.LastLocationUpdate,
+		OutsideServiceArea: profile.OutsideServiceAreaSince != nil,
+	}
+}
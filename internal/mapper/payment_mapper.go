@@ -0,0 +1,41 @@
+package mapper
+
+import (
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+)
+
+// ============================================================================
+// Payment Mappers
+// ============================================================================
+
+// ToPaymentChargeResponse converts entity.PaymentCharge to dto.PaymentChargeResponse
+func ToPaymentChargeResponse(charge *entity.PaymentCharge) *dto.PaymentChargeResponse {
+	if charge == nil {
+		return nil
+	}
+
+	return &dto.PaymentChargeResponse{
+		ID:         charge.ID,
+		Reference:  charge.Reference,
+		Channel:    charge.Channel,
+		Bank:       charge.Bank,
+		Amount:     charge.Amount,
+		Status:     string(charge.Status),
+		QRString:   charge.QRString,
+		QRImageURL: charge.QRImageURL,
+		VANumber:   charge.VANumber,
+		ExpiresAt:  charge.ExpiresAt,
+		PaidAt:     charge.PaidAt,
+		CreatedAt:  charge.CreatedAt,
+	}
+}
+
+// ToPaymentChargeResponses converts a list of charges
+func ToPaymentChargeResponses(charges []*entity.PaymentCharge) []*dto.PaymentChargeResponse {
+	responses := make([]*dto.PaymentChargeResponse, 0, len(charges))
+	for _, charge := range charges {
+		responses = append(responses, ToPaymentChargeResponse(charge))
+	}
+	return responses
+}
@@ -13,13 +13,16 @@ import (
 // ToUserResponse converts entity.User to dto.UserResponse
 func ToUserResponse(user *entity.User) *dto.UserResponse {
 	return &dto.UserResponse{
-		ID:            user.ID,
-		PhoneNumber:   user.PhoneNumber,
-		Email:         user.Email,
-		FullName:      user.FullName,
-		Role:          string(user.Role),
-		Status:        string(user.Status),
-		PhoneVerified: user.PhoneVerified,
+		ID:             user.ID,
+		PhoneNumber:    user.PhoneNumber,
+		Email:          user.Email,
+		FullName:       user.FullName,
+		Role:           string(user.Role),
+		Status:         string(user.Status),
+		PhoneVerified:  user.PhoneVerified,
+		EmailVerified:  user.EmailVerified,
+		CampusEligible: user.CampusEligible,
+		Locale:         user.PreferredLocale,
 	}
 }
 
@@ -60,6 +63,7 @@ func ToDriverProfileResponse(profile *entity.DriverProfile) *dto.DriverProfileRe
 	return &dto.DriverProfileResponse{
 		ID:                   profile.ID,
 		UserID:               profile.UserID,
+		ProfilePicture:       profile.ProfilePicture,
 		VehicleType:          profile.VehicleType,
 		VehiclePlate:         profile.VehiclePlate,
 		VehicleBrand:         profile.VehicleBrand,
@@ -69,6 +73,7 @@ func ToDriverProfileResponse(profile *entity.DriverProfile) *dto.DriverProfileRe
 		VerificationStatus:   verificationStatus,
 		RejectionReason:      profile.RejectionReason,
 		IsActive:             profile.IsActive,
+		IsOnline:             profile.IsOnline,
 		TotalCompletedOrders: profile.TotalCompletedOrders,
 		RatingAvg:            profile.RatingAvg,
 		Documents:            toDocumentsResponse(profile),
@@ -96,6 +101,22 @@ func toDocumentsResponse(profile *entity.DriverProfile) *dto.Documents {
 	}
 }
 
+// ToDriverProfileChangeResponses converts driver profile change history to DTOs
+func ToDriverProfileChangeResponses(changes []*entity.DriverProfileChange) []*dto.DriverProfileChangeResponse {
+	responses := make([]*dto.DriverProfileChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		responses = append(responses, &dto.DriverProfileChangeResponse{
+			ID:        change.ID,
+			Field:     change.Field,
+			OldValue:  change.OldValue,
+			NewValue:  change.NewValue,
+			ChangedBy: change.ChangedBy,
+			CreatedAt: change.CreatedAt,
+		})
+	}
+	return responses
+}
+
 // ============================================================================
 // Auth Response Builders
 // ============================================================================
@@ -0,0 +1,60 @@
+package mapper
+
+import (
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+)
+
+// ============================================================================
+// Promo Mappers
+// ============================================================================
+
+// ToPromoResponse converts entity.Promo to dto.PromoResponse
+func ToPromoResponse(promo *entity.Promo) *dto.PromoResponse {
+	if promo == nil {
+		return nil
+	}
+
+	return &dto.PromoResponse{
+		ID:            promo.ID,
+		Code:          promo.Code,
+		Name:          promo.Name,
+		Description:   promo.Description,
+		DiscountType:  string(promo.DiscountType),
+		DiscountValue: promo.DiscountValue,
+		MaxDiscount:   promo.MaxDiscount,
+		MinFare:       promo.MinFare,
+		UsageLimit:    promo.UsageLimit,
+		PerUserLimit:  promo.PerUserLimit,
+		UsedCount:     promo.UsedCount,
+		EligibleRoles: promo.EligibleRoles,
+		NewUsersOnly:  promo.NewUsersOnly,
+		CampusOnly:    promo.CampusOnly,
+		EmailDomains:  promo.EmailDomains,
+		StartsAt:      promo.StartsAt,
+		EndsAt:        promo.EndsAt,
+		IsActive:      promo.IsActive,
+		CreatedAt:     promo.CreatedAt,
+		UpdatedAt:     promo.UpdatedAt,
+	}
+}
+
+// ToPromoResponses converts a list of promos
+func ToPromoResponses(promos []*entity.Promo) []*dto.PromoResponse {
+	responses := make([]*dto.PromoResponse, 0, len(promos))
+	for _, promo := range promos {
+		responses = append(responses, ToPromoResponse(promo))
+	}
+	return responses
+}
+
+// ToAppliedPromoResponse describes a promo applied to a fare
+func ToAppliedPromoResponse(promo *entity.Promo, discount int) *dto.AppliedPromoResponse {
+	return &dto.AppliedPromoResponse{
+		Code:        promo.Code,
+		Name:        promo.Name,
+		Description: promo.Description,
+		Discount:    discount,
+		EndsAt:      promo.EndsAt,
+	}
+}
@@ -0,0 +1,44 @@
+package mapper
+
+import (
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+)
+
+// ============================================================================
+// Rating Mappers
+// ============================================================================
+
+// ToRatingResponse converts entity.Rating to dto.RatingResponse
+func ToRatingResponse(rating *entity.Rating) *dto.RatingResponse {
+	if rating == nil {
+		return nil
+	}
+
+	return &dto.RatingResponse{
+		ID:        rating.ID,
+		OrderID:   rating.OrderID,
+		RaterRole: string(rating.RaterRole),
+		Stars:     rating.Stars,
+		Tags:      rating.Tags,
+		Comment:   rating.Comment,
+		CreatedAt: rating.CreatedAt,
+	}
+}
+
+// ToFlaggedDriverResponses converts flagged drivers to the admin review list
+func ToFlaggedDriverResponses(drivers []*entity.FlaggedDriver) []*dto.FlaggedDriverResponse {
+	responses := make([]*dto.FlaggedDriverResponse, 0, len(drivers))
+	for _, driver := range drivers {
+		responses = append(responses, &dto.FlaggedDriverResponse{
+			UserID:       driver.UserID,
+			FullName:     driver.FullName,
+			PhoneNumber:  driver.PhoneNumber,
+			VehiclePlate: driver.VehiclePlate,
+			RatingAvg:    driver.RatingAvg,
+			RatingCount:  driver.RatingCount,
+			FlaggedAt:    driver.FlaggedAt,
+		})
+	}
+	return responses
+}
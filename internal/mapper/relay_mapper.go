@@ -0,0 +1,26 @@
+package mapper
+
+import (
+	"strings"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+)
+
+// ============================================================================
+// WhatsApp Relay Mappers
+// ============================================================================
+
+// ToRelayContactResponse describes the relay of an order for one of its riders; the
+// counterpart is the other rider
+func ToRelayContactResponse(session *entity.RelaySession, relayNumber string, counterpart *entity.User) *dto.RelayContactResponse {
+	return &dto.RelayContactResponse{
+		OrderID:     session.OrderID,
+		RelayNumber: relayNumber,
+		WhatsAppURL: "https://wa.me/" + strings.TrimPrefix(relayNumber, "+"),
+		Counterpart: dto.RelayCounterpartResponse{
+			FirstName: counterpart.FirstName(),
+			Role:      string(counterpart.Role),
+		},
+	}
+}
@@ -0,0 +1,103 @@
+package mapper
+
+import (
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+)
+
+// ============================================================================
+// Safety Mappers
+// ============================================================================
+
+// ToTrustedContactResponse converts entity.TrustedContact to dto.TrustedContactResponse
+func ToTrustedContactResponse(contact *entity.TrustedContact) *dto.TrustedContactResponse {
+	if contact == nil {
+		return nil
+	}
+
+	return &dto.TrustedContactResponse{
+		ID:          contact.ID,
+		Name:        contact.Name,
+		PhoneNumber: contact.PhoneNumber,
+		Verified:    contact.IsVerified(),
+		VerifiedAt:  contact.VerifiedAt,
+		CreatedAt:   contact.CreatedAt,
+	}
+}
+
+// ToTrustedContactResponses converts a list of trusted contacts
+func ToTrustedContactResponses(contacts []*entity.TrustedContact) []*dto.TrustedContactResponse {
+	responses := make([]*dto.TrustedContactResponse, 0, len(contacts))
+	for _, contact := range contacts {
+		responses = append(responses, ToTrustedContactResponse(contact))
+	}
+	return responses
+}
+
+// ToSOSResponse converts entity.SOSIncident to the reporter's dto.SOSResponse
+func ToSOSResponse(incident *entity.SOSIncident) *dto.SOSResponse {
+	if incident == nil {
+		return nil
+	}
+
+	return &dto.SOSResponse{
+		ID:              incident.ID,
+		OrderID:         incident.OrderID,
+		Status:          string(incident.Status),
+		Lat:             incident.Lat,
+		Long:            incident.Long,
+		ContactsAlerted: incident.ContactsAlerted,
+		CreatedAt:       incident.CreatedAt,
+	}
+}
+
+// ToIncidentResponse converts entity.SOSIncident to the admin dto.IncidentResponse
+func ToIncidentResponse(incident *entity.SOSIncident) *dto.IncidentResponse {
+	if incident == nil {
+		return nil
+	}
+
+	trip := incident.Trip
+	return &dto.IncidentResponse{
+		ID:          incident.ID,
+		OrderID:     incident.OrderID,
+		ReportedBy:  incident.ReportedBy,
+		Role:        string(incident.Role),
+		Lat:         incident.Lat,
+		Long:        incident.Long,
+		OrderStatus: string(incident.OrderStatus),
+		Trip: dto.TripSnapshotResponse{
+			PassengerID:    trip.PassengerID,
+			PassengerName:  trip.PassengerName,
+			PassengerPhone: trip.PassengerPhone,
+			DriverID:       trip.DriverID,
+			DriverName:     trip.DriverName,
+			DriverPhone:    trip.DriverPhone,
+			VehiclePlate:   trip.VehiclePlate,
+			Vehicle:        trip.Vehicle,
+			PickupAddress:  trip.PickupAddress,
+			DropoffAddress: trip.DropoffAddress,
+			DriverLat:      trip.DriverLat,
+			DriverLong:     trip.DriverLong,
+		},
+		Note:            incident.Note,
+		Status:          string(incident.Status),
+		ContactsAlerted: incident.ContactsAlerted,
+		AcknowledgedBy:  incident.AcknowledgedBy,
+		AcknowledgedAt:  incident.AcknowledgedAt,
+		ResolvedBy:      incident.ResolvedBy,
+		ResolvedAt:      incident.ResolvedAt,
+		ResolutionNote:  incident.ResolutionNote,
+		CreatedAt:       incident.CreatedAt,
+		UpdatedAt:       incident.UpdatedAt,
+	}
+}
+
+// ToIncidentResponses converts a list of incidents
+func ToIncidentResponses(incidents []*entity.SOSIncident) []*dto.IncidentResponse {
+	responses := make([]*dto.IncidentResponse, 0, len(incidents))
+	for _, incident := range incidents {
+		responses = append(responses, ToIncidentResponse(incident))
+	}
+	return responses
+}
@@ -0,0 +1,36 @@
+package mapper
+
+import (
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+)
+
+// ============================================================================
+// Trip Tracking Mappers
+// ============================================================================
+
+// ToTrackingLinkResponse converts entity.TrackingLink to dto.TrackingLinkResponse
+func ToTrackingLinkResponse(link *entity.TrackingLink, active bool) *dto.TrackingLinkResponse {
+	if link == nil {
+		return nil
+	}
+
+	return &dto.TrackingLinkResponse{
+		ID:        link.ID,
+		OrderID:   link.OrderID,
+		Active:    active,
+		ExpiresAt: link.ExpiresAt,
+		RevokedAt: link.RevokedAt,
+		CreatedAt: link.CreatedAt,
+	}
+}
+
+// ToTrackedDriverResponse describes a driver for tracking links without personal data
+func ToTrackedDriverResponse(driver *entity.User, profile *entity.DriverProfile) dto.TrackedDriverResponse {
+	return dto.TrackedDriverResponse{
+		FirstName:    driver.FirstName(),
+		VehiclePlate: profile.VehiclePlate,
+		VehicleColor: profile.VehicleColor,
+		Vehicle:      profile.VehicleDescription(),
+	}
+}
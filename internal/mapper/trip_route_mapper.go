@@ -0,0 +1,47 @@
+package mapper
+
+import (
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/geo"
+)
+
+// ============================================================================
+// Trip Route Mappers
+// ============================================================================
+
+// ToTripRouteResponse converts a trip route to dto.TripRouteResponse in the given format,
+// decoding the stored polyline when GeoJSON is asked for
+func ToTripRouteResponse(order *entity.Order, route *entity.TripRoute, format string, live bool) (*dto.TripRouteResponse, error) {
+	response := &dto.TripRouteResponse{
+		OrderID:          route.OrderID,
+		Format:           format,
+		DistanceKm:       route.DistanceKm,
+		DurationSeconds:  route.DurationSeconds,
+		QuotedDistanceKm: order.DistanceKm,
+		QuotedFare:       order.Fare,
+		MeasuredFare:     route.MeasuredFare,
+		RawPoints:        route.RawPoints,
+		KeptPoints:       route.KeptPoints,
+		SimplifiedPoints: route.SimplifiedPoints,
+		StartedAt:        route.StartedAt,
+		EndedAt:          route.EndedAt,
+		Live:             live,
+	}
+
+	if format == constants.RouteFormatPolyline {
+		response.Polyline = route.Polyline
+		return response, nil
+	}
+
+	points, err := geo.DecodePolyline(route.Polyline)
+	if err != nil {
+		return nil, err
+	}
+	response.Geometry, err = geo.MarshalLineString(points)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -0,0 +1,96 @@
+package mapper
+
+import (
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/ledger"
+)
+
+// ============================================================================
+// Wallet Mappers
+// ============================================================================
+
+// ToWalletEntryResponses converts ledger statement entries to the wallet history
+func ToWalletEntryResponses(entries []*ledger.StatementEntry) []*dto.WalletEntryResponse {
+	responses := make([]*dto.WalletEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		responses = append(responses, &dto.WalletEntryResponse{
+			ID:            entry.ID,
+			TransactionID: entry.TransactionID,
+			Kind:          entry.Kind,
+			Amount:        entry.Amount,
+			BalanceAfter:  entry.BalanceAfter,
+			ReferenceType: entry.ReferenceType,
+			ReferenceID:   entry.ReferenceID,
+			Description:   entry.Description,
+			CreatedAt:     entry.CreatedAt,
+		})
+	}
+	return responses
+}
+
+// ToPayoutResponse converts entity.PayoutRequest to dto.PayoutResponse
+func ToPayoutResponse(payout *entity.PayoutRequest) *dto.PayoutResponse {
+	if payout == nil {
+		return nil
+	}
+
+	return &dto.PayoutResponse{
+		ID:              payout.ID,
+		DriverID:        payout.DriverID,
+		Amount:          payout.Amount,
+		Status:          string(payout.Status),
+		BankName:        payout.BankName,
+		AccountNumber:   payout.AccountNumber,
+		AccountHolder:   payout.AccountHolder,
+		RejectionReason: payout.RejectionReason,
+		ReviewedAt:      payout.ReviewedAt,
+		CreatedAt:       payout.CreatedAt,
+	}
+}
+
+// ToPayoutResponses converts a list of payout requests
+func ToPayoutResponses(payouts []*entity.PayoutRequest) []*dto.PayoutResponse {
+	responses := make([]*dto.PayoutResponse, 0, len(payouts))
+	for _, payout := range payouts {
+		responses = append(responses, ToPayoutResponse(payout))
+	}
+	return responses
+}
+
+// ToReconciliationResponse converts a ledger reconciliation report
+func ToReconciliationResponse(report *ledger.Report) *dto.ReconciliationResponse {
+	response := &dto.ReconciliationResponse{
+		GeneratedAt:            report.GeneratedAt,
+		Healthy:                report.Healthy(),
+		Accounts:               report.Accounts,
+		Transactions:           report.Transactions,
+		LedgerSum:              report.LedgerSum,
+		TotalsByType:           make([]dto.LedgerTypeTotal, 0, len(report.TotalsByType)),
+		BalanceMismatches:      toLedgerMismatches(report.BalanceMismatches),
+		NegativeAccounts:       toLedgerMismatches(report.NegativeAccounts),
+		UnbalancedTransactions: report.UnbalancedTransactions,
+	}
+	for _, total := range report.TotalsByType {
+		response.TotalsByType = append(response.TotalsByType, dto.LedgerTypeTotal{
+			Type:     string(total.Type),
+			Accounts: total.Accounts,
+			Balance:  total.Balance,
+		})
+	}
+	return response
+}
+
+func toLedgerMismatches(mismatches []ledger.BalanceMismatch) []dto.LedgerAccountMismatch {
+	responses := make([]dto.LedgerAccountMismatch, 0, len(mismatches))
+	for _, mismatch := range mismatches {
+		responses = append(responses, dto.LedgerAccountMismatch{
+			AccountID: mismatch.AccountID,
+			Type:      string(mismatch.Type),
+			OwnerID:   mismatch.OwnerID,
+			Cached:    mismatch.Cached,
+			Derived:   mismatch.Derived,
+		})
+	}
+	return responses
+}
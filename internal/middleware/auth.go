@@ -1,42 +1,37 @@
 package middleware
 
 import (
-	"net/http"
 	"strings"
 
-	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
 	jwtPkg "github.com/AnggaKay/ojek-kampus-backend/pkg/jwt"
 	"github.com/labstack/echo/v4"
 )
 
+// accessTokenQueryParam carries the token on WebSocket upgrades, since browsers
+// cannot set the Authorization header on a WebSocket handshake
+const accessTokenQueryParam = "access_token"
+
 // JWTAuth validates JWT token from Authorization header
 func JWTAuth() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			// Get Authorization header
-			authHeader := c.Request().Header.Get("Authorization")
-			if authHeader == "" {
-				return c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Missing authorization token"))
-			}
-
-			// Check Bearer format
-			parts := strings.Split(authHeader, " ")
-			if len(parts) != 2 || parts[0] != "Bearer" {
-				return c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Invalid authorization format"))
+			tokenString, err := bearerToken(c)
+			if err != nil {
+				return err
 			}
 
-			tokenString := parts[1]
-
 			// Validate token
 			claims, err := jwtPkg.ValidateToken(tokenString)
 			if err != nil {
-				return c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Invalid or expired token"))
+				return apperror.ErrInvalidToken.Wrap(err)
 			}
 
 			// Store claims in context
 			c.Set("user_id", claims.UserID)
 			c.Set("user_role", claims.Role)
 			c.Set("user_type", claims.UserType)
+			applyUserLocale(c, claims.Locale)
 
 			return next(c)
 		}
@@ -49,7 +44,7 @@ func RoleGuard(allowedRoles ...string) echo.MiddlewareFunc {
 		return func(c echo.Context) error {
 			userType := c.Get("user_type")
 			if userType == nil {
-				return c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", "Access denied"))
+				return apperror.ErrForbidden
 			}
 
 			userTypeStr := userType.(string)
@@ -62,10 +57,37 @@ func RoleGuard(allowedRoles ...string) echo.MiddlewareFunc {
 			}
 
 			if !allowed {
-				return c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", "Insufficient permissions"))
+				return apperror.ErrForbidden
 			}
 
 			return next(c)
 		}
 	}
 }
+
+// bearerToken extracts the token from the Authorization header, falling back to the
+// access_token query parameter for WebSocket upgrades and EventSource streams, which
+// cannot set headers in browsers
+func bearerToken(c echo.Context) (string, error) {
+	authHeader := c.Request().Header.Get("Authorization")
+	if authHeader == "" {
+		if c.IsWebSocket() || isEventStream(c) {
+			if token := c.QueryParam(accessTokenQueryParam); token != "" {
+				return token, nil
+			}
+		}
+		return "", apperror.ErrMissingToken
+	}
+
+	// Check Bearer format
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return "", apperror.ErrInvalidAuthFormat
+	}
+
+	return parts[1], nil
+}
+
+func isEventStream(c echo.Context) bool {
+	return strings.Contains(c.Request().Header.Get(echo.HeaderAccept), "text/event-stream")
+}
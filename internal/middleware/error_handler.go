@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/i18n"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+// ErrorHandler renders every error returned by handlers and middlewares as dto.Response.
+// apperror.Error values keep their status and code; echo errors are mapped by status and
+// anything else becomes a 500 whose cause is only logged.
+func ErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	appErr, ok := apperror.As(err)
+	if !ok {
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			appErr = apperror.FromStatus(httpErr.Code).Wrap(err)
+		} else {
+			appErr = apperror.Internal(err)
+		}
+	}
+
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+
+	event := logger.Log.Warn()
+	if appErr.Status >= http.StatusInternalServerError {
+		event = logger.Log.Error()
+	}
+	event.Err(err).
+		Str("request_id", requestID).
+		Str("method", c.Request().Method).
+		Str("path", c.Path()).
+		Int("status", appErr.Status).
+		Str("code", appErr.Code).
+		Msg("Request failed")
+
+	message := i18n.T(GetLocale(c), appErr.MessageKey, appErr.Vars)
+	if appErr.Detail != "" {
+		message += ": " + appErr.Detail
+	}
+
+	response := dto.Response{
+		Success: false,
+		Message: message,
+		Error: &dto.ErrorDetail{
+			Code:      appErr.Code,
+			Message:   message,
+			Fields:    appErr.Fields,
+			RequestID: requestID,
+		},
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(appErr.Status)
+	} else {
+		err = c.JSON(appErr.Status, response)
+	}
+	if err != nil {
+		logger.Log.Error().Err(err).Str("request_id", requestID).Msg("Failed to write error response")
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/i18n"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	headerAcceptLanguage = "Accept-Language"
+
+	localeKey         = "locale"
+	localeExplicitKey = "locale_explicit"
+)
+
+// Locale picks the response language from the Accept-Language header.
+// Without a supported header, JWTAuth later falls back to the user's stored preference.
+func Locale() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			locale, explicit := i18n.ParseAcceptLanguage(c.Request().Header.Get(headerAcceptLanguage))
+			if !explicit {
+				locale = i18n.DefaultLocale
+			}
+			c.Set(localeExplicitKey, explicit)
+			setLocale(c, locale)
+			return next(c)
+		}
+	}
+}
+
+// GetLocale returns the locale chosen for the current request
+func GetLocale(c echo.Context) i18n.Locale {
+	if locale, ok := c.Get(localeKey).(i18n.Locale); ok {
+		return locale
+	}
+	return i18n.DefaultLocale
+}
+
+// applyUserLocale uses the locale stored in the access token unless the client sent Accept-Language
+func applyUserLocale(c echo.Context, stored string) {
+	if explicit, _ := c.Get(localeExplicitKey).(bool); explicit {
+		return
+	}
+	if locale, ok := i18n.Parse(stored); ok {
+		setLocale(c, locale)
+	}
+}
+
+// setLocale stores the locale on the echo context and on the request context for services
+func setLocale(c echo.Context, locale i18n.Locale) {
+	c.Set(localeKey, locale)
+	req := c.Request()
+	c.SetRequest(req.WithContext(i18n.WithLocale(req.Context(), locale)))
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// redactedQueryParams carry credentials and are masked in access logs: access tokens of
+// WebSocket/SSE connections and the WhatsApp webhook token
+var redactedQueryParams = []string{accessTokenQueryParam, "token"}
+
+// redactedPathPrefixes are followed by a credential in the path (trip tracking tokens);
+// everything after the prefix is masked in access logs
+var redactedPathPrefixes = []string{"/api/track/"}
+
+// RequestLogger is Echo's access logger with the request URI logged through redactURI,
+// so credentials passed in query strings never reach the logs
+func RequestLogger() echo.MiddlewareFunc {
+	config := middleware.DefaultLoggerConfig
+	config.Format = strings.Replace(config.Format, `"uri":"${uri}"`, `"uri":"${custom}"`, 1)
+	config.CustomTagFunc = func(c echo.Context, buf *bytes.Buffer) (int, error) {
+		return buf.WriteString(redactURI(c.Request().URL))
+	}
+	return middleware.LoggerWithConfig(config)
+}
+
+// redactURI renders the request path and query with credentials masked
+func redactURI(u *url.URL) string {
+	uri := u.EscapedPath()
+	for _, prefix := range redactedPathPrefixes {
+		if strings.HasPrefix(uri, prefix) && len(uri) > len(prefix) {
+			uri = prefix + "REDACTED"
+			break
+		}
+	}
+	if u.RawQuery == "" {
+		return uri
+	}
+
+	query := u.Query()
+	for _, param := range redactedQueryParams {
+		if query.Has(param) {
+			query.Set(param, "REDACTED")
+		}
+	}
+	return uri + "?" + query.Encode()
+}
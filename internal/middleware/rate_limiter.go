@@ -1,11 +1,10 @@
 package middleware
 
 import (
-	"net/http"
 	"sync"
 	"time"
 
-	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
 	"github.com/labstack/echo/v4"
 )
 
@@ -91,10 +90,7 @@ func RateLimit(limit int, window time.Duration) echo.MiddlewareFunc {
 			ip := c.RealIP()
 
 			if !limiter.allow(ip) {
-				return c.JSON(http.StatusTooManyRequests, dto.ErrorResponse(
-					"RATE_LIMIT_EXCEEDED",
-					"Too many requests. Please try again later.",
-				))
+				return apperror.ErrRateLimited
 			}
 
 			return next(c)
@@ -1,9 +1,8 @@
 package middleware
 
 import (
-	"net/http"
-
-	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/i18n"
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 )
@@ -26,31 +25,27 @@ func (cv *CustomValidator) Validate(i interface{}) error {
 // ValidateRequest binds and validates request body
 func ValidateRequest(c echo.Context, req interface{}) error {
 	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, dto.ErrorResponse("INVALID_REQUEST", "Invalid request body"))
+		return apperror.ErrInvalidRequest.Wrap(err)
 	}
 
 	if err := c.Validate(req); err != nil {
 		validationErrors := make(map[string]string)
+		locale := GetLocale(c)
 
 		if ve, ok := err.(validator.ValidationErrors); ok {
 			for _, fe := range ve {
 				field := fe.Field()
+				vars := map[string]string{"field": field, "param": fe.Param()}
 				switch fe.Tag() {
-				case "required":
-					validationErrors[field] = field + " is required"
-				case "email":
-					validationErrors[field] = field + " must be a valid email"
-				case "min":
-					validationErrors[field] = field + " is too short"
-				case "max":
-					validationErrors[field] = field + " is too long"
+				case "required", "email", "min", "max", "oneof":
+					validationErrors[field] = i18n.T(locale, "validation."+fe.Tag(), vars)
 				default:
-					validationErrors[field] = field + " is invalid"
+					validationErrors[field] = i18n.T(locale, "validation.invalid", vars)
 				}
 			}
 		}
 
-		return c.JSON(http.StatusBadRequest, dto.ValidationErrorResponse(validationErrors))
+		return apperror.Validation(validationErrors).Wrap(err)
 	}
 
 	return nil
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CampusDomainRepository interface {
+	Create(ctx context.Context, domain *entity.CampusDomain) error
+	Delete(ctx context.Context, id int) (bool, error)
+	List(ctx context.Context) ([]*entity.CampusDomain, error)
+	ExistsByPattern(ctx context.Context, pattern string) (bool, error)
+	MatchesEmail(ctx context.Context, email string) (bool, error)
+	RefreshEligibility(ctx context.Context, userID *int) error
+	WithTx(tx pgx.Tx) CampusDomainRepository
+}
+
+type campusDomainRepository struct {
+	db database.DBTX
+}
+
+func NewCampusDomainRepository(db *pgxpool.Pool) CampusDomainRepository {
+	return &campusDomainRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *campusDomainRepository) WithTx(tx pgx.Tx) CampusDomainRepository {
+	return &campusDomainRepository{db: tx}
+}
+
+// campusDomainMatch builds the condition that campus domain d allows the given email
+// expression: its domain equals the pattern, or the pattern is "*.suffix" and the domain
+// ends in ".suffix"
+func campusDomainMatch(email string) string {
+	domain := "lower(split_part(" + email + ", '@', 2))"
+	return "(" + domain + " = d.pattern OR (left(d.pattern, 2) = '*.' AND " + domain + " LIKE '%' || substr(d.pattern, 2)))"
+}
+
+func (r *campusDomainRepository) Create(ctx context.Context, domain *entity.CampusDomain) error {
+	query := `
+		INSERT INTO campus_domains (pattern, name, created_by)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+	return r.db.QueryRow(ctx, query, domain.Pattern, domain.Name, domain.CreatedBy).Scan(&domain.ID, &domain.CreatedAt)
+}
+
+// Delete reports whether a domain was removed
+func (r *campusDomainRepository) Delete(ctx context.Context, id int) (bool, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM campus_domains WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *campusDomainRepository) List(ctx context.Context) ([]*entity.CampusDomain, error) {
+	query := `
+		SELECT id, pattern, name, created_by, created_at
+		FROM campus_domains
+		ORDER BY pattern
+	`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var domains []*entity.CampusDomain
+	for rows.Next() {
+		var domain entity.CampusDomain
+		if err := rows.Scan(&domain.ID, &domain.Pattern, &domain.Name, &domain.CreatedBy, &domain.CreatedAt); err != nil {
+			return nil, err
+		}
+		domains = append(domains, &domain)
+	}
+	return domains, rows.Err()
+}
+
+func (r *campusDomainRepository) ExistsByPattern(ctx context.Context, pattern string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM campus_domains WHERE pattern = $1)`
+	var exists bool
+	err := r.db.QueryRow(ctx, query, pattern).Scan(&exists)
+	return exists, err
+}
+
+// MatchesEmail reports whether the email is on an allow-listed campus domain
+func (r *campusDomainRepository) MatchesEmail(ctx context.Context, email string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM campus_domains d WHERE ` + campusDomainMatch("$1") + `)`
+	var matches bool
+	err := r.db.QueryRow(ctx, query, email).Scan(&matches)
+	return matches, err
+}
+
+// RefreshEligibility recomputes campus_eligible from the verified email, for one user or,
+// when userID is nil, for every user after the allow-list changed
+func (r *campusDomainRepository) RefreshEligibility(ctx context.Context, userID *int) error {
+	query := `
+		UPDATE users u
+		SET campus_eligible = eligible, updated_at = NOW()
+		FROM (
+			SELECT x.id, x.email_verified AND x.email IS NOT NULL AND EXISTS(
+				SELECT 1 FROM campus_domains d WHERE ` + campusDomainMatch("x.email") + `
+			) AS eligible
+			FROM users x
+			WHERE $1::int IS NULL OR x.id = $1
+		) e
+		WHERE u.id = e.id AND u.campus_eligible <> e.eligible
+	`
+	_, err := r.db.Exec(ctx, query, userID)
+	return err
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CancellationRepository interface {
+	Create(ctx context.Context, cancellation *entity.Cancellation) error
+	CountRate(ctx context.Context, userID int, role entity.UserRole, since time.Time) (cancelled, orders int, err error)
+	FindCooldown(ctx context.Context, userID int) (*entity.UserCooldown, error)
+	UpsertCooldown(ctx context.Context, cooldown *entity.UserCooldown) error
+	FindActiveCooldowns(ctx context.Context, now time.Time, limit, offset int) ([]*entity.CooldownWithUser, error)
+	DeleteCooldown(ctx context.Context, userID int) (bool, error)
+	WithTx(tx pgx.Tx) CancellationRepository
+}
+
+type cancellationRepository struct {
+	db database.DBTX
+}
+
+func NewCancellationRepository(db *pgxpool.Pool) CancellationRepository {
+	return &cancellationRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *cancellationRepository) WithTx(tx pgx.Tx) CancellationRepository {
+	return &cancellationRepository{db: tx}
+}
+
+func (r *cancellationRepository) Create(ctx context.Context, cancellation *entity.Cancellation) error {
+	query := `
+		INSERT INTO cancellations (order_id, cancelled_by, role, reason_code, note, order_status, fee, fee_settled_by, charged_user_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at
+	`
+	return r.db.QueryRow(ctx, query,
+		cancellation.OrderID,
+		cancellation.CancelledBy,
+		cancellation.Role,
+		cancellation.ReasonCode,
+		cancellation.Note,
+		cancellation.OrderStatus,
+		cancellation.Fee,
+		cancellation.FeeSettledBy,
+		cancellation.ChargedUserID,
+	).Scan(&cancellation.ID, &cancellation.CreatedAt)
+}
+
+// CountRate returns the cancellations counted against the user since the given time and
+// the orders they had in the same window. A passenger's orders are the ones they placed;
+// a driver's are the ones they accepted, including those they later dropped, which no
+// longer carry their driver_id.
+func (r *cancellationRepository) CountRate(ctx context.Context, userID int, role entity.UserRole, since time.Time) (int, int, error) {
+	ordersQuery := `SELECT COUNT(*) FROM orders WHERE passenger_id = $1 AND created_at >= $2`
+	if role == entity.RoleDriver {
+		ordersQuery = `
+			SELECT (SELECT COUNT(*) FROM orders WHERE driver_id = $1 AND accepted_at >= $2)
+			     + (SELECT COUNT(*) FROM cancellations
+			        WHERE cancelled_by = $1 AND role = 'DRIVER' AND charged_user_id = $1 AND created_at >= $2)
+		`
+	}
+
+	var orders int
+	if err := r.db.QueryRow(ctx, ordersQuery, userID, since).Scan(&orders); err != nil {
+		return 0, 0, err
+	}
+
+	query := `SELECT COUNT(*) FROM cancellations WHERE charged_user_id = $1 AND created_at >= $2`
+	var cancelled int
+	if err := r.db.QueryRow(ctx, query, userID, since).Scan(&cancelled); err != nil {
+		return 0, 0, err
+	}
+	return cancelled, orders, nil
+}
+
+// FindCooldown returns nil when the user has no cooldown on record; callers check
+// whether it is still active
+func (r *cancellationRepository) FindCooldown(ctx context.Context, userID int) (*entity.UserCooldown, error) {
+	query := `
+		SELECT user_id, role, until, cancellations, orders, created_at
+		FROM user_cooldowns
+		WHERE user_id = $1
+	`
+	var cooldown entity.UserCooldown
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&cooldown.UserID,
+		&cooldown.Role,
+		&cooldown.Until,
+		&cooldown.Cancellations,
+		&cooldown.Orders,
+		&cooldown.CreatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &cooldown, nil
+}
+
+// UpsertCooldown starts a cooldown, replacing an earlier one that has run out
+func (r *cancellationRepository) UpsertCooldown(ctx context.Context, cooldown *entity.UserCooldown) error {
+	query := `
+		INSERT INTO user_cooldowns (user_id, role, until, cancellations, orders)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE
+		SET role = EXCLUDED.role, until = EXCLUDED.until, cancellations = EXCLUDED.cancellations,
+		    orders = EXCLUDED.orders, created_at = NOW()
+		RETURNING created_at
+	`
+	return r.db.QueryRow(ctx, query,
+		cooldown.UserID,
+		cooldown.Role,
+		cooldown.Until,
+		cooldown.Cancellations,
+		cooldown.Orders,
+	).Scan(&cooldown.CreatedAt)
+}
+
+// FindActiveCooldowns lists cooldowns still running at now, ending soonest first
+func (r *cancellationRepository) FindActiveCooldowns(ctx context.Context, now time.Time, limit, offset int) ([]*entity.CooldownWithUser, error) {
+	query := `
+		SELECT c.user_id, c.role, c.until, c.cancellations, c.orders, c.created_at,
+		       u.full_name, u.phone_number
+		FROM user_cooldowns c
+		JOIN users u ON u.id = c.user_id
+		WHERE c.until > $1
+		ORDER BY c.until
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Query(ctx, query, now, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cooldowns := []*entity.CooldownWithUser{}
+	for rows.Next() {
+		var cooldown entity.CooldownWithUser
+		if err := rows.Scan(
+			&cooldown.UserID,
+			&cooldown.Role,
+			&cooldown.Until,
+			&cooldown.Cancellations,
+			&cooldown.Orders,
+			&cooldown.CreatedAt,
+			&cooldown.FullName,
+			&cooldown.PhoneNumber,
+		); err != nil {
+			return nil, err
+		}
+		cooldowns = append(cooldowns, &cooldown)
+	}
+	return cooldowns, rows.Err()
+}
+
+// DeleteCooldown lifts a user's cooldown; it reports false when there was none
+func (r *cancellationRepository) DeleteCooldown(ctx context.Context, userID int) (bool, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM user_cooldowns WHERE user_id = $1`, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ChatRepository interface {
+	Create(ctx context.Context, message *entity.ChatMessage) error
+	FindByOrderID(ctx context.Context, orderID int, afterID int64, limit int) ([]*entity.ChatMessage, error)
+	MarkRead(ctx context.Context, orderID, readerID int, upToID int64, readAt time.Time) (int64, error)
+	DeleteBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+	WithTx(tx pgx.Tx) ChatRepository
+}
+
+type chatRepository struct {
+	db database.DBTX
+}
+
+func NewChatRepository(db *pgxpool.Pool) ChatRepository {
+	return &chatRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *chatRepository) WithTx(tx pgx.Tx) ChatRepository {
+	return &chatRepository{db: tx}
+}
+
+func (r *chatRepository) Create(ctx context.Context, message *entity.ChatMessage) error {
+	query := `
+		INSERT INTO chat_messages (order_id, sender_id, sender_role, body, quick_reply, masked)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+	return r.db.QueryRow(ctx, query,
+		message.OrderID,
+		message.SenderID,
+		message.SenderRole,
+		message.Body,
+		message.QuickReply,
+		message.Masked,
+	).Scan(&message.ID, &message.CreatedAt)
+}
+
+// FindByOrderID returns up to limit messages of the order sent after afterID, oldest first
+func (r *chatRepository) FindByOrderID(ctx context.Context, orderID int, afterID int64, limit int) ([]*entity.ChatMessage, error) {
+	query := `
+		SELECT id, order_id, sender_id, sender_role, body, quick_reply, masked, read_at, created_at
+		FROM chat_messages
+		WHERE order_id = $1 AND id > $2
+		ORDER BY id
+		LIMIT $3
+	`
+	rows, err := r.db.Query(ctx, query, orderID, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := []*entity.ChatMessage{}
+	for rows.Next() {
+		var message entity.ChatMessage
+		if err := rows.Scan(
+			&message.ID,
+			&message.OrderID,
+			&message.SenderID,
+			&message.SenderRole,
+			&message.Body,
+			&message.QuickReply,
+			&message.Masked,
+			&message.ReadAt,
+			&message.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		messages = append(messages, &message)
+	}
+	return messages, rows.Err()
+}
+
+// MarkRead marks the unread messages the reader received up to and including upToID as
+// read, returning how many changed
+func (r *chatRepository) MarkRead(ctx context.Context, orderID, readerID int, upToID int64, readAt time.Time) (int64, error) {
+	query := `
+		UPDATE chat_messages SET read_at = $4
+		WHERE order_id = $1 AND sender_id <> $2 AND id <= $3 AND read_at IS NULL
+	`
+	tag, err := r.db.Exec(ctx, query, orderID, readerID, upToID, readAt)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// DeleteBefore deletes up to limit messages sent before the given time, returning how
+// many were deleted; callers repeat until it returns fewer than limit
+func (r *chatRepository) DeleteBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	query := `
+		DELETE FROM chat_messages
+		WHERE id IN (SELECT id FROM chat_messages WHERE created_at < $1 ORDER BY id LIMIT $2)
+	`
+	tag, err := r.db.Exec(ctx, query, before, limit)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
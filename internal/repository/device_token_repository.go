@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type DeviceTokenRepository interface {
+	Upsert(ctx context.Context, device *entity.DeviceToken) error
+	FindByUserID(ctx context.Context, userID int) ([]*entity.DeviceToken, error)
+	DeleteByToken(ctx context.Context, token string) error
+	DeleteByUserAndToken(ctx context.Context, userID int, token string) error
+}
+
+type deviceTokenRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewDeviceTokenRepository(db *pgxpool.Pool) DeviceTokenRepository {
+	return &deviceTokenRepository{db: db}
+}
+
+// Upsert registers a token; a token re-registered by another account moves to that account
+func (r *deviceTokenRepository) Upsert(ctx context.Context, device *entity.DeviceToken) error {
+	query := `
+		INSERT INTO device_tokens (user_id, token, platform)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (token) DO UPDATE
+		SET user_id = EXCLUDED.user_id, platform = EXCLUDED.platform, last_seen_at = NOW()
+		RETURNING id, created_at, last_seen_at
+	`
+	return r.db.QueryRow(ctx, query, device.UserID, device.Token, device.Platform).
+		Scan(&device.ID, &device.CreatedAt, &device.LastSeenAt)
+}
+
+func (r *deviceTokenRepository) FindByUserID(ctx context.Context, userID int) ([]*entity.DeviceToken, error) {
+	query := `
+		SELECT id, user_id, token, platform, created_at, last_seen_at
+		FROM device_tokens
+		WHERE user_id = $1
+		ORDER BY last_seen_at DESC
+	`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	devices := []*entity.DeviceToken{}
+	for rows.Next() {
+		var device entity.DeviceToken
+		if err := rows.Scan(
+			&device.ID,
+			&device.UserID,
+			&device.Token,
+			&device.Platform,
+			&device.CreatedAt,
+			&device.LastSeenAt,
+		); err != nil {
+			return nil, err
+		}
+		devices = append(devices, &device)
+	}
+	return devices, rows.Err()
+}
+
+func (r *deviceTokenRepository) DeleteByToken(ctx context.Context, token string) error {
+	query := `DELETE FROM device_tokens WHERE token = $1`
+	_, err := r.db.Exec(ctx, query, token)
+	return err
+}
+
+func (r *deviceTokenRepository) DeleteByUserAndToken(ctx context.Context, userID int, token string) error {
+	query := `DELETE FROM device_tokens WHERE user_id = $1 AND token = $2`
+	_, err := r.db.Exec(ctx, query, userID, token)
+	return err
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type DispatchOfferRepository interface {
+	Create(ctx context.Context, offer *entity.DispatchOffer) error
+	FindByIDForUpdate(ctx context.Context, id int) (*entity.DispatchOffer, error)
+	FindByOrderID(ctx context.Context, orderID int) ([]*entity.DispatchOffer, error)
+	FindPendingByDriver(ctx context.Context, driverID int) (*entity.DispatchOffer, error)
+	UpdateStatus(ctx context.Context, id int, status entity.OfferStatus, respondedAt time.Time) error
+	WithTx(tx pgx.Tx) DispatchOfferRepository
+}
+
+type dispatchOfferRepository struct {
+	db database.DBTX
+}
+
+func NewDispatchOfferRepository(db *pgxpool.Pool) DispatchOfferRepository {
+	return &dispatchOfferRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *dispatchOfferRepository) WithTx(tx pgx.Tx) DispatchOfferRepository {
+	return &dispatchOfferRepository{db: tx}
+}
+
+const dispatchOfferColumns = `id, order_id, driver_id, attempt, status, radius_km, distance_km,
+	rating, acceptance_rate, score, offered_at, expires_at, responded_at`
+
+func (r *dispatchOfferRepository) Create(ctx context.Context, offer *entity.DispatchOffer) error {
+	query := `
+		INSERT INTO dispatch_offers (
+			order_id, driver_id, attempt, status, radius_km, distance_km,
+			rating, acceptance_rate, score, offered_at, expires_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id
+	`
+	return r.db.QueryRow(ctx, query,
+		offer.OrderID,
+		offer.DriverID,
+		offer.Attempt,
+		offer.Status,
+		offer.RadiusKm,
+		offer.DistanceKm,
+		offer.Rating,
+		offer.AcceptanceRate,
+		offer.Score,
+		offer.OfferedAt,
+		offer.ExpiresAt,
+	).Scan(&offer.ID)
+}
+
+// FindByIDForUpdate locks the offer row until the surrounding transaction ends; nil when missing
+func (r *dispatchOfferRepository) FindByIDForUpdate(ctx context.Context, id int) (*entity.DispatchOffer, error) {
+	query := `SELECT ` + dispatchOfferColumns + ` FROM dispatch_offers WHERE id = $1 FOR UPDATE`
+
+	offer, err := scanDispatchOffer(r.db.QueryRow(ctx, query, id))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return offer, err
+}
+
+// FindByOrderID returns every offer of an order in attempt order
+func (r *dispatchOfferRepository) FindByOrderID(ctx context.Context, orderID int) ([]*entity.DispatchOffer, error) {
+	query := `SELECT ` + dispatchOfferColumns + ` FROM dispatch_offers WHERE order_id = $1 ORDER BY attempt`
+	rows, err := r.db.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	offers := []*entity.DispatchOffer{}
+	for rows.Next() {
+		offer, err := scanDispatchOffer(rows)
+		if err != nil {
+			return nil, err
+		}
+		offers = append(offers, offer)
+	}
+	return offers, rows.Err()
+}
+
+// FindPendingByDriver returns the driver's open offer, or nil
+func (r *dispatchOfferRepository) FindPendingByDriver(ctx context.Context, driverID int) (*entity.DispatchOffer, error) {
+	query := `
+		SELECT ` + dispatchOfferColumns + `
+		FROM dispatch_offers
+		WHERE driver_id = $1 AND status = 'PENDING'
+		ORDER BY offered_at DESC
+		LIMIT 1
+	`
+	offer, err := scanDispatchOffer(r.db.QueryRow(ctx, query, driverID))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return offer, err
+}
+
+func (r *dispatchOfferRepository) UpdateStatus(ctx context.Context, id int, status entity.OfferStatus, respondedAt time.Time) error {
+	query := `UPDATE dispatch_offers SET status = $1, responded_at = $2 WHERE id = $3`
+	_, err := r.db.Exec(ctx, query, status, respondedAt, id)
+	return err
+}
+
+func scanDispatchOffer(row pgx.Row) (*entity.DispatchOffer, error) {
+	var offer entity.DispatchOffer
+	err := row.Scan(
+		&offer.ID,
+		&offer.OrderID,
+		&offer.DriverID,
+		&offer.Attempt,
+		&offer.Status,
+		&offer.RadiusKm,
+		&offer.DistanceKm,
+		&offer.Rating,
+		&offer.AcceptanceRate,
+		&offer.Score,
+		&offer.OfferedAt,
+		&offer.ExpiresAt,
+		&offer.RespondedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &offer, nil
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type DriverDocumentExpiryRepository interface {
+	Upsert(ctx context.Context, expiry *entity.DriverDocumentExpiry) error
+	FindByDriverProfileID(ctx context.Context, driverProfileID int) ([]*entity.DriverDocumentExpiry, error)
+	FindPending(ctx context.Context, until time.Time) ([]*entity.ExpiringDocument, error)
+	FindExpiring(ctx context.Context, until time.Time, limit, offset int) ([]*entity.ExpiringDocument, error)
+	MarkReminded(ctx context.Context, driverProfileID int, documentType string, days int) error
+	MarkExpired(ctx context.Context, driverProfileID int, documentType string) error
+	WithTx(tx pgx.Tx) DriverDocumentExpiryRepository
+}
+
+type driverDocumentExpiryRepository struct {
+	db database.DBTX
+}
+
+func NewDriverDocumentExpiryRepository(db *pgxpool.Pool) DriverDocumentExpiryRepository {
+	return &driverDocumentExpiryRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *driverDocumentExpiryRepository) WithTx(tx pgx.Tx) DriverDocumentExpiryRepository {
+	return &driverDocumentExpiryRepository{db: tx}
+}
+
+const expiringDocumentSelect = `
+	SELECT e.driver_profile_id, e.document_type, e.expires_on, e.last_reminder_days, e.expired_at,
+	       e.recorded_by, e.recorded_at,
+	       dp.user_id, u.full_name, u.phone_number, dp.vehicle_plate, dp.is_verified, dp.is_online
+	FROM driver_document_expiries e
+	JOIN driver_profiles dp ON dp.id = e.driver_profile_id
+	JOIN users u ON u.id = dp.user_id`
+
+// Upsert records a document's expiry date; a new date restarts its reminders
+func (r *driverDocumentExpiryRepository) Upsert(ctx context.Context, expiry *entity.DriverDocumentExpiry) error {
+	query := `
+		INSERT INTO driver_document_expiries (driver_profile_id, document_type, expires_on, recorded_by)
+		VALUES ($1, $2, $3::date, $4)
+		ON CONFLICT (driver_profile_id, document_type) DO UPDATE
+		SET expires_on = EXCLUDED.expires_on, recorded_by = EXCLUDED.recorded_by, recorded_at = NOW(),
+		    last_reminder_days = NULL, expired_at = NULL
+		RETURNING recorded_at
+	`
+	expiry.LastReminderDays = nil
+	expiry.ExpiredAt = nil
+	return r.db.QueryRow(ctx, query,
+		expiry.DriverProfileID,
+		expiry.DocumentType,
+		expiry.ExpiresOn,
+		expiry.RecordedBy,
+	).Scan(&expiry.RecordedAt)
+}
+
+func (r *driverDocumentExpiryRepository) FindByDriverProfileID(ctx context.Context, driverProfileID int) ([]*entity.DriverDocumentExpiry, error) {
+	query := `
+		SELECT driver_profile_id, document_type, expires_on, last_reminder_days, expired_at,
+		       recorded_by, recorded_at
+		FROM driver_document_expiries
+		WHERE driver_profile_id = $1
+		ORDER BY document_type
+	`
+	rows, err := r.db.Query(ctx, query, driverProfileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	expiries := []*entity.DriverDocumentExpiry{}
+	for rows.Next() {
+		var expiry entity.DriverDocumentExpiry
+		if err := rows.Scan(
+			&expiry.DriverProfileID, &expiry.DocumentType, &expiry.ExpiresOn, &expiry.LastReminderDays,
+			&expiry.ExpiredAt, &expiry.RecordedBy, &expiry.RecordedAt,
+		); err != nil {
+			return nil, err
+		}
+		expiries = append(expiries, &expiry)
+	}
+	return expiries, rows.Err()
+}
+
+// FindPending returns documents expiring on or before until whose lapse has not been
+// enforced yet, for the daily expiry scan
+func (r *driverDocumentExpiryRepository) FindPending(ctx context.Context, until time.Time) ([]*entity.ExpiringDocument, error) {
+	query := expiringDocumentSelect + `
+		WHERE e.expired_at IS NULL AND e.expires_on <= $1::date
+		ORDER BY e.expires_on, e.driver_profile_id
+	`
+	return r.queryExpiring(ctx, query, until)
+}
+
+// FindExpiring lists documents expiring on or before until, lapsed ones included, soonest first
+func (r *driverDocumentExpiryRepository) FindExpiring(ctx context.Context, until time.Time, limit, offset int) ([]*entity.ExpiringDocument, error) {
+	query := expiringDocumentSelect + `
+		WHERE e.expires_on <= $1::date
+		ORDER BY e.expires_on, e.driver_profile_id, e.document_type
+		LIMIT $2 OFFSET $3
+	`
+	return r.queryExpiring(ctx, query, until, limit, offset)
+}
+
+func (r *driverDocumentExpiryRepository) MarkReminded(ctx context.Context, driverProfileID int, documentType string, days int) error {
+	query := `
+		UPDATE driver_document_expiries
+		SET last_reminder_days = $3
+		WHERE driver_profile_id = $1 AND document_type = $2
+	`
+	_, err := r.db.Exec(ctx, query, driverProfileID, documentType, days)
+	return err
+}
+
+func (r *driverDocumentExpiryRepository) MarkExpired(ctx context.Context, driverProfileID int, documentType string) error {
+	query := `
+		UPDATE driver_document_expiries
+		SET expired_at = NOW()
+		WHERE driver_profile_id = $1 AND document_type = $2 AND expired_at IS NULL
+	`
+	_, err := r.db.Exec(ctx, query, driverProfileID, documentType)
+	return err
+}
+
+func (r *driverDocumentExpiryRepository) queryExpiring(ctx context.Context, query string, args ...any) ([]*entity.ExpiringDocument, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	documents := []*entity.ExpiringDocument{}
+	for rows.Next() {
+		var document entity.ExpiringDocument
+		if err := rows.Scan(
+			&document.DriverProfileID, &document.DocumentType, &document.ExpiresOn, &document.LastReminderDays,
+			&document.ExpiredAt, &document.RecordedBy, &document.RecordedAt,
+			&document.UserID, &document.FullName, &document.PhoneNumber, &document.VehiclePlate,
+			&document.IsVerified, &document.IsOnline,
+		); err != nil {
+			return nil, err
+		}
+		documents = append(documents, &document)
+	}
+	return documents, rows.Err()
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type DriverProfileChangeRepository interface {
+	CreateBatch(ctx context.Context, changes []*entity.DriverProfileChange) error
+	FindByDriverProfileID(ctx context.Context, driverProfileID, limit, offset int) ([]*entity.DriverProfileChange, error)
+	WithTx(tx pgx.Tx) DriverProfileChangeRepository
+}
+
+type driverProfileChangeRepository struct {
+	db database.DBTX
+}
+
+func NewDriverProfileChangeRepository(db *pgxpool.Pool) DriverProfileChangeRepository {
+	return &driverProfileChangeRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *driverProfileChangeRepository) WithTx(tx pgx.Tx) DriverProfileChangeRepository {
+	return &driverProfileChangeRepository{db: tx}
+}
+
+// CreateBatch records all changes of a single profile update at once
+func (r *driverProfileChangeRepository) CreateBatch(ctx context.Context, changes []*entity.DriverProfileChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO driver_profile_changes (driver_profile_id, field, old_value, new_value, changed_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+
+	batch := &pgx.Batch{}
+	for _, change := range changes {
+		batch.Queue(query,
+			change.DriverProfileID,
+			change.Field,
+			change.OldValue,
+			change.NewValue,
+			change.ChangedBy,
+		)
+	}
+
+	results := r.db.SendBatch(ctx, batch)
+	defer results.Close()
+
+	for _, change := range changes {
+		if err := results.QueryRow().Scan(&change.ID, &change.CreatedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *driverProfileChangeRepository) FindByDriverProfileID(ctx context.Context, driverProfileID, limit, offset int) ([]*entity.DriverProfileChange, error) {
+	query := `
+		SELECT id, driver_profile_id, field, old_value, new_value, changed_by, created_at
+		FROM driver_profile_changes
+		WHERE driver_profile_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, driverProfileID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []*entity.DriverProfileChange{}
+	for rows.Next() {
+		var change entity.DriverProfileChange
+		if err := rows.Scan(
+			&change.ID,
+			&change.DriverProfileID,
+			&change.Field,
+			&change.OldValue,
+			&change.NewValue,
+			&change.ChangedBy,
+			&change.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		changes = append(changes, &change)
+	}
+	return changes, rows.Err()
+}
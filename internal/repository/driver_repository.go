@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -13,19 +14,33 @@ type DriverRepository interface {
 	Create(ctx context.Context, profile *entity.DriverProfile) error
 	FindByID(ctx context.Context, id int) (*entity.DriverProfile, error)
 	FindByUserID(ctx context.Context, userID int) (*entity.DriverProfile, error)
+	LockByUserID(ctx context.Context, userID int) error
 	ExistsByVehiclePlate(ctx context.Context, vehiclePlate string) (bool, error)
 	Update(ctx context.Context, profile *entity.DriverProfile) error
 	UpdateVerificationStatus(ctx context.Context, profileID int, isVerified bool, notes, reason *string, verifiedBy *int) error
+	UpdateLocation(ctx context.Context, userID int, lat, long float64) error
+	SetOnline(ctx context.Context, userID int, online bool) error
+	SetOutsideServiceArea(ctx context.Context, userID int, outside bool) (bool, error)
+	FindOutsideServiceArea(ctx context.Context, limit, offset int) ([]*entity.OutOfAreaDriver, error)
+	FindDispatchCandidates(ctx context.Context, area entity.CandidateArea) ([]*entity.DriverCandidate, error)
+	IncrementCompletedOrders(ctx context.Context, userID int) error
+	IncrementCancelledOrders(ctx context.Context, userID int) error
+	WithTx(tx pgx.Tx) DriverRepository
 }
 
 type driverRepository struct {
-	db *pgxpool.Pool
+	db database.DBTX
 }
 
 func NewDriverRepository(db *pgxpool.Pool) DriverRepository {
 	return &driverRepository{db: db}
 }
 
+// WithTx returns a repository bound to the given transaction
+func (r *driverRepository) WithTx(tx pgx.Tx) DriverRepository {
+	return &driverRepository{db: tx}
+}
+
 func (r *driverRepository) Create(ctx context.Context, profile *entity.DriverProfile) error {
 	query := `
 		INSERT INTO driver_profiles (
@@ -57,8 +72,8 @@ func (r *driverRepository) FindByID(ctx context.Context, id int) (*entity.Driver
 		       vehicle_brand, vehicle_model, vehicle_color,
 		       ktp_photo, sim_photo, stnk_photo, ktm_photo,
 		       is_verified, verification_notes, verified_by, verified_at, rejection_reason,
-		       is_active, current_lat, current_long, last_location_update,
-		       total_completed_orders, total_cancelled_orders, rating_avg,
+		       is_active, is_online, current_lat, current_long, last_location_update,
+		       total_completed_orders, total_cancelled_orders, rating_avg, outside_service_area_since,
 		       created_at, updated_at
 		FROM driver_profiles WHERE id = $1
 	`
@@ -84,12 +99,14 @@ func (r *driverRepository) FindByID(ctx context.Context, id int) (*entity.Driver
 		&profile.VerifiedAt,
 		&profile.RejectionReason,
 		&profile.IsActive,
+		&profile.IsOnline,
 		&profile.CurrentLat,
 		&profile.CurrentLong,
 		&profile.LastLocationUpdate,
 		&profile.TotalCompletedOrders,
 		&profile.TotalCancelledOrders,
 		&profile.RatingAvg,
+		&profile.OutsideServiceAreaSince,
 		&profile.CreatedAt,
 		&profile.UpdatedAt,
 	)
@@ -108,8 +125,8 @@ func (r *driverRepository) FindByUserID(ctx context.Context, userID int) (*entit
 		       vehicle_brand, vehicle_model, vehicle_color,
 		       ktp_photo, sim_photo, stnk_photo, ktm_photo,
 		       is_verified, verification_notes, verified_by, verified_at, rejection_reason,
-		       is_active, current_lat, current_long, last_location_update,
-		       total_completed_orders, total_cancelled_orders, rating_avg,
+		       is_active, is_online, current_lat, current_long, last_location_update,
+		       total_completed_orders, total_cancelled_orders, rating_avg, outside_service_area_since,
 		       created_at, updated_at
 		FROM driver_profiles WHERE user_id = $1
 	`
@@ -135,12 +152,14 @@ func (r *driverRepository) FindByUserID(ctx context.Context, userID int) (*entit
 		&profile.VerifiedAt,
 		&profile.RejectionReason,
 		&profile.IsActive,
+		&profile.IsOnline,
 		&profile.CurrentLat,
 		&profile.CurrentLong,
 		&profile.LastLocationUpdate,
 		&profile.TotalCompletedOrders,
 		&profile.TotalCancelledOrders,
 		&profile.RatingAvg,
+		&profile.OutsideServiceAreaSince,
 		&profile.CreatedAt,
 		&profile.UpdatedAt,
 	)
@@ -156,13 +175,14 @@ func (r *driverRepository) FindByUserID(ctx context.Context, userID int) (*entit
 func (r *driverRepository) Update(ctx context.Context, profile *entity.DriverProfile) error {
 	query := `
 		UPDATE driver_profiles
-		SET profile_picture = $1, vehicle_brand = $2, vehicle_model = $3, vehicle_color = $4,
-		    ktp_photo = $5, sim_photo = $6, stnk_photo = $7, ktm_photo = $8,
+		SET profile_picture = $1, vehicle_plate = $2, vehicle_brand = $3, vehicle_model = $4, vehicle_color = $5,
+		    ktp_photo = $6, sim_photo = $7, stnk_photo = $8, ktm_photo = $9,
 		    updated_at = NOW()
-		WHERE id = $9
+		WHERE id = $10
 	`
 	_, err := r.db.Exec(ctx, query,
 		profile.ProfilePicture,
+		profile.VehiclePlate,
 		profile.VehicleBrand,
 		profile.VehicleModel,
 		profile.VehicleColor,
@@ -176,6 +196,14 @@ func (r *driverRepository) Update(ctx context.Context, profile *entity.DriverPro
 }
 
 // ExistsByVehiclePlate checks if vehicle plate already exists
+// LockByUserID locks the driver's profile row until the surrounding transaction ends, so
+// assignments of the same driver are serialized
+func (r *driverRepository) LockByUserID(ctx context.Context, userID int) error {
+	query := `SELECT id FROM driver_profiles WHERE user_id = $1 FOR UPDATE`
+	var id int
+	return r.db.QueryRow(ctx, query, userID).Scan(&id)
+}
+
 func (r *driverRepository) ExistsByVehiclePlate(ctx context.Context, plate string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM driver_profiles WHERE vehicle_plate = $1)`
 	var exists bool
@@ -183,10 +211,12 @@ func (r *driverRepository) ExistsByVehiclePlate(ctx context.Context, plate strin
 	return exists, err
 }
 
+// UpdateVerificationStatus records a review outcome. Approval also activates the account;
+// unverifying leaves is_active alone so the driver only needs a new review.
 func (r *driverRepository) UpdateVerificationStatus(ctx context.Context, profileID int, isVerified bool, notes, reason *string, verifiedBy *int) error {
 	query := `
 		UPDATE driver_profiles
-		SET is_verified = $1, verification_notes = $2, rejection_reason = $3, 
+		SET is_verified = $1, is_active = is_active OR $1, verification_notes = $2, rejection_reason = $3, 
 		    verified_by = $4, verified_at = CASE WHEN $1 = TRUE THEN NOW() ELSE NULL END,
 		    updated_at = NOW()
 		WHERE id = $5
@@ -194,3 +224,130 @@ func (r *driverRepository) UpdateVerificationStatus(ctx context.Context, profile
 	_, err := r.db.Exec(ctx, query, isVerified, notes, reason, verifiedBy, profileID)
 	return err
 }
+
+func (r *driverRepository) UpdateLocation(ctx context.Context, userID int, lat, long float64) error {
+	query := `
+		UPDATE driver_profiles
+		SET current_lat = $1, current_long = $2, last_location_update = NOW()
+		WHERE user_id = $3
+	`
+	_, err := r.db.Exec(ctx, query, lat, long, userID)
+	return err
+}
+
+func (r *driverRepository) SetOnline(ctx context.Context, userID int, online bool) error {
+	query := `UPDATE driver_profiles SET is_online = $1, updated_at = NOW() WHERE user_id = $2`
+	_, err := r.db.Exec(ctx, query, online, userID)
+	return err
+}
+
+// SetOutsideServiceArea flags or clears a driver operating outside the service area and
+// reports whether the flag changed; the flag keeps the time the driver first left
+func (r *driverRepository) SetOutsideServiceArea(ctx context.Context, userID int, outside bool) (bool, error) {
+	query := `
+		UPDATE driver_profiles
+		SET outside_service_area_since = CASE WHEN $2 THEN NOW() ELSE NULL END
+		WHERE user_id = $1 AND (outside_service_area_since IS NOT NULL) <> $2
+	`
+	tag, err := r.db.Exec(ctx, query, userID, outside)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// FindOutsideServiceArea lists drivers flagged outside the service area, longest first
+func (r *driverRepository) FindOutsideServiceArea(ctx context.Context, limit, offset int) ([]*entity.OutOfAreaDriver, error) {
+	query := `
+		SELECT dp.user_id, u.full_name, u.phone_number, dp.vehicle_plate, dp.is_online,
+		       dp.current_lat, dp.current_long, dp.outside_service_area_since
+		FROM driver_profiles dp
+		JOIN users u ON u.id = dp.user_id
+		WHERE dp.outside_service_area_since IS NOT NULL
+		ORDER BY dp.outside_service_area_since
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	drivers := []*entity.OutOfAreaDriver{}
+	for rows.Next() {
+		var driver entity.OutOfAreaDriver
+		if err := rows.Scan(
+			&driver.UserID, &driver.FullName, &driver.PhoneNumber, &driver.VehiclePlate, &driver.IsOnline,
+			&driver.Lat, &driver.Long, &driver.Since,
+		); err != nil {
+			return nil, err
+		}
+		drivers = append(drivers, &driver)
+	}
+	return drivers, rows.Err()
+}
+
+func (r *driverRepository) IncrementCompletedOrders(ctx context.Context, userID int) error {
+	query := `UPDATE driver_profiles SET total_completed_orders = total_completed_orders + 1, updated_at = NOW() WHERE user_id = $1`
+	_, err := r.db.Exec(ctx, query, userID)
+	return err
+}
+
+// IncrementCancelledOrders counts an order the driver gave up
+func (r *driverRepository) IncrementCancelledOrders(ctx context.Context, userID int) error {
+	query := `UPDATE driver_profiles SET total_cancelled_orders = total_cancelled_orders + 1, updated_at = NOW() WHERE user_id = $1`
+	_, err := r.db.Exec(ctx, query, userID)
+	return err
+}
+
+// FindDispatchCandidates returns online, verified drivers with a fresh location inside the area
+// that are neither holding an open offer nor serving an order, with their recent offer stats
+func (r *driverRepository) FindDispatchCandidates(ctx context.Context, area entity.CandidateArea) ([]*entity.DriverCandidate, error) {
+	query := `
+		SELECT dp.user_id, dp.current_lat, dp.current_long, dp.rating_avg,
+		       COALESCE(stats.accepted, 0), COALESCE(stats.decided, 0)
+		FROM driver_profiles dp
+		JOIN users u ON u.id = dp.user_id AND u.status = 'ACTIVE'
+		LEFT JOIN LATERAL (
+			SELECT COUNT(*) FILTER (WHERE o.status = 'ACCEPTED') AS accepted,
+			       COUNT(*) FILTER (WHERE o.status IN ('ACCEPTED', 'DECLINED', 'TIMEOUT')) AS decided
+			FROM dispatch_offers o
+			WHERE o.driver_id = dp.user_id AND o.offered_at > $6
+		) stats ON TRUE
+		WHERE dp.is_online = TRUE AND dp.is_verified = TRUE AND dp.is_active = TRUE
+		  AND dp.current_lat BETWEEN $1 AND $2
+		  AND dp.current_long BETWEEN $3 AND $4
+		  AND dp.last_location_update > $5
+		  AND NOT EXISTS (
+			SELECT 1 FROM dispatch_offers p WHERE p.driver_id = dp.user_id AND p.status = 'PENDING'
+		  )
+		  AND NOT EXISTS (
+			SELECT 1 FROM orders a WHERE a.driver_id = dp.user_id AND a.status IN ('ACCEPTED', 'ARRIVED', 'ON_TRIP')
+		  )
+	`
+	rows, err := r.db.Query(ctx, query,
+		area.MinLat, area.MaxLat, area.MinLong, area.MaxLong,
+		area.LocationSince, area.StatsSince,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidates := []*entity.DriverCandidate{}
+	for rows.Next() {
+		var candidate entity.DriverCandidate
+		if err := rows.Scan(
+			&candidate.UserID,
+			&candidate.Lat,
+			&candidate.Long,
+			&candidate.RatingAvg,
+			&candidate.OffersAccepted,
+			&candidate.OffersDecided,
+		); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, &candidate)
+	}
+	return candidates, rows.Err()
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EmailChangeRepository interface {
+	Create(ctx context.Context, request *entity.EmailChangeRequest) error
+	FindByID(ctx context.Context, id int) (*entity.EmailChangeRequest, error)
+	FindLatestPendingByUserID(ctx context.Context, userID int) (*entity.EmailChangeRequest, error)
+	IncrementAttempts(ctx context.Context, id int) error
+	MarkVerified(ctx context.Context, id int) error
+	InvalidatePending(ctx context.Context, userID int) error
+	WithTx(tx pgx.Tx) EmailChangeRepository
+}
+
+type emailChangeRepository struct {
+	db database.DBTX
+}
+
+func NewEmailChangeRepository(db *pgxpool.Pool) EmailChangeRepository {
+	return &emailChangeRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *emailChangeRepository) WithTx(tx pgx.Tx) EmailChangeRepository {
+	return &emailChangeRepository{db: tx}
+}
+
+func (r *emailChangeRepository) Create(ctx context.Context, request *entity.EmailChangeRequest) error {
+	query := `
+		INSERT INTO email_change_requests (user_id, new_email, code_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, attempts, is_used, created_at
+	`
+	return r.db.QueryRow(ctx, query,
+		request.UserID,
+		request.NewEmail,
+		request.CodeHash,
+		request.ExpiresAt,
+	).Scan(&request.ID, &request.Attempts, &request.IsUsed, &request.CreatedAt)
+}
+
+// FindByID returns the request, or nil if it does not exist
+func (r *emailChangeRepository) FindByID(ctx context.Context, id int) (*entity.EmailChangeRequest, error) {
+	query := `
+		SELECT id, user_id, new_email, code_hash, expires_at, attempts, is_used, verified_at, created_at
+		FROM email_change_requests
+		WHERE id = $1
+	`
+	var request entity.EmailChangeRequest
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&request.ID,
+		&request.UserID,
+		&request.NewEmail,
+		&request.CodeHash,
+		&request.ExpiresAt,
+		&request.Attempts,
+		&request.IsUsed,
+		&request.VerifiedAt,
+		&request.CreatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// FindLatestPendingByUserID returns the latest unused request, or nil if none
+func (r *emailChangeRepository) FindLatestPendingByUserID(ctx context.Context, userID int) (*entity.EmailChangeRequest, error) {
+	query := `
+		SELECT id, user_id, new_email, code_hash, expires_at, attempts, is_used, verified_at, created_at
+		FROM email_change_requests
+		WHERE user_id = $1 AND is_used = false
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+	var request entity.EmailChangeRequest
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&request.ID,
+		&request.UserID,
+		&request.NewEmail,
+		&request.CodeHash,
+		&request.ExpiresAt,
+		&request.Attempts,
+		&request.IsUsed,
+		&request.VerifiedAt,
+		&request.CreatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *emailChangeRepository) IncrementAttempts(ctx context.Context, id int) error {
+	query := `UPDATE email_change_requests SET attempts = attempts + 1 WHERE id = $1`
+	_, err := r.db.Exec(ctx, query, id)
+	return err
+}
+
+func (r *emailChangeRepository) MarkVerified(ctx context.Context, id int) error {
+	query := `UPDATE email_change_requests SET is_used = true, verified_at = NOW() WHERE id = $1`
+	_, err := r.db.Exec(ctx, query, id)
+	return err
+}
+
+// InvalidatePending discards older pending requests when a new one is made
+func (r *emailChangeRepository) InvalidatePending(ctx context.Context, userID int) error {
+	query := `UPDATE email_change_requests SET is_used = true WHERE user_id = $1 AND is_used = false`
+	_, err := r.db.Exec(ctx, query, userID)
+	return err
+}
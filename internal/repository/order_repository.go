@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OrderRepository interface {
+	Create(ctx context.Context, order *entity.Order) error
+	FindByID(ctx context.Context, id int) (*entity.Order, error)
+	FindByIDForUpdate(ctx context.Context, id int) (*entity.Order, error)
+	FindActiveByPassenger(ctx context.Context, passengerID int) (*entity.Order, error)
+	FindActiveByDriver(ctx context.Context, driverID int) (*entity.Order, error)
+	Assign(ctx context.Context, id, driverID int, acceptedAt time.Time) error
+	MarkExpired(ctx context.Context, id int, expiredAt time.Time) error
+	MarkArrived(ctx context.Context, id int, arrivedAt time.Time) error
+	MarkStarted(ctx context.Context, id int, startedAt time.Time) error
+	MarkCompleted(ctx context.Context, id int, completedAt time.Time) error
+	UpdatePaymentMethod(ctx context.Context, id int, method entity.PaymentMethod) error
+	FindScheduledByPassenger(ctx context.Context, passengerID int) ([]*entity.Order, error)
+	FindScheduledByDriver(ctx context.Context, driverID int) ([]*entity.Order, error)
+	FindOpenScheduled(ctx context.Context, after time.Time, limit, offset int) ([]*entity.Order, error)
+	PreAssign(ctx context.Context, id, driverID int, acceptedAt time.Time) error
+	ReleaseDriver(ctx context.Context, id int) error
+	MarkSearching(ctx context.Context, id int) error
+	MarkCancelled(ctx context.Context, id int, cancelledAt time.Time) error
+	WithTx(tx pgx.Tx) OrderRepository
+}
+
+type orderRepository struct {
+	db database.DBTX
+}
+
+func NewOrderRepository(db *pgxpool.Pool) OrderRepository {
+	return &orderRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *orderRepository) WithTx(tx pgx.Tx) OrderRepository {
+	return &orderRepository{db: tx}
+}
+
+const orderColumns = `id, passenger_id, driver_id, status,
+	pickup_lat, pickup_long, pickup_address, dropoff_lat, dropoff_long, dropoff_address,
+	distance_km, fare, zone_surcharge, promo_code, discount, payment_method, notes, scheduled_at,
+	accepted_at, arrived_at, started_at, completed_at, expired_at, cancelled_at,
+	created_at, updated_at`
+
+func (r *orderRepository) Create(ctx context.Context, order *entity.Order) error {
+	query := `
+		INSERT INTO orders (
+			passenger_id, status, pickup_lat, pickup_long, pickup_address,
+			dropoff_lat, dropoff_long, dropoff_address, distance_km, fare, zone_surcharge, promo_code, discount,
+			payment_method, notes, scheduled_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		RETURNING id, created_at, updated_at
+	`
+	return r.db.QueryRow(ctx, query,
+		order.PassengerID,
+		order.Status,
+		order.PickupLat,
+		order.PickupLong,
+		order.PickupAddress,
+		order.DropoffLat,
+		order.DropoffLong,
+		order.DropoffAddress,
+		order.DistanceKm,
+		order.Fare,
+		order.ZoneSurcharge,
+		order.PromoCode,
+		order.Discount,
+		order.PaymentMethod,
+		order.Notes,
+		order.ScheduledAt,
+	).Scan(&order.ID, &order.CreatedAt, &order.UpdatedAt)
+}
+
+// FindByID returns nil when the order does not exist
+func (r *orderRepository) FindByID(ctx context.Context, id int) (*entity.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = $1`
+	return r.scanOne(r.db.QueryRow(ctx, query, id))
+}
+
+// FindByIDForUpdate locks the order row until the surrounding transaction ends
+func (r *orderRepository) FindByIDForUpdate(ctx context.Context, id int) (*entity.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = $1 FOR UPDATE`
+	return r.scanOne(r.db.QueryRow(ctx, query, id))
+}
+
+// FindActiveByPassenger returns the passenger's order that is still in progress, or nil
+func (r *orderRepository) FindActiveByPassenger(ctx context.Context, passengerID int) (*entity.Order, error) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders
+		WHERE passenger_id = $1 AND status IN ('SEARCHING', 'ACCEPTED', 'ARRIVED', 'ON_TRIP')
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+	return r.scanOne(r.db.QueryRow(ctx, query, passengerID))
+}
+
+// FindActiveByDriver returns the order the driver is currently serving, or nil
+func (r *orderRepository) FindActiveByDriver(ctx context.Context, driverID int) (*entity.Order, error) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders
+		WHERE driver_id = $1 AND status IN ('ACCEPTED', 'ARRIVED', 'ON_TRIP')
+		ORDER BY accepted_at DESC
+		LIMIT 1
+	`
+	return r.scanOne(r.db.QueryRow(ctx, query, driverID))
+}
+
+func (r *orderRepository) Assign(ctx context.Context, id, driverID int, acceptedAt time.Time) error {
+	query := `
+		UPDATE orders
+		SET driver_id = $1, status = 'ACCEPTED', accepted_at = $2, updated_at = NOW()
+		WHERE id = $3
+	`
+	_, err := r.db.Exec(ctx, query, driverID, acceptedAt, id)
+	return err
+}
+
+func (r *orderRepository) MarkExpired(ctx context.Context, id int, expiredAt time.Time) error {
+	query := `UPDATE orders SET status = 'EXPIRED', expired_at = $1, updated_at = NOW() WHERE id = $2`
+	_, err := r.db.Exec(ctx, query, expiredAt, id)
+	return err
+}
+
+func (r *orderRepository) MarkArrived(ctx context.Context, id int, arrivedAt time.Time) error {
+	query := `UPDATE orders SET status = 'ARRIVED', arrived_at = $1, updated_at = NOW() WHERE id = $2`
+	_, err := r.db.Exec(ctx, query, arrivedAt, id)
+	return err
+}
+
+func (r *orderRepository) MarkStarted(ctx context.Context, id int, startedAt time.Time) error {
+	query := `UPDATE orders SET status = 'ON_TRIP', started_at = $1, updated_at = NOW() WHERE id = $2`
+	_, err := r.db.Exec(ctx, query, startedAt, id)
+	return err
+}
+
+func (r *orderRepository) MarkCompleted(ctx context.Context, id int, completedAt time.Time) error {
+	query := `UPDATE orders SET status = 'COMPLETED', completed_at = $1, updated_at = NOW() WHERE id = $2`
+	_, err := r.db.Exec(ctx, query, completedAt, id)
+	return err
+}
+
+func (r *orderRepository) UpdatePaymentMethod(ctx context.Context, id int, method entity.PaymentMethod) error {
+	query := `UPDATE orders SET payment_method = $1, updated_at = NOW() WHERE id = $2`
+	_, err := r.db.Exec(ctx, query, method, id)
+	return err
+}
+
+// FindScheduledByPassenger returns the passenger's upcoming scheduled rides, soonest first
+func (r *orderRepository) FindScheduledByPassenger(ctx context.Context, passengerID int) ([]*entity.Order, error) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders
+		WHERE passenger_id = $1 AND status = 'SCHEDULED'
+		ORDER BY scheduled_at
+	`
+	return r.query(ctx, query, passengerID)
+}
+
+// FindScheduledByDriver returns the scheduled rides the driver pre-accepted, soonest first
+func (r *orderRepository) FindScheduledByDriver(ctx context.Context, driverID int) ([]*entity.Order, error) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders
+		WHERE driver_id = $1 AND status = 'SCHEDULED'
+		ORDER BY scheduled_at
+	`
+	return r.query(ctx, query, driverID)
+}
+
+// FindOpenScheduled returns scheduled rides no driver has pre-accepted yet with a pickup
+// after the given time, soonest first
+func (r *orderRepository) FindOpenScheduled(ctx context.Context, after time.Time, limit, offset int) ([]*entity.Order, error) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders
+		WHERE status = 'SCHEDULED' AND driver_id IS NULL AND scheduled_at > $1
+		ORDER BY scheduled_at, id
+		LIMIT $2 OFFSET $3
+	`
+	return r.query(ctx, query, after, limit, offset)
+}
+
+// PreAssign records the driver who pre-accepted a scheduled ride; the order stays SCHEDULED
+func (r *orderRepository) PreAssign(ctx context.Context, id, driverID int, acceptedAt time.Time) error {
+	query := `UPDATE orders SET driver_id = $1, accepted_at = $2, updated_at = NOW() WHERE id = $3`
+	_, err := r.db.Exec(ctx, query, driverID, acceptedAt, id)
+	return err
+}
+
+// ReleaseDriver removes the pre-accepted driver from a scheduled ride
+func (r *orderRepository) ReleaseDriver(ctx context.Context, id int) error {
+	query := `UPDATE orders SET driver_id = NULL, accepted_at = NULL, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.Exec(ctx, query, id)
+	return err
+}
+
+// MarkSearching hands a scheduled ride, or one whose driver cancelled, to the dispatcher
+func (r *orderRepository) MarkSearching(ctx context.Context, id int) error {
+	query := `UPDATE orders SET status = 'SEARCHING', driver_id = NULL, accepted_at = NULL, arrived_at = NULL, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.Exec(ctx, query, id)
+	return err
+}
+
+func (r *orderRepository) MarkCancelled(ctx context.Context, id int, cancelledAt time.Time) error {
+	query := `UPDATE orders SET status = 'CANCELLED', cancelled_at = $1, updated_at = NOW() WHERE id = $2`
+	_, err := r.db.Exec(ctx, query, cancelledAt, id)
+	return err
+}
+
+func (r *orderRepository) query(ctx context.Context, query string, args ...any) ([]*entity.Order, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := []*entity.Order{}
+	for rows.Next() {
+		order, err := r.scanOne(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	return orders, rows.Err()
+}
+
+func (r *orderRepository) scanOne(row pgx.Row) (*entity.Order, error) {
+	var order entity.Order
+	err := row.Scan(
+		&order.ID,
+		&order.PassengerID,
+		&order.DriverID,
+		&order.Status,
+		&order.PickupLat,
+		&order.PickupLong,
+		&order.PickupAddress,
+		&order.DropoffLat,
+		&order.DropoffLong,
+		&order.DropoffAddress,
+		&order.DistanceKm,
+		&order.Fare,
+		&order.ZoneSurcharge,
+		&order.PromoCode,
+		&order.Discount,
+		&order.PaymentMethod,
+		&order.Notes,
+		&order.ScheduledAt,
+		&order.AcceptedAt,
+		&order.ArrivedAt,
+		&order.StartedAt,
+		&order.CompletedAt,
+		&order.ExpiredAt,
+		&order.CancelledAt,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -16,13 +17,15 @@ type OTPRepository interface {
 	Create(ctx context.Context, otp *entity.OTPCode) error
 	FindLatestByPhoneAndPurpose(ctx context.Context, phoneNumber string, purpose entity.OTPPurpose) (*entity.OTPCode, error)
 	FindByPhoneAndCode(ctx context.Context, phoneNumber, otpCode string) (*entity.OTPCode, error)
+	FindByID(ctx context.Context, id int) (*entity.OTPCode, error)
 	MarkAsUsed(ctx context.Context, id int) error
 	IncrementAttempts(ctx context.Context, id int) error
 	InvalidateOldOTPs(ctx context.Context, phoneNumber string, purpose entity.OTPPurpose) error
+	WithTx(tx pgx.Tx) OTPRepository
 }
 
 type otpRepository struct {
-	db *pgxpool.Pool
+	db database.DBTX
 }
 
 // NewOTPRepository creates a new OTP repository
@@ -30,14 +33,19 @@ func NewOTPRepository(db *pgxpool.Pool) OTPRepository {
 	return &otpRepository{db: db}
 }
 
+// WithTx returns a repository bound to the given transaction
+func (r *otpRepository) WithTx(tx pgx.Tx) OTPRepository {
+	return &otpRepository{db: tx}
+}
+
 // Create inserts a new OTP code
 func (r *otpRepository) Create(ctx context.Context, otp *entity.OTPCode) error {
 	query := `
 		INSERT INTO otp_codes (
 			phone_number, otp_code, purpose, expires_at, 
-			ip_address, user_agent, attempts, is_used, created_at
+			ip_address, user_agent, locale, attempts, is_used, created_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, 0, false, $7
+			$1, $2, $3, $4, $5, $6, $7, 0, false, $8
 		) RETURNING id
 	`
 
@@ -50,6 +58,7 @@ func (r *otpRepository) Create(ctx context.Context, otp *entity.OTPCode) error {
 		otp.ExpiresAt,
 		otp.IPAddress,
 		otp.UserAgent,
+		otp.Locale,
 		otp.CreatedAt,
 	).Scan(&otp.ID)
 
@@ -74,7 +83,7 @@ func (r *otpRepository) Create(ctx context.Context, otp *entity.OTPCode) error {
 func (r *otpRepository) FindLatestByPhoneAndPurpose(ctx context.Context, phoneNumber string, purpose entity.OTPPurpose) (*entity.OTPCode, error) {
 	query := `
 		SELECT id, phone_number, otp_code, purpose, expires_at, 
-		       is_used, used_at, attempts, ip_address, user_agent, created_at
+		       is_used, used_at, attempts, ip_address, user_agent, locale, created_at
 		FROM otp_codes
 		WHERE phone_number = $1 AND purpose = $2
 		ORDER BY created_at DESC
@@ -93,6 +102,7 @@ func (r *otpRepository) FindLatestByPhoneAndPurpose(ctx context.Context, phoneNu
 		&otp.Attempts,
 		&otp.IPAddress,
 		&otp.UserAgent,
+		&otp.Locale,
 		&otp.CreatedAt,
 	)
 
@@ -115,7 +125,7 @@ func (r *otpRepository) FindLatestByPhoneAndPurpose(ctx context.Context, phoneNu
 func (r *otpRepository) FindByPhoneAndCode(ctx context.Context, phoneNumber, otpCode string) (*entity.OTPCode, error) {
 	query := `
 		SELECT id, phone_number, otp_code, purpose, expires_at, 
-		       is_used, used_at, attempts, ip_address, user_agent, created_at
+		       is_used, used_at, attempts, ip_address, user_agent, locale, created_at
 		FROM otp_codes
 		WHERE phone_number = $1 AND otp_code = $2
 		ORDER BY created_at DESC
@@ -134,6 +144,7 @@ func (r *otpRepository) FindByPhoneAndCode(ctx context.Context, phoneNumber, otp
 		&otp.Attempts,
 		&otp.IPAddress,
 		&otp.UserAgent,
+		&otp.Locale,
 		&otp.CreatedAt,
 	)
 
@@ -151,6 +162,45 @@ func (r *otpRepository) FindByPhoneAndCode(ctx context.Context, phoneNumber, otp
 	return &otp, nil
 }
 
+// FindByID finds OTP by ID (used by the delivery job)
+func (r *otpRepository) FindByID(ctx context.Context, id int) (*entity.OTPCode, error) {
+	query := `
+		SELECT id, phone_number, otp_code, purpose, expires_at, 
+		       is_used, used_at, attempts, ip_address, user_agent, locale, created_at
+		FROM otp_codes
+		WHERE id = $1
+	`
+
+	var otp entity.OTPCode
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&otp.ID,
+		&otp.PhoneNumber,
+		&otp.OTPCode,
+		&otp.Purpose,
+		&otp.ExpiresAt,
+		&otp.IsUsed,
+		&otp.UsedAt,
+		&otp.Attempts,
+		&otp.IPAddress,
+		&otp.UserAgent,
+		&otp.Locale,
+		&otp.CreatedAt,
+	)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil // Not found
+		}
+		logger.Log.Error().
+			Err(err).
+			Int("id", id).
+			Msg("Failed to find OTP by ID")
+		return nil, fmt.Errorf("failed to find OTP: %w", err)
+	}
+
+	return &otp, nil
+}
+
 // MarkAsUsed marks an OTP as used
 func (r *otpRepository) MarkAsUsed(ctx context.Context, id int) error {
 	query := `
@@ -4,7 +4,9 @@ import (
 	"context"
 
 	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -13,16 +15,24 @@ type PassengerRepository interface {
 	FindByID(ctx context.Context, id int) (*entity.PassengerProfile, error)
 	FindByUserID(ctx context.Context, userID int) (*entity.PassengerProfile, error)
 	Update(ctx context.Context, profile *entity.PassengerProfile) error
+	IncrementTotalOrders(ctx context.Context, userID int) error
+	IncrementCancellations(ctx context.Context, userID int) error
+	WithTx(tx pgx.Tx) PassengerRepository
 }
 
 type passengerRepository struct {
-	db *pgxpool.Pool
+	db database.DBTX
 }
 
 func NewPassengerRepository(db *pgxpool.Pool) PassengerRepository {
 	return &passengerRepository{db: db}
 }
 
+// WithTx returns a repository bound to the given transaction
+func (r *passengerRepository) WithTx(tx pgx.Tx) PassengerRepository {
+	return &passengerRepository{db: tx}
+}
+
 func (r *passengerRepository) Create(ctx context.Context, profile *entity.PassengerProfile) error {
 	query := `
 		INSERT INTO passenger_profiles (
@@ -159,3 +169,17 @@ func (r *passengerRepository) Update(ctx context.Context, profile *entity.Passen
 	logger.Log.Info().Int("passenger_id", profile.ID).Msg("Passenger profile updated")
 	return nil
 }
+
+// IncrementTotalOrders counts a completed trip on the passenger's profile
+func (r *passengerRepository) IncrementTotalOrders(ctx context.Context, userID int) error {
+	query := `UPDATE passenger_profiles SET total_orders = total_orders + 1, updated_at = NOW() WHERE user_id = $1`
+	_, err := r.db.Exec(ctx, query, userID)
+	return err
+}
+
+// IncrementCancellations counts a cancellation held against the passenger
+func (r *passengerRepository) IncrementCancellations(ctx context.Context, userID int) error {
+	query := `UPDATE passenger_profiles SET total_cancellations = total_cancellations + 1, updated_at = NOW() WHERE user_id = $1`
+	_, err := r.db.Exec(ctx, query, userID)
+	return err
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PaymentChargeRepository interface {
+	Create(ctx context.Context, charge *entity.PaymentCharge) error
+	UpdateInstructions(ctx context.Context, charge *entity.PaymentCharge) error
+	FindByID(ctx context.Context, id int64) (*entity.PaymentCharge, error)
+	FindByReferenceForUpdate(ctx context.Context, reference string) (*entity.PaymentCharge, error)
+	FindByUser(ctx context.Context, userID, limit, offset int) ([]*entity.PaymentCharge, error)
+	MarkPaid(ctx context.Context, id int64, paidAt time.Time, ledgerTransactionID int64) error
+	MarkClosed(ctx context.Context, id int64, status entity.PaymentChargeStatus) error
+	MarkChecked(ctx context.Context, id int64, checkedAt time.Time) error
+	WithTx(tx pgx.Tx) PaymentChargeRepository
+}
+
+type paymentChargeRepository struct {
+	db database.DBTX
+}
+
+func NewPaymentChargeRepository(db *pgxpool.Pool) PaymentChargeRepository {
+	return &paymentChargeRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *paymentChargeRepository) WithTx(tx pgx.Tx) PaymentChargeRepository {
+	return &paymentChargeRepository{db: tx}
+}
+
+const paymentChargeColumns = `id, user_id, provider, reference, provider_ref, channel, bank, amount, status,
+	qr_string, qr_image_url, va_number, expires_at, paid_at, ledger_transaction_id, last_checked_at,
+	created_at, updated_at`
+
+func (r *paymentChargeRepository) Create(ctx context.Context, charge *entity.PaymentCharge) error {
+	query := `
+		INSERT INTO payment_charges (user_id, provider, reference, channel, bank, amount, status, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at
+	`
+	return r.db.QueryRow(ctx, query,
+		charge.UserID,
+		charge.Provider,
+		charge.Reference,
+		charge.Channel,
+		charge.Bank,
+		charge.Amount,
+		charge.Status,
+		charge.ExpiresAt,
+	).Scan(&charge.ID, &charge.CreatedAt, &charge.UpdatedAt)
+}
+
+// UpdateInstructions stores what the gateway returned for a new charge
+func (r *paymentChargeRepository) UpdateInstructions(ctx context.Context, charge *entity.PaymentCharge) error {
+	query := `
+		UPDATE payment_charges
+		SET provider_ref = $1, bank = $2, qr_string = $3, qr_image_url = $4, va_number = $5,
+		    expires_at = $6, updated_at = NOW()
+		WHERE id = $7
+	`
+	_, err := r.db.Exec(ctx, query,
+		charge.ProviderRef,
+		charge.Bank,
+		charge.QRString,
+		charge.QRImageURL,
+		charge.VANumber,
+		charge.ExpiresAt,
+		charge.ID,
+	)
+	return err
+}
+
+// FindByID returns nil when the charge does not exist
+func (r *paymentChargeRepository) FindByID(ctx context.Context, id int64) (*entity.PaymentCharge, error) {
+	query := `SELECT ` + paymentChargeColumns + ` FROM payment_charges WHERE id = $1`
+
+	charge, err := scanPaymentCharge(r.db.QueryRow(ctx, query, id))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return charge, err
+}
+
+// FindByReferenceForUpdate locks the charge; it returns nil when it does not exist
+func (r *paymentChargeRepository) FindByReferenceForUpdate(ctx context.Context, reference string) (*entity.PaymentCharge, error) {
+	query := `SELECT ` + paymentChargeColumns + ` FROM payment_charges WHERE reference = $1 FOR UPDATE`
+
+	charge, err := scanPaymentCharge(r.db.QueryRow(ctx, query, reference))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return charge, err
+}
+
+// FindByUser returns the user's charges, newest first
+func (r *paymentChargeRepository) FindByUser(ctx context.Context, userID, limit, offset int) ([]*entity.PaymentCharge, error) {
+	query := `
+		SELECT ` + paymentChargeColumns + `
+		FROM payment_charges
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	charges := []*entity.PaymentCharge{}
+	for rows.Next() {
+		charge, err := scanPaymentCharge(rows)
+		if err != nil {
+			return nil, err
+		}
+		charges = append(charges, charge)
+	}
+	return charges, rows.Err()
+}
+
+func (r *paymentChargeRepository) MarkPaid(ctx context.Context, id int64, paidAt time.Time, ledgerTransactionID int64) error {
+	query := `
+		UPDATE payment_charges
+		SET status = 'PAID', paid_at = $1, ledger_transaction_id = $2, updated_at = NOW()
+		WHERE id = $3
+	`
+	_, err := r.db.Exec(ctx, query, paidAt, ledgerTransactionID, id)
+	return err
+}
+
+// MarkClosed moves a pending charge to FAILED or EXPIRED
+func (r *paymentChargeRepository) MarkClosed(ctx context.Context, id int64, status entity.PaymentChargeStatus) error {
+	query := `UPDATE payment_charges SET status = $1, updated_at = NOW() WHERE id = $2 AND status = 'PENDING'`
+	_, err := r.db.Exec(ctx, query, status, id)
+	return err
+}
+
+func (r *paymentChargeRepository) MarkChecked(ctx context.Context, id int64, checkedAt time.Time) error {
+	query := `UPDATE payment_charges SET last_checked_at = $1 WHERE id = $2`
+	_, err := r.db.Exec(ctx, query, checkedAt, id)
+	return err
+}
+
+func scanPaymentCharge(row pgx.Row) (*entity.PaymentCharge, error) {
+	var charge entity.PaymentCharge
+	err := row.Scan(
+		&charge.ID,
+		&charge.UserID,
+		&charge.Provider,
+		&charge.Reference,
+		&charge.ProviderRef,
+		&charge.Channel,
+		&charge.Bank,
+		&charge.Amount,
+		&charge.Status,
+		&charge.QRString,
+		&charge.QRImageURL,
+		&charge.VANumber,
+		&charge.ExpiresAt,
+		&charge.PaidAt,
+		&charge.LedgerTransactionID,
+		&charge.LastCheckedAt,
+		&charge.CreatedAt,
+		&charge.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &charge, nil
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PayoutRepository interface {
+	Create(ctx context.Context, payout *entity.PayoutRequest) error
+	FindByIDForUpdate(ctx context.Context, id int64) (*entity.PayoutRequest, error)
+	FindByDriver(ctx context.Context, driverID, limit, offset int) ([]*entity.PayoutRequest, error)
+	FindByStatus(ctx context.Context, status entity.PayoutStatus, limit, offset int) ([]*entity.PayoutRequest, error)
+	MarkReviewed(ctx context.Context, id int64, status entity.PayoutStatus, reviewedBy int, reason *string, reviewedAt time.Time) error
+	WithTx(tx pgx.Tx) PayoutRepository
+}
+
+type payoutRepository struct {
+	db database.DBTX
+}
+
+func NewPayoutRepository(db *pgxpool.Pool) PayoutRepository {
+	return &payoutRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *payoutRepository) WithTx(tx pgx.Tx) PayoutRepository {
+	return &payoutRepository{db: tx}
+}
+
+const payoutColumns = `id, driver_id, amount, status, bank_name, account_number, account_holder,
+	rejection_reason, reviewed_by, reviewed_at, created_at, updated_at`
+
+func (r *payoutRepository) Create(ctx context.Context, payout *entity.PayoutRequest) error {
+	query := `
+		INSERT INTO payout_requests (driver_id, amount, status, bank_name, account_number, account_holder)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`
+	return r.db.QueryRow(ctx, query,
+		payout.DriverID,
+		payout.Amount,
+		payout.Status,
+		payout.BankName,
+		payout.AccountNumber,
+		payout.AccountHolder,
+	).Scan(&payout.ID, &payout.CreatedAt, &payout.UpdatedAt)
+}
+
+// FindByIDForUpdate locks the payout request; it returns nil when it does not exist
+func (r *payoutRepository) FindByIDForUpdate(ctx context.Context, id int64) (*entity.PayoutRequest, error) {
+	query := `SELECT ` + payoutColumns + ` FROM payout_requests WHERE id = $1 FOR UPDATE`
+
+	payout, err := scanPayout(r.db.QueryRow(ctx, query, id))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return payout, err
+}
+
+// FindByDriver returns the driver's payout requests, newest first
+func (r *payoutRepository) FindByDriver(ctx context.Context, driverID, limit, offset int) ([]*entity.PayoutRequest, error) {
+	query := `
+		SELECT ` + payoutColumns + `
+		FROM payout_requests
+		WHERE driver_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	return r.query(ctx, query, driverID, limit, offset)
+}
+
+// FindByStatus returns payout requests in the status, oldest first (review queue order)
+func (r *payoutRepository) FindByStatus(ctx context.Context, status entity.PayoutStatus, limit, offset int) ([]*entity.PayoutRequest, error) {
+	query := `
+		SELECT ` + payoutColumns + `
+		FROM payout_requests
+		WHERE status = $1
+		ORDER BY created_at
+		LIMIT $2 OFFSET $3
+	`
+	return r.query(ctx, query, status, limit, offset)
+}
+
+func (r *payoutRepository) MarkReviewed(ctx context.Context, id int64, status entity.PayoutStatus, reviewedBy int, reason *string, reviewedAt time.Time) error {
+	query := `
+		UPDATE payout_requests
+		SET status = $1, reviewed_by = $2, rejection_reason = $3, reviewed_at = $4, updated_at = NOW()
+		WHERE id = $5
+	`
+	_, err := r.db.Exec(ctx, query, status, reviewedBy, reason, reviewedAt, id)
+	return err
+}
+
+func (r *payoutRepository) query(ctx context.Context, query string, args ...any) ([]*entity.PayoutRequest, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payouts := []*entity.PayoutRequest{}
+	for rows.Next() {
+		payout, err := scanPayout(rows)
+		if err != nil {
+			return nil, err
+		}
+		payouts = append(payouts, payout)
+	}
+	return payouts, rows.Err()
+}
+
+func scanPayout(row pgx.Row) (*entity.PayoutRequest, error) {
+	var payout entity.PayoutRequest
+	err := row.Scan(
+		&payout.ID,
+		&payout.DriverID,
+		&payout.Amount,
+		&payout.Status,
+		&payout.BankName,
+		&payout.AccountNumber,
+		&payout.AccountHolder,
+		&payout.RejectionReason,
+		&payout.ReviewedBy,
+		&payout.ReviewedAt,
+		&payout.CreatedAt,
+		&payout.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &payout, nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/geo"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PickupPointRepository interface {
+	Create(ctx context.Context, point *entity.PickupPoint) error
+	Update(ctx context.Context, point *entity.PickupPoint) error
+	Delete(ctx context.Context, id int) (bool, error)
+	FindByID(ctx context.Context, id int) (*entity.PickupPoint, error)
+	List(ctx context.Context, activeOnly bool) ([]*entity.PickupPoint, error)
+	FindNearestActive(ctx context.Context, p geo.Point, radiusKm float64) (*entity.PickupPoint, error)
+	WithTx(tx pgx.Tx) PickupPointRepository
+}
+
+type pickupPointRepository struct {
+	db database.DBTX
+}
+
+func NewPickupPointRepository(db *pgxpool.Pool) PickupPointRepository {
+	return &pickupPointRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *pickupPointRepository) WithTx(tx pgx.Tx) PickupPointRepository {
+	return &pickupPointRepository{db: tx}
+}
+
+const pickupPointColumns = `id, name, kind, lat, long, description, is_active, created_by, created_at, updated_at`
+
+func (r *pickupPointRepository) Create(ctx context.Context, point *entity.PickupPoint) error {
+	query := `
+		INSERT INTO pickup_points (name, kind, lat, long, description, is_active, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at
+	`
+	return r.db.QueryRow(ctx, query,
+		point.Name,
+		point.Kind,
+		point.Lat,
+		point.Long,
+		point.Description,
+		point.IsActive,
+		point.CreatedBy,
+	).Scan(&point.ID, &point.CreatedAt, &point.UpdatedAt)
+}
+
+func (r *pickupPointRepository) Update(ctx context.Context, point *entity.PickupPoint) error {
+	query := `
+		UPDATE pickup_points
+		SET name = $1, kind = $2, lat = $3, long = $4, description = $5, is_active = $6, updated_at = NOW()
+		WHERE id = $7
+		RETURNING updated_at
+	`
+	return r.db.QueryRow(ctx, query,
+		point.Name,
+		point.Kind,
+		point.Lat,
+		point.Long,
+		point.Description,
+		point.IsActive,
+		point.ID,
+	).Scan(&point.UpdatedAt)
+}
+
+// Delete reports whether a pickup point was removed
+func (r *pickupPointRepository) Delete(ctx context.Context, id int) (bool, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM pickup_points WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// FindByID returns nil when the pickup point does not exist
+func (r *pickupPointRepository) FindByID(ctx context.Context, id int) (*entity.PickupPoint, error) {
+	points, err := r.query(ctx, `SELECT `+pickupPointColumns+` FROM pickup_points WHERE id = $1`, id)
+	if err != nil || len(points) == 0 {
+		return nil, err
+	}
+	return points[0], nil
+}
+
+func (r *pickupPointRepository) List(ctx context.Context, activeOnly bool) ([]*entity.PickupPoint, error) {
+	query := `
+		SELECT ` + pickupPointColumns + `
+		FROM pickup_points
+		WHERE NOT $1 OR is_active = TRUE
+		ORDER BY kind, name
+	`
+	return r.query(ctx, query, activeOnly)
+}
+
+// FindNearestActive returns the active point closest to p within radiusKm, or nil
+func (r *pickupPointRepository) FindNearestActive(ctx context.Context, p geo.Point, radiusKm float64) (*entity.PickupPoint, error) {
+	minLat, maxLat, minLong, maxLong := geo.BoundingBox(p, radiusKm)
+	query := `
+		SELECT ` + pickupPointColumns + `
+		FROM pickup_points
+		WHERE is_active = TRUE
+		  AND lat BETWEEN $1 AND $2
+		  AND long BETWEEN $3 AND $4
+	`
+	points, err := r.query(ctx, query, minLat, maxLat, minLong, maxLong)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([]geo.Point, len(points))
+	for i, point := range points {
+		locations[i] = geo.Point{Lat: point.Lat, Long: point.Long}
+	}
+	nearest, km := geo.Nearest(p, locations)
+	if nearest < 0 || km > radiusKm {
+		return nil, nil
+	}
+	return points[nearest], nil
+}
+
+func (r *pickupPointRepository) query(ctx context.Context, query string, args ...any) ([]*entity.PickupPoint, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	points := []*entity.PickupPoint{}
+	for rows.Next() {
+		var point entity.PickupPoint
+		if err := rows.Scan(
+			&point.ID, &point.Name, &point.Kind, &point.Lat, &point.Long, &point.Description,
+			&point.IsActive, &point.CreatedBy, &point.CreatedAt, &point.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		points = append(points, &point)
+	}
+	return points, rows.Err()
+}
@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PromoRepository interface {
+	Create(ctx context.Context, promo *entity.Promo) error
+	Update(ctx context.Context, promo *entity.Promo) error
+	FindByID(ctx context.Context, id int) (*entity.Promo, error)
+	FindByCode(ctx context.Context, code string) (*entity.Promo, error)
+	FindByCodeForUpdate(ctx context.Context, code string) (*entity.Promo, error)
+	FindByIDForUpdate(ctx context.Context, id int) (*entity.Promo, error)
+	ExistsByCode(ctx context.Context, code string) (bool, error)
+	List(ctx context.Context, activeOnly bool, limit, offset int) ([]*entity.Promo, error)
+	CountLiveRedemptions(ctx context.Context, promoID, userID int) (int, error)
+	CreateRedemption(ctx context.Context, redemption *entity.PromoRedemption) error
+	FindRedemptionByOrderForUpdate(ctx context.Context, orderID int) (*entity.PromoRedemption, error)
+	MarkRedeemed(ctx context.Context, redemptionID int64, redeemedAt time.Time) error
+	MarkReleased(ctx context.Context, redemptionID int64, releasedAt time.Time) error
+	AdjustUsage(ctx context.Context, promoID, delta int) error
+	WithTx(tx pgx.Tx) PromoRepository
+}
+
+type promoRepository struct {
+	db database.DBTX
+}
+
+func NewPromoRepository(db *pgxpool.Pool) PromoRepository {
+	return &promoRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *promoRepository) WithTx(tx pgx.Tx) PromoRepository {
+	return &promoRepository{db: tx}
+}
+
+const promoColumns = `id, code, name, description, discount_type, discount_value, max_discount, min_fare,
+	usage_limit, per_user_limit, used_count, eligible_roles, new_users_only, campus_only, email_domains,
+	starts_at, ends_at, is_active, created_by, created_at, updated_at`
+
+func (r *promoRepository) Create(ctx context.Context, promo *entity.Promo) error {
+	query := `
+		INSERT INTO promos (
+			code, name, description, discount_type, discount_value, max_discount, min_fare,
+			usage_limit, per_user_limit, eligible_roles, new_users_only, campus_only, email_domains,
+			starts_at, ends_at, is_active, created_by
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+		RETURNING id, used_count, created_at, updated_at
+	`
+	return r.db.QueryRow(ctx, query,
+		promo.Code,
+		promo.Name,
+		promo.Description,
+		promo.DiscountType,
+		promo.DiscountValue,
+		promo.MaxDiscount,
+		promo.MinFare,
+		promo.UsageLimit,
+		promo.PerUserLimit,
+		promo.EligibleRoles,
+		promo.NewUsersOnly,
+		promo.CampusOnly,
+		promo.EmailDomains,
+		promo.StartsAt,
+		promo.EndsAt,
+		promo.IsActive,
+		promo.CreatedBy,
+	).Scan(&promo.ID, &promo.UsedCount, &promo.CreatedAt, &promo.UpdatedAt)
+}
+
+// Update saves the campaign settings; the discount rule itself is fixed once created so
+// reserved discounts always match the promo they came from
+func (r *promoRepository) Update(ctx context.Context, promo *entity.Promo) error {
+	query := `
+		UPDATE promos
+		SET name = $1, description = $2, usage_limit = $3, per_user_limit = $4,
+		    eligible_roles = $5, new_users_only = $6, campus_only = $7, email_domains = $8,
+		    starts_at = $9, ends_at = $10, is_active = $11, updated_at = NOW()
+		WHERE id = $12
+		RETURNING updated_at
+	`
+	return r.db.QueryRow(ctx, query,
+		promo.Name,
+		promo.Description,
+		promo.UsageLimit,
+		promo.PerUserLimit,
+		promo.EligibleRoles,
+		promo.NewUsersOnly,
+		promo.CampusOnly,
+		promo.EmailDomains,
+		promo.StartsAt,
+		promo.EndsAt,
+		promo.IsActive,
+		promo.ID,
+	).Scan(&promo.UpdatedAt)
+}
+
+// FindByID returns nil when the promo does not exist
+func (r *promoRepository) FindByID(ctx context.Context, id int) (*entity.Promo, error) {
+	query := `SELECT ` + promoColumns + ` FROM promos WHERE id = $1`
+	return scanPromoRow(r.db.QueryRow(ctx, query, id))
+}
+
+// FindByIDForUpdate locks the promo; it returns nil when it does not exist
+func (r *promoRepository) FindByIDForUpdate(ctx context.Context, id int) (*entity.Promo, error) {
+	query := `SELECT ` + promoColumns + ` FROM promos WHERE id = $1 FOR UPDATE`
+	return scanPromoRow(r.db.QueryRow(ctx, query, id))
+}
+
+// FindByCode returns nil when no promo has the code
+func (r *promoRepository) FindByCode(ctx context.Context, code string) (*entity.Promo, error) {
+	query := `SELECT ` + promoColumns + ` FROM promos WHERE code = $1`
+	return scanPromoRow(r.db.QueryRow(ctx, query, code))
+}
+
+// FindByCodeForUpdate locks the promo so usage can be counted and reserved atomically
+func (r *promoRepository) FindByCodeForUpdate(ctx context.Context, code string) (*entity.Promo, error) {
+	query := `SELECT ` + promoColumns + ` FROM promos WHERE code = $1 FOR UPDATE`
+	return scanPromoRow(r.db.QueryRow(ctx, query, code))
+}
+
+func (r *promoRepository) ExistsByCode(ctx context.Context, code string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM promos WHERE code = $1)`
+	var exists bool
+	err := r.db.QueryRow(ctx, query, code).Scan(&exists)
+	return exists, err
+}
+
+// List returns promos, newest first
+func (r *promoRepository) List(ctx context.Context, activeOnly bool, limit, offset int) ([]*entity.Promo, error) {
+	query := `
+		SELECT ` + promoColumns + `
+		FROM promos
+		WHERE NOT $1 OR (is_active = TRUE AND ends_at > NOW())
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Query(ctx, query, activeOnly, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	promos := []*entity.Promo{}
+	for rows.Next() {
+		promo, err := scanPromo(rows)
+		if err != nil {
+			return nil, err
+		}
+		promos = append(promos, promo)
+	}
+	return promos, rows.Err()
+}
+
+// CountLiveRedemptions counts the user's reserved and redeemed uses of the promo
+func (r *promoRepository) CountLiveRedemptions(ctx context.Context, promoID, userID int) (int, error) {
+	query := `SELECT COUNT(*) FROM promo_redemptions WHERE promo_id = $1 AND user_id = $2 AND status <> 'RELEASED'`
+	var count int
+	err := r.db.QueryRow(ctx, query, promoID, userID).Scan(&count)
+	return count, err
+}
+
+func (r *promoRepository) CreateRedemption(ctx context.Context, redemption *entity.PromoRedemption) error {
+	query := `
+		INSERT INTO promo_redemptions (promo_id, user_id, order_id, discount, status, reserved_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+	return r.db.QueryRow(ctx, query,
+		redemption.PromoID,
+		redemption.UserID,
+		redemption.OrderID,
+		redemption.Discount,
+		redemption.Status,
+		redemption.ReservedAt,
+	).Scan(&redemption.ID)
+}
+
+// FindRedemptionByOrderForUpdate locks the order's redemption; it returns nil when the
+// order used no promo
+func (r *promoRepository) FindRedemptionByOrderForUpdate(ctx context.Context, orderID int) (*entity.PromoRedemption, error) {
+	query := `
+		SELECT id, promo_id, user_id, order_id, discount, status, reserved_at, redeemed_at, released_at
+		FROM promo_redemptions
+		WHERE order_id = $1
+		FOR UPDATE
+	`
+	var redemption entity.PromoRedemption
+	err := r.db.QueryRow(ctx, query, orderID).Scan(
+		&redemption.ID,
+		&redemption.PromoID,
+		&redemption.UserID,
+		&redemption.OrderID,
+		&redemption.Discount,
+		&redemption.Status,
+		&redemption.ReservedAt,
+		&redemption.RedeemedAt,
+		&redemption.ReleasedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &redemption, nil
+}
+
+func (r *promoRepository) MarkRedeemed(ctx context.Context, redemptionID int64, redeemedAt time.Time) error {
+	query := `UPDATE promo_redemptions SET status = 'REDEEMED', redeemed_at = $1 WHERE id = $2`
+	_, err := r.db.Exec(ctx, query, redeemedAt, redemptionID)
+	return err
+}
+
+func (r *promoRepository) MarkReleased(ctx context.Context, redemptionID int64, releasedAt time.Time) error {
+	query := `UPDATE promo_redemptions SET status = 'RELEASED', released_at = $1 WHERE id = $2`
+	_, err := r.db.Exec(ctx, query, releasedAt, redemptionID)
+	return err
+}
+
+// AdjustUsage changes the promo's live redemption count; callers hold the promo lock
+func (r *promoRepository) AdjustUsage(ctx context.Context, promoID, delta int) error {
+	query := `UPDATE promos SET used_count = used_count + $1, updated_at = NOW() WHERE id = $2`
+	_, err := r.db.Exec(ctx, query, delta, promoID)
+	return err
+}
+
+func scanPromoRow(row pgx.Row) (*entity.Promo, error) {
+	promo, err := scanPromo(row)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return promo, err
+}
+
+func scanPromo(row pgx.Row) (*entity.Promo, error) {
+	var promo entity.Promo
+	err := row.Scan(
+		&promo.ID,
+		&promo.Code,
+		&promo.Name,
+		&promo.Description,
+		&promo.DiscountType,
+		&promo.DiscountValue,
+		&promo.MaxDiscount,
+		&promo.MinFare,
+		&promo.UsageLimit,
+		&promo.PerUserLimit,
+		&promo.UsedCount,
+		&promo.EligibleRoles,
+		&promo.NewUsersOnly,
+		&promo.CampusOnly,
+		&promo.EmailDomains,
+		&promo.StartsAt,
+		&promo.EndsAt,
+		&promo.IsActive,
+		&promo.CreatedBy,
+		&promo.CreatedAt,
+		&promo.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &promo, nil
+}
@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RatingPrior is the Bayesian prior the profile averages are smoothed with:
+// avg = (Mean*Weight + sum) / (Weight + count)
+type RatingPrior struct {
+	Mean   float64
+	Weight int
+}
+
+type RatingRepository interface {
+	Create(ctx context.Context, rating *entity.Rating) error
+	FindByOrderAndRater(ctx context.Context, orderID, raterID int) (*entity.Rating, error)
+	FindByOrderID(ctx context.Context, orderID int) ([]*entity.Rating, error)
+	ApplyToDriver(ctx context.Context, userID, stars int, prior RatingPrior) (*entity.RatingStats, error)
+	ApplyToPassenger(ctx context.Context, userID, stars int, prior RatingPrior) (*entity.RatingStats, error)
+	FlagDriver(ctx context.Context, userID int, flaggedAt time.Time) (bool, error)
+	ClearDriverFlag(ctx context.Context, userID int) (bool, error)
+	FindFlaggedDrivers(ctx context.Context, limit, offset int) ([]*entity.FlaggedDriver, error)
+	WithTx(tx pgx.Tx) RatingRepository
+}
+
+type ratingRepository struct {
+	db database.DBTX
+}
+
+func NewRatingRepository(db *pgxpool.Pool) RatingRepository {
+	return &ratingRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *ratingRepository) WithTx(tx pgx.Tx) RatingRepository {
+	return &ratingRepository{db: tx}
+}
+
+func (r *ratingRepository) Create(ctx context.Context, rating *entity.Rating) error {
+	query := `
+		INSERT INTO ratings (order_id, rater_id, ratee_id, rater_role, stars, tags, comment)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+	return r.db.QueryRow(ctx, query,
+		rating.OrderID,
+		rating.RaterID,
+		rating.RateeID,
+		rating.RaterRole,
+		rating.Stars,
+		rating.Tags,
+		rating.Comment,
+	).Scan(&rating.ID, &rating.CreatedAt)
+}
+
+// FindByOrderAndRater returns nil when the user has not rated the order yet
+func (r *ratingRepository) FindByOrderAndRater(ctx context.Context, orderID, raterID int) (*entity.Rating, error) {
+	query := `
+		SELECT id, order_id, rater_id, ratee_id, rater_role, stars, tags, comment, created_at
+		FROM ratings
+		WHERE order_id = $1 AND rater_id = $2
+	`
+	var rating entity.Rating
+	err := r.db.QueryRow(ctx, query, orderID, raterID).Scan(
+		&rating.ID, &rating.OrderID, &rating.RaterID, &rating.RateeID, &rating.RaterRole,
+		&rating.Stars, &rating.Tags, &rating.Comment, &rating.CreatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rating, nil
+}
+
+func (r *ratingRepository) FindByOrderID(ctx context.Context, orderID int) ([]*entity.Rating, error) {
+	query := `
+		SELECT id, order_id, rater_id, ratee_id, rater_role, stars, tags, comment, created_at
+		FROM ratings
+		WHERE order_id = $1
+		ORDER BY id
+	`
+	rows, err := r.db.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ratings := []*entity.Rating{}
+	for rows.Next() {
+		var rating entity.Rating
+		if err := rows.Scan(
+			&rating.ID, &rating.OrderID, &rating.RaterID, &rating.RateeID, &rating.RaterRole,
+			&rating.Stars, &rating.Tags, &rating.Comment, &rating.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		ratings = append(ratings, &rating)
+	}
+	return ratings, rows.Err()
+}
+
+// ApplyToDriver adds a rating to the driver's totals and recomputes the smoothed average
+func (r *ratingRepository) ApplyToDriver(ctx context.Context, userID, stars int, prior RatingPrior) (*entity.RatingStats, error) {
+	return r.apply(ctx, "driver_profiles", userID, stars, prior)
+}
+
+// ApplyToPassenger adds a rating to the passenger's totals and recomputes the smoothed average
+func (r *ratingRepository) ApplyToPassenger(ctx context.Context, userID, stars int, prior RatingPrior) (*entity.RatingStats, error) {
+	return r.apply(ctx, "passenger_profiles", userID, stars, prior)
+}
+
+// apply updates the totals in a single statement, so concurrent ratings of the same
+// user cannot lose an increment
+func (r *ratingRepository) apply(ctx context.Context, table string, userID, stars int, prior RatingPrior) (*entity.RatingStats, error) {
+	query := `
+		UPDATE ` + table + `
+		SET rating_count = rating_count + 1,
+		    rating_sum = rating_sum + $1,
+		    rating_avg = ROUND(($2::numeric * $3 + rating_sum + $1) / ($3 + rating_count + 1), 2),
+		    updated_at = NOW()
+		WHERE user_id = $4
+		RETURNING rating_count, rating_sum, rating_avg
+	`
+	var stats entity.RatingStats
+	err := r.db.QueryRow(ctx, query, stars, prior.Mean, prior.Weight, userID).Scan(&stats.Count, &stats.Sum, &stats.Average)
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// FlagDriver marks the driver for rating review; it reports false when already flagged
+func (r *ratingRepository) FlagDriver(ctx context.Context, userID int, flaggedAt time.Time) (bool, error) {
+	query := `
+		UPDATE driver_profiles
+		SET rating_flagged_at = $1, updated_at = NOW()
+		WHERE user_id = $2 AND rating_flagged_at IS NULL
+	`
+	tag, err := r.db.Exec(ctx, query, flaggedAt, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// ClearDriverFlag closes a rating review; it reports false when the driver was not flagged
+func (r *ratingRepository) ClearDriverFlag(ctx context.Context, userID int) (bool, error) {
+	query := `
+		UPDATE driver_profiles
+		SET rating_flagged_at = NULL, updated_at = NOW()
+		WHERE user_id = $1 AND rating_flagged_at IS NOT NULL
+	`
+	tag, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// FindFlaggedDrivers lists drivers awaiting rating review, oldest flag first
+func (r *ratingRepository) FindFlaggedDrivers(ctx context.Context, limit, offset int) ([]*entity.FlaggedDriver, error) {
+	query := `
+		SELECT dp.user_id, u.full_name, u.phone_number, dp.vehicle_plate,
+		       dp.rating_avg, dp.rating_count, dp.rating_flagged_at
+		FROM driver_profiles dp
+		JOIN users u ON u.id = dp.user_id
+		WHERE dp.rating_flagged_at IS NOT NULL
+		ORDER BY dp.rating_flagged_at
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	drivers := []*entity.FlaggedDriver{}
+	for rows.Next() {
+		var driver entity.FlaggedDriver
+		if err := rows.Scan(
+			&driver.UserID, &driver.FullName, &driver.PhoneNumber, &driver.VehiclePlate,
+			&driver.RatingAvg, &driver.RatingCount, &driver.FlaggedAt,
+		); err != nil {
+			return nil, err
+		}
+		drivers = append(drivers, &driver)
+	}
+	return drivers, rows.Err()
+}
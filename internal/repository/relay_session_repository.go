@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RelaySessionRepository interface {
+	Create(ctx context.Context, session *entity.RelaySession) error
+	FindOpenByOrderID(ctx context.Context, orderID int) (*entity.RelaySession, error)
+	FindLatestByPhone(ctx context.Context, phone string) (*entity.RelaySession, error)
+	IncrementRelayed(ctx context.Context, id int) error
+	Close(ctx context.Context, id int, closedAt time.Time) error
+	WithTx(tx pgx.Tx) RelaySessionRepository
+}
+
+type relaySessionRepository struct {
+	db database.DBTX
+}
+
+func NewRelaySessionRepository(db *pgxpool.Pool) RelaySessionRepository {
+	return &relaySessionRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *relaySessionRepository) WithTx(tx pgx.Tx) RelaySessionRepository {
+	return &relaySessionRepository{db: tx}
+}
+
+const relaySessionColumns = `id, order_id, passenger_id, driver_id, passenger_phone, driver_phone, messages_relayed, created_at, closed_at`
+
+func (r *relaySessionRepository) Create(ctx context.Context, session *entity.RelaySession) error {
+	query := `
+		INSERT INTO relay_sessions (order_id, passenger_id, driver_id, passenger_phone, driver_phone)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+	return r.db.QueryRow(ctx, query,
+		session.OrderID,
+		session.PassengerID,
+		session.DriverID,
+		session.PassengerPhone,
+		session.DriverPhone,
+	).Scan(&session.ID, &session.CreatedAt)
+}
+
+// FindOpenByOrderID returns nil when the order has no open session
+func (r *relaySessionRepository) FindOpenByOrderID(ctx context.Context, orderID int) (*entity.RelaySession, error) {
+	query := `SELECT ` + relaySessionColumns + ` FROM relay_sessions WHERE order_id = $1 AND closed_at IS NULL`
+	session, err := scanRelaySession(r.db.QueryRow(ctx, query, orderID))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return session, err
+}
+
+// FindLatestByPhone returns the most recent session, open or closed, the phone takes part
+// in; nil when there is none
+func (r *relaySessionRepository) FindLatestByPhone(ctx context.Context, phone string) (*entity.RelaySession, error) {
+	query := `
+		SELECT ` + relaySessionColumns + `
+		FROM relay_sessions
+		WHERE passenger_phone = $1 OR driver_phone = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`
+	session, err := scanRelaySession(r.db.QueryRow(ctx, query, phone))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return session, err
+}
+
+func (r *relaySessionRepository) IncrementRelayed(ctx context.Context, id int) error {
+	_, err := r.db.Exec(ctx, `UPDATE relay_sessions SET messages_relayed = messages_relayed + 1 WHERE id = $1`, id)
+	return err
+}
+
+// Close stops a session relaying; closing a closed session keeps its first close time
+func (r *relaySessionRepository) Close(ctx context.Context, id int, closedAt time.Time) error {
+	_, err := r.db.Exec(ctx, `UPDATE relay_sessions SET closed_at = $2 WHERE id = $1 AND closed_at IS NULL`, id, closedAt)
+	return err
+}
+
+func scanRelaySession(row pgx.Row) (*entity.RelaySession, error) {
+	var session entity.RelaySession
+	err := row.Scan(
+		&session.ID,
+		&session.OrderID,
+		&session.PassengerID,
+		&session.DriverID,
+		&session.PassengerPhone,
+		&session.DriverPhone,
+		&session.MessagesRelayed,
+		&session.CreatedAt,
+		&session.ClosedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
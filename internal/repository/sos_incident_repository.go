@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SOSIncidentRepository interface {
+	Create(ctx context.Context, incident *entity.SOSIncident) error
+	FindByID(ctx context.Context, id int) (*entity.SOSIncident, error)
+	FindByIDForUpdate(ctx context.Context, id int) (*entity.SOSIncident, error)
+	FindOpen(ctx context.Context, orderID, reportedBy int) (*entity.SOSIncident, error)
+	FindByStatuses(ctx context.Context, statuses []entity.IncidentStatus, limit, offset int) ([]*entity.SOSIncident, error)
+	SetContactsAlerted(ctx context.Context, id, count int) error
+	Acknowledge(ctx context.Context, id, adminID int, at time.Time) error
+	Close(ctx context.Context, id int, status entity.IncidentStatus, adminID int, note string, at time.Time) error
+	WithTx(tx pgx.Tx) SOSIncidentRepository
+}
+
+type sosIncidentRepository struct {
+	db database.DBTX
+}
+
+func NewSOSIncidentRepository(db *pgxpool.Pool) SOSIncidentRepository {
+	return &sosIncidentRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *sosIncidentRepository) WithTx(tx pgx.Tx) SOSIncidentRepository {
+	return &sosIncidentRepository{db: tx}
+}
+
+const sosIncidentColumns = `id, order_id, reported_by, role, lat, long, order_status, trip, note, status,
+	contacts_alerted, acknowledged_by, acknowledged_at, resolved_by, resolved_at, resolution_note,
+	created_at, updated_at`
+
+func (r *sosIncidentRepository) Create(ctx context.Context, incident *entity.SOSIncident) error {
+	trip, err := json.Marshal(incident.Trip)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO sos_incidents (order_id, reported_by, role, lat, long, order_status, trip, note, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at, updated_at
+	`
+	return r.db.QueryRow(ctx, query,
+		incident.OrderID,
+		incident.ReportedBy,
+		incident.Role,
+		incident.Lat,
+		incident.Long,
+		incident.OrderStatus,
+		trip,
+		incident.Note,
+		incident.Status,
+	).Scan(&incident.ID, &incident.CreatedAt, &incident.UpdatedAt)
+}
+
+// FindByID returns nil when the incident does not exist
+func (r *sosIncidentRepository) FindByID(ctx context.Context, id int) (*entity.SOSIncident, error) {
+	query := `SELECT ` + sosIncidentColumns + ` FROM sos_incidents WHERE id = $1`
+	return r.scanOne(r.db.QueryRow(ctx, query, id))
+}
+
+// FindByIDForUpdate locks the incident; it returns nil when it does not exist
+func (r *sosIncidentRepository) FindByIDForUpdate(ctx context.Context, id int) (*entity.SOSIncident, error) {
+	query := `SELECT ` + sosIncidentColumns + ` FROM sos_incidents WHERE id = $1 FOR UPDATE`
+	return r.scanOne(r.db.QueryRow(ctx, query, id))
+}
+
+// FindOpen returns the incident the user raised on the order that is still being
+// handled, or nil
+func (r *sosIncidentRepository) FindOpen(ctx context.Context, orderID, reportedBy int) (*entity.SOSIncident, error) {
+	query := `
+		SELECT ` + sosIncidentColumns + `
+		FROM sos_incidents
+		WHERE order_id = $1 AND reported_by = $2 AND status IN ('OPEN', 'ACKNOWLEDGED')
+	`
+	return r.scanOne(r.db.QueryRow(ctx, query, orderID, reportedBy))
+}
+
+// FindByStatuses returns incidents in any of the statuses, oldest first (handling order)
+func (r *sosIncidentRepository) FindByStatuses(ctx context.Context, statuses []entity.IncidentStatus, limit, offset int) ([]*entity.SOSIncident, error) {
+	query := `
+		SELECT ` + sosIncidentColumns + `
+		FROM sos_incidents
+		WHERE status = ANY($1)
+		ORDER BY created_at
+		LIMIT $2 OFFSET $3
+	`
+	values := make([]string, len(statuses))
+	for i, status := range statuses {
+		values[i] = string(status)
+	}
+
+	rows, err := r.db.Query(ctx, query, values, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	incidents := []*entity.SOSIncident{}
+	for rows.Next() {
+		incident, err := scanSOSIncident(rows)
+		if err != nil {
+			return nil, err
+		}
+		incidents = append(incidents, incident)
+	}
+	return incidents, rows.Err()
+}
+
+func (r *sosIncidentRepository) SetContactsAlerted(ctx context.Context, id, count int) error {
+	_, err := r.db.Exec(ctx, `UPDATE sos_incidents SET contacts_alerted = $1, updated_at = NOW() WHERE id = $2`, count, id)
+	return err
+}
+
+func (r *sosIncidentRepository) Acknowledge(ctx context.Context, id, adminID int, at time.Time) error {
+	query := `
+		UPDATE sos_incidents
+		SET status = 'ACKNOWLEDGED', acknowledged_by = $1, acknowledged_at = $2, updated_at = NOW()
+		WHERE id = $3
+	`
+	_, err := r.db.Exec(ctx, query, adminID, at, id)
+	return err
+}
+
+// Close resolves or dismisses an incident with the handling admin's note
+func (r *sosIncidentRepository) Close(ctx context.Context, id int, status entity.IncidentStatus, adminID int, note string, at time.Time) error {
+	query := `
+		UPDATE sos_incidents
+		SET status = $1, resolved_by = $2, resolution_note = $3, resolved_at = $4, updated_at = NOW()
+		WHERE id = $5
+	`
+	_, err := r.db.Exec(ctx, query, status, adminID, note, at, id)
+	return err
+}
+
+func (r *sosIncidentRepository) scanOne(row pgx.Row) (*entity.SOSIncident, error) {
+	incident, err := scanSOSIncident(row)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return incident, err
+}
+
+func scanSOSIncident(row pgx.Row) (*entity.SOSIncident, error) {
+	var incident entity.SOSIncident
+	var trip []byte
+	err := row.Scan(
+		&incident.ID,
+		&incident.OrderID,
+		&incident.ReportedBy,
+		&incident.Role,
+		&incident.Lat,
+		&incident.Long,
+		&incident.OrderStatus,
+		&trip,
+		&incident.Note,
+		&incident.Status,
+		&incident.ContactsAlerted,
+		&incident.AcknowledgedBy,
+		&incident.AcknowledgedAt,
+		&incident.ResolvedBy,
+		&incident.ResolvedAt,
+		&incident.ResolutionNote,
+		&incident.CreatedAt,
+		&incident.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(trip, &incident.Trip); err != nil {
+		return nil, fmt.Errorf("sos incident %d: %w", incident.ID, err)
+	}
+	return &incident, nil
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TrackingLinkRepository interface {
+	Create(ctx context.Context, link *entity.TrackingLink) error
+	FindByID(ctx context.Context, id int) (*entity.TrackingLink, error)
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entity.TrackingLink, error)
+	FindByOrderID(ctx context.Context, orderID int) ([]*entity.TrackingLink, error)
+	Revoke(ctx context.Context, id int, revokedAt time.Time) (bool, error)
+	WithTx(tx pgx.Tx) TrackingLinkRepository
+}
+
+type trackingLinkRepository struct {
+	db database.DBTX
+}
+
+func NewTrackingLinkRepository(db *pgxpool.Pool) TrackingLinkRepository {
+	return &trackingLinkRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *trackingLinkRepository) WithTx(tx pgx.Tx) TrackingLinkRepository {
+	return &trackingLinkRepository{db: tx}
+}
+
+const trackingLinkColumns = `id, order_id, created_by, token_hash, expires_at, revoked_at, created_at`
+
+func (r *trackingLinkRepository) Create(ctx context.Context, link *entity.TrackingLink) error {
+	query := `
+		INSERT INTO tracking_links (order_id, created_by, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	return r.db.QueryRow(ctx, query, link.OrderID, link.CreatedBy, link.TokenHash, link.ExpiresAt).Scan(&link.ID, &link.CreatedAt)
+}
+
+// FindByID returns nil when the link does not exist
+func (r *trackingLinkRepository) FindByID(ctx context.Context, id int) (*entity.TrackingLink, error) {
+	query := `SELECT ` + trackingLinkColumns + ` FROM tracking_links WHERE id = $1`
+	link, err := scanTrackingLink(r.db.QueryRow(ctx, query, id))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return link, err
+}
+
+// FindByTokenHash returns nil when no link has the token
+func (r *trackingLinkRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.TrackingLink, error) {
+	query := `SELECT ` + trackingLinkColumns + ` FROM tracking_links WHERE token_hash = $1`
+	link, err := scanTrackingLink(r.db.QueryRow(ctx, query, tokenHash))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return link, err
+}
+
+// FindByOrderID returns the order's links, newest first
+func (r *trackingLinkRepository) FindByOrderID(ctx context.Context, orderID int) ([]*entity.TrackingLink, error) {
+	query := `SELECT ` + trackingLinkColumns + ` FROM tracking_links WHERE order_id = $1 ORDER BY created_at DESC, id DESC`
+	rows, err := r.db.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := []*entity.TrackingLink{}
+	for rows.Next() {
+		link, err := scanTrackingLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+// Revoke switches a link off; it reports false when the link was already revoked
+func (r *trackingLinkRepository) Revoke(ctx context.Context, id int, revokedAt time.Time) (bool, error) {
+	tag, err := r.db.Exec(ctx, `UPDATE tracking_links SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL`, id, revokedAt)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func scanTrackingLink(row pgx.Row) (*entity.TrackingLink, error) {
+	var link entity.TrackingLink
+	err := row.Scan(
+		&link.ID,
+		&link.OrderID,
+		&link.CreatedBy,
+		&link.TokenHash,
+		&link.ExpiresAt,
+		&link.RevokedAt,
+		&link.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
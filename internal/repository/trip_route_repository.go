@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/geo"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TripRouteRepository interface {
+	AddPoint(ctx context.Context, orderID int, p geo.Point, recordedAt time.Time) error
+	FindPoints(ctx context.Context, orderID int) ([]geo.TrackPoint, error)
+	Upsert(ctx context.Context, route *entity.TripRoute) error
+	FindByOrderID(ctx context.Context, orderID int) (*entity.TripRoute, error)
+	WithTx(tx pgx.Tx) TripRouteRepository
+}
+
+type tripRouteRepository struct {
+	db database.DBTX
+}
+
+func NewTripRouteRepository(db *pgxpool.Pool) TripRouteRepository {
+	return &tripRouteRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *tripRouteRepository) WithTx(tx pgx.Tx) TripRouteRepository {
+	return &tripRouteRepository{db: tx}
+}
+
+func (r *tripRouteRepository) AddPoint(ctx context.Context, orderID int, p geo.Point, recordedAt time.Time) error {
+	query := `INSERT INTO trip_points (order_id, lat, long, recorded_at) VALUES ($1, $2, $3, $4)`
+	_, err := r.db.Exec(ctx, query, orderID, p.Lat, p.Long, recordedAt)
+	return err
+}
+
+// FindPoints returns the raw pings of a trip in the order they were taken
+func (r *tripRouteRepository) FindPoints(ctx context.Context, orderID int) ([]geo.TrackPoint, error) {
+	query := `
+		SELECT lat, long, recorded_at
+		FROM trip_points
+		WHERE order_id = $1
+		ORDER BY recorded_at, id
+	`
+	rows, err := r.db.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	track := []geo.TrackPoint{}
+	for rows.Next() {
+		var p geo.TrackPoint
+		if err := rows.Scan(&p.Lat, &p.Long, &p.At); err != nil {
+			return nil, err
+		}
+		track = append(track, p)
+	}
+	return track, rows.Err()
+}
+
+// Upsert stores the route of a trip, replacing an earlier build
+func (r *tripRouteRepository) Upsert(ctx context.Context, route *entity.TripRoute) error {
+	query := `
+		INSERT INTO trip_routes (order_id, polyline, distance_km, duration_seconds, raw_points, kept_points,
+		                         simplified_points, measured_fare, started_at, ended_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (order_id) DO UPDATE
+		SET polyline = EXCLUDED.polyline, distance_km = EXCLUDED.distance_km,
+		    duration_seconds = EXCLUDED.duration_seconds, raw_points = EXCLUDED.raw_points,
+		    kept_points = EXCLUDED.kept_points, simplified_points = EXCLUDED.simplified_points,
+		    measured_fare = EXCLUDED.measured_fare, started_at = EXCLUDED.started_at,
+		    ended_at = EXCLUDED.ended_at, built_at = NOW()
+		RETURNING built_at
+	`
+	return r.db.QueryRow(ctx, query,
+		route.OrderID,
+		route.Polyline,
+		route.DistanceKm,
+		route.DurationSeconds,
+		route.RawPoints,
+		route.KeptPoints,
+		route.SimplifiedPoints,
+		route.MeasuredFare,
+		route.StartedAt,
+		route.EndedAt,
+	).Scan(&route.BuiltAt)
+}
+
+// FindByOrderID returns nil when the trip has no stored route
+func (r *tripRouteRepository) FindByOrderID(ctx context.Context, orderID int) (*entity.TripRoute, error) {
+	query := `
+		SELECT order_id, polyline, distance_km, duration_seconds, raw_points, kept_points,
+		       simplified_points, measured_fare, started_at, ended_at, built_at
+		FROM trip_routes
+		WHERE order_id = $1
+	`
+	var route entity.TripRoute
+	err := r.db.QueryRow(ctx, query, orderID).Scan(
+		&route.OrderID,
+		&route.Polyline,
+		&route.DistanceKm,
+		&route.DurationSeconds,
+		&route.RawPoints,
+		&route.KeptPoints,
+		&route.SimplifiedPoints,
+		&route.MeasuredFare,
+		&route.StartedAt,
+		&route.EndedAt,
+		&route.BuiltAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &route, nil
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TrustedContactRepository interface {
+	Create(ctx context.Context, contact *entity.TrustedContact) error
+	FindByID(ctx context.Context, id int) (*entity.TrustedContact, error)
+	FindByUserID(ctx context.Context, userID int) ([]*entity.TrustedContact, error)
+	FindByUserAndPhone(ctx context.Context, userID int, phoneNumber string) (*entity.TrustedContact, error)
+	MarkVerified(ctx context.Context, id int, verifiedAt time.Time) error
+	Delete(ctx context.Context, id int) (bool, error)
+	WithTx(tx pgx.Tx) TrustedContactRepository
+}
+
+type trustedContactRepository struct {
+	db database.DBTX
+}
+
+func NewTrustedContactRepository(db *pgxpool.Pool) TrustedContactRepository {
+	return &trustedContactRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *trustedContactRepository) WithTx(tx pgx.Tx) TrustedContactRepository {
+	return &trustedContactRepository{db: tx}
+}
+
+const trustedContactColumns = `id, user_id, name, phone_number, verified_at, created_at`
+
+func (r *trustedContactRepository) Create(ctx context.Context, contact *entity.TrustedContact) error {
+	query := `
+		INSERT INTO trusted_contacts (user_id, name, phone_number)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+	return r.db.QueryRow(ctx, query, contact.UserID, contact.Name, contact.PhoneNumber).Scan(&contact.ID, &contact.CreatedAt)
+}
+
+// FindByID returns nil when the contact does not exist
+func (r *trustedContactRepository) FindByID(ctx context.Context, id int) (*entity.TrustedContact, error) {
+	query := `SELECT ` + trustedContactColumns + ` FROM trusted_contacts WHERE id = $1`
+	contact, err := scanTrustedContact(r.db.QueryRow(ctx, query, id))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return contact, err
+}
+
+// FindByUserID returns the user's contacts in the order they were added
+func (r *trustedContactRepository) FindByUserID(ctx context.Context, userID int) ([]*entity.TrustedContact, error) {
+	query := `SELECT ` + trustedContactColumns + ` FROM trusted_contacts WHERE user_id = $1 ORDER BY created_at, id`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contacts := []*entity.TrustedContact{}
+	for rows.Next() {
+		contact, err := scanTrustedContact(rows)
+		if err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, contact)
+	}
+	return contacts, rows.Err()
+}
+
+// FindByUserAndPhone returns nil when the user has no contact with that number
+func (r *trustedContactRepository) FindByUserAndPhone(ctx context.Context, userID int, phoneNumber string) (*entity.TrustedContact, error) {
+	query := `SELECT ` + trustedContactColumns + ` FROM trusted_contacts WHERE user_id = $1 AND phone_number = $2`
+	contact, err := scanTrustedContact(r.db.QueryRow(ctx, query, userID, phoneNumber))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return contact, err
+}
+
+func (r *trustedContactRepository) MarkVerified(ctx context.Context, id int, verifiedAt time.Time) error {
+	_, err := r.db.Exec(ctx, `UPDATE trusted_contacts SET verified_at = $1 WHERE id = $2`, verifiedAt, id)
+	return err
+}
+
+// Delete removes a contact; it reports false when there was none
+func (r *trustedContactRepository) Delete(ctx context.Context, id int) (bool, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM trusted_contacts WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func scanTrustedContact(row pgx.Row) (*entity.TrustedContact, error) {
+	var contact entity.TrustedContact
+	err := row.Scan(
+		&contact.ID,
+		&contact.UserID,
+		&contact.Name,
+		&contact.PhoneNumber,
+		&contact.VerifiedAt,
+		&contact.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
@@ -2,9 +2,9 @@ package repository
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -19,21 +19,28 @@ type UserRepository interface {
 	Update(ctx context.Context, user *entity.User) error
 	UpdateLastLogin(ctx context.Context, userID int) error
 	UpdatePhoneVerified(ctx context.Context, userID int, verified bool) error
+	MarkEmailVerified(ctx context.Context, userID int, email string) error
+	WithTx(tx pgx.Tx) UserRepository
 }
 
 type userRepository struct {
-	db *pgxpool.Pool
+	db database.DBTX
 }
 
 func NewUserRepository(db *pgxpool.Pool) UserRepository {
 	return &userRepository{db: db}
 }
 
+// WithTx returns a repository bound to the given transaction
+func (r *userRepository) WithTx(tx pgx.Tx) UserRepository {
+	return &userRepository{db: tx}
+}
+
 func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
 	query := `
-		INSERT INTO users (phone_number, password_hash, email, full_name, role, status, phone_verified)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id, created_at, updated_at
+		INSERT INTO users (phone_number, password_hash, email, full_name, role, status, phone_verified, preferred_locale)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE(NULLIF($8, ''), 'id'))
+		RETURNING id, preferred_locale, created_at, updated_at
 	`
 	return r.db.QueryRow(ctx, query,
 		user.PhoneNumber,
@@ -43,13 +50,15 @@ func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
 		user.Role,
 		user.Status,
 		user.PhoneVerified,
-	).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+		user.PreferredLocale,
+	).Scan(&user.ID, &user.PreferredLocale, &user.CreatedAt, &user.UpdatedAt)
 }
 
+// FindByID returns nil when the user does not exist
 func (r *userRepository) FindByID(ctx context.Context, id int) (*entity.User, error) {
 	query := `
 		SELECT id, phone_number, password_hash, email, full_name, role, status, 
-		       phone_verified, last_login_at, created_at, updated_at
+		       phone_verified, email_verified, campus_eligible, preferred_locale, last_login_at, created_at, updated_at
 		FROM users WHERE id = $1
 	`
 	var user entity.User
@@ -62,12 +71,15 @@ func (r *userRepository) FindByID(ctx context.Context, id int) (*entity.User, er
 		&user.Role,
 		&user.Status,
 		&user.PhoneVerified,
+		&user.EmailVerified,
+		&user.CampusEligible,
+		&user.PreferredLocale,
 		&user.LastLoginAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
 	if err == pgx.ErrNoRows {
-		return nil, fmt.Errorf("user not found")
+		return nil, nil
 	}
 	if err != nil {
 		return nil, err
@@ -75,10 +87,11 @@ func (r *userRepository) FindByID(ctx context.Context, id int) (*entity.User, er
 	return &user, nil
 }
 
+// FindByPhoneNumber returns nil when no user has the phone number
 func (r *userRepository) FindByPhoneNumber(ctx context.Context, phoneNumber string) (*entity.User, error) {
 	query := `
 		SELECT id, phone_number, password_hash, email, full_name, role, status,
-		       phone_verified, last_login_at, created_at, updated_at
+		       phone_verified, email_verified, campus_eligible, preferred_locale, last_login_at, created_at, updated_at
 		FROM users WHERE phone_number = $1
 	`
 	var user entity.User
@@ -91,12 +104,15 @@ func (r *userRepository) FindByPhoneNumber(ctx context.Context, phoneNumber stri
 		&user.Role,
 		&user.Status,
 		&user.PhoneVerified,
+		&user.EmailVerified,
+		&user.CampusEligible,
+		&user.PreferredLocale,
 		&user.LastLoginAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
 	if err == pgx.ErrNoRows {
-		return nil, fmt.Errorf("user not found")
+		return nil, nil
 	}
 	if err != nil {
 		return nil, err
@@ -104,10 +120,11 @@ func (r *userRepository) FindByPhoneNumber(ctx context.Context, phoneNumber stri
 	return &user, nil
 }
 
+// FindByEmail returns nil when no user has the email
 func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
 	query := `
 		SELECT id, phone_number, password_hash, email, full_name, role, status,
-		       phone_verified, last_login_at, created_at, updated_at
+		       phone_verified, email_verified, campus_eligible, preferred_locale, last_login_at, created_at, updated_at
 		FROM users WHERE email = $1
 	`
 	var user entity.User
@@ -120,12 +137,15 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity
 		&user.Role,
 		&user.Status,
 		&user.PhoneVerified,
+		&user.EmailVerified,
+		&user.CampusEligible,
+		&user.PreferredLocale,
 		&user.LastLoginAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
 	if err == pgx.ErrNoRows {
-		return nil, fmt.Errorf("user not found")
+		return nil, nil
 	}
 	if err != nil {
 		return nil, err
@@ -136,14 +156,15 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity
 func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
 	query := `
 		UPDATE users 
-		SET email = $1, full_name = $2, status = $3, phone_verified = $4, updated_at = NOW()
-		WHERE id = $5
+		SET email = $1, full_name = $2, status = $3, phone_verified = $4, preferred_locale = $5, updated_at = NOW()
+		WHERE id = $6
 	`
 	_, err := r.db.Exec(ctx, query,
 		user.Email,
 		user.FullName,
 		user.Status,
 		user.PhoneVerified,
+		user.PreferredLocale,
 		user.ID,
 	)
 	return err
@@ -161,6 +182,13 @@ func (r *userRepository) UpdatePhoneVerified(ctx context.Context, userID int, ve
 	return err
 }
 
+// MarkEmailVerified sets the user's email and marks it verified
+func (r *userRepository) MarkEmailVerified(ctx context.Context, userID int, email string) error {
+	query := `UPDATE users SET email = $1, email_verified = TRUE, updated_at = NOW() WHERE id = $2`
+	_, err := r.db.Exec(ctx, query, email, userID)
+	return err
+}
+
 func (r *userRepository) ExistsByPhoneNumber(ctx context.Context, phoneNumber string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM users WHERE phone_number = $1)`
 	var exists bool
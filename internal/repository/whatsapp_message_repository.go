@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WhatsAppMessageRepository interface {
+	Create(ctx context.Context, message *entity.WhatsAppMessage) error
+	FindByProviderID(ctx context.Context, provider, providerMessageID string) (*entity.WhatsAppMessage, error)
+	FindLatestByReference(ctx context.Context, purpose entity.WhatsAppPurpose, referenceID int) (*entity.WhatsAppMessage, error)
+	CountByReference(ctx context.Context, purpose entity.WhatsAppPurpose, referenceID int) (int, error)
+	UpdateStatus(ctx context.Context, id int64, from, to entity.WhatsAppStatus, errorText *string, at time.Time) (bool, error)
+	WithTx(tx pgx.Tx) WhatsAppMessageRepository
+}
+
+type whatsAppMessageRepository struct {
+	db database.DBTX
+}
+
+func NewWhatsAppMessageRepository(db *pgxpool.Pool) WhatsAppMessageRepository {
+	return &whatsAppMessageRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *whatsAppMessageRepository) WithTx(tx pgx.Tx) WhatsAppMessageRepository {
+	return &whatsAppMessageRepository{db: tx}
+}
+
+const whatsAppMessageColumns = `id, provider, provider_message_id, phone_number, purpose, reference_id, status, error, sent_at, delivered_at, read_at, failed_at`
+
+func (r *whatsAppMessageRepository) Create(ctx context.Context, message *entity.WhatsAppMessage) error {
+	query := `
+		INSERT INTO whatsapp_messages (provider, provider_message_id, phone_number, purpose, reference_id, status, sent_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+	return r.db.QueryRow(ctx, query,
+		message.Provider,
+		message.ProviderMessageID,
+		message.PhoneNumber,
+		message.Purpose,
+		message.ReferenceID,
+		message.Status,
+		message.SentAt,
+	).Scan(&message.ID)
+}
+
+// FindByProviderID returns nil when no message was recorded under the provider's id
+func (r *whatsAppMessageRepository) FindByProviderID(ctx context.Context, provider, providerMessageID string) (*entity.WhatsAppMessage, error) {
+	query := `SELECT ` + whatsAppMessageColumns + ` FROM whatsapp_messages WHERE provider = $1 AND provider_message_id = $2`
+	message, err := scanWhatsAppMessage(r.db.QueryRow(ctx, query, provider, providerMessageID))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return message, err
+}
+
+// FindLatestByReference returns the last message sent for a reference; nil when none was
+func (r *whatsAppMessageRepository) FindLatestByReference(ctx context.Context, purpose entity.WhatsAppPurpose, referenceID int) (*entity.WhatsAppMessage, error) {
+	query := `
+		SELECT ` + whatsAppMessageColumns + `
+		FROM whatsapp_messages
+		WHERE purpose = $1 AND reference_id = $2
+		ORDER BY id DESC
+		LIMIT 1
+	`
+	message, err := scanWhatsAppMessage(r.db.QueryRow(ctx, query, purpose, referenceID))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return message, err
+}
+
+func (r *whatsAppMessageRepository) CountByReference(ctx context.Context, purpose entity.WhatsAppPurpose, referenceID int) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx,
+		`SELECT COUNT(*) FROM whatsapp_messages WHERE purpose = $1 AND reference_id = $2`,
+		purpose, referenceID,
+	).Scan(&count)
+	return count, err
+}
+
+// UpdateStatus moves a message from one status to the next and stamps when it happened;
+// a read message counts as delivered too. It reports false when the message was no
+// longer in the from status, i.e. a concurrent webhook got there first.
+func (r *whatsAppMessageRepository) UpdateStatus(ctx context.Context, id int64, from, to entity.WhatsAppStatus, errorText *string, at time.Time) (bool, error) {
+	query := `
+		UPDATE whatsapp_messages
+		SET status = $3,
+		    error = COALESCE($4, error),
+		    delivered_at = CASE WHEN $3 IN ('DELIVERED', 'READ') THEN COALESCE(delivered_at, $5) ELSE delivered_at END,
+		    read_at = CASE WHEN $3 = 'READ' THEN $5 ELSE read_at END,
+		    failed_at = CASE WHEN $3 = 'FAILED' THEN $5 ELSE failed_at END
+		WHERE id = $1 AND status = $2
+	`
+	tag, err := r.db.Exec(ctx, query, id, string(from), string(to), errorText, at)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func scanWhatsAppMessage(row pgx.Row) (*entity.WhatsAppMessage, error) {
+	var message entity.WhatsAppMessage
+	err := row.Scan(
+		&message.ID,
+		&message.Provider,
+		&message.ProviderMessageID,
+		&message.PhoneNumber,
+		&message.Purpose,
+		&message.ReferenceID,
+		&message.Status,
+		&message.Error,
+		&message.SentAt,
+		&message.DeliveredAt,
+		&message.ReadAt,
+		&message.FailedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
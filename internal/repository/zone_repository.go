@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/geo"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ZoneRepository interface {
+	Create(ctx context.Context, zone *entity.Zone) error
+	Update(ctx context.Context, zone *entity.Zone) error
+	Delete(ctx context.Context, id int) (bool, error)
+	FindByID(ctx context.Context, id int) (*entity.Zone, error)
+	List(ctx context.Context, kind entity.ZoneKind) ([]*entity.Zone, error)
+	FindActiveContaining(ctx context.Context, p geo.Point) ([]*entity.Zone, error)
+	ExistsActive(ctx context.Context, kind entity.ZoneKind) (bool, error)
+	WithTx(tx pgx.Tx) ZoneRepository
+}
+
+type zoneRepository struct {
+	db database.DBTX
+}
+
+func NewZoneRepository(db *pgxpool.Pool) ZoneRepository {
+	return &zoneRepository{db: db}
+}
+
+// WithTx returns a repository bound to the given transaction
+func (r *zoneRepository) WithTx(tx pgx.Tx) ZoneRepository {
+	return &zoneRepository{db: tx}
+}
+
+const zoneColumns = `id, name, kind, geometry, surcharge, is_active, created_by, created_at, updated_at`
+
+func (r *zoneRepository) Create(ctx context.Context, zone *entity.Zone) error {
+	geometry, err := zone.Area.MarshalGeoJSON()
+	if err != nil {
+		return err
+	}
+	minLat, maxLat, minLong, maxLong := zone.Area.Bounds()
+
+	query := `
+		INSERT INTO zones (name, kind, geometry, min_lat, max_lat, min_long, max_long, surcharge, is_active, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at, updated_at
+	`
+	return r.db.QueryRow(ctx, query,
+		zone.Name,
+		zone.Kind,
+		geometry,
+		minLat, maxLat, minLong, maxLong,
+		zone.Surcharge,
+		zone.IsActive,
+		zone.CreatedBy,
+	).Scan(&zone.ID, &zone.CreatedAt, &zone.UpdatedAt)
+}
+
+func (r *zoneRepository) Update(ctx context.Context, zone *entity.Zone) error {
+	geometry, err := zone.Area.MarshalGeoJSON()
+	if err != nil {
+		return err
+	}
+	minLat, maxLat, minLong, maxLong := zone.Area.Bounds()
+
+	query := `
+		UPDATE zones
+		SET name = $1, geometry = $2, min_lat = $3, max_lat = $4, min_long = $5, max_long = $6,
+		    surcharge = $7, is_active = $8, updated_at = NOW()
+		WHERE id = $9
+		RETURNING updated_at
+	`
+	return r.db.QueryRow(ctx, query,
+		zone.Name,
+		geometry,
+		minLat, maxLat, minLong, maxLong,
+		zone.Surcharge,
+		zone.IsActive,
+		zone.ID,
+	).Scan(&zone.UpdatedAt)
+}
+
+// Delete reports whether a zone was removed
+func (r *zoneRepository) Delete(ctx context.Context, id int) (bool, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM zones WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// FindByID returns nil when the zone does not exist
+func (r *zoneRepository) FindByID(ctx context.Context, id int) (*entity.Zone, error) {
+	zones, err := r.query(ctx, `SELECT `+zoneColumns+` FROM zones WHERE id = $1`, id)
+	if err != nil || len(zones) == 0 {
+		return nil, err
+	}
+	return zones[0], nil
+}
+
+// List returns the zones of a kind, or every zone when kind is empty
+func (r *zoneRepository) List(ctx context.Context, kind entity.ZoneKind) ([]*entity.Zone, error) {
+	query := `
+		SELECT ` + zoneColumns + `
+		FROM zones
+		WHERE $1 = '' OR kind = $1
+		ORDER BY kind, name
+	`
+	return r.query(ctx, query, string(kind))
+}
+
+// FindActiveContaining returns the active zones whose area contains p. The bounding box
+// narrows the candidates in SQL; the exact polygon test runs here.
+func (r *zoneRepository) FindActiveContaining(ctx context.Context, p geo.Point) ([]*entity.Zone, error) {
+	query := `
+		SELECT ` + zoneColumns + `
+		FROM zones
+		WHERE is_active = TRUE
+		  AND $1 BETWEEN min_lat AND max_lat
+		  AND $2 BETWEEN min_long AND max_long
+		ORDER BY id
+	`
+	candidates, err := r.query(ctx, query, p.Lat, p.Long)
+	if err != nil {
+		return nil, err
+	}
+
+	zones := candidates[:0]
+	for _, zone := range candidates {
+		if zone.Area.Contains(p) {
+			zones = append(zones, zone)
+		}
+	}
+	return zones, nil
+}
+
+func (r *zoneRepository) ExistsActive(ctx context.Context, kind entity.ZoneKind) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM zones WHERE kind = $1 AND is_active = TRUE)`
+	var exists bool
+	err := r.db.QueryRow(ctx, query, kind).Scan(&exists)
+	return exists, err
+}
+
+func (r *zoneRepository) query(ctx context.Context, query string, args ...any) ([]*entity.Zone, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	zones := []*entity.Zone{}
+	for rows.Next() {
+		var zone entity.Zone
+		var geometry []byte
+		if err := rows.Scan(
+			&zone.ID, &zone.Name, &zone.Kind, &geometry, &zone.Surcharge, &zone.IsActive,
+			&zone.CreatedBy, &zone.CreatedAt, &zone.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if zone.Area, err = geo.ParseGeoJSON(geometry); err != nil {
+			return nil, fmt.Errorf("zone %d: %w", zone.ID, err)
+		}
+		zones = append(zones, &zone)
+	}
+	return zones, rows.Err()
+}
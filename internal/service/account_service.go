@@ -0,0 +1,347 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/mapper"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/i18n"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/mailer"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AccountService handles account-level edits shared by passengers and drivers.
+// Emails are verified with a code or a signed link sent to the address; a verified email
+// on an allow-listed campus domain makes the user campus eligible.
+type AccountService interface {
+	UpdateAccount(ctx context.Context, userID int, req dto.UpdateAccountRequest) (*dto.AccountResponse, error)
+	SendEmailVerification(ctx context.Context, userID int) (*dto.AccountResponse, error)
+	VerifyEmailChange(ctx context.Context, userID int, req dto.VerifyEmailChangeRequest) (*dto.AccountResponse, error)
+	VerifyEmailLink(ctx context.Context, token string) (*dto.AccountResponse, error)
+}
+
+type accountService struct {
+	db               *pgxpool.Pool
+	userRepo         repository.UserRepository
+	emailChangeRepo  repository.EmailChangeRepository
+	campusDomainRepo repository.CampusDomainRepository
+	mailer           mailer.Mailer
+	linkSecret       []byte
+	linkBaseURL      string
+}
+
+func NewAccountService(
+	db *pgxpool.Pool,
+	userRepo repository.UserRepository,
+	emailChangeRepo repository.EmailChangeRepository,
+	campusDomainRepo repository.CampusDomainRepository,
+	mailer mailer.Mailer,
+	linkSecret, linkBaseURL string,
+) AccountService {
+	return &accountService{
+		db:               db,
+		userRepo:         userRepo,
+		emailChangeRepo:  emailChangeRepo,
+		campusDomainRepo: campusDomainRepo,
+		mailer:           mailer,
+		linkSecret:       []byte(linkSecret),
+		linkBaseURL:      strings.TrimSuffix(linkBaseURL, "/"),
+	}
+}
+
+// UpdateAccount updates full name immediately and starts verification for a new email
+func (s *accountService) UpdateAccount(ctx context.Context, userID int, req dto.UpdateAccountRequest) (*dto.AccountResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if user == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+
+	if req.FullName == nil && req.Email == nil && req.Locale == nil {
+		return nil, apperror.ErrNoProfileChanges
+	}
+
+	// 1. Full name and preferred language
+	updated := false
+	if req.FullName != nil {
+		fullName := strings.TrimSpace(*req.FullName)
+		if fullName != "" && fullName != user.FullName {
+			user.FullName = fullName
+			updated = true
+		}
+	}
+	if req.Locale != nil && *req.Locale != user.PreferredLocale {
+		user.PreferredLocale = *req.Locale
+		updated = true
+	}
+	if updated {
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			logger.Log.Error().Err(err).Int("user_id", userID).Msg("Failed to update account")
+			return nil, fmt.Errorf("failed to update account: %w", err)
+		}
+		logger.Log.Info().Int("user_id", userID).Str("locale", user.PreferredLocale).Msg("Account details updated")
+	}
+
+	response := &dto.AccountResponse{User: mapper.ToUserResponse(user)}
+
+	// 2. Email (pending until verified)
+	if req.Email != nil {
+		newEmail := strings.ToLower(strings.TrimSpace(*req.Email))
+		if user.Email != nil && strings.EqualFold(*user.Email, newEmail) {
+			return nil, apperror.ErrEmailUnchanged
+		}
+
+		if err := s.startEmailChange(ctx, user, newEmail); err != nil {
+			return nil, err
+		}
+
+		expiresIn := int(constants.EmailChangeCodeTTL.Seconds())
+		response.PendingEmail = &newEmail
+		response.EmailVerificationExpires = &expiresIn
+	}
+
+	return response, nil
+}
+
+// SendEmailVerification sends a code and link to the account's current, unverified email
+func (s *accountService) SendEmailVerification(ctx context.Context, userID int) (*dto.AccountResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if user == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+	if user.Email == nil {
+		return nil, apperror.ErrEmailRequired
+	}
+	if user.EmailVerified {
+		return nil, apperror.ErrEmailAlreadyVerified
+	}
+
+	email := strings.ToLower(*user.Email)
+	if err := s.startEmailChange(ctx, user, email); err != nil {
+		return nil, err
+	}
+
+	expiresIn := int(constants.EmailChangeCodeTTL.Seconds())
+	return &dto.AccountResponse{
+		User:                     mapper.ToUserResponse(user),
+		PendingEmail:             &email,
+		EmailVerificationExpires: &expiresIn,
+	}, nil
+}
+
+// VerifyEmailChange confirms the pending email with the code sent to it
+func (s *accountService) VerifyEmailChange(ctx context.Context, userID int, req dto.VerifyEmailChangeRequest) (*dto.AccountResponse, error) {
+	request, err := s.emailChangeRepo.FindLatestPendingByUserID(ctx, userID)
+	if err != nil {
+		logger.Log.Error().Err(err).Int("user_id", userID).Msg("Failed to find pending email change")
+		return nil, fmt.Errorf("failed to find pending email change: %w", err)
+	}
+	if request == nil {
+		return nil, apperror.ErrNoPendingEmailChange
+	}
+
+	if request.IsExpired() {
+		return nil, apperror.ErrVerificationCodeExpired
+	}
+	if request.Attempts >= constants.MaxEmailChangeAttempts {
+		return nil, apperror.ErrTooManyAttempts
+	}
+
+	// The attempt must be counted before the code is checked, or guesses go unlimited
+	if err := s.emailChangeRepo.IncrementAttempts(ctx, request.ID); err != nil {
+		logger.Log.Error().Err(err).Int("request_id", request.ID).Msg("Failed to increment email change attempts")
+		return nil, fmt.Errorf("failed to record verification attempt: %w", err)
+	}
+
+	if HashToken(req.Code) != request.CodeHash {
+		logger.Log.Warn().Int("user_id", userID).Msg("Invalid email change code")
+		return nil, apperror.ErrInvalidVerificationCode
+	}
+
+	return s.applyEmailVerification(ctx, request)
+}
+
+// VerifyEmailLink confirms a pending email from the signed link sent to it. The link
+// works without logging in, so it can be opened from any device's mail client.
+func (s *accountService) VerifyEmailLink(ctx context.Context, token string) (*dto.AccountResponse, error) {
+	requestID, expiresAt, ok := s.parseEmailLinkToken(token)
+	if !ok || time.Now().After(expiresAt) {
+		return nil, apperror.ErrInvalidVerificationLink
+	}
+
+	request, err := s.emailChangeRepo.FindByID(ctx, requestID)
+	if err != nil {
+		logger.Log.Error().Err(err).Int("request_id", requestID).Msg("Failed to find email change")
+		return nil, fmt.Errorf("failed to find email change: %w", err)
+	}
+	if request == nil || request.IsUsed || request.IsExpired() ||
+		!hmac.Equal([]byte(token), []byte(s.emailLinkToken(request.ID, request.NewEmail, expiresAt))) {
+		logger.Log.Warn().Int("request_id", requestID).Msg("Invalid email verification link")
+		return nil, apperror.ErrInvalidVerificationLink
+	}
+
+	return s.applyEmailVerification(ctx, request)
+}
+
+// applyEmailVerification sets the verified email and recomputes campus eligibility
+func (s *accountService) applyEmailVerification(ctx context.Context, request *entity.EmailChangeRequest) (*dto.AccountResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, request.UserID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if user == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+
+	// Email may have been taken while verification was pending
+	if user.Email == nil || !strings.EqualFold(*user.Email, request.NewEmail) {
+		emailExists, err := s.userRepo.ExistsByEmail(ctx, request.NewEmail)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check email availability")
+		}
+		if emailExists {
+			return nil, apperror.ErrEmailAlreadyRegistered
+		}
+	}
+
+	// The email, campus eligibility and the used request change together, so a request
+	// can never be replayed against an email that was already applied
+	err = database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		if err := s.userRepo.WithTx(tx).MarkEmailVerified(ctx, user.ID, request.NewEmail); err != nil {
+			return fmt.Errorf("failed to apply email change: %w", err)
+		}
+		if err := s.campusDomainRepo.WithTx(tx).RefreshEligibility(ctx, &user.ID); err != nil {
+			return fmt.Errorf("failed to refresh campus eligibility: %w", err)
+		}
+		if err := s.emailChangeRepo.WithTx(tx).MarkVerified(ctx, request.ID); err != nil {
+			return fmt.Errorf("failed to mark email change as verified: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Log.Error().Err(err).Int("user_id", user.ID).Int("request_id", request.ID).Msg("Failed to apply email verification")
+		return nil, fmt.Errorf("failed to update account: %w", err)
+	}
+
+	user, err = s.userRepo.FindByID(ctx, user.ID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if user == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+
+	logger.Log.Info().Int("user_id", user.ID).Bool("campus_eligible", user.CampusEligible).Msg("Email verified")
+
+	return &dto.AccountResponse{User: mapper.ToUserResponse(user)}, nil
+}
+
+// startEmailChange creates a pending email change and sends the code and link to the
+// new address. newEmail may be the user's own current address when verifying it.
+func (s *accountService) startEmailChange(ctx context.Context, user *entity.User, newEmail string) error {
+	if user.Email == nil || !strings.EqualFold(*user.Email, newEmail) {
+		emailExists, err := s.userRepo.ExistsByEmail(ctx, newEmail)
+		if err != nil {
+			logger.Log.Error().Err(err).Str("email", newEmail).Msg("Failed to check email existence")
+			return fmt.Errorf("failed to check email availability")
+		}
+		if emailExists {
+			return apperror.ErrEmailAlreadyRegistered
+		}
+	}
+
+	code, err := generateNumericCode(constants.EmailChangeCodeLength)
+	if err != nil {
+		return fmt.Errorf("failed to generate verification code: %w", err)
+	}
+
+	if err := s.emailChangeRepo.InvalidatePending(ctx, user.ID); err != nil {
+		logger.Log.Warn().Err(err).Int("user_id", user.ID).Msg("Failed to invalidate pending email changes")
+	}
+
+	request := &entity.EmailChangeRequest{
+		UserID:    user.ID,
+		NewEmail:  newEmail,
+		CodeHash:  HashToken(code),
+		ExpiresAt: time.Now().Add(constants.EmailChangeCodeTTL),
+	}
+	if err := s.emailChangeRepo.Create(ctx, request); err != nil {
+		logger.Log.Error().Err(err).Int("user_id", user.ID).Msg("Failed to create email change request")
+		return fmt.Errorf("failed to create email change request: %w", err)
+	}
+
+	locale := i18n.OrDefault(user.PreferredLocale)
+	subject := i18n.T(locale, "email.change.subject", nil)
+	link := s.linkBaseURL + "/api/auth/email/verify?token=" + url.QueryEscape(s.emailLinkToken(request.ID, newEmail, request.ExpiresAt))
+	body := i18n.T(locale, "email.change.body", map[string]string{
+		"name":    user.FullName,
+		"code":    code,
+		"link":    link,
+		"minutes": strconv.Itoa(int(constants.EmailChangeCodeTTL.Minutes())),
+	})
+	if err := s.mailer.Send(ctx, newEmail, subject, body); err != nil {
+		logger.Log.Error().Err(err).Int("user_id", user.ID).Msg("Failed to send email verification code")
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+
+	logger.Log.Info().Int("user_id", user.ID).Int("request_id", request.ID).Msg("Email change verification sent")
+	return nil
+}
+
+// emailLinkToken signs "<request id>.<expiry>" together with the address being verified,
+// so a link only ever confirms the email it was sent to
+func (s *accountService) emailLinkToken(requestID int, email string, expiresAt time.Time) string {
+	payload := fmt.Sprintf("%d.%d", requestID, expiresAt.Unix())
+	mac := hmac.New(sha256.New, s.linkSecret)
+	mac.Write([]byte(payload + "." + strings.ToLower(email)))
+	return payload + "." + hex.EncodeToString(mac.Sum(nil))
+}
+
+// parseEmailLinkToken reads the request id and expiry; the signature is checked by the caller
+func (s *accountService) parseEmailLinkToken(token string) (int, time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return 0, time.Time{}, false
+	}
+	requestID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	expiresUnix, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	return requestID, time.Unix(expiresUnix, 0), true
+}
+
+// generateNumericCode generates a cryptographically secure numeric code of given length
+func generateNumericCode(length int) (string, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(length)), nil)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", length, n.Int64()), nil
+}
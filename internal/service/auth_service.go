@@ -9,7 +9,9 @@ import (
 	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
 	"github.com/AnggaKay/ojek-kampus-backend/internal/mapper"
 	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/i18n"
 	jwtPkg "github.com/AnggaKay/ojek-kampus-backend/pkg/jwt"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/password"
@@ -66,7 +68,7 @@ func (s *authService) RegisterPassenger(ctx context.Context, req dto.RegisterPas
 	}
 	if phoneExists {
 		logger.Log.Warn().Str("phone", phoneNumber).Msg("Phone number already registered")
-		return nil, fmt.Errorf(constants.ErrPhoneAlreadyRegistered)
+		return nil, apperror.ErrPhoneAlreadyRegistered
 	}
 
 	// Check email if provided
@@ -78,7 +80,7 @@ func (s *authService) RegisterPassenger(ctx context.Context, req dto.RegisterPas
 		}
 		if emailExists {
 			logger.Log.Warn().Str("email", *req.Email).Msg("Email already registered")
-			return nil, fmt.Errorf(constants.ErrEmailAlreadyRegistered)
+			return nil, apperror.ErrEmailAlreadyRegistered
 		}
 	}
 
@@ -86,23 +88,24 @@ func (s *authService) RegisterPassenger(ctx context.Context, req dto.RegisterPas
 	hashedPassword, err := password.Hash(req.Password)
 	if err != nil {
 		logger.Log.Error().Err(err).Msg("Failed to hash password")
-		return nil, fmt.Errorf(constants.ErrFailedToHashPassword+": %w", err)
+		return nil, apperror.Internal(err)
 	}
 
 	// Create user
 	user := &entity.User{
-		PhoneNumber:   phoneNumber,
-		PasswordHash:  hashedPassword,
-		Email:         req.Email,
-		FullName:      req.FullName,
-		Role:          entity.RolePassenger,
-		Status:        entity.StatusActive,
-		PhoneVerified: false,
+		PhoneNumber:     phoneNumber,
+		PasswordHash:    hashedPassword,
+		Email:           req.Email,
+		FullName:        req.FullName,
+		Role:            entity.RolePassenger,
+		Status:          entity.StatusActive,
+		PhoneVerified:   false,
+		PreferredLocale: string(i18n.FromContext(ctx)),
 	}
 
 	if err := s.userRepo.Create(ctx, user); err != nil {
 		logger.Log.Error().Err(err).Str("phone", phoneNumber).Msg("Failed to create user")
-		return nil, fmt.Errorf(constants.ErrFailedToCreateUser+": %w", err)
+		return nil, apperror.Internal(err)
 	}
 
 	logger.Log.Info().Int("user_id", user.ID).Str("phone", phoneNumber).Msg("User created successfully")
@@ -127,16 +130,16 @@ func (s *authService) RegisterPassenger(ctx context.Context, req dto.RegisterPas
 	logger.Log.Info().Int("passenger_id", passengerProfile.ID).Int("user_id", user.ID).Msg("Passenger profile created successfully")
 
 	// Generate tokens
-	accessToken, err := jwtPkg.GenerateAccessToken(user.ID, user.Role, string(user.Role))
+	accessToken, err := jwtPkg.GenerateAccessToken(user.ID, user.Role, string(user.Role), user.PreferredLocale)
 	if err != nil {
 		logger.Log.Error().Err(err).Int("user_id", user.ID).Msg("Failed to generate access token")
-		return nil, fmt.Errorf(constants.ErrFailedToGenerateToken+": %w", err)
+		return nil, apperror.Internal(err)
 	}
 
 	refreshToken, err := s.createRefreshToken(ctx, user.ID, string(user.Role), req.PhoneNumber)
 	if err != nil {
 		logger.Log.Error().Err(err).Int("user_id", user.ID).Msg("Failed to generate refresh token")
-		return nil, fmt.Errorf(constants.ErrFailedToGenerateToken+": %w", err)
+		return nil, apperror.Internal(err)
 	}
 
 	logger.Log.Info().Int("user_id", user.ID).Msg("Registration completed successfully")
@@ -153,20 +156,24 @@ func (s *authService) Login(ctx context.Context, req dto.LoginRequest) (*dto.Log
 	// Find user
 	user, err := s.userRepo.FindByPhoneNumber(ctx, phoneNumber)
 	if err != nil {
+		logger.Log.Error().Err(err).Str("phone", phoneNumber).Msg("Failed to load user for login")
+		return nil, apperror.Internal(err)
+	}
+	if user == nil {
 		logger.Log.Warn().Str("phone", phoneNumber).Msg("Login failed: user not found")
-		return nil, fmt.Errorf(constants.ErrInvalidCredentials)
+		return nil, apperror.ErrInvalidCredentials
 	}
 
 	// Verify password
 	if !password.Verify(user.PasswordHash, req.Password) {
 		logger.Log.Warn().Int("user_id", user.ID).Str("phone", phoneNumber).Msg("Login failed: invalid password")
-		return nil, fmt.Errorf(constants.ErrInvalidCredentials)
+		return nil, apperror.ErrInvalidCredentials
 	}
 
 	// Check if account is suspended
 	if user.Status == entity.StatusSuspended {
 		logger.Log.Warn().Int("user_id", user.ID).Msg("Login attempt on suspended account")
-		return nil, fmt.Errorf(constants.ErrAccountSuspended)
+		return nil, apperror.ErrAccountSuspended
 	}
 
 	// Update last login
@@ -176,16 +183,16 @@ func (s *authService) Login(ctx context.Context, req dto.LoginRequest) (*dto.Log
 	}
 
 	// Generate tokens
-	accessToken, err := jwtPkg.GenerateAccessToken(user.ID, user.Role, string(user.Role))
+	accessToken, err := jwtPkg.GenerateAccessToken(user.ID, user.Role, string(user.Role), user.PreferredLocale)
 	if err != nil {
 		logger.Log.Error().Err(err).Int("user_id", user.ID).Msg("Failed to generate access token")
-		return nil, fmt.Errorf(constants.ErrFailedToGenerateToken+": %w", err)
+		return nil, apperror.Internal(err)
 	}
 
 	refreshToken, err := s.createRefreshToken(ctx, user.ID, string(user.Role), req.DeviceInfo)
 	if err != nil {
 		logger.Log.Error().Err(err).Int("user_id", user.ID).Msg("Failed to generate refresh token")
-		return nil, fmt.Errorf(constants.ErrFailedToGenerateToken+": %w", err)
+		return nil, apperror.Internal(err)
 	}
 
 	// Prepare base response
@@ -243,19 +250,19 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*d
 	token, err := s.refreshTokenRepo.FindByTokenHash(ctx, tokenHash)
 	if err != nil {
 		logger.Log.Warn().Msg("Invalid refresh token provided")
-		return nil, fmt.Errorf(constants.ErrInvalidRefreshToken)
+		return nil, apperror.ErrInvalidRefreshToken
 	}
 
 	// Check if revoked
 	if token.IsRevoked {
 		logger.Log.Warn().Int("token_id", token.ID).Int("user_id", token.UserID).Msg("Attempted to use revoked token")
-		return nil, fmt.Errorf(constants.ErrTokenRevoked)
+		return nil, apperror.ErrTokenRevoked
 	}
 
 	// Check if expired
 	if token.ExpiresAt.Before(time.Now()) {
 		logger.Log.Warn().Int("token_id", token.ID).Int("user_id", token.UserID).Msg("Attempted to use expired token")
-		return nil, fmt.Errorf(constants.ErrTokenExpired)
+		return nil, apperror.ErrTokenExpired
 	}
 
 	// Update last used
@@ -267,15 +274,19 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*d
 	// Find user
 	user, err := s.userRepo.FindByID(ctx, token.UserID)
 	if err != nil {
-		logger.Log.Error().Err(err).Int("user_id", token.UserID).Msg("User not found for valid token")
-		return nil, fmt.Errorf(constants.ErrUserNotFound)
+		logger.Log.Error().Err(err).Int("user_id", token.UserID).Msg("Failed to load user for token refresh")
+		return nil, apperror.Internal(err)
+	}
+	if user == nil {
+		logger.Log.Error().Int("user_id", token.UserID).Msg("User not found for valid token")
+		return nil, apperror.ErrUserNotFound
 	}
 
 	// Generate new access token
-	accessToken, err := jwtPkg.GenerateAccessToken(user.ID, user.Role, token.UserType)
+	accessToken, err := jwtPkg.GenerateAccessToken(user.ID, user.Role, token.UserType, user.PreferredLocale)
 	if err != nil {
 		logger.Log.Error().Err(err).Int("user_id", user.ID).Msg("Failed to generate access token")
-		return nil, fmt.Errorf(constants.ErrFailedToGenerateToken+": %w", err)
+		return nil, apperror.Internal(err)
 	}
 
 	logger.Log.Info().Int("user_id", user.ID).Msg("Token refreshed successfully")
@@ -294,7 +305,7 @@ func (s *authService) Logout(ctx context.Context, refreshToken string) error {
 	token, err := s.refreshTokenRepo.FindByTokenHash(ctx, tokenHash)
 	if err != nil {
 		logger.Log.Warn().Msg("Invalid refresh token on logout")
-		return fmt.Errorf(constants.ErrInvalidRefreshToken)
+		return apperror.ErrInvalidRefreshToken
 	}
 
 	if err := s.refreshTokenRepo.Revoke(ctx, token.ID, constants.RevokeReasonLogout); err != nil {
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/mapper"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// campusDomainPattern accepts "ui.ac.id" or "*.ac.id"
+var campusDomainPattern = regexp.MustCompile(`^(\*\.)?([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,}$`)
+
+// CampusService manages the campus email domain allow-list and enforces the campus
+// eligibility requirements. A user is campus eligible once their verified email is on an
+// allow-listed domain; whether ordering and driving require it is configured per deployment.
+type CampusService interface {
+	AddDomain(ctx context.Context, adminID int, req dto.CreateCampusDomainRequest) (*dto.CampusDomainResponse, error)
+	ListDomains(ctx context.Context) ([]*dto.CampusDomainResponse, error)
+	RemoveDomain(ctx context.Context, domainID int) error
+	CheckCanOrder(ctx context.Context, userID int) error
+	CheckDriverRegistration(ctx context.Context, email string) error
+	CheckCanDrive(ctx context.Context, userID int, profile *entity.DriverProfile) error
+}
+
+type campusService struct {
+	db                 *pgxpool.Pool
+	campusDomainRepo   repository.CampusDomainRepository
+	userRepo           repository.UserRepository
+	requiredForOrders  bool
+	requiredForDrivers bool
+}
+
+func NewCampusService(
+	db *pgxpool.Pool,
+	campusDomainRepo repository.CampusDomainRepository,
+	userRepo repository.UserRepository,
+	requiredForOrders, requiredForDrivers bool,
+) CampusService {
+	return &campusService{
+		db:                 db,
+		campusDomainRepo:   campusDomainRepo,
+		userRepo:           userRepo,
+		requiredForOrders:  requiredForOrders,
+		requiredForDrivers: requiredForDrivers,
+	}
+}
+
+// AddDomain allow-lists a domain and grants eligibility to users already verified on it
+func (s *campusService) AddDomain(ctx context.Context, adminID int, req dto.CreateCampusDomainRequest) (*dto.CampusDomainResponse, error) {
+	pattern := strings.ToLower(strings.TrimSpace(req.Pattern))
+	if !campusDomainPattern.MatchString(pattern) {
+		return nil, apperror.ErrInvalidCampusDomain.WithVars(map[string]string{"pattern": req.Pattern})
+	}
+
+	exists, err := s.campusDomainRepo.ExistsByPattern(ctx, pattern)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if exists {
+		return nil, apperror.ErrCampusDomainExists
+	}
+
+	domain := &entity.CampusDomain{
+		Pattern:   pattern,
+		Name:      strings.TrimSpace(req.Name),
+		CreatedBy: &adminID,
+	}
+	err = database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		campusDomainRepo := s.campusDomainRepo.WithTx(tx)
+		if err := campusDomainRepo.Create(ctx, domain); err != nil {
+			return err
+		}
+		return campusDomainRepo.RefreshEligibility(ctx, nil)
+	})
+	if err != nil {
+		logger.Log.Error().Err(err).Str("pattern", pattern).Msg("Failed to add campus domain")
+		return nil, apperror.Internal(err)
+	}
+
+	logger.Log.Info().Int("domain_id", domain.ID).Str("pattern", pattern).Int("admin_id", adminID).Msg("Campus domain added")
+	return mapper.ToCampusDomainResponse(domain), nil
+}
+
+// ListDomains returns the allow-list
+func (s *campusService) ListDomains(ctx context.Context) ([]*dto.CampusDomainResponse, error) {
+	domains, err := s.campusDomainRepo.List(ctx)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return mapper.ToCampusDomainResponses(domains), nil
+}
+
+// RemoveDomain drops a domain; users verified only through it lose campus eligibility
+func (s *campusService) RemoveDomain(ctx context.Context, domainID int) error {
+	err := database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		campusDomainRepo := s.campusDomainRepo.WithTx(tx)
+		removed, err := campusDomainRepo.Delete(ctx, domainID)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			return apperror.ErrCampusDomainNotFound
+		}
+		return campusDomainRepo.RefreshEligibility(ctx, nil)
+	})
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return err
+		}
+		logger.Log.Error().Err(err).Int("domain_id", domainID).Msg("Failed to remove campus domain")
+		return apperror.Internal(err)
+	}
+
+	logger.Log.Info().Int("domain_id", domainID).Msg("Campus domain removed")
+	return nil
+}
+
+// CheckCanOrder rejects passengers without a verified campus email when orders require one
+func (s *campusService) CheckCanOrder(ctx context.Context, userID int) error {
+	if !s.requiredForOrders {
+		return nil
+	}
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if user == nil {
+		return apperror.ErrUserNotFound
+	}
+	if !user.CampusEligible {
+		return apperror.ErrCampusVerificationRequired
+	}
+	return nil
+}
+
+// CheckDriverRegistration requires an allow-listed campus email at sign-up when drivers
+// must be campus members; the address is verified after registration
+func (s *campusService) CheckDriverRegistration(ctx context.Context, email string) error {
+	if !s.requiredForDrivers {
+		return nil
+	}
+	if email == "" {
+		return apperror.ErrCampusEmailRequired
+	}
+	matches, err := s.campusDomainRepo.MatchesEmail(ctx, email)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if !matches {
+		return apperror.ErrCampusEmailRequired
+	}
+	return nil
+}
+
+// CheckCanDrive requires a verified campus email and a KTM on file before a driver goes
+// online, when drivers must be campus members
+func (s *campusService) CheckCanDrive(ctx context.Context, userID int, profile *entity.DriverProfile) error {
+	if !s.requiredForDrivers {
+		return nil
+	}
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if user == nil {
+		return apperror.ErrUserNotFound
+	}
+	if !user.CampusEligible {
+		return apperror.ErrCampusVerificationRequired
+	}
+	if profile.KTMPhoto == nil || *profile.KTMPhoto == "" {
+		return apperror.ErrKTMRequired
+	}
+	return nil
+}
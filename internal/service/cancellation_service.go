@@ -0,0 +1,541 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/mapper"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/cancellation"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/clock"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/jobqueue"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/push"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/realtime"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/schedule"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// CancellationService applies the cancellation policy. Passengers and drivers cancel with
+// a reason code; late passenger cancellations and no-shows carry a fee paid to the driver
+// from the wallet or as debt, and users whose rolling cancellation rate crosses the
+// threshold are paused for a while.
+type CancellationService interface {
+	GetReasons(userType string) *dto.CancelReasonsResponse
+	CancelByPassenger(ctx context.Context, passengerID, orderID int, req dto.CancelOrderRequest) (*dto.CancellationResponse, error)
+	CancelByDriver(ctx context.Context, driverID, orderID int, req dto.CancelOrderRequest) (*dto.CancellationResponse, error)
+	RecordTx(ctx context.Context, tx pgx.Tx, order *entity.Order, record *entity.Cancellation) error
+	CheckCanOrder(ctx context.Context, passengerID int) error
+	CheckCanDrive(ctx context.Context, driverID int) error
+	ListCooldowns(ctx context.Context, limit, offset int) ([]*dto.CooldownResponse, error)
+	LiftCooldown(ctx context.Context, userID int) error
+}
+
+type cancellationService struct {
+	db                  *pgxpool.Pool
+	clock               clock.Clock
+	policy              cancellation.Policy
+	schedulePolicy      schedule.Policy
+	cancellationRepo    repository.CancellationRepository
+	orderRepo           repository.OrderRepository
+	offerRepo           repository.DispatchOfferRepository
+	driverRepo          repository.DriverRepository
+	passengerRepo       repository.PassengerRepository
+	notificationService NotificationService
+	walletService       WalletService
+	promoService        PromoService
+	publisher           realtime.Publisher
+}
+
+func NewCancellationService(
+	db *pgxpool.Pool,
+	clk clock.Clock,
+	policy cancellation.Policy,
+	schedulePolicy schedule.Policy,
+	cancellationRepo repository.CancellationRepository,
+	orderRepo repository.OrderRepository,
+	offerRepo repository.DispatchOfferRepository,
+	driverRepo repository.DriverRepository,
+	passengerRepo repository.PassengerRepository,
+	notificationService NotificationService,
+	walletService WalletService,
+	promoService PromoService,
+	publisher realtime.Publisher,
+) CancellationService {
+	return &cancellationService{
+		db:                  db,
+		clock:               clk,
+		policy:              policy.WithDefaults(),
+		schedulePolicy:      schedulePolicy.WithDefaults(),
+		cancellationRepo:    cancellationRepo,
+		orderRepo:           orderRepo,
+		offerRepo:           offerRepo,
+		driverRepo:          driverRepo,
+		passengerRepo:       passengerRepo,
+		notificationService: notificationService,
+		walletService:       walletService,
+		promoService:        promoService,
+		publisher:           publisher,
+	}
+}
+
+// GetReasons returns the reason codes the user can give when cancelling
+func (s *cancellationService) GetReasons(userType string) *dto.CancelReasonsResponse {
+	return &dto.CancelReasonsResponse{Reasons: cancelReasonsFor(entity.UserRole(userType))}
+}
+
+// CancelByPassenger cancels an order that has not started. Scheduled rides follow the
+// scheduled free-cancel window; once a driver is assigned the cancellation policy decides
+// the fee and whether it counts.
+func (s *cancellationService) CancelByPassenger(ctx context.Context, passengerID, orderID int, req dto.CancelOrderRequest) (*dto.CancellationResponse, error) {
+	if err := checkCancelReason(entity.RolePassenger, req); err != nil {
+		return nil, err
+	}
+
+	var order *entity.Order
+	var record *entity.Cancellation
+
+	err := database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		var err error
+		order, err = s.orderRepo.WithTx(tx).FindByIDForUpdate(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil || order.PassengerID != passengerID {
+			return apperror.ErrOrderNotFound
+		}
+
+		now := s.clock.Now()
+		var outcome cancellation.Outcome
+		switch order.Status {
+		case entity.OrderStatusScheduled:
+			outcome.Counted = s.schedulePolicy.IsLateCancel(now, *order.ScheduledAt)
+		case entity.OrderStatusSearching:
+			if err := s.closePendingOffers(ctx, tx, order.ID); err != nil {
+				return err
+			}
+		case entity.OrderStatusAccepted:
+			outcome = s.policy.PassengerCancel(cancellation.StageAssigned, order.AcceptedAt, now)
+		case entity.OrderStatusArrived:
+			outcome = s.policy.PassengerCancel(cancellation.StageArrived, order.AcceptedAt, now)
+		default:
+			return apperror.ErrInvalidOrderStatus
+		}
+
+		record = &entity.Cancellation{
+			OrderID:     order.ID,
+			CancelledBy: passengerID,
+			Role:        entity.RolePassenger,
+			ReasonCode:  req.ReasonCode,
+			Note:        req.Note,
+			OrderStatus: order.Status,
+		}
+		if outcome.Counted {
+			record.ChargedUserID = &passengerID
+		}
+
+		if err := s.closeOrder(ctx, tx, order, record, outcome.Fee, now); err != nil {
+			return err
+		}
+
+		if order.DriverID != nil {
+			if err := s.notificationService.NotifyUserTx(ctx, tx, *order.DriverID, push.TemplateOrderCancelled, map[string]string{
+				"order_id": strconv.Itoa(order.ID),
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return nil, err
+		}
+		logger.Log.Error().Err(err).Int("order_id", orderID).Int("passenger_id", passengerID).Msg("Failed to cancel order")
+		return nil, apperror.Internal(err)
+	}
+
+	logger.Log.Info().
+		Int("order_id", orderID).
+		Int("passenger_id", passengerID).
+		Str("reason", record.ReasonCode).
+		Int("fee", record.Fee).
+		Bool("counted", record.ChargedUserID != nil).
+		Msg("Order cancelled by passenger")
+
+	return mapper.ToCancellationResponse(order, record, record.ChargedUserID != nil), nil
+}
+
+// CancelByDriver drops an accepted order. A no-show, reported after waiting at the pickup,
+// cancels the order and charges the passenger; any other reason counts against the
+// driver and sends the order back to dispatch.
+func (s *cancellationService) CancelByDriver(ctx context.Context, driverID, orderID int, req dto.CancelOrderRequest) (*dto.CancellationResponse, error) {
+	if err := checkCancelReason(entity.RoleDriver, req); err != nil {
+		return nil, err
+	}
+	noShow := req.ReasonCode == constants.CancelReasonNoShow
+
+	var order *entity.Order
+	var record *entity.Cancellation
+
+	err := database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		var err error
+		order, err = s.orderRepo.WithTx(tx).FindByIDForUpdate(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil || order.DriverID == nil || *order.DriverID != driverID {
+			return apperror.ErrOrderNotFound
+		}
+
+		var stage cancellation.Stage
+		switch order.Status {
+		case entity.OrderStatusAccepted:
+			stage = cancellation.StageAssigned
+		case entity.OrderStatusArrived:
+			stage = cancellation.StageArrived
+		default:
+			return apperror.ErrInvalidOrderStatus
+		}
+
+		now := s.clock.Now()
+		outcome, err := s.policy.DriverCancel(stage, noShow, order.ArrivedAt, now)
+		if errors.Is(err, cancellation.ErrNoShowTooEarly) {
+			return apperror.ErrNoShowTooEarly.WithVars(map[string]string{
+				"minutes": strconv.Itoa(int(s.policy.NoShowWait.Minutes())),
+			})
+		}
+
+		record = &entity.Cancellation{
+			OrderID:     order.ID,
+			CancelledBy: driverID,
+			Role:        entity.RoleDriver,
+			ReasonCode:  req.ReasonCode,
+			Note:        req.Note,
+			OrderStatus: order.Status,
+		}
+
+		if noShow {
+			record.ChargedUserID = &order.PassengerID
+			if err := s.closeOrder(ctx, tx, order, record, outcome.Fee, now); err != nil {
+				return err
+			}
+			return s.notificationService.NotifyUserTx(ctx, tx, order.PassengerID, push.TemplatePassengerNoShow, map[string]string{
+				"order_id": strconv.Itoa(order.ID),
+				"fee":      strconv.Itoa(record.Fee),
+			})
+		}
+
+		record.ChargedUserID = &driverID
+		return s.redispatch(ctx, tx, order, record)
+	})
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return nil, err
+		}
+		logger.Log.Error().Err(err).Int("order_id", orderID).Int("driver_id", driverID).Msg("Failed to cancel order")
+		return nil, apperror.Internal(err)
+	}
+
+	logger.Log.Info().
+		Int("order_id", orderID).
+		Int("driver_id", driverID).
+		Str("reason", record.ReasonCode).
+		Int("fee", record.Fee).
+		Str("status", string(order.Status)).
+		Msg("Order cancelled by driver")
+
+	return mapper.ToCancellationResponse(order, record, !noShow), nil
+}
+
+// RecordTx saves a cancellation. When it counts against a user, their profile counter is
+// bumped and they are paused if their rolling cancellation rate crossed the threshold.
+func (s *cancellationService) RecordTx(ctx context.Context, tx pgx.Tx, order *entity.Order, record *entity.Cancellation) error {
+	if err := s.cancellationRepo.WithTx(tx).Create(ctx, record); err != nil {
+		return fmt.Errorf("failed to record cancellation: %w", err)
+	}
+	if record.ChargedUserID == nil {
+		return nil
+	}
+
+	userID := *record.ChargedUserID
+	role := entity.RolePassenger
+	if userID != order.PassengerID {
+		role = entity.RoleDriver
+	}
+
+	if role == entity.RoleDriver {
+		if err := s.driverRepo.WithTx(tx).IncrementCancelledOrders(ctx, userID); err != nil {
+			return err
+		}
+	} else {
+		if err := s.passengerRepo.WithTx(tx).IncrementCancellations(ctx, userID); err != nil {
+			return err
+		}
+	}
+	return s.enforceRateTx(ctx, tx, userID, role)
+}
+
+// CheckCanOrder rejects passengers who are cooling down or still owe cancellation fees
+func (s *cancellationService) CheckCanOrder(ctx context.Context, passengerID int) error {
+	if err := s.checkCooldown(ctx, passengerID, apperror.ErrOrderingCooldown); err != nil {
+		return err
+	}
+
+	debt, err := s.walletService.OutstandingDebt(ctx, passengerID)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if debt > 0 {
+		return apperror.ErrOutstandingFee.WithVars(map[string]string{
+			"amount": strconv.FormatInt(debt, 10),
+		})
+	}
+	return nil
+}
+
+// CheckCanDrive rejects drivers who are cooling down
+func (s *cancellationService) CheckCanDrive(ctx context.Context, driverID int) error {
+	return s.checkCooldown(ctx, driverID, apperror.ErrDrivingCooldown)
+}
+
+// ListCooldowns returns the users currently paused (admin)
+func (s *cancellationService) ListCooldowns(ctx context.Context, limit, offset int) ([]*dto.CooldownResponse, error) {
+	cooldowns, err := s.cancellationRepo.FindActiveCooldowns(ctx, s.clock.Now(), limit, offset)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return mapper.ToCooldownResponses(cooldowns), nil
+}
+
+// LiftCooldown ends a user's cooldown early (admin)
+func (s *cancellationService) LiftCooldown(ctx context.Context, userID int) error {
+	lifted, err := s.cancellationRepo.DeleteCooldown(ctx, userID)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if !lifted {
+		return apperror.ErrCooldownNotFound
+	}
+
+	logger.Log.Info().Int("user_id", userID).Msg("Cancellation cooldown lifted")
+	return nil
+}
+
+// closeOrder cancels the order, releases its promo, charges any fee and records the
+// cancellation, publishing the new status in the same transaction
+func (s *cancellationService) closeOrder(ctx context.Context, tx pgx.Tx, order *entity.Order, record *entity.Cancellation, fee int64, now time.Time) error {
+	if err := s.orderRepo.WithTx(tx).MarkCancelled(ctx, order.ID, now); err != nil {
+		return err
+	}
+	order.Status = entity.OrderStatusCancelled
+	order.CancelledAt = &now
+
+	if err := s.promoService.ReleaseTx(ctx, tx, order.ID); err != nil {
+		return fmt.Errorf("failed to release promo: %w", err)
+	}
+
+	if fee > 0 {
+		settlement, err := s.walletService.ChargeCancellationFeeTx(ctx, tx, order, fee)
+		if err != nil {
+			return fmt.Errorf("failed to charge cancellation fee: %w", err)
+		}
+		record.Fee = int(fee)
+		record.FeeSettledBy = &settlement
+	}
+
+	if err := s.RecordTx(ctx, tx, order, record); err != nil {
+		return err
+	}
+	if err := publishOrderStatus(ctx, s.publisher, tx, order); err != nil {
+		return err
+	}
+
+	return jobqueue.WriteEvent(ctx, tx, constants.AggregateOrder, strconv.Itoa(order.ID), constants.EventOrderCancelled, map[string]any{
+		"order_id":       order.ID,
+		"passenger_id":   order.PassengerID,
+		"driver_id":      order.DriverID,
+		"cancelled_by":   record.Role,
+		"reason_code":    record.ReasonCode,
+		"status":         record.OrderStatus,
+		"scheduled_at":   order.ScheduledAt,
+		"fee":            record.Fee,
+		"fee_settled_by": record.FeeSettledBy,
+		"counted":        record.ChargedUserID != nil,
+	})
+}
+
+// redispatch takes the order back from a driver who cancelled and queues the next
+// dispatch attempt. The driver's accepted offer keeps them out of the new search.
+func (s *cancellationService) redispatch(ctx context.Context, tx pgx.Tx, order *entity.Order, record *entity.Cancellation) error {
+	driverID := *order.DriverID
+	if err := s.RecordTx(ctx, tx, order, record); err != nil {
+		return err
+	}
+
+	offers, err := s.offerRepo.WithTx(tx).FindByOrderID(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.orderRepo.WithTx(tx).MarkSearching(ctx, order.ID); err != nil {
+		return err
+	}
+	order.Status = entity.OrderStatusSearching
+	order.DriverID = nil
+	order.AcceptedAt = nil
+	order.ArrivedAt = nil
+
+	if err := s.notificationService.NotifyUserTx(ctx, tx, order.PassengerID, push.TemplateDriverCancelled, map[string]string{
+		"order_id": strconv.Itoa(order.ID),
+	}); err != nil {
+		return err
+	}
+	if err := publishOrderStatus(ctx, s.publisher, tx, order); err != nil {
+		return err
+	}
+	if err := jobqueue.WriteEvent(ctx, tx, constants.AggregateOrder, strconv.Itoa(order.ID), constants.EventOrderDriverCancelled, map[string]any{
+		"order_id":    order.ID,
+		"driver_id":   driverID,
+		"reason_code": record.ReasonCode,
+		"status":      record.OrderStatus,
+	}); err != nil {
+		return err
+	}
+	return enqueueDispatch(ctx, tx, order.ID, len(offers)+1)
+}
+
+// closePendingOffers withdraws the open dispatch offer of an order cancelled while searching
+func (s *cancellationService) closePendingOffers(ctx context.Context, tx pgx.Tx, orderID int) error {
+	offerRepo := s.offerRepo.WithTx(tx)
+
+	offers, err := offerRepo.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	for _, offer := range offers {
+		if offer.Status != entity.OfferStatusPending {
+			continue
+		}
+		if err := offerRepo.UpdateStatus(ctx, offer.ID, entity.OfferStatusCancelled, s.clock.Now()); err != nil {
+			return err
+		}
+		if err := s.publisher.Publish(ctx, tx, realtime.UserTopic(offer.DriverID), constants.RealtimeDispatchOfferGone, map[string]any{
+			"offer_id": offer.ID,
+			"order_id": offer.OrderID,
+			"reason":   entity.OfferStatusCancelled,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enforceRateTx pauses a user whose counted cancellations over the rolling window cross
+// the threshold. Drivers are also taken offline.
+func (s *cancellationService) enforceRateTx(ctx context.Context, tx pgx.Tx, userID int, role entity.UserRole) error {
+	repo := s.cancellationRepo.WithTx(tx)
+	now := s.clock.Now()
+
+	current, err := repo.FindCooldown(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if current != nil && current.IsActive(now) {
+		return nil
+	}
+
+	cancelled, orders, err := repo.CountRate(ctx, userID, role, now.Add(-s.policy.RateWindow))
+	if err != nil {
+		return err
+	}
+	driver := role == entity.RoleDriver
+	if !s.policy.Exceeds(cancelled, orders, s.policy.MaxRate(driver)) {
+		return nil
+	}
+
+	cooldown := &entity.UserCooldown{
+		UserID:        userID,
+		Role:          role,
+		Until:         now.Add(s.policy.Cooldown(driver)),
+		Cancellations: cancelled,
+		Orders:        orders,
+	}
+	if err := repo.UpsertCooldown(ctx, cooldown); err != nil {
+		return err
+	}
+
+	template := push.TemplateOrderingCooldown
+	if driver {
+		if err := s.driverRepo.WithTx(tx).SetOnline(ctx, userID, false); err != nil {
+			return err
+		}
+		template = push.TemplateDrivingCooldown
+	}
+	if err := s.notificationService.NotifyUserTx(ctx, tx, userID, template, map[string]string{
+		"cancelled": strconv.Itoa(cancelled),
+		"orders":    strconv.Itoa(orders),
+		"until":     formatLocalTime(cooldown.Until),
+	}); err != nil {
+		return err
+	}
+
+	logger.Log.Warn().
+		Int("user_id", userID).
+		Str("role", string(role)).
+		Int("cancelled", cancelled).
+		Int("orders", orders).
+		Time("until", cooldown.Until).
+		Msg("Cancellation rate crossed the threshold, cooldown started")
+
+	return jobqueue.WriteEvent(ctx, tx, constants.AggregateUser, strconv.Itoa(userID), constants.EventUserCooldownStarted, map[string]any{
+		"user_id":       userID,
+		"role":          role,
+		"until":         cooldown.Until,
+		"cancellations": cancelled,
+		"orders":        orders,
+	})
+}
+
+// checkCooldown returns denied, with the cooldown's end filled in, while the user is paused
+func (s *cancellationService) checkCooldown(ctx context.Context, userID int, denied *apperror.Error) error {
+	cooldown, err := s.cancellationRepo.FindCooldown(ctx, userID)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if cooldown == nil || !cooldown.IsActive(s.clock.Now()) {
+		return nil
+	}
+	return denied.WithVars(map[string]string{
+		"until": formatLocalTime(cooldown.Until),
+	})
+}
+
+// checkCancelReason validates the reason code for the role; OTHER needs a note
+func checkCancelReason(role entity.UserRole, req dto.CancelOrderRequest) error {
+	if !slices.Contains(cancelReasonsFor(role), req.ReasonCode) {
+		return apperror.ErrInvalidCancelReason
+	}
+	if req.ReasonCode == constants.CancelReasonOther && (req.Note == nil || *req.Note == "") {
+		return apperror.ErrCancelNoteRequired
+	}
+	return nil
+}
+
+// cancelReasonsFor returns the reason codes a user of the given role may give
+func cancelReasonsFor(role entity.UserRole) []string {
+	if role == entity.RoleDriver {
+		return constants.DriverCancelReasons
+	}
+	return constants.PassengerCancelReasons
+}
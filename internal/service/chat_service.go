@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/mapper"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/clock"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/i18n"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/jobqueue"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/profanity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/realtime"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ChatService runs the in-ride chat between a passenger and their driver, so pickups can
+// be coordinated without sharing phone numbers. The chat is open while a driver is
+// assigned and the order is active; messages are delivered on the order's realtime topic
+// and kept for the retention period afterwards for dispute handling.
+type ChatService interface {
+	GetQuickReplies(ctx context.Context, userType string) *dto.QuickRepliesResponse
+	ListMessages(ctx context.Context, userID int, userType string, orderID int, afterID int64, limit int) (*dto.ChatResponse, error)
+	SendMessage(ctx context.Context, userID, orderID int, req dto.SendChatMessageRequest) (*dto.ChatMessageResponse, error)
+	MarkRead(ctx context.Context, userID, orderID int, req dto.MarkChatReadRequest) (*dto.ChatReadResponse, error)
+	SchedulePurge(ctx context.Context) error
+	HandlePurgeJob(ctx context.Context, job *jobqueue.Job) error
+}
+
+type chatService struct {
+	db        *pgxpool.Pool
+	clock     clock.Clock
+	retention time.Duration
+	chatRepo  repository.ChatRepository
+	orderRepo repository.OrderRepository
+	publisher realtime.Publisher
+}
+
+// chatPurgePayload is the payload of the chat.purge job (one per day)
+type chatPurgePayload struct {
+	Date string `json:"date"`
+}
+
+func NewChatService(
+	db *pgxpool.Pool,
+	clk clock.Clock,
+	retention time.Duration,
+	chatRepo repository.ChatRepository,
+	orderRepo repository.OrderRepository,
+	publisher realtime.Publisher,
+) ChatService {
+	return &chatService{
+		db:        db,
+		clock:     clk,
+		retention: retention,
+		chatRepo:  chatRepo,
+		orderRepo: orderRepo,
+		publisher: publisher,
+	}
+}
+
+// GetQuickReplies returns the quick replies for the caller's role in the request language
+func (s *chatService) GetQuickReplies(ctx context.Context, userType string) *dto.QuickRepliesResponse {
+	locale := i18n.FromContext(ctx)
+	codes := quickRepliesFor(entity.UserRole(userType))
+
+	replies := make([]dto.QuickReplyResponse, 0, len(codes))
+	for _, code := range codes {
+		replies = append(replies, dto.QuickReplyResponse{Code: code, Text: quickReplyText(locale, code)})
+	}
+	return &dto.QuickRepliesResponse{QuickReplies: replies}
+}
+
+// ListMessages returns the order's messages after afterID, oldest first. Participants
+// can read their chat until it is purged; admins can read any chat for disputes.
+func (s *chatService) ListMessages(ctx context.Context, userID int, userType string, orderID int, afterID int64, limit int) (*dto.ChatResponse, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if order == nil || (userType != string(entity.RoleAdmin) && !isOrderParticipant(order, userID)) {
+		return nil, apperror.ErrOrderNotFound
+	}
+
+	messages, err := s.chatRepo.FindByOrderID(ctx, order.ID, afterID, limit)
+	if err != nil {
+		logger.Log.Error().Err(err).Int("order_id", order.ID).Msg("Failed to list chat messages")
+		return nil, apperror.Internal(err)
+	}
+
+	return &dto.ChatResponse{
+		OrderID:  order.ID,
+		Open:     isTripInProgress(order),
+		Messages: mapper.ToChatMessageResponses(messages),
+	}, nil
+}
+
+// SendMessage posts a message to the order's chat. Quick replies are rendered in the
+// sender's language; free text has profanity masked before it is stored.
+func (s *chatService) SendMessage(ctx context.Context, userID, orderID int, req dto.SendChatMessageRequest) (*dto.ChatMessageResponse, error) {
+	body := strings.TrimSpace(req.Body)
+	if (body == "") == (req.QuickReply == "") {
+		return nil, apperror.ErrInvalidChatMessage
+	}
+
+	var message *entity.ChatMessage
+	err := database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		// Locking the order serializes messages with the order ending, so nothing is
+		// accepted once it has completed or been cancelled
+		order, err := s.orderRepo.WithTx(tx).FindByIDForUpdate(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil || !isOrderParticipant(order, userID) {
+			return apperror.ErrOrderNotFound
+		}
+		if !isTripInProgress(order) {
+			return apperror.ErrChatClosed
+		}
+
+		role := entity.RolePassenger
+		if userID != order.PassengerID {
+			role = entity.RoleDriver
+		}
+		message = &entity.ChatMessage{OrderID: order.ID, SenderID: userID, SenderRole: role}
+
+		if req.QuickReply != "" {
+			if !slices.Contains(quickRepliesFor(role), req.QuickReply) {
+				return apperror.ErrInvalidQuickReply
+			}
+			code := req.QuickReply
+			message.QuickReply = &code
+			message.Body = quickReplyText(i18n.FromContext(ctx), code)
+		} else {
+			message.Body, message.Masked = profanity.Mask(body)
+		}
+
+		if err := s.chatRepo.WithTx(tx).Create(ctx, message); err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, tx, realtime.OrderTopic(order.ID), constants.RealtimeChatMessage, mapper.ToChatMessageResponse(message))
+	})
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return nil, err
+		}
+		logger.Log.Error().Err(err).Int("order_id", orderID).Int("user_id", userID).Msg("Failed to send chat message")
+		return nil, apperror.Internal(err)
+	}
+
+	if message.Masked {
+		logger.Log.Info().Int("order_id", orderID).Int64("message_id", message.ID).Msg("Chat message masked")
+	}
+	return mapper.ToChatMessageResponse(message), nil
+}
+
+// MarkRead records that the user has read the messages they received up to the given
+// id and sends the read receipt to the other participant
+func (s *chatService) MarkRead(ctx context.Context, userID, orderID int, req dto.MarkChatReadRequest) (*dto.ChatReadResponse, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if order == nil || !isOrderParticipant(order, userID) {
+		return nil, apperror.ErrOrderNotFound
+	}
+
+	receipt := &dto.ChatReadResponse{
+		OrderID:       order.ID,
+		ReaderID:      userID,
+		LastMessageID: req.LastMessageID,
+		ReadAt:        s.clock.Now(),
+	}
+	err = database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		updated, err := s.chatRepo.WithTx(tx).MarkRead(ctx, order.ID, userID, req.LastMessageID, receipt.ReadAt)
+		if err != nil || updated == 0 {
+			return err
+		}
+		receipt.Updated = updated
+		return s.publisher.Publish(ctx, tx, realtime.OrderTopic(order.ID), constants.RealtimeChatRead, receipt)
+	})
+	if err != nil {
+		logger.Log.Error().Err(err).Int("order_id", order.ID).Int("user_id", userID).Msg("Failed to mark chat as read")
+		return nil, apperror.Internal(err)
+	}
+	return receipt, nil
+}
+
+// SchedulePurge queues today's retention purge; each purge queues the next day's, so
+// calling this at startup keeps the daily chain alive
+func (s *chatService) SchedulePurge(ctx context.Context) error {
+	return s.enqueuePurge(ctx, s.clock.Now())
+}
+
+// HandlePurgeJob deletes chat messages older than the retention period in batches
+func (s *chatService) HandlePurgeJob(ctx context.Context, job *jobqueue.Job) error {
+	now := s.clock.Now()
+	if err := s.enqueuePurge(ctx, now.AddDate(0, 0, 1)); err != nil {
+		return fmt.Errorf("failed to schedule next chat purge: %w", err)
+	}
+
+	before := now.Add(-s.retention)
+	var total int64
+	for {
+		deleted, err := s.chatRepo.DeleteBefore(ctx, before, constants.ChatPurgeBatch)
+		if err != nil {
+			return fmt.Errorf("failed to purge chat messages: %w", err)
+		}
+		total += deleted
+		if deleted < constants.ChatPurgeBatch {
+			break
+		}
+	}
+
+	logger.Log.Info().Int64("deleted", total).Time("before", before).Msg("Chat retention purge finished")
+	return nil
+}
+
+// enqueuePurge queues the purge of day's date at the purge hour; the date in the
+// idempotency key makes repeated calls for the same day a no-op
+func (s *chatService) enqueuePurge(ctx context.Context, day time.Time) error {
+	date := day.Format(time.DateOnly)
+	y, m, d := day.Date()
+	_, err := jobqueue.Enqueue(ctx, s.db, jobqueue.NewJob{
+		Type:           constants.JobTypeChatPurge,
+		Payload:        chatPurgePayload{Date: date},
+		IdempotencyKey: constants.JobTypeChatPurge + ":" + date,
+		RunAt:          time.Date(y, m, d, constants.ChatPurgeHour, 0, 0, 0, day.Location()),
+	})
+	return err
+}
+
+func quickRepliesFor(role entity.UserRole) []string {
+	if role == entity.RoleDriver {
+		return constants.DriverQuickReplies
+	}
+	return constants.PassengerQuickReplies
+}
+
+func quickReplyText(locale i18n.Locale, code string) string {
+	return i18n.T(locale, "chat.quick_reply."+strings.ToLower(code), nil)
+}
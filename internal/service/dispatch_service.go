@@ -0,0 +1,483 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/mapper"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/clock"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/dispatch"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/geo"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/jobqueue"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/push"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/realtime"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DispatchService offers searching orders to drivers one at a time. Every step runs as a job:
+// dispatch.order makes the next offer (or expires the order) and dispatch.offer_timeout,
+// scheduled at the end of the accept window, cascades to the next driver.
+type DispatchService interface {
+	HandleDispatchJob(ctx context.Context, job *jobqueue.Job) error
+	HandleOfferTimeoutJob(ctx context.Context, job *jobqueue.Job) error
+	GetPendingOffer(ctx context.Context, driverID int) (*dto.DispatchOfferResponse, error)
+	AcceptOffer(ctx context.Context, driverID, offerID int) (*dto.OrderResponse, error)
+	DeclineOffer(ctx context.Context, driverID, offerID int) error
+	ListOrderOffers(ctx context.Context, orderID int) ([]*dto.DispatchOfferLogResponse, error)
+}
+
+// dispatchOrderPayload is the payload of the dispatch.order job
+type dispatchOrderPayload struct {
+	OrderID int `json:"order_id"`
+}
+
+// offerTimeoutPayload is the payload of the dispatch.offer_timeout job
+type offerTimeoutPayload struct {
+	OfferID int `json:"offer_id"`
+}
+
+// activeDriverOrderIndex is the unique index allowing a driver one order in progress
+const activeDriverOrderIndex = "idx_orders_active_driver"
+
+type dispatchService struct {
+	db                  *pgxpool.Pool
+	engine              *dispatch.Engine
+	clock               clock.Clock
+	orderRepo           repository.OrderRepository
+	offerRepo           repository.DispatchOfferRepository
+	driverRepo          repository.DriverRepository
+	userRepo            repository.UserRepository
+	notificationService NotificationService
+	promoService        PromoService
+	publisher           realtime.Publisher
+}
+
+func NewDispatchService(
+	db *pgxpool.Pool,
+	engine *dispatch.Engine,
+	clk clock.Clock,
+	orderRepo repository.OrderRepository,
+	offerRepo repository.DispatchOfferRepository,
+	driverRepo repository.DriverRepository,
+	userRepo repository.UserRepository,
+	notificationService NotificationService,
+	promoService PromoService,
+	publisher realtime.Publisher,
+) DispatchService {
+	return &dispatchService{
+		db:                  db,
+		engine:              engine,
+		clock:               clk,
+		orderRepo:           orderRepo,
+		offerRepo:           offerRepo,
+		driverRepo:          driverRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+		promoService:        promoService,
+		publisher:           publisher,
+	}
+}
+
+// HandleDispatchJob rebuilds the order's dispatch state from its recorded offers and applies
+// the engine's decision. The order row stays locked meanwhile, so concurrent triggers serialize.
+func (s *dispatchService) HandleDispatchJob(ctx context.Context, job *jobqueue.Job) error {
+	var payload dispatchOrderPayload
+	if err := job.Decode(&payload); err != nil {
+		return jobqueue.Permanent(fmt.Errorf("invalid dispatch.order payload: %w", err))
+	}
+
+	return database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		order, err := s.orderRepo.WithTx(tx).FindByIDForUpdate(ctx, payload.OrderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return jobqueue.Permanent(fmt.Errorf("order %d not found", payload.OrderID))
+		}
+		if order.Status != entity.OrderStatusSearching {
+			logger.Log.Info().Int("order_id", order.ID).Str("status", string(order.Status)).Msg("Order no longer searching, dispatch stopped")
+			return nil
+		}
+
+		offers, err := s.offerRepo.WithTx(tx).FindByOrderID(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		pickup := geo.Point{Lat: order.PickupLat, Long: order.PickupLong}
+		state := dispatch.State{
+			Pickup:   pickup,
+			Attempts: len(offers),
+			Offered:  make(map[int]bool, len(offers)),
+		}
+		for _, offer := range offers {
+			if offer.Status == entity.OfferStatusPending {
+				// The open offer's timeout (or the driver's answer) continues the cascade
+				return nil
+			}
+			state.Offered[offer.DriverID] = true
+			state.RadiusKm = offer.RadiusKm
+		}
+
+		candidates, err := s.findCandidates(ctx, tx, pickup)
+		if err != nil {
+			return err
+		}
+
+		decision := s.engine.Next(state, candidates)
+		switch decision.Action {
+		case dispatch.ActionOffer:
+			return s.makeOffer(ctx, tx, order, decision)
+		default:
+			return s.expireOrder(ctx, tx, order, decision)
+		}
+	})
+}
+
+// HandleOfferTimeoutJob closes an offer the driver did not answer in time and moves on
+func (s *dispatchService) HandleOfferTimeoutJob(ctx context.Context, job *jobqueue.Job) error {
+	var payload offerTimeoutPayload
+	if err := job.Decode(&payload); err != nil {
+		return jobqueue.Permanent(fmt.Errorf("invalid dispatch.offer_timeout payload: %w", err))
+	}
+
+	return database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		offerRepo := s.offerRepo.WithTx(tx)
+
+		offer, err := offerRepo.FindByIDForUpdate(ctx, payload.OfferID)
+		if err != nil {
+			return err
+		}
+		if offer == nil {
+			return jobqueue.Permanent(fmt.Errorf("dispatch offer %d not found", payload.OfferID))
+		}
+		if offer.Status != entity.OfferStatusPending {
+			return nil
+		}
+
+		if err := offerRepo.UpdateStatus(ctx, offer.ID, entity.OfferStatusTimeout, s.clock.Now()); err != nil {
+			return err
+		}
+		if err := s.publisher.Publish(ctx, tx, realtime.UserTopic(offer.DriverID), constants.RealtimeDispatchOfferGone, map[string]any{
+			"offer_id": offer.ID,
+			"order_id": offer.OrderID,
+			"reason":   entity.OfferStatusTimeout,
+		}); err != nil {
+			return err
+		}
+
+		logger.Log.Info().Int("order_id", offer.OrderID).Int("offer_id", offer.ID).Int("driver_id", offer.DriverID).Msg("Dispatch offer timed out")
+		return enqueueDispatch(ctx, tx, offer.OrderID, offer.Attempt+1)
+	})
+}
+
+// GetPendingOffer returns the driver's open offer
+func (s *dispatchService) GetPendingOffer(ctx context.Context, driverID int) (*dto.DispatchOfferResponse, error) {
+	offer, err := s.offerRepo.FindPendingByDriver(ctx, driverID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if offer == nil || s.engine.IsExpired(offer.ExpiresAt) {
+		return nil, apperror.ErrOfferNotFound
+	}
+
+	order, err := s.orderRepo.FindByID(ctx, offer.OrderID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if order == nil {
+		return nil, apperror.ErrOfferNotFound
+	}
+
+	return mapper.ToDispatchOfferResponse(offer, order, s.clock.Now()), nil
+}
+
+// AcceptOffer assigns the order to the driver when the offer is still open
+func (s *dispatchService) AcceptOffer(ctx context.Context, driverID, offerID int) (*dto.OrderResponse, error) {
+	var order *entity.Order
+
+	err := database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		offerRepo := s.offerRepo.WithTx(tx)
+		orderRepo := s.orderRepo.WithTx(tx)
+
+		// Lock the driver first so two acceptances (or a scheduled handoff) cannot both
+		// find the driver free
+		if err := s.driverRepo.WithTx(tx).LockByUserID(ctx, driverID); err != nil {
+			return fmt.Errorf("failed to lock driver profile: %w", err)
+		}
+
+		offer, err := s.lockDriverOffer(ctx, offerRepo, driverID, offerID)
+		if err != nil {
+			return err
+		}
+
+		active, err := orderRepo.FindActiveByDriver(ctx, driverID)
+		if err != nil {
+			return err
+		}
+		if active != nil {
+			return apperror.ErrDriverBusy
+		}
+
+		order, err = orderRepo.FindByIDForUpdate(ctx, offer.OrderID)
+		if err != nil {
+			return err
+		}
+		if order == nil || order.Status != entity.OrderStatusSearching {
+			return apperror.ErrOfferClosed
+		}
+
+		now := s.clock.Now()
+		if err := offerRepo.UpdateStatus(ctx, offer.ID, entity.OfferStatusAccepted, now); err != nil {
+			return err
+		}
+		if err := orderRepo.Assign(ctx, order.ID, driverID, now); err != nil {
+			if database.IsUniqueViolation(err, activeDriverOrderIndex) {
+				return apperror.ErrDriverBusy
+			}
+			return err
+		}
+		order.Status = entity.OrderStatusAccepted
+		order.DriverID = &driverID
+		order.AcceptedAt = &now
+
+		if err := s.notifyOrderAccepted(ctx, tx, order, driverID); err != nil {
+			return err
+		}
+		if err := publishOrderStatus(ctx, s.publisher, tx, order); err != nil {
+			return err
+		}
+
+		return jobqueue.WriteEvent(ctx, tx, constants.AggregateOrder, strconv.Itoa(order.ID), constants.EventOrderAccepted, map[string]any{
+			"order_id":  order.ID,
+			"driver_id": driverID,
+			"offer_id":  offer.ID,
+			"attempt":   offer.Attempt,
+		})
+	})
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return nil, err
+		}
+		logger.Log.Error().Err(err).Int("offer_id", offerID).Int("driver_id", driverID).Msg("Failed to accept dispatch offer")
+		return nil, apperror.Internal(err)
+	}
+
+	logger.Log.Info().Int("order_id", order.ID).Int("driver_id", driverID).Msg("Order accepted")
+	return mapper.ToOrderResponse(order), nil
+}
+
+// DeclineOffer closes the driver's offer and immediately offers the order to the next driver
+func (s *dispatchService) DeclineOffer(ctx context.Context, driverID, offerID int) error {
+	err := database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		offerRepo := s.offerRepo.WithTx(tx)
+
+		offer, err := s.lockDriverOffer(ctx, offerRepo, driverID, offerID)
+		if err != nil {
+			return err
+		}
+
+		if err := offerRepo.UpdateStatus(ctx, offer.ID, entity.OfferStatusDeclined, s.clock.Now()); err != nil {
+			return err
+		}
+		return enqueueDispatch(ctx, tx, offer.OrderID, offer.Attempt+1)
+	})
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return err
+		}
+		logger.Log.Error().Err(err).Int("offer_id", offerID).Int("driver_id", driverID).Msg("Failed to decline dispatch offer")
+		return apperror.Internal(err)
+	}
+
+	logger.Log.Info().Int("offer_id", offerID).Int("driver_id", driverID).Msg("Dispatch offer declined")
+	return nil
+}
+
+// ListOrderOffers returns the recorded offers of an order (admin view for tuning)
+func (s *dispatchService) ListOrderOffers(ctx context.Context, orderID int) ([]*dto.DispatchOfferLogResponse, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if order == nil {
+		return nil, apperror.ErrOrderNotFound
+	}
+
+	offers, err := s.offerRepo.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return mapper.ToDispatchOfferLogResponses(offers), nil
+}
+
+// lockDriverOffer locks an offer that belongs to the driver and is still open
+func (s *dispatchService) lockDriverOffer(ctx context.Context, offerRepo repository.DispatchOfferRepository, driverID, offerID int) (*entity.DispatchOffer, error) {
+	offer, err := offerRepo.FindByIDForUpdate(ctx, offerID)
+	if err != nil {
+		return nil, err
+	}
+	if offer == nil || offer.DriverID != driverID {
+		return nil, apperror.ErrOfferNotFound
+	}
+	if offer.Status != entity.OfferStatusPending || s.engine.IsExpired(offer.ExpiresAt) {
+		return nil, apperror.ErrOfferClosed
+	}
+	return offer, nil
+}
+
+// findCandidates loads available drivers inside the engine's max radius
+func (s *dispatchService) findCandidates(ctx context.Context, tx pgx.Tx, pickup geo.Point) ([]dispatch.Candidate, error) {
+	now := s.clock.Now()
+	minLat, maxLat, minLong, maxLong := geo.BoundingBox(pickup, s.engine.Config().MaxRadiusKm)
+
+	drivers, err := s.driverRepo.WithTx(tx).FindDispatchCandidates(ctx, entity.CandidateArea{
+		MinLat:        minLat,
+		MaxLat:        maxLat,
+		MinLong:       minLong,
+		MaxLong:       maxLong,
+		LocationSince: now.Add(-constants.DriverLocationMaxAge),
+		StatsSince:    now.Add(-constants.DispatchStatsWindow),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dispatch candidates: %w", err)
+	}
+
+	candidates := make([]dispatch.Candidate, 0, len(drivers))
+	for _, driver := range drivers {
+		candidates = append(candidates, dispatch.Candidate{
+			DriverID:       driver.UserID,
+			Location:       geo.Point{Lat: driver.Lat, Long: driver.Long},
+			Rating:         driver.RatingAvg,
+			OffersAccepted: driver.OffersAccepted,
+			OffersDecided:  driver.OffersDecided,
+		})
+	}
+	return candidates, nil
+}
+
+// makeOffer records the offer, schedules its timeout and notifies the driver
+func (s *dispatchService) makeOffer(ctx context.Context, tx pgx.Tx, order *entity.Order, decision dispatch.Decision) error {
+	offer := &entity.DispatchOffer{
+		OrderID:        order.ID,
+		DriverID:       decision.Driver.DriverID,
+		Attempt:        decision.Attempt,
+		Status:         entity.OfferStatusPending,
+		RadiusKm:       decision.RadiusKm,
+		DistanceKm:     decision.Driver.DistanceKm,
+		Rating:         decision.Driver.Rating,
+		AcceptanceRate: decision.Driver.AcceptanceRate,
+		Score:          decision.Driver.Score,
+		OfferedAt:      decision.OfferedAt,
+		ExpiresAt:      decision.ExpiresAt,
+	}
+	if err := s.offerRepo.WithTx(tx).Create(ctx, offer); err != nil {
+		// Another order's dispatch offered the driver something meanwhile; the job is
+		// retried and picks among the drivers still free
+		return fmt.Errorf("failed to save dispatch offer: %w", err)
+	}
+
+	if _, err := jobqueue.Enqueue(ctx, tx, jobqueue.NewJob{
+		Type:           constants.JobTypeDispatchOfferTimeout,
+		Payload:        offerTimeoutPayload{OfferID: offer.ID},
+		IdempotencyKey: fmt.Sprintf("dispatch-timeout:%d", offer.ID),
+		RunAt:          offer.ExpiresAt,
+	}); err != nil {
+		return err
+	}
+
+	// Live offer for a connected driver app; the push below covers a backgrounded one
+	if err := s.publisher.Publish(ctx, tx, realtime.UserTopic(offer.DriverID), constants.RealtimeDispatchOffer,
+		mapper.ToDispatchOfferResponse(offer, order, decision.OfferedAt)); err != nil {
+		return err
+	}
+
+	timeout := int(s.engine.Config().OfferTimeout.Seconds())
+	if err := s.notificationService.NotifyUserTx(ctx, tx, offer.DriverID, push.TemplateNewOrderOffer, map[string]string{
+		"order_id": strconv.Itoa(order.ID),
+		"offer_id": strconv.Itoa(offer.ID),
+		"pickup":   order.PickupAddress,
+		"distance": strconv.FormatFloat(offer.DistanceKm, 'f', 1, 64),
+		"timeout":  strconv.Itoa(timeout),
+	}); err != nil {
+		return err
+	}
+
+	logger.Log.Info().
+		Int("order_id", order.ID).
+		Int("driver_id", offer.DriverID).
+		Int("attempt", offer.Attempt).
+		Float64("radius_km", offer.RadiusKm).
+		Float64("score", offer.Score).
+		Msg("Order offered to driver")
+
+	return jobqueue.WriteEvent(ctx, tx, constants.AggregateOrder, strconv.Itoa(order.ID), constants.EventOrderOffered, map[string]any{
+		"order_id":   order.ID,
+		"offer_id":   offer.ID,
+		"driver_id":  offer.DriverID,
+		"attempt":    offer.Attempt,
+		"expires_at": offer.ExpiresAt,
+	})
+}
+
+// expireOrder gives up on the order and tells the passenger
+func (s *dispatchService) expireOrder(ctx context.Context, tx pgx.Tx, order *entity.Order, decision dispatch.Decision) error {
+	now := s.clock.Now()
+	if err := s.orderRepo.WithTx(tx).MarkExpired(ctx, order.ID, now); err != nil {
+		return fmt.Errorf("failed to expire order: %w", err)
+	}
+	order.Status = entity.OrderStatusExpired
+	order.ExpiredAt = &now
+
+	if err := s.promoService.ReleaseTx(ctx, tx, order.ID); err != nil {
+		return fmt.Errorf("failed to release promo: %w", err)
+	}
+
+	if err := publishOrderStatus(ctx, s.publisher, tx, order); err != nil {
+		return err
+	}
+
+	if err := s.notificationService.NotifyUserTx(ctx, tx, order.PassengerID, push.TemplateOrderExpired, map[string]string{
+		"order_id": strconv.Itoa(order.ID),
+	}); err != nil {
+		return err
+	}
+
+	logger.Log.Info().Int("order_id", order.ID).Str("reason", decision.Reason).Float64("radius_km", decision.RadiusKm).Msg("Order expired without driver")
+
+	return jobqueue.WriteEvent(ctx, tx, constants.AggregateOrder, strconv.Itoa(order.ID), constants.EventOrderExpired, map[string]any{
+		"order_id":  order.ID,
+		"reason":    decision.Reason,
+		"radius_km": decision.RadiusKm,
+	})
+}
+
+// notifyOrderAccepted tells the passenger who is coming
+func (s *dispatchService) notifyOrderAccepted(ctx context.Context, tx pgx.Tx, order *entity.Order, driverID int) error {
+	driver, err := s.userRepo.FindByID(ctx, driverID)
+	if err != nil {
+		return fmt.Errorf("failed to load driver: %w", err)
+	}
+	if driver == nil {
+		return fmt.Errorf("driver %d not found", driverID)
+	}
+	profile, err := s.driverRepo.WithTx(tx).FindByUserID(ctx, driverID)
+	if err != nil {
+		return fmt.Errorf("failed to load driver profile: %w", err)
+	}
+
+	return s.notificationService.NotifyUserTx(ctx, tx, order.PassengerID, push.TemplateOrderAccepted, map[string]string{
+		"order_id":      strconv.Itoa(order.ID),
+		"driver_name":   driver.FullName,
+		"vehicle_plate": profile.VehiclePlate,
+	})
+}
@@ -0,0 +1,349 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/mapper"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/clock"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/jobqueue"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/push"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DriverReviewService handles the admin review of driver documents and keeps verified
+// drivers' documents current: the SIM, STNK and KTM expiry dates recorded at review are
+// scanned daily, drivers are reminded 30, 7 and 1 days ahead, and drivers whose documents
+// lapse are taken offline and sent back to verification.
+type DriverReviewService interface {
+	ReviewDriver(ctx context.Context, adminID, driverProfileID int, req dto.ReviewDriverRequest) (*dto.DriverReviewResponse, error)
+	ListExpiringDocuments(ctx context.Context, days, limit, offset int) ([]*dto.ExpiringDocumentResponse, error)
+	ScheduleExpiryScan(ctx context.Context) error
+	HandleExpiryScanJob(ctx context.Context, job *jobqueue.Job) error
+}
+
+// expiryScanPayload is the payload of the documents.expiry_scan job (one per day)
+type expiryScanPayload struct {
+	Date string `json:"date"`
+}
+
+type driverReviewService struct {
+	db                  *pgxpool.Pool
+	clock               clock.Clock
+	driverRepo          repository.DriverRepository
+	expiryRepo          repository.DriverDocumentExpiryRepository
+	notificationService NotificationService
+}
+
+func NewDriverReviewService(
+	db *pgxpool.Pool,
+	clk clock.Clock,
+	driverRepo repository.DriverRepository,
+	expiryRepo repository.DriverDocumentExpiryRepository,
+	notificationService NotificationService,
+) DriverReviewService {
+	return &driverReviewService{
+		db:                  db,
+		clock:               clk,
+		driverRepo:          driverRepo,
+		expiryRepo:          expiryRepo,
+		notificationService: notificationService,
+	}
+}
+
+// ReviewDriver approves or rejects a driver's documents, recording the expiry dates given.
+// Approval requires the expiry date of every expiring document; rejection requires a reason
+// and takes the driver offline.
+func (s *driverReviewService) ReviewDriver(ctx context.Context, adminID, driverProfileID int, req dto.ReviewDriverRequest) (*dto.DriverReviewResponse, error) {
+	profile, err := s.driverRepo.FindByID(ctx, driverProfileID)
+	if err != nil {
+		return nil, apperror.ErrDriverProfileNotFound
+	}
+
+	today := s.clock.Now()
+	expiries, err := parseDocumentExpiries(req, today, driverProfileID, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	var reason *string
+	if req.Approve {
+		for _, documentType := range constants.ExpiringDocumentTypes {
+			if !hasDocumentExpiry(expiries, documentType) {
+				return nil, apperror.ErrDocumentExpiryRequired.WithVars(map[string]string{"document": documentType})
+			}
+		}
+	} else {
+		if req.RejectionReason == nil || strings.TrimSpace(*req.RejectionReason) == "" {
+			return nil, apperror.ErrRejectionReasonRequired
+		}
+		trimmed := strings.TrimSpace(*req.RejectionReason)
+		reason = &trimmed
+	}
+
+	err = database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		driverRepo := s.driverRepo.WithTx(tx)
+		expiryRepo := s.expiryRepo.WithTx(tx)
+
+		for _, expiry := range expiries {
+			if err := expiryRepo.Upsert(ctx, expiry); err != nil {
+				return fmt.Errorf("failed to record %s expiry: %w", expiry.DocumentType, err)
+			}
+		}
+		if err := driverRepo.UpdateVerificationStatus(ctx, profile.ID, req.Approve, req.Notes, reason, &adminID); err != nil {
+			return fmt.Errorf("failed to update driver verification: %w", err)
+		}
+		if !req.Approve {
+			if err := driverRepo.SetOnline(ctx, profile.UserID, false); err != nil {
+				return err
+			}
+		}
+
+		if err := jobqueue.WriteEvent(ctx, tx, constants.AggregateDriver, strconv.Itoa(profile.ID), constants.EventDriverReviewed, map[string]any{
+			"driver_profile_id": profile.ID,
+			"user_id":           profile.UserID,
+			"approved":          req.Approve,
+			"reason":            reason,
+			"reviewed_by":       adminID,
+		}); err != nil {
+			return err
+		}
+
+		if req.Approve {
+			return s.notificationService.NotifyUserTx(ctx, tx, profile.UserID, push.TemplateDriverVerified, nil)
+		}
+		return s.notificationService.NotifyUserTx(ctx, tx, profile.UserID, push.TemplateDriverRejected, map[string]string{
+			"reason": *reason,
+		})
+	})
+	if err != nil {
+		logger.Log.Error().Err(err).Int("profile_id", driverProfileID).Msg("Failed to review driver")
+		return nil, apperror.Internal(err)
+	}
+
+	logger.Log.Info().
+		Int("profile_id", driverProfileID).
+		Int("admin_id", adminID).
+		Bool("approved", req.Approve).
+		Msg("Driver reviewed")
+
+	profile, err = s.driverRepo.FindByID(ctx, driverProfileID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	recorded, err := s.expiryRepo.FindByDriverProfileID(ctx, driverProfileID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return &dto.DriverReviewResponse{
+		Profile:          mapper.ToDriverProfileResponse(profile),
+		DocumentExpiries: mapper.ToDocumentExpiryResponses(recorded, today),
+	}, nil
+}
+
+// ListExpiringDocuments returns documents lapsing within the next days, lapsed ones first
+func (s *driverReviewService) ListExpiringDocuments(ctx context.Context, days, limit, offset int) ([]*dto.ExpiringDocumentResponse, error) {
+	today := s.clock.Now()
+	documents, err := s.expiryRepo.FindExpiring(ctx, documentDate(today).AddDate(0, 0, days), limit, offset)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return mapper.ToExpiringDocumentResponses(documents, today), nil
+}
+
+// ScheduleExpiryScan queues today's expiry scan; each scan queues the next day's, so
+// calling this at startup keeps the daily chain alive
+func (s *driverReviewService) ScheduleExpiryScan(ctx context.Context) error {
+	return s.enqueueExpiryScan(ctx, s.clock.Now())
+}
+
+// HandleExpiryScanJob sends due expiry reminders and enforces lapsed documents. Every
+// document is handled in its own transaction and its progress is recorded, so a retried
+// scan only redoes what failed.
+func (s *driverReviewService) HandleExpiryScanJob(ctx context.Context, job *jobqueue.Job) error {
+	now := s.clock.Now()
+	if err := s.enqueueExpiryScan(ctx, now.AddDate(0, 0, 1)); err != nil {
+		return fmt.Errorf("failed to schedule next expiry scan: %w", err)
+	}
+
+	horizon := constants.DocumentExpiryReminderDays[0]
+	documents, err := s.expiryRepo.FindPending(ctx, documentDate(now).AddDate(0, 0, horizon))
+	if err != nil {
+		return fmt.Errorf("failed to load expiring documents: %w", err)
+	}
+
+	var failed error
+	reminded, expired := 0, 0
+	for _, document := range documents {
+		daysLeft := document.DaysLeft(now)
+		if daysLeft < 0 {
+			err = s.expireDocument(ctx, document)
+			if err == nil {
+				expired++
+			}
+		} else {
+			threshold := reminderThreshold(daysLeft)
+			if document.LastReminderDays != nil && *document.LastReminderDays <= threshold {
+				continue
+			}
+			err = s.remindDocument(ctx, document, threshold, daysLeft)
+			if err == nil {
+				reminded++
+			}
+		}
+		if err != nil {
+			logger.Log.Error().
+				Err(err).
+				Int("profile_id", document.DriverProfileID).
+				Str("document", document.DocumentType).
+				Msg("Failed to process expiring document")
+			failed = err
+		}
+	}
+
+	logger.Log.Info().
+		Int("pending", len(documents)).
+		Int("reminded", reminded).
+		Int("expired", expired).
+		Msg("Document expiry scan finished")
+	return failed
+}
+
+// remindDocument warns the driver over push and WhatsApp and records the threshold sent
+func (s *driverReviewService) remindDocument(ctx context.Context, document *entity.ExpiringDocument, threshold, daysLeft int) error {
+	vars := map[string]string{
+		"document": document.DocumentType,
+		"date":     document.ExpiresOn.Format(constants.DocumentExpiryDateLayout),
+		"days":     strconv.Itoa(daysLeft),
+	}
+	return database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		if err := s.expiryRepo.WithTx(tx).MarkReminded(ctx, document.DriverProfileID, document.DocumentType, threshold); err != nil {
+			return err
+		}
+		if err := s.notificationService.NotifyUserTx(ctx, tx, document.UserID, push.TemplateDocumentExpiring, vars); err != nil {
+			return err
+		}
+		return s.notificationService.NotifyUserWhatsAppTx(ctx, tx, document.UserID, push.TemplateDocumentExpiring, vars)
+	})
+}
+
+// expireDocument takes the driver offline, sends a verified driver back to verification
+// and tells them why
+func (s *driverReviewService) expireDocument(ctx context.Context, document *entity.ExpiringDocument) error {
+	expiresOn := document.ExpiresOn.Format(constants.DocumentExpiryDateLayout)
+	vars := map[string]string{
+		"document": document.DocumentType,
+		"date":     expiresOn,
+	}
+	return database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		driverRepo := s.driverRepo.WithTx(tx)
+		if err := driverRepo.SetOnline(ctx, document.UserID, false); err != nil {
+			return err
+		}
+		// Pending or rejected drivers keep their review state
+		if document.IsVerified {
+			notes := fmt.Sprintf("%s expired on %s, re-verification required", document.DocumentType, expiresOn)
+			if err := driverRepo.UpdateVerificationStatus(ctx, document.DriverProfileID, false, &notes, nil, nil); err != nil {
+				return fmt.Errorf("failed to reset driver verification: %w", err)
+			}
+		}
+		if err := s.expiryRepo.WithTx(tx).MarkExpired(ctx, document.DriverProfileID, document.DocumentType); err != nil {
+			return err
+		}
+
+		if err := jobqueue.WriteEvent(ctx, tx, constants.AggregateDriver, strconv.Itoa(document.DriverProfileID), constants.EventDriverDocumentExpired, map[string]any{
+			"driver_profile_id": document.DriverProfileID,
+			"user_id":           document.UserID,
+			"document_type":     document.DocumentType,
+			"expires_on":        expiresOn,
+			"was_verified":      document.IsVerified,
+		}); err != nil {
+			return err
+		}
+
+		if err := s.notificationService.NotifyUserTx(ctx, tx, document.UserID, push.TemplateDocumentExpired, vars); err != nil {
+			return err
+		}
+		return s.notificationService.NotifyUserWhatsAppTx(ctx, tx, document.UserID, push.TemplateDocumentExpired, vars)
+	})
+}
+
+// enqueueExpiryScan queues the scan of day's date at the scan hour; the date in the
+// idempotency key makes repeated calls for the same day a no-op
+func (s *driverReviewService) enqueueExpiryScan(ctx context.Context, day time.Time) error {
+	date := day.Format(constants.DocumentExpiryDateLayout)
+	y, m, d := day.Date()
+	_, err := jobqueue.Enqueue(ctx, s.db, jobqueue.NewJob{
+		Type:           constants.JobTypeDocumentExpiryScan,
+		Payload:        expiryScanPayload{Date: date},
+		IdempotencyKey: constants.JobTypeDocumentExpiryScan + ":" + date,
+		RunAt:          time.Date(y, m, d, constants.DocumentExpiryScanHour, 0, 0, 0, day.Location()),
+	})
+	return err
+}
+
+// reminderThreshold returns the smallest reminder threshold not below daysLeft, e.g.
+// 7 for a document lapsing in 5 days
+func reminderThreshold(daysLeft int) int {
+	threshold := constants.DocumentExpiryReminderDays[0]
+	for _, days := range constants.DocumentExpiryReminderDays {
+		if days >= daysLeft && days < threshold {
+			threshold = days
+		}
+	}
+	return threshold
+}
+
+// parseDocumentExpiries validates the expiry dates of a review; dates must not be in the past
+func parseDocumentExpiries(req dto.ReviewDriverRequest, today time.Time, driverProfileID, adminID int) ([]*entity.DriverDocumentExpiry, error) {
+	dates := map[string]*string{
+		constants.DocumentTypeSIM:  req.SIMExpiresOn,
+		constants.DocumentTypeSTNK: req.STNKExpiresOn,
+		constants.DocumentTypeKTM:  req.KTMExpiresOn,
+	}
+
+	var expiries []*entity.DriverDocumentExpiry
+	for _, documentType := range constants.ExpiringDocumentTypes {
+		value := dates[documentType]
+		if value == nil {
+			continue
+		}
+		expiresOn, err := time.Parse(constants.DocumentExpiryDateLayout, *value)
+		if err != nil || expiresOn.Before(documentDate(today)) {
+			return nil, apperror.ErrInvalidDocumentExpiry.WithVars(map[string]string{"document": documentType})
+		}
+		expiries = append(expiries, &entity.DriverDocumentExpiry{
+			DriverProfileID: driverProfileID,
+			DocumentType:    documentType,
+			ExpiresOn:       expiresOn,
+			RecordedBy:      &adminID,
+		})
+	}
+	return expiries, nil
+}
+
+func hasDocumentExpiry(expiries []*entity.DriverDocumentExpiry, documentType string) bool {
+	for _, expiry := range expiries {
+		if expiry.DocumentType == documentType {
+			return true
+		}
+	}
+	return false
+}
+
+// documentDate returns t's calendar date as UTC midnight, the form DATE columns use
+func documentDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -4,43 +4,83 @@ import (
 	"context"
 	"fmt"
 	"mime/multipart"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
 	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
 	"github.com/AnggaKay/ojek-kampus-backend/internal/mapper"
 	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/geo"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/i18n"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/jobqueue"
 	jwtPkg "github.com/AnggaKay/ojek-kampus-backend/pkg/jwt"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/password"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/realtime"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/storage"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type DriverService interface {
 	RegisterDriver(ctx context.Context, req dto.RegisterDriverRequest, files map[string]*multipart.FileHeader) (*dto.DriverAuthResponse, error)
+	UpdateProfile(ctx context.Context, userID int, req dto.UpdateDriverProfileRequest, stnkFile *multipart.FileHeader) (*dto.DriverProfileResponse, error)
+	UpdateProfilePicture(ctx context.Context, userID int, file *multipart.FileHeader) (*dto.DriverProfileResponse, error)
+	GetProfileChanges(ctx context.Context, driverProfileID, limit, offset int) ([]*dto.DriverProfileChangeResponse, error)
+	UpdateLocation(ctx context.Context, userID int, req dto.UpdateDriverLocationRequest) (*dto.DriverStatusResponse, error)
+	SetOnlineStatus(ctx context.Context, userID int, online bool) (*dto.DriverStatusResponse, error)
 }
 
 type driverService struct {
-	userRepo         repository.UserRepository
-	driverRepo       repository.DriverRepository
-	refreshTokenRepo repository.RefreshTokenRepository
-	fileStorage      storage.FileStorage
-	tokenHelper      *TokenHelper
+	db                  *pgxpool.Pool
+	userRepo            repository.UserRepository
+	driverRepo          repository.DriverRepository
+	profileChangeRepo   repository.DriverProfileChangeRepository
+	refreshTokenRepo    repository.RefreshTokenRepository
+	orderRepo           repository.OrderRepository
+	fileStorage         storage.FileStorage
+	campusService       CampusService
+	geofenceService     GeofenceService
+	cancellationService CancellationService
+	tripRouteService    TripRouteService
+	publisher           realtime.Publisher
+	tokenHelper         *TokenHelper
 }
 
 func NewDriverService(
+	db *pgxpool.Pool,
 	userRepo repository.UserRepository,
 	driverRepo repository.DriverRepository,
+	profileChangeRepo repository.DriverProfileChangeRepository,
 	refreshTokenRepo repository.RefreshTokenRepository,
+	orderRepo repository.OrderRepository,
 	fileStorage storage.FileStorage,
+	campusService CampusService,
+	geofenceService GeofenceService,
+	cancellationService CancellationService,
+	tripRouteService TripRouteService,
+	publisher realtime.Publisher,
 ) DriverService {
 	return &driverService{
-		userRepo:         userRepo,
-		driverRepo:       driverRepo,
-		refreshTokenRepo: refreshTokenRepo,
-		fileStorage:      fileStorage,
-		tokenHelper:      NewTokenHelper(refreshTokenRepo),
+		db:                  db,
+		userRepo:            userRepo,
+		driverRepo:          driverRepo,
+		profileChangeRepo:   profileChangeRepo,
+		refreshTokenRepo:    refreshTokenRepo,
+		orderRepo:           orderRepo,
+		fileStorage:         fileStorage,
+		campusService:       campusService,
+		geofenceService:     geofenceService,
+		cancellationService: cancellationService,
+		tripRouteService:    tripRouteService,
+		publisher:           publisher,
+		tokenHelper:         NewTokenHelper(refreshTokenRepo),
 	}
 }
 
@@ -68,7 +108,7 @@ func (s *driverService) RegisterDriver(
 	}
 	if phoneExists {
 		logger.Log.Warn().Str("phone", phoneNumber).Msg("Phone number already registered")
-		return nil, fmt.Errorf(constants.ErrPhoneAlreadyRegistered)
+		return nil, apperror.ErrPhoneAlreadyRegistered
 	}
 
 	// 4. Check if email already exists (if provided)
@@ -80,11 +120,17 @@ func (s *driverService) RegisterDriver(
 		}
 		if emailExists {
 			logger.Log.Warn().Str("email", req.Email).Msg("Email already registered")
-			return nil, fmt.Errorf(constants.ErrEmailAlreadyRegistered)
+			return nil, apperror.ErrEmailAlreadyRegistered
 		}
 	}
 
-	// 5. Check if vehicle plate already exists
+	// 5. Check campus membership when drivers must be students or staff
+	if err := s.campusService.CheckDriverRegistration(ctx, req.Email); err != nil {
+		logger.Log.Warn().Str("phone", phoneNumber).Msg("Driver registration without campus email")
+		return nil, err
+	}
+
+	// 6. Check if vehicle plate already exists
 	plateExists, err := s.driverRepo.ExistsByVehiclePlate(ctx, req.VehiclePlate)
 	if err != nil {
 		logger.Log.Error().Err(err).Str("plate", req.VehiclePlate).Msg("Failed to check vehicle plate existence")
@@ -92,49 +138,50 @@ func (s *driverService) RegisterDriver(
 	}
 	if plateExists {
 		logger.Log.Warn().Str("plate", req.VehiclePlate).Msg("Vehicle plate already registered")
-		return nil, fmt.Errorf(constants.ErrVehiclePlateExists)
+		return nil, apperror.ErrVehiclePlateExists
 	}
 
-	// 6. Validate all required files are present
+	// 7. Validate all required files are present
 	requiredFiles := []string{"ktp", "sim", "stnk", "ktm"}
 	for _, docType := range requiredFiles {
 		if files[docType] == nil {
 			logger.Log.Warn().Str("doc_type", docType).Msg("Required document missing")
-			return nil, fmt.Errorf("document %s is required", docType)
+			return nil, apperror.ErrMissingFile.WithVars(map[string]string{"field": docType})
 		}
 	}
 
-	// 7. Hash password
+	// 8. Hash password
 	hashedPassword, err := password.Hash(req.Password)
 	if err != nil {
 		logger.Log.Error().Err(err).Msg("Failed to hash password")
-		return nil, fmt.Errorf(constants.ErrFailedToHashPassword+": %w", err)
+		return nil, apperror.Internal(err)
 	}
 
-	// 8. Create user (role=DRIVER)
+	// 9. Create user (role=DRIVER)
 	var emailPtr *string
 	if req.Email != "" {
 		emailPtr = &req.Email
 	}
 
 	user := &entity.User{
-		PhoneNumber:   phoneNumber,
-		PasswordHash:  hashedPassword,
-		Email:         emailPtr,
-		FullName:      req.FullName,
-		Role:          entity.RoleDriver,
-		Status:        entity.StatusActive,
-		PhoneVerified: false,
+		PhoneNumber:     phoneNumber,
+		PasswordHash:    hashedPassword,
+		Email:           emailPtr,
+		FullName:        req.FullName,
+		Role:            entity.RoleDriver,
+		Status:          entity.StatusActive,
+		PhoneVerified:   false,
+		PreferredLocale: string(i18n.FromContext(ctx)),
 	}
 
 	if err := s.userRepo.Create(ctx, user); err != nil {
 		logger.Log.Error().Err(err).Str("phone", phoneNumber).Msg("Failed to create user")
-		return nil, fmt.Errorf(constants.ErrFailedToCreateUser+": %w", err)
+		return nil, apperror.Internal(err)
 	}
 
 	logger.Log.Info().Int("user_id", user.ID).Str("phone", phoneNumber).Msg("Driver user created successfully")
 
-	// 9. Upload documents
+	// 10. Upload documents
 	uploadedDocs := make(map[string]string)
 	var uploadErr error
 
@@ -143,7 +190,7 @@ func (s *driverService) RegisterDriver(
 			continue
 		}
 
-		filePath, err := s.fileStorage.Upload(file, user.ID, docType)
+		filePath, err := s.fileStorage.Upload(file, constants.StorageNamespaceDrivers, user.ID, docType)
 		if err != nil {
 			logger.Log.Error().Err(err).Int("user_id", user.ID).Str("doc_type", docType).Msg("Failed to upload document")
 			uploadErr = err
@@ -159,10 +206,10 @@ func (s *driverService) RegisterDriver(
 		for _, filePath := range uploadedDocs {
 			_ = s.fileStorage.Delete(filePath)
 		}
-		return nil, fmt.Errorf(constants.ErrFailedToUploadFile+": %w", uploadErr)
+		return nil, uploadErr
 	}
 
-	// 10. Create driver profile
+	// 11. Create driver profile
 	driverProfile := &entity.DriverProfile{
 		UserID:       user.ID,
 		VehicleType:  constants.VehicleTypeMotor, // Always MOTOR as specified
@@ -207,26 +254,375 @@ func (s *driverService) RegisterDriver(
 
 	logger.Log.Info().Int("user_id", user.ID).Int("profile_id", driverProfile.ID).Msg("Driver profile created successfully")
 
-	// 11. Generate tokens
-	accessToken, err := jwtPkg.GenerateAccessToken(user.ID, user.Role, string(user.Role))
+	// 12. Generate tokens
+	accessToken, err := jwtPkg.GenerateAccessToken(user.ID, user.Role, string(user.Role), user.PreferredLocale)
 	if err != nil {
 		logger.Log.Error().Err(err).Int("user_id", user.ID).Msg("Failed to generate access token")
-		return nil, fmt.Errorf(constants.ErrFailedToGenerateToken+": %w", err)
+		return nil, apperror.Internal(err)
 	}
 
 	refreshToken, err := s.createRefreshToken(ctx, user.ID, string(user.Role), req.PhoneNumber)
 	if err != nil {
 		logger.Log.Error().Err(err).Int("user_id", user.ID).Msg("Failed to generate refresh token")
-		return nil, fmt.Errorf(constants.ErrFailedToGenerateToken+": %w", err)
+		return nil, apperror.Internal(err)
 	}
 
 	logger.Log.Info().Int("user_id", user.ID).Msg("Driver registration completed successfully")
 
-	// 12. Build response using mapper
+	// 13. Build response using mapper
 	return mapper.BuildDriverAuthResponse(user, driverProfile, accessToken, refreshToken, int(constants.AccessTokenTTL.Seconds())), nil
 }
 
+// UpdateProfile applies a partial vehicle update requested by the driver.
+// Changing the vehicle plate requires a new STNK document and sends the driver back to verification.
+func (s *driverService) UpdateProfile(
+	ctx context.Context,
+	userID int,
+	req dto.UpdateDriverProfileRequest,
+	stnkFile *multipart.FileHeader,
+) (*dto.DriverProfileResponse, error) {
+	logger.Log.Info().Int("user_id", userID).Msg("Driver profile update attempt")
+
+	profile, err := s.driverRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		logger.Log.Warn().Err(err).Int("user_id", userID).Msg("Driver profile not found for update")
+		return nil, apperror.ErrDriverProfileNotFound
+	}
+
+	var changes []*entity.DriverProfileChange
+	recordChange := func(field string, oldValue, newValue *string) {
+		changes = append(changes, &entity.DriverProfileChange{
+			DriverProfileID: profile.ID,
+			Field:           field,
+			OldValue:        oldValue,
+			NewValue:        newValue,
+			ChangedBy:       userID,
+		})
+	}
+
+	// 1. Vehicle plate (requires uniqueness check and STNK re-verification)
+	plateChanged := false
+	var newSTNKPath string
+	if req.VehiclePlate != nil {
+		newPlate := strings.TrimSpace(*req.VehiclePlate)
+		if newPlate != "" && newPlate != profile.VehiclePlate {
+			if stnkFile == nil {
+				logger.Log.Warn().Int("user_id", userID).Msg("Plate change without new STNK document")
+				return nil, apperror.ErrSTNKRequired
+			}
+
+			plateExists, err := s.driverRepo.ExistsByVehiclePlate(ctx, newPlate)
+			if err != nil {
+				logger.Log.Error().Err(err).Str("plate", newPlate).Msg("Failed to check vehicle plate existence")
+				return nil, fmt.Errorf("failed to check vehicle plate availability")
+			}
+			if plateExists {
+				logger.Log.Warn().Str("plate", newPlate).Msg("Vehicle plate already registered")
+				return nil, apperror.ErrVehiclePlateExists
+			}
+
+			newSTNKPath, err = s.fileStorage.Upload(stnkFile, constants.StorageNamespaceDrivers, userID, "stnk")
+			if err != nil {
+				logger.Log.Error().Err(err).Int("user_id", userID).Msg("Failed to upload new STNK document")
+				return nil, err
+			}
+
+			oldPlate := profile.VehiclePlate
+			recordChange(constants.ProfileFieldVehiclePlate, &oldPlate, &newPlate)
+			recordChange(constants.ProfileFieldSTNKPhoto, profile.STNKPhoto, &newSTNKPath)
+
+			profile.VehiclePlate = newPlate
+			profile.STNKPhoto = &newSTNKPath
+			plateChanged = true
+		}
+	}
+
+	// 2. Optional vehicle details
+	if oldValue, newValue, changed := applyOptionalField(&profile.VehicleBrand, req.VehicleBrand); changed {
+		recordChange(constants.ProfileFieldVehicleBrand, oldValue, newValue)
+	}
+	if oldValue, newValue, changed := applyOptionalField(&profile.VehicleModel, req.VehicleModel); changed {
+		recordChange(constants.ProfileFieldVehicleModel, oldValue, newValue)
+	}
+	if oldValue, newValue, changed := applyOptionalField(&profile.VehicleColor, req.VehicleColor); changed {
+		recordChange(constants.ProfileFieldVehicleColor, oldValue, newValue)
+	}
+
+	if len(changes) == 0 {
+		return nil, apperror.ErrNoProfileChanges
+	}
+
+	// 3. Persist profile, verification reset, history and outbox event atomically
+	var notes *string
+	if plateChanged {
+		// New plate means the STNK must be verified again by an admin
+		resetNotes := "Vehicle plate changed, STNK re-verification required"
+		notes = &resetNotes
+	}
+	if err := s.persistProfileUpdate(ctx, profile, changes, notes); err != nil {
+		logger.Log.Error().Err(err).Int("profile_id", profile.ID).Msg("Failed to update driver profile")
+		if newSTNKPath != "" {
+			_ = s.fileStorage.Delete(newSTNKPath)
+		}
+		return nil, fmt.Errorf("failed to update driver profile: %w", err)
+	}
+	if plateChanged {
+		profile.IsVerified = false
+		profile.VerificationNotes = notes
+		profile.RejectionReason = nil
+		profile.VerifiedBy = nil
+		profile.VerifiedAt = nil
+	}
+
+	logger.Log.Info().
+		Int("user_id", userID).
+		Int("profile_id", profile.ID).
+		Int("changes", len(changes)).
+		Bool("plate_changed", plateChanged).
+		Msg("Driver profile updated successfully")
+
+	return mapper.ToDriverProfileResponse(profile), nil
+}
+
+// UpdateProfilePicture replaces the driver's profile picture
+func (s *driverService) UpdateProfilePicture(ctx context.Context, userID int, file *multipart.FileHeader) (*dto.DriverProfileResponse, error) {
+	profile, err := s.driverRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		logger.Log.Warn().Err(err).Int("user_id", userID).Msg("Driver profile not found for picture update")
+		return nil, apperror.ErrDriverProfileNotFound
+	}
+
+	if err := storage.ValidateImage(file); err != nil {
+		logger.Log.Warn().Err(err).Int("user_id", userID).Msg("Invalid profile picture")
+		return nil, err
+	}
+
+	newPath, err := s.fileStorage.Upload(file, constants.StorageNamespaceDrivers, userID, constants.DocumentTypeProfilePicture)
+	if err != nil {
+		logger.Log.Error().Err(err).Int("user_id", userID).Msg("Failed to upload profile picture")
+		return nil, err
+	}
+
+	oldPath := profile.ProfilePicture
+	profile.ProfilePicture = &newPath
+
+	changes := []*entity.DriverProfileChange{{
+		DriverProfileID: profile.ID,
+		Field:           constants.ProfileFieldProfilePicture,
+		OldValue:        oldPath,
+		NewValue:        &newPath,
+		ChangedBy:       userID,
+	}}
+	if err := s.persistProfileUpdate(ctx, profile, changes, nil); err != nil {
+		logger.Log.Error().Err(err).Int("profile_id", profile.ID).Msg("Failed to save profile picture")
+		_ = s.fileStorage.Delete(newPath)
+		return nil, fmt.Errorf("failed to update driver profile: %w", err)
+	}
+
+	// Remove previous picture from storage (history keeps the old path for reference)
+	if oldPath != nil && *oldPath != "" {
+		_ = s.fileStorage.Delete(*oldPath)
+	}
+
+	logger.Log.Info().Int("user_id", userID).Str("path", newPath).Msg("Driver profile picture updated")
+
+	return mapper.ToDriverProfileResponse(profile), nil
+}
+
+// GetProfileChanges returns profile change history of a driver (admin view)
+func (s *driverService) GetProfileChanges(ctx context.Context, driverProfileID, limit, offset int) ([]*dto.DriverProfileChangeResponse, error) {
+	if _, err := s.driverRepo.FindByID(ctx, driverProfileID); err != nil {
+		logger.Log.Warn().Err(err).Int("profile_id", driverProfileID).Msg("Driver profile not found for change history")
+		return nil, apperror.ErrDriverProfileNotFound
+	}
+
+	changes, err := s.profileChangeRepo.FindByDriverProfileID(ctx, driverProfileID, limit, offset)
+	if err != nil {
+		logger.Log.Error().Err(err).Int("profile_id", driverProfileID).Msg("Failed to fetch driver profile changes")
+		return nil, fmt.Errorf("failed to fetch profile changes: %w", err)
+	}
+
+	return mapper.ToDriverProfileChangeResponses(changes), nil
+}
+
+// UpdateLocation stores the driver's latest position used by the dispatcher, streams it
+// to the passenger of the order the driver is serving and, during the trip, adds it to
+// the trip's route
+func (s *driverService) UpdateLocation(ctx context.Context, userID int, req dto.UpdateDriverLocationRequest) (*dto.DriverStatusResponse, error) {
+	if err := s.driverRepo.UpdateLocation(ctx, userID, req.Lat, req.Long); err != nil {
+		logger.Log.Error().Err(err).Int("user_id", userID).Msg("Failed to update driver location")
+		return nil, apperror.Internal(err)
+	}
+
+	order, err := s.orderRepo.FindActiveByDriver(ctx, userID)
+	if err != nil {
+		// The location is saved; the passenger simply gets the next ping
+		logger.Log.Warn().Err(err).Int("user_id", userID).Msg("Failed to load active order for driver location")
+	}
+	if order != nil {
+		now := time.Now()
+		if err := s.publishLocation(ctx, order, userID, req, now); err != nil {
+			logger.Log.Warn().Err(err).Int("user_id", userID).Msg("Failed to stream driver location")
+		}
+		if order.Status == entity.OrderStatusOnTrip {
+			if err := s.tripRouteService.RecordPing(ctx, order.ID, geo.Point{Lat: req.Lat, Long: req.Long}, now); err != nil {
+				// A missing ping only leaves a straight segment in the route
+				logger.Log.Warn().Err(err).Int("order_id", order.ID).Msg("Failed to record trip location")
+			}
+		}
+	}
+
+	if err := s.trackServiceArea(ctx, userID, req); err != nil {
+		// Only the admin view depends on the flag; the next ping retries
+		logger.Log.Warn().Err(err).Int("user_id", userID).Msg("Failed to check driver service area")
+	}
+
+	profile, err := s.driverRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperror.ErrDriverProfileNotFound
+	}
+	return mapper.ToDriverStatusResponse(profile), nil
+}
+
+// trackServiceArea flags a driver who moved outside the service area and clears the flag
+// on return. Leaving raises an outbox event so operations can follow up.
+func (s *driverService) trackServiceArea(ctx context.Context, userID int, req dto.UpdateDriverLocationRequest) error {
+	outside, err := s.geofenceService.IsOutsideServiceArea(ctx, geo.Point{Lat: req.Lat, Long: req.Long})
+	if err != nil {
+		return err
+	}
+
+	return database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		changed, err := s.driverRepo.WithTx(tx).SetOutsideServiceArea(ctx, userID, outside)
+		if err != nil || !changed || !outside {
+			return err
+		}
+
+		logger.Log.Info().Int("user_id", userID).Float64("lat", req.Lat).Float64("long", req.Long).Msg("Driver left service area")
+		return jobqueue.WriteEvent(ctx, tx, constants.AggregateDriver, strconv.Itoa(userID), constants.EventDriverLeftServiceArea, map[string]any{
+			"user_id": userID,
+			"lat":     req.Lat,
+			"long":    req.Long,
+		})
+	})
+}
+
+// SetOnlineStatus toggles whether the driver receives order offers.
+// Going online requires a verified account, a recent location and, when drivers must be
+// campus members, a verified campus email and a KTM.
+func (s *driverService) SetOnlineStatus(ctx context.Context, userID int, online bool) (*dto.DriverStatusResponse, error) {
+	profile, err := s.driverRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		logger.Log.Warn().Err(err).Int("user_id", userID).Msg("Driver profile not found for status update")
+		return nil, apperror.ErrDriverProfileNotFound
+	}
+
+	if online {
+		if !profile.IsVerified {
+			return nil, apperror.ErrDriverNotVerified
+		}
+		if profile.LastLocationUpdate == nil || time.Since(*profile.LastLocationUpdate) > constants.DriverLocationMaxAge {
+			return nil, apperror.ErrDriverLocationStale
+		}
+		if err := s.campusService.CheckCanDrive(ctx, userID, profile); err != nil {
+			return nil, err
+		}
+		if err := s.cancellationService.CheckCanDrive(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.driverRepo.SetOnline(ctx, userID, online); err != nil {
+		logger.Log.Error().Err(err).Int("user_id", userID).Msg("Failed to update driver online status")
+		return nil, apperror.Internal(err)
+	}
+	profile.IsOnline = online
+
+	logger.Log.Info().Int("user_id", userID).Bool("online", online).Msg("Driver online status changed")
+	return mapper.ToDriverStatusResponse(profile), nil
+}
+
+// publishLocation sends the location to the topic of the driver's active order
+func (s *driverService) publishLocation(ctx context.Context, order *entity.Order, userID int, req dto.UpdateDriverLocationRequest, at time.Time) error {
+	return s.publisher.Publish(ctx, s.db, realtime.OrderTopic(order.ID), constants.RealtimeDriverLocation, map[string]any{
+		"order_id":  order.ID,
+		"driver_id": userID,
+		"lat":       req.Lat,
+		"long":      req.Long,
+		"at":        at,
+	})
+}
+
+// persistProfileUpdate saves the profile, its change history and the outbox event in one transaction.
+// A non-nil resetNotes also sends the driver back to verification.
+func (s *driverService) persistProfileUpdate(
+	ctx context.Context,
+	profile *entity.DriverProfile,
+	changes []*entity.DriverProfileChange,
+	resetNotes *string,
+) error {
+	return database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		driverRepo := s.driverRepo.WithTx(tx)
+		if err := driverRepo.Update(ctx, profile); err != nil {
+			return err
+		}
+		if resetNotes != nil {
+			if err := driverRepo.UpdateVerificationStatus(ctx, profile.ID, false, resetNotes, nil, nil); err != nil {
+				return fmt.Errorf("failed to reset driver verification: %w", err)
+			}
+		}
+		if err := s.profileChangeRepo.WithTx(tx).CreateBatch(ctx, changes); err != nil {
+			return fmt.Errorf("failed to record profile changes: %w", err)
+		}
+
+		fields := make([]string, 0, len(changes))
+		for _, change := range changes {
+			fields = append(fields, change.Field)
+		}
+		aggregateID := strconv.Itoa(profile.ID)
+		if err := jobqueue.WriteEvent(ctx, tx, constants.AggregateDriver, aggregateID, constants.EventDriverProfileUpdated, map[string]any{
+			"driver_profile_id": profile.ID,
+			"user_id":           profile.UserID,
+			"fields":            fields,
+		}); err != nil {
+			return err
+		}
+		if resetNotes != nil {
+			return jobqueue.WriteEvent(ctx, tx, constants.AggregateDriver, aggregateID, constants.EventDriverVerificationReset, map[string]any{
+				"driver_profile_id": profile.ID,
+				"user_id":           profile.UserID,
+				"reason":            *resetNotes,
+			})
+		}
+		return nil
+	})
+}
+
 // createRefreshToken creates a refresh token
 func (s *driverService) createRefreshToken(ctx context.Context, userID int, userType, deviceInfo string) (string, error) {
 	return s.tokenHelper.CreateRefreshToken(ctx, userID, userType, deviceInfo)
 }
+
+// applyOptionalField sets an optional profile field from a partial update request.
+// A nil request value leaves the field untouched, an empty string clears it.
+func applyOptionalField(current **string, requested *string) (oldValue, newValue *string, changed bool) {
+	if requested == nil {
+		return nil, nil, false
+	}
+
+	trimmed := strings.TrimSpace(*requested)
+	if trimmed != "" {
+		newValue = &trimmed
+	}
+	oldValue = *current
+
+	if oldValue == nil && newValue == nil {
+		return nil, nil, false
+	}
+	if oldValue != nil && newValue != nil && *oldValue == *newValue {
+		return nil, nil, false
+	}
+
+	*current = newValue
+	return oldValue, newValue, true
+}
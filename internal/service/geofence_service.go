@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/mapper"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/geo"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+)
+
+// GeofenceService manages the campus geofence: service areas that rides must start or end
+// in, pricing zones that add a surcharge, no-go zones, and the catalogue of official pickup
+// points that pickups and dropoffs snap onto. With no active service area configured, rides
+// are not restricted to one.
+type GeofenceService interface {
+	CreateZone(ctx context.Context, adminID int, req dto.CreateZoneRequest) (*dto.ZoneResponse, error)
+	UpdateZone(ctx context.Context, zoneID int, req dto.UpdateZoneRequest) (*dto.ZoneResponse, error)
+	GetZone(ctx context.Context, zoneID int) (*dto.ZoneResponse, error)
+	ListZones(ctx context.Context, kind string) ([]*dto.ZoneResponse, error)
+	DeleteZone(ctx context.Context, zoneID int) error
+	CreatePickupPoint(ctx context.Context, adminID int, req dto.CreatePickupPointRequest) (*dto.PickupPointResponse, error)
+	UpdatePickupPoint(ctx context.Context, pointID int, req dto.UpdatePickupPointRequest) (*dto.PickupPointResponse, error)
+	ListPickupPoints(ctx context.Context, activeOnly bool) ([]*dto.PickupPointResponse, error)
+	DeletePickupPoint(ctx context.Context, pointID int) error
+	ListDriversOutsideArea(ctx context.Context, limit, offset int) ([]*dto.OutOfAreaDriverResponse, error)
+	PlanTrip(ctx context.Context, pickup, dropoff entity.TripStop) (*entity.TripPlan, error)
+	IsOutsideServiceArea(ctx context.Context, p geo.Point) (bool, error)
+}
+
+type geofenceService struct {
+	zoneRepo        repository.ZoneRepository
+	pickupPointRepo repository.PickupPointRepository
+	driverRepo      repository.DriverRepository
+}
+
+func NewGeofenceService(
+	zoneRepo repository.ZoneRepository,
+	pickupPointRepo repository.PickupPointRepository,
+	driverRepo repository.DriverRepository,
+) GeofenceService {
+	return &geofenceService{
+		zoneRepo:        zoneRepo,
+		pickupPointRepo: pickupPointRepo,
+		driverRepo:      driverRepo,
+	}
+}
+
+func (s *geofenceService) CreateZone(ctx context.Context, adminID int, req dto.CreateZoneRequest) (*dto.ZoneResponse, error) {
+	area, err := parseZoneGeometry(req.Geometry)
+	if err != nil {
+		return nil, err
+	}
+	kind := entity.ZoneKind(req.Kind)
+	if req.Surcharge > 0 && kind != entity.ZoneKindPricing {
+		return nil, apperror.ErrZoneSurchargeNotAllowed
+	}
+
+	zone := &entity.Zone{
+		Name:      strings.TrimSpace(req.Name),
+		Kind:      kind,
+		Area:      area,
+		Surcharge: req.Surcharge,
+		IsActive:  req.IsActive == nil || *req.IsActive,
+		CreatedBy: &adminID,
+	}
+	if err := s.zoneRepo.Create(ctx, zone); err != nil {
+		logger.Log.Error().Err(err).Str("name", zone.Name).Msg("Failed to create zone")
+		return nil, apperror.Internal(err)
+	}
+
+	logger.Log.Info().Int("zone_id", zone.ID).Str("kind", req.Kind).Int("admin_id", adminID).Msg("Zone created")
+	return zoneResponse(zone)
+}
+
+func (s *geofenceService) UpdateZone(ctx context.Context, zoneID int, req dto.UpdateZoneRequest) (*dto.ZoneResponse, error) {
+	zone, err := s.zoneRepo.FindByID(ctx, zoneID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if zone == nil {
+		return nil, apperror.ErrZoneNotFound
+	}
+
+	if req.Name != nil {
+		zone.Name = strings.TrimSpace(*req.Name)
+	}
+	if len(req.Geometry) > 0 {
+		if zone.Area, err = parseZoneGeometry(req.Geometry); err != nil {
+			return nil, err
+		}
+	}
+	if req.Surcharge != nil {
+		if *req.Surcharge > 0 && zone.Kind != entity.ZoneKindPricing {
+			return nil, apperror.ErrZoneSurchargeNotAllowed
+		}
+		zone.Surcharge = *req.Surcharge
+	}
+	if req.IsActive != nil {
+		zone.IsActive = *req.IsActive
+	}
+
+	if err := s.zoneRepo.Update(ctx, zone); err != nil {
+		logger.Log.Error().Err(err).Int("zone_id", zoneID).Msg("Failed to update zone")
+		return nil, apperror.Internal(err)
+	}
+	return zoneResponse(zone)
+}
+
+func (s *geofenceService) GetZone(ctx context.Context, zoneID int) (*dto.ZoneResponse, error) {
+	zone, err := s.zoneRepo.FindByID(ctx, zoneID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if zone == nil {
+		return nil, apperror.ErrZoneNotFound
+	}
+	return zoneResponse(zone)
+}
+
+// ListZones returns the zones of a kind, or all zones when kind is empty
+func (s *geofenceService) ListZones(ctx context.Context, kind string) ([]*dto.ZoneResponse, error) {
+	zones, err := s.zoneRepo.List(ctx, entity.ZoneKind(kind))
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	responses, err := mapper.ToZoneResponses(zones)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return responses, nil
+}
+
+func (s *geofenceService) DeleteZone(ctx context.Context, zoneID int) error {
+	removed, err := s.zoneRepo.Delete(ctx, zoneID)
+	if err != nil {
+		logger.Log.Error().Err(err).Int("zone_id", zoneID).Msg("Failed to delete zone")
+		return apperror.Internal(err)
+	}
+	if !removed {
+		return apperror.ErrZoneNotFound
+	}
+
+	logger.Log.Info().Int("zone_id", zoneID).Msg("Zone deleted")
+	return nil
+}
+
+func (s *geofenceService) CreatePickupPoint(ctx context.Context, adminID int, req dto.CreatePickupPointRequest) (*dto.PickupPointResponse, error) {
+	point := &entity.PickupPoint{
+		Name:        strings.TrimSpace(req.Name),
+		Kind:        entity.PickupPointKind(req.Kind),
+		Lat:         req.Lat,
+		Long:        req.Long,
+		Description: req.Description,
+		IsActive:    req.IsActive == nil || *req.IsActive,
+		CreatedBy:   &adminID,
+	}
+	if err := s.pickupPointRepo.Create(ctx, point); err != nil {
+		logger.Log.Error().Err(err).Str("name", point.Name).Msg("Failed to create pickup point")
+		return nil, apperror.Internal(err)
+	}
+
+	logger.Log.Info().Int("pickup_point_id", point.ID).Int("admin_id", adminID).Msg("Pickup point created")
+	return mapper.ToPickupPointResponse(point), nil
+}
+
+func (s *geofenceService) UpdatePickupPoint(ctx context.Context, pointID int, req dto.UpdatePickupPointRequest) (*dto.PickupPointResponse, error) {
+	point, err := s.pickupPointRepo.FindByID(ctx, pointID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if point == nil {
+		return nil, apperror.ErrPickupPointNotFound
+	}
+
+	if req.Name != nil {
+		point.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Kind != nil {
+		point.Kind = entity.PickupPointKind(*req.Kind)
+	}
+	if req.Lat != nil {
+		point.Lat = *req.Lat
+	}
+	if req.Long != nil {
+		point.Long = *req.Long
+	}
+	if req.Description != nil {
+		point.Description = req.Description
+	}
+	if req.IsActive != nil {
+		point.IsActive = *req.IsActive
+	}
+
+	if err := s.pickupPointRepo.Update(ctx, point); err != nil {
+		logger.Log.Error().Err(err).Int("pickup_point_id", pointID).Msg("Failed to update pickup point")
+		return nil, apperror.Internal(err)
+	}
+	return mapper.ToPickupPointResponse(point), nil
+}
+
+// ListPickupPoints returns the catalogue; apps only see active points
+func (s *geofenceService) ListPickupPoints(ctx context.Context, activeOnly bool) ([]*dto.PickupPointResponse, error) {
+	points, err := s.pickupPointRepo.List(ctx, activeOnly)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return mapper.ToPickupPointResponses(points), nil
+}
+
+func (s *geofenceService) DeletePickupPoint(ctx context.Context, pointID int) error {
+	removed, err := s.pickupPointRepo.Delete(ctx, pointID)
+	if err != nil {
+		logger.Log.Error().Err(err).Int("pickup_point_id", pointID).Msg("Failed to delete pickup point")
+		return apperror.Internal(err)
+	}
+	if !removed {
+		return apperror.ErrPickupPointNotFound
+	}
+	return nil
+}
+
+// ListDriversOutsideArea returns drivers whose last location was outside the service area
+func (s *geofenceService) ListDriversOutsideArea(ctx context.Context, limit, offset int) ([]*dto.OutOfAreaDriverResponse, error) {
+	drivers, err := s.driverRepo.FindOutsideServiceArea(ctx, limit, offset)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return mapper.ToOutOfAreaDriverResponses(drivers), nil
+}
+
+// PlanTrip snaps the stops onto nearby pickup points, then checks the trip against the
+// zones: neither stop may be in a no-go zone, at least one must be in the service area,
+// and every pricing zone a stop is in adds its surcharge once
+func (s *geofenceService) PlanTrip(ctx context.Context, pickup, dropoff entity.TripStop) (*entity.TripPlan, error) {
+	plan := &entity.TripPlan{}
+	var err error
+	if plan.Pickup, err = s.snap(ctx, pickup); err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if plan.Dropoff, err = s.snap(ctx, dropoff); err != nil {
+		return nil, apperror.Internal(err)
+	}
+
+	pickupZones, err := s.zoneRepo.FindActiveContaining(ctx, geo.Point{Lat: plan.Pickup.Lat, Long: plan.Pickup.Long})
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	dropoffZones, err := s.zoneRepo.FindActiveContaining(ctx, geo.Point{Lat: plan.Dropoff.Lat, Long: plan.Dropoff.Long})
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+
+	inServiceArea := false
+	charged := map[int]bool{}
+	for _, zone := range append(pickupZones, dropoffZones...) {
+		switch zone.Kind {
+		case entity.ZoneKindNoGo:
+			return nil, apperror.ErrNoGoZone.WithVars(map[string]string{"zone": zone.Name})
+		case entity.ZoneKindServiceArea:
+			inServiceArea = true
+		case entity.ZoneKindPricing:
+			if !charged[zone.ID] {
+				charged[zone.ID] = true
+				plan.Surcharge += zone.Surcharge
+			}
+		}
+	}
+
+	if !inServiceArea {
+		restricted, err := s.zoneRepo.ExistsActive(ctx, entity.ZoneKindServiceArea)
+		if err != nil {
+			return nil, apperror.Internal(err)
+		}
+		if restricted {
+			return nil, apperror.ErrOutsideServiceArea
+		}
+	}
+	return plan, nil
+}
+
+// IsOutsideServiceArea reports whether p is outside every active service area; it is
+// never outside when no service area is configured
+func (s *geofenceService) IsOutsideServiceArea(ctx context.Context, p geo.Point) (bool, error) {
+	zones, err := s.zoneRepo.FindActiveContaining(ctx, p)
+	if err != nil {
+		return false, err
+	}
+	for _, zone := range zones {
+		if zone.Kind == entity.ZoneKindServiceArea {
+			return false, nil
+		}
+	}
+	return s.zoneRepo.ExistsActive(ctx, entity.ZoneKindServiceArea)
+}
+
+// snap moves a stop onto the nearest active pickup point within the snap radius
+func (s *geofenceService) snap(ctx context.Context, stop entity.TripStop) (entity.TripStop, error) {
+	point, err := s.pickupPointRepo.FindNearestActive(ctx, geo.Point{Lat: stop.Lat, Long: stop.Long}, constants.PickupSnapRadiusKm)
+	if err != nil || point == nil {
+		return stop, err
+	}
+	return entity.TripStop{
+		Lat:     point.Lat,
+		Long:    point.Long,
+		Address: point.Name,
+		PointID: &point.ID,
+	}, nil
+}
+
+func parseZoneGeometry(raw []byte) (geo.MultiPolygon, error) {
+	area, err := geo.ParseGeoJSON(raw)
+	if err != nil {
+		return nil, apperror.ErrInvalidZoneGeometry.WithVars(map[string]string{"reason": err.Error()})
+	}
+	return area, nil
+}
+
+func zoneResponse(zone *entity.Zone) (*dto.ZoneResponse, error) {
+	response, err := mapper.ToZoneResponse(zone)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return response, nil
+}
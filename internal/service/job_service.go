@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/jobqueue"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// JobService exposes dead-lettered background jobs to admins
+type JobService interface {
+	ListDeadJobs(ctx context.Context, limit, offset int) ([]*jobqueue.Job, error)
+	RetryJob(ctx context.Context, id int64) error
+}
+
+type jobService struct {
+	db *pgxpool.Pool
+}
+
+func NewJobService(db *pgxpool.Pool) JobService {
+	return &jobService{db: db}
+}
+
+func (s *jobService) ListDeadJobs(ctx context.Context, limit, offset int) ([]*jobqueue.Job, error) {
+	jobs, err := jobqueue.ListDead(ctx, s.db, limit, offset)
+	if err != nil {
+		logger.Log.Error().Err(err).Msg("Failed to list dead jobs")
+		return nil, fmt.Errorf("failed to list dead jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+func (s *jobService) RetryJob(ctx context.Context, id int64) error {
+	if err := jobqueue.Retry(ctx, s.db, id); err != nil {
+		logger.Log.Warn().Err(err).Int64("job_id", id).Msg("Failed to retry dead job")
+		if errors.Is(err, jobqueue.ErrJobNotFound) {
+			return apperror.ErrJobNotFound
+		}
+		return err
+	}
+	logger.Log.Info().Int64("job_id", id).Msg("Dead job requeued")
+	return nil
+}
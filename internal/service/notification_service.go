@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/i18n"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/jobqueue"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/push"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NotificationService manages push devices and delivers notifications to every device of a user,
+// or to the user's WhatsApp number for messages that must reach drivers without the app open.
+// WhatsApp messages can also go to people without an account, such as trusted contacts.
+type NotificationService interface {
+	RegisterDevice(ctx context.Context, userID int, req dto.RegisterDeviceRequest) error
+	UnregisterDevice(ctx context.Context, userID int, token string) error
+	NotifyUser(ctx context.Context, userID int, template push.TemplateID, vars map[string]string) error
+	NotifyUserTx(ctx context.Context, tx database.DBTX, userID int, template push.TemplateID, vars map[string]string) error
+	NotifyUserWhatsAppTx(ctx context.Context, tx database.DBTX, userID int, template push.TemplateID, vars map[string]string) error
+	NotifyPhoneWhatsAppTx(ctx context.Context, tx database.DBTX, phoneNumber string, locale i18n.Locale, template push.TemplateID, vars map[string]string) error
+	HandleFanOutJob(ctx context.Context, job *jobqueue.Job) error
+	HandleSendJob(ctx context.Context, job *jobqueue.Job) error
+	HandleWhatsAppJob(ctx context.Context, job *jobqueue.Job) error
+	HandleWhatsAppSendJob(ctx context.Context, job *jobqueue.Job) error
+}
+
+// pushFanOutPayload is the payload of the push.fanout job (one per user).
+// The template is rendered at fan-out time in the recipient's preferred locale.
+type pushFanOutPayload struct {
+	UserID   int               `json:"user_id"`
+	Template push.TemplateID   `json:"template"`
+	Vars     map[string]string `json:"vars,omitempty"`
+}
+
+// pushSendPayload is the payload of the push.send job (one per device)
+type pushSendPayload struct {
+	DeviceID int          `json:"device_id"`
+	Token    string       `json:"token"`
+	Message  push.Message `json:"message"`
+}
+
+// whatsappNotifyPayload is the payload of the whatsapp.notify job. The message is rendered
+// from the "whatsapp.<template>" catalog entry in the recipient's locale.
+type whatsappNotifyPayload struct {
+	UserID   int               `json:"user_id"`
+	Template push.TemplateID   `json:"template"`
+	Vars     map[string]string `json:"vars,omitempty"`
+}
+
+// whatsappSendPayload is the payload of the whatsapp.send job, a templated message to a
+// phone number that need not belong to a user
+type whatsappSendPayload struct {
+	PhoneNumber string            `json:"phone_number"`
+	Locale      i18n.Locale       `json:"locale"`
+	Template    push.TemplateID   `json:"template"`
+	Vars        map[string]string `json:"vars,omitempty"`
+}
+
+type notificationService struct {
+	db              *pgxpool.Pool
+	jobs            jobqueue.Enqueuer
+	userRepo        repository.UserRepository
+	deviceTokenRepo repository.DeviceTokenRepository
+	notifier        push.Notifier
+	whatsAppService WhatsAppService
+}
+
+func NewNotificationService(
+	db *pgxpool.Pool,
+	userRepo repository.UserRepository,
+	deviceTokenRepo repository.DeviceTokenRepository,
+	notifier push.Notifier,
+	whatsAppService WhatsAppService,
+) NotificationService {
+	return &notificationService{
+		db:              db,
+		jobs:            jobqueue.PostgresEnqueuer{},
+		userRepo:        userRepo,
+		deviceTokenRepo: deviceTokenRepo,
+		notifier:        notifier,
+		whatsAppService: whatsAppService,
+	}
+}
+
+func (s *notificationService) RegisterDevice(ctx context.Context, userID int, req dto.RegisterDeviceRequest) error {
+	platform := entity.DevicePlatform(req.Platform)
+	if platform == "" {
+		platform = entity.PlatformAndroid
+	}
+
+	device := &entity.DeviceToken{
+		UserID:   userID,
+		Token:    req.FCMToken,
+		Platform: platform,
+	}
+	if err := s.deviceTokenRepo.Upsert(ctx, device); err != nil {
+		logger.Log.Error().Err(err).Int("user_id", userID).Msg("Failed to register push device")
+		return fmt.Errorf("failed to register device: %w", err)
+	}
+
+	logger.Log.Info().Int("user_id", userID).Int("device_id", device.ID).Str("platform", string(platform)).Msg("Push device registered")
+	return nil
+}
+
+func (s *notificationService) UnregisterDevice(ctx context.Context, userID int, token string) error {
+	if err := s.deviceTokenRepo.DeleteByUserAndToken(ctx, userID, token); err != nil {
+		logger.Log.Error().Err(err).Int("user_id", userID).Msg("Failed to unregister push device")
+		return fmt.Errorf("failed to unregister device: %w", err)
+	}
+	return nil
+}
+
+// NotifyUser queues a templated notification; rendering and delivery happen outside the request
+func (s *notificationService) NotifyUser(ctx context.Context, userID int, template push.TemplateID, vars map[string]string) error {
+	return s.NotifyUserTx(ctx, s.db, userID, template, vars)
+}
+
+// NotifyUserTx queues a notification inside the caller's transaction
+func (s *notificationService) NotifyUserTx(ctx context.Context, tx database.DBTX, userID int, template push.TemplateID, vars map[string]string) error {
+	if !push.HasTemplate(template) {
+		return fmt.Errorf("unknown push template: %s", template)
+	}
+
+	if _, err := s.jobs.Enqueue(ctx, tx, jobqueue.NewJob{
+		Type:    constants.JobTypePushFanOut,
+		Payload: pushFanOutPayload{UserID: userID, Template: template, Vars: vars},
+	}); err != nil {
+		logger.Log.Error().Err(err).Int("user_id", userID).Str("template", string(template)).Msg("Failed to queue push notification")
+		return err
+	}
+	return nil
+}
+
+// NotifyUserWhatsAppTx queues a templated WhatsApp message inside the caller's transaction
+func (s *notificationService) NotifyUserWhatsAppTx(ctx context.Context, tx database.DBTX, userID int, template push.TemplateID, vars map[string]string) error {
+	if !i18n.Has(whatsappCatalogKey(template)) {
+		return fmt.Errorf("unknown whatsapp template: %s", template)
+	}
+
+	if _, err := s.jobs.Enqueue(ctx, tx, jobqueue.NewJob{
+		Type:    constants.JobTypeWhatsAppNotify,
+		Payload: whatsappNotifyPayload{UserID: userID, Template: template, Vars: vars},
+	}); err != nil {
+		logger.Log.Error().Err(err).Int("user_id", userID).Str("template", string(template)).Msg("Failed to queue WhatsApp notification")
+		return err
+	}
+	return nil
+}
+
+// NotifyPhoneWhatsAppTx queues a templated WhatsApp message to a phone number inside the
+// caller's transaction, rendered in the given locale
+func (s *notificationService) NotifyPhoneWhatsAppTx(ctx context.Context, tx database.DBTX, phoneNumber string, locale i18n.Locale, template push.TemplateID, vars map[string]string) error {
+	if !i18n.Has(whatsappCatalogKey(template)) {
+		return fmt.Errorf("unknown whatsapp template: %s", template)
+	}
+
+	if _, err := s.jobs.Enqueue(ctx, tx, jobqueue.NewJob{
+		Type:    constants.JobTypeWhatsAppSend,
+		Payload: whatsappSendPayload{PhoneNumber: phoneNumber, Locale: locale, Template: template, Vars: vars},
+	}); err != nil {
+		logger.Log.Error().Err(err).Str("phone", phoneNumber).Str("template", string(template)).Msg("Failed to queue WhatsApp message")
+		return err
+	}
+	return nil
+}
+
+// HandleFanOutJob splits a user notification into one push.send job per registered device,
+// so a failing device is retried on its own without re-sending to the others
+func (s *notificationService) HandleFanOutJob(ctx context.Context, job *jobqueue.Job) error {
+	var payload pushFanOutPayload
+	if err := job.Decode(&payload); err != nil {
+		return jobqueue.Permanent(fmt.Errorf("invalid push.fanout payload: %w", err))
+	}
+
+	user, err := s.userRepo.FindByID(ctx, payload.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to load push recipient: %w", err)
+	}
+	if user == nil {
+		return jobqueue.Permanent(fmt.Errorf("push recipient %d not found", payload.UserID))
+	}
+
+	msg, err := push.Render(i18n.OrDefault(user.PreferredLocale), payload.Template, payload.Vars)
+	if err != nil {
+		return jobqueue.Permanent(err)
+	}
+
+	devices, err := s.deviceTokenRepo.FindByUserID(ctx, payload.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to load push devices: %w", err)
+	}
+
+	for _, device := range devices {
+		if _, err := s.jobs.Enqueue(ctx, s.db, jobqueue.NewJob{
+			Type:           constants.JobTypePushSend,
+			Payload:        pushSendPayload{DeviceID: device.ID, Token: device.Token, Message: msg},
+			IdempotencyKey: fmt.Sprintf("push:%d:%d", job.ID, device.ID),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HandleSendJob sends to a single device, removing tokens FCM reports as unregistered
+func (s *notificationService) HandleSendJob(ctx context.Context, job *jobqueue.Job) error {
+	var payload pushSendPayload
+	if err := job.Decode(&payload); err != nil {
+		return jobqueue.Permanent(fmt.Errorf("invalid push.send payload: %w", err))
+	}
+
+	err := s.notifier.Send(ctx, payload.Token, payload.Message)
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, push.ErrUnregistered) {
+		logger.Log.Info().Int("device_id", payload.DeviceID).Msg("Removing unregistered push device")
+		if err := s.deviceTokenRepo.DeleteByToken(ctx, payload.Token); err != nil {
+			return fmt.Errorf("failed to remove unregistered push device: %w", err)
+		}
+		return nil
+	}
+
+	logger.Log.Error().Err(err).Int("device_id", payload.DeviceID).Msg("Failed to send push notification")
+	return err
+}
+
+// HandleWhatsAppJob renders a WhatsApp notification in the recipient's locale and sends it
+// to their phone number
+func (s *notificationService) HandleWhatsAppJob(ctx context.Context, job *jobqueue.Job) error {
+	var payload whatsappNotifyPayload
+	if err := job.Decode(&payload); err != nil {
+		return jobqueue.Permanent(fmt.Errorf("invalid whatsapp.notify payload: %w", err))
+	}
+
+	user, err := s.userRepo.FindByID(ctx, payload.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to load whatsapp recipient: %w", err)
+	}
+	if user == nil {
+		return jobqueue.Permanent(fmt.Errorf("whatsapp recipient %d not found", payload.UserID))
+	}
+
+	key := whatsappCatalogKey(payload.Template)
+	if !i18n.Has(key) {
+		return jobqueue.Permanent(fmt.Errorf("unknown whatsapp template: %s", payload.Template))
+	}
+	message := i18n.T(i18n.OrDefault(user.PreferredLocale), key, payload.Vars)
+
+	if err := s.whatsAppService.Send(ctx, user.PhoneNumber, message, entity.WhatsAppPurposeNotification, &user.ID); err != nil {
+		logger.Log.Error().
+			Err(err).
+			Int("user_id", user.ID).
+			Str("template", string(payload.Template)).
+			Int("attempt", job.Attempts).
+			Msg("Failed to send WhatsApp notification")
+		return fmt.Errorf("failed to send whatsapp notification: %w", err)
+	}
+	return nil
+}
+
+// HandleWhatsAppSendJob renders a WhatsApp message and sends it to a phone number
+func (s *notificationService) HandleWhatsAppSendJob(ctx context.Context, job *jobqueue.Job) error {
+	var payload whatsappSendPayload
+	if err := job.Decode(&payload); err != nil {
+		return jobqueue.Permanent(fmt.Errorf("invalid whatsapp.send payload: %w", err))
+	}
+
+	key := whatsappCatalogKey(payload.Template)
+	if !i18n.Has(key) {
+		return jobqueue.Permanent(fmt.Errorf("unknown whatsapp template: %s", payload.Template))
+	}
+	message := i18n.T(i18n.OrDefault(string(payload.Locale)), key, payload.Vars)
+
+	if err := s.whatsAppService.Send(ctx, payload.PhoneNumber, message, entity.WhatsAppPurposeNotification, nil); err != nil {
+		logger.Log.Error().
+			Err(err).
+			Str("phone", payload.PhoneNumber).
+			Str("template", string(payload.Template)).
+			Int("attempt", job.Attempts).
+			Msg("Failed to send WhatsApp message")
+		return fmt.Errorf("failed to send whatsapp message: %w", err)
+	}
+	return nil
+}
+
+// whatsappCatalogKey maps a template to its WhatsApp body, e.g. DOCUMENT_EXPIRING -> whatsapp.document_expiring
+func whatsappCatalogKey(template push.TemplateID) string {
+	return "whatsapp." + strings.ToLower(string(template))
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/i18n"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/jobqueue"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/push"
+)
+
+// jobRecorder is a jobqueue.Enqueuer that keeps the jobs instead of writing them
+type jobRecorder struct {
+	jobs []jobqueue.NewJob
+}
+
+func (r *jobRecorder) Enqueue(ctx context.Context, db database.DBTX, job jobqueue.NewJob) (int64, error) {
+	r.jobs = append(r.jobs, job)
+	return int64(len(r.jobs)), nil
+}
+
+// claimed returns the recorded job as the worker would hand it to a handler
+func (r *jobRecorder) claimed(t *testing.T, i int) *jobqueue.Job {
+	t.Helper()
+	payload, err := json.Marshal(r.jobs[i].Payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &jobqueue.Job{ID: int64(i + 1), Type: r.jobs[i].Type, Payload: payload}
+}
+
+type stubUserRepository struct {
+	repository.UserRepository
+	users map[int]*entity.User
+}
+
+func (r *stubUserRepository) FindByID(ctx context.Context, id int) (*entity.User, error) {
+	if user, ok := r.users[id]; ok {
+		return user, nil
+	}
+	return nil, nil
+}
+
+type stubDeviceTokenRepository struct {
+	repository.DeviceTokenRepository
+	devices map[int][]*entity.DeviceToken
+	deleted []string
+}
+
+func (r *stubDeviceTokenRepository) FindByUserID(ctx context.Context, userID int) ([]*entity.DeviceToken, error) {
+	return r.devices[userID], nil
+}
+
+func (r *stubDeviceTokenRepository) DeleteByToken(ctx context.Context, token string) error {
+	r.deleted = append(r.deleted, token)
+	return nil
+}
+
+func TestNotificationFanOut(t *testing.T) {
+	vars := map[string]string{"order_id": "42", "driver_name": "Budi", "vehicle_plate": "B 1234 XYZ"}
+	users := map[int]*entity.User{
+		1: {ID: 1, PreferredLocale: string(i18n.English)},
+		2: {ID: 2, PreferredLocale: string(i18n.Indonesian)},
+		3: {ID: 3, PreferredLocale: ""},
+		4: {ID: 4, PreferredLocale: string(i18n.English)},
+	}
+	devices := map[int][]*entity.DeviceToken{
+		1: {{ID: 10, UserID: 1, Token: "phone-1"}, {ID: 11, UserID: 1, Token: "tablet-1"}},
+		2: {{ID: 20, UserID: 2, Token: "phone-2"}},
+		3: {{ID: 30, UserID: 3, Token: "phone-3"}},
+	}
+
+	tests := []struct {
+		name          string
+		userID        int
+		unregistered  []string
+		wantLocale    i18n.Locale
+		wantTokens    []string
+		wantDeleted   []string
+		wantPermanent bool
+	}{
+		{
+			name:       "every device of the user gets the message in their language",
+			userID:     1,
+			wantLocale: i18n.English,
+			wantTokens: []string{"phone-1", "tablet-1"},
+		},
+		{
+			name:       "indonesian user",
+			userID:     2,
+			wantLocale: i18n.Indonesian,
+			wantTokens: []string{"phone-2"},
+		},
+		{
+			name:       "user without a language gets the default",
+			userID:     3,
+			wantLocale: i18n.DefaultLocale,
+			wantTokens: []string{"phone-3"},
+		},
+		{
+			name:         "unregistered device is removed while the others still receive",
+			userID:       1,
+			unregistered: []string{"tablet-1"},
+			wantLocale:   i18n.English,
+			wantTokens:   []string{"phone-1"},
+			wantDeleted:  []string{"tablet-1"},
+		},
+		{
+			name:   "user without devices receives nothing",
+			userID: 4,
+		},
+		{
+			name:          "deleted user is not retried",
+			userID:        5,
+			wantPermanent: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier := push.NewFakeNotifier()
+			for _, token := range tt.unregistered {
+				notifier.Unregister(token)
+			}
+			recorder := &jobRecorder{}
+			deviceRepo := &stubDeviceTokenRepository{devices: devices}
+			userRepo := &stubUserRepository{users: users}
+			s := &notificationService{jobs: recorder, userRepo: userRepo, deviceTokenRepo: deviceRepo, notifier: notifier}
+			ctx := context.Background()
+
+			fanOut := pushFanOutJob(t, tt.userID, push.TemplateOrderAccepted, vars)
+			err := s.HandleFanOutJob(ctx, fanOut)
+			if tt.wantPermanent {
+				if !jobqueue.IsPermanent(err) {
+					t.Fatalf("HandleFanOutJob() error = %v, want a permanent error", err)
+				}
+			} else if err != nil {
+				t.Fatalf("HandleFanOutJob() error = %v", err)
+			}
+			for i := range recorder.jobs {
+				job := recorder.claimed(t, i)
+				if job.Type != constants.JobTypePushSend {
+					t.Fatalf("fan-out enqueued %s, want %s", job.Type, constants.JobTypePushSend)
+				}
+				if err := s.HandleSendJob(ctx, job); err != nil {
+					t.Fatalf("HandleSendJob() error = %v", err)
+				}
+			}
+
+			sent := notifier.Sent()
+			tokens := make([]string, 0, len(sent))
+			for _, msg := range sent {
+				tokens = append(tokens, msg.Token)
+			}
+			if !slices.Equal(tokens, tt.wantTokens) {
+				t.Fatalf("sent to %v, want %v", tokens, tt.wantTokens)
+			}
+			if !slices.Equal(deviceRepo.deleted, tt.wantDeleted) {
+				t.Errorf("deleted %v, want %v", deviceRepo.deleted, tt.wantDeleted)
+			}
+			if len(sent) == 0 {
+				return
+			}
+
+			want, err := push.Render(tt.wantLocale, push.TemplateOrderAccepted, vars)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			for _, msg := range sent {
+				if msg.Message.Title != want.Title || msg.Message.Body != want.Body {
+					t.Errorf("message to %s = %q / %q, want %q / %q", msg.Token, msg.Message.Title, msg.Message.Body, want.Title, want.Body)
+				}
+			}
+		})
+	}
+}
+
+func TestNotificationSendJob(t *testing.T) {
+	message := push.Message{Title: "Driver found", Body: "Budi is on the way"}
+	providerDown := errors.New("fcm unavailable")
+
+	tests := []struct {
+		name          string
+		payload       string
+		failWith      error
+		wantErr       error
+		wantPermanent bool
+		wantSent      int
+	}{
+		{
+			name:     "message reaches the device",
+			payload:  sendPayload(t, "phone-1", message),
+			wantSent: 1,
+		},
+		{
+			name:     "provider failure is retried",
+			payload:  sendPayload(t, "phone-1", message),
+			failWith: providerDown,
+			wantErr:  providerDown,
+		},
+		{
+			name:          "malformed payload is not retried",
+			payload:       `{"device_id": "ten"}`,
+			wantPermanent: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier := push.NewFakeNotifier()
+			notifier.FailWith(tt.failWith)
+			s := &notificationService{deviceTokenRepo: &stubDeviceTokenRepository{}, notifier: notifier}
+
+			err := s.HandleSendJob(context.Background(), &jobqueue.Job{ID: 1, Type: constants.JobTypePushSend, Payload: json.RawMessage(tt.payload)})
+			switch {
+			case tt.wantPermanent:
+				if !jobqueue.IsPermanent(err) {
+					t.Errorf("error = %v, want a permanent error", err)
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) || jobqueue.IsPermanent(err) {
+					t.Errorf("error = %v, want retryable %v", err, tt.wantErr)
+				}
+			case err != nil:
+				t.Errorf("error = %v, want nil", err)
+			}
+
+			sent := notifier.Sent()
+			if len(sent) != tt.wantSent {
+				t.Fatalf("sent %d messages, want %d", len(sent), tt.wantSent)
+			}
+			if tt.wantSent > 0 && (sent[0].Token != "phone-1" || sent[0].Message.Title != message.Title || sent[0].Message.Body != message.Body) {
+				t.Errorf("sent %+v, want %q to phone-1", sent[0], message.Title)
+			}
+		})
+	}
+}
+
+func pushFanOutJob(t *testing.T, userID int, template push.TemplateID, vars map[string]string) *jobqueue.Job {
+	t.Helper()
+	payload, err := json.Marshal(pushFanOutPayload{UserID: userID, Template: template, Vars: vars})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &jobqueue.Job{ID: 100 + int64(userID), Type: constants.JobTypePushFanOut, Payload: payload}
+}
+
+func sendPayload(t *testing.T, token string, message push.Message) string {
+	t.Helper()
+	payload, err := json.Marshal(pushSendPayload{DeviceID: 10, Token: token, Message: message})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(payload)
+}
@@ -0,0 +1,457 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/mapper"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/geo"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/jobqueue"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/ledger"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/push"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/realtime"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OrderService handles ride orders placed by passengers
+type OrderService interface {
+	EstimateFare(ctx context.Context, passengerID int, req dto.EstimateFareRequest) (*dto.FareEstimateResponse, error)
+	CreateOrder(ctx context.Context, passengerID int, req dto.CreateOrderRequest) (*dto.OrderResponse, error)
+	GetOrder(ctx context.Context, userID, orderID int) (*dto.OrderResponse, error)
+	ArriveAtPickup(ctx context.Context, driverID, orderID int) (*dto.OrderResponse, error)
+	StartTrip(ctx context.Context, driverID, orderID int) (*dto.OrderResponse, error)
+	CompleteTrip(ctx context.Context, driverID, orderID int) (*dto.OrderResponse, error)
+}
+
+type orderService struct {
+	db                   *pgxpool.Pool
+	orderRepo            repository.OrderRepository
+	driverRepo           repository.DriverRepository
+	passengerRepo        repository.PassengerRepository
+	userRepo             repository.UserRepository
+	notificationService  NotificationService
+	walletService        WalletService
+	promoService         PromoService
+	campusService        CampusService
+	geofenceService      GeofenceService
+	scheduledRideService ScheduledRideService
+	cancellationService  CancellationService
+	tripRouteService     TripRouteService
+	publisher            realtime.Publisher
+}
+
+func NewOrderService(
+	db *pgxpool.Pool,
+	orderRepo repository.OrderRepository,
+	driverRepo repository.DriverRepository,
+	passengerRepo repository.PassengerRepository,
+	userRepo repository.UserRepository,
+	notificationService NotificationService,
+	walletService WalletService,
+	promoService PromoService,
+	campusService CampusService,
+	geofenceService GeofenceService,
+	scheduledRideService ScheduledRideService,
+	cancellationService CancellationService,
+	tripRouteService TripRouteService,
+	publisher realtime.Publisher,
+) OrderService {
+	return &orderService{
+		db:                   db,
+		orderRepo:            orderRepo,
+		driverRepo:           driverRepo,
+		passengerRepo:        passengerRepo,
+		userRepo:             userRepo,
+		notificationService:  notificationService,
+		walletService:        walletService,
+		promoService:         promoService,
+		campusService:        campusService,
+		geofenceService:      geofenceService,
+		scheduledRideService: scheduledRideService,
+		cancellationService:  cancellationService,
+		tripRouteService:     tripRouteService,
+		publisher:            publisher,
+	}
+}
+
+// EstimateFare quotes the fare of a trip and, when a promo code is given, the discount
+// it would get. Nothing is reserved until the order is placed.
+func (s *orderService) EstimateFare(ctx context.Context, passengerID int, req dto.EstimateFareRequest) (*dto.FareEstimateResponse, error) {
+	plan, distance, fare, err := s.quoteTrip(ctx,
+		entity.TripStop{Lat: req.PickupLat, Long: req.PickupLong, Address: req.PickupAddress},
+		entity.TripStop{Lat: req.DropoffLat, Long: req.DropoffLong, Address: req.DropoffAddress},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.FareEstimateResponse{
+		Pickup:        mapper.ToTripStopResponse(plan.Pickup),
+		Dropoff:       mapper.ToTripStopResponse(plan.Dropoff),
+		DistanceKm:    distance,
+		Fare:          fare,
+		ZoneSurcharge: plan.Surcharge,
+		AmountDue:     fare,
+	}
+	if req.PromoCode != "" {
+		promo, discount, err := s.promoService.Quote(ctx, passengerID, req.PromoCode, fare)
+		if err != nil {
+			return nil, err
+		}
+		resp.Discount = discount
+		resp.AmountDue = fare - discount
+		resp.Promo = mapper.ToAppliedPromoResponse(promo, discount)
+	}
+	return resp, nil
+}
+
+// CreateOrder saves a new order and queues its dispatch in the same transaction. An order
+// with a pickup time is scheduled instead and dispatched shortly before pickup.
+func (s *orderService) CreateOrder(ctx context.Context, passengerID int, req dto.CreateOrderRequest) (*dto.OrderResponse, error) {
+	if err := s.campusService.CheckCanOrder(ctx, passengerID); err != nil {
+		return nil, err
+	}
+	if err := s.cancellationService.CheckCanOrder(ctx, passengerID); err != nil {
+		return nil, err
+	}
+
+	status := entity.OrderStatusSearching
+	var scheduledAt *time.Time
+	if req.ScheduledAt != nil {
+		pickupAt := req.ScheduledAt.UTC()
+		if err := s.scheduledRideService.CheckBooking(ctx, passengerID, pickupAt); err != nil {
+			return nil, err
+		}
+		status = entity.OrderStatusScheduled
+		scheduledAt = &pickupAt
+	} else {
+		active, err := s.orderRepo.FindActiveByPassenger(ctx, passengerID)
+		if err != nil {
+			return nil, apperror.Internal(err)
+		}
+		if active != nil {
+			return nil, apperror.ErrActiveOrderExists
+		}
+	}
+
+	plan, distance, fare, err := s.quoteTrip(ctx,
+		entity.TripStop{Lat: req.PickupLat, Long: req.PickupLong, Address: req.PickupAddress},
+		entity.TripStop{Lat: req.DropoffLat, Long: req.DropoffLong, Address: req.DropoffAddress},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var promoCode *string
+	discount := 0
+	if req.PromoCode != "" {
+		promo, promoDiscount, err := s.promoService.Quote(ctx, passengerID, req.PromoCode, fare)
+		if err != nil {
+			return nil, err
+		}
+		promoCode = &promo.Code
+		discount = promoDiscount
+	}
+
+	paymentMethod := entity.PaymentMethodCash
+	if req.PaymentMethod != "" {
+		paymentMethod = entity.PaymentMethod(req.PaymentMethod)
+	}
+	if paymentMethod == entity.PaymentMethodWallet {
+		if err := s.walletService.CheckBalance(ctx, passengerID, int64(fare-discount)); err != nil {
+			return nil, err
+		}
+	}
+
+	order := &entity.Order{
+		PassengerID:    passengerID,
+		Status:         status,
+		PickupLat:      plan.Pickup.Lat,
+		PickupLong:     plan.Pickup.Long,
+		PickupAddress:  plan.Pickup.Address,
+		DropoffLat:     plan.Dropoff.Lat,
+		DropoffLong:    plan.Dropoff.Long,
+		DropoffAddress: plan.Dropoff.Address,
+		DistanceKm:     distance,
+		Fare:           fare,
+		ZoneSurcharge:  plan.Surcharge,
+		PaymentMethod:  paymentMethod,
+		PromoCode:      promoCode,
+		Discount:       discount,
+		Notes:          req.Notes,
+		ScheduledAt:    scheduledAt,
+	}
+
+	err = database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		if err := s.orderRepo.WithTx(tx).Create(ctx, order); err != nil {
+			return err
+		}
+		if err := s.promoService.ReserveTx(ctx, tx, order); err != nil {
+			return err
+		}
+
+		if err := jobqueue.WriteEvent(ctx, tx, constants.AggregateOrder, strconv.Itoa(order.ID), constants.EventOrderCreated, map[string]any{
+			"order_id":     order.ID,
+			"passenger_id": order.PassengerID,
+			"distance_km":  order.DistanceKm,
+			"fare":         order.Fare,
+			"discount":     order.Discount,
+			"payment":      order.PaymentMethod,
+			"scheduled_at": order.ScheduledAt,
+		}); err != nil {
+			return err
+		}
+
+		if err := publishOrderStatus(ctx, s.publisher, tx, order); err != nil {
+			return err
+		}
+
+		if order.ScheduledAt != nil {
+			return s.scheduledRideService.ScheduleTx(ctx, tx, order)
+		}
+		return enqueueDispatch(ctx, tx, order.ID, 1)
+	})
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return nil, err
+		}
+		logger.Log.Error().Err(err).Int("passenger_id", passengerID).Msg("Failed to create order")
+		return nil, apperror.Internal(err)
+	}
+
+	logger.Log.Info().
+		Int("order_id", order.ID).
+		Int("passenger_id", passengerID).
+		Float64("distance_km", order.DistanceKm).
+		Int("fare", order.Fare).
+		Int("discount", order.Discount).
+		Str("status", string(order.Status)).
+		Msg("Order created")
+
+	return mapper.ToOrderResponse(order), nil
+}
+
+// GetOrder returns an order to its passenger or assigned driver
+func (s *orderService) GetOrder(ctx context.Context, userID, orderID int) (*dto.OrderResponse, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if order == nil || !isOrderParticipant(order, userID) {
+		return nil, apperror.ErrOrderNotFound
+	}
+	return mapper.ToOrderResponse(order), nil
+}
+
+// ArriveAtPickup marks that the driver is waiting at the pickup point
+func (s *orderService) ArriveAtPickup(ctx context.Context, driverID, orderID int) (*dto.OrderResponse, error) {
+	return s.advanceTrip(ctx, driverID, orderID, entity.OrderStatusAccepted, func(ctx context.Context, tx pgx.Tx, order *entity.Order, now time.Time) error {
+		if err := s.orderRepo.WithTx(tx).MarkArrived(ctx, order.ID, now); err != nil {
+			return err
+		}
+		order.Status = entity.OrderStatusArrived
+		order.ArrivedAt = &now
+
+		driver, err := s.userRepo.FindByID(ctx, driverID)
+		if err != nil {
+			return fmt.Errorf("failed to load driver: %w", err)
+		}
+		if driver == nil {
+			return fmt.Errorf("driver %d not found", driverID)
+		}
+		if err := s.notificationService.NotifyUserTx(ctx, tx, order.PassengerID, push.TemplateDriverArrived, map[string]string{
+			"order_id":    strconv.Itoa(order.ID),
+			"driver_name": driver.FullName,
+		}); err != nil {
+			return err
+		}
+		return jobqueue.WriteEvent(ctx, tx, constants.AggregateOrder, strconv.Itoa(order.ID), constants.EventOrderArrived, map[string]any{
+			"order_id":  order.ID,
+			"driver_id": driverID,
+		})
+	})
+}
+
+// StartTrip marks that the passenger has been picked up
+func (s *orderService) StartTrip(ctx context.Context, driverID, orderID int) (*dto.OrderResponse, error) {
+	return s.advanceTrip(ctx, driverID, orderID, entity.OrderStatusArrived, func(ctx context.Context, tx pgx.Tx, order *entity.Order, now time.Time) error {
+		if err := s.orderRepo.WithTx(tx).MarkStarted(ctx, order.ID, now); err != nil {
+			return err
+		}
+		order.Status = entity.OrderStatusOnTrip
+		order.StartedAt = &now
+
+		if err := s.notificationService.NotifyUserTx(ctx, tx, order.PassengerID, push.TemplateTripStarted, map[string]string{
+			"order_id":    strconv.Itoa(order.ID),
+			"destination": order.DropoffAddress,
+		}); err != nil {
+			return err
+		}
+		return jobqueue.WriteEvent(ctx, tx, constants.AggregateOrder, strconv.Itoa(order.ID), constants.EventOrderStarted, map[string]any{
+			"order_id":  order.ID,
+			"driver_id": driverID,
+		})
+	})
+}
+
+// CompleteTrip finishes the trip, measures its recorded route and counts it on both
+// profiles; from here on both participants can rate each other
+func (s *orderService) CompleteTrip(ctx context.Context, driverID, orderID int) (*dto.OrderResponse, error) {
+	return s.advanceTrip(ctx, driverID, orderID, entity.OrderStatusOnTrip, func(ctx context.Context, tx pgx.Tx, order *entity.Order, now time.Time) error {
+		if err := s.orderRepo.WithTx(tx).MarkCompleted(ctx, order.ID, now); err != nil {
+			return err
+		}
+		order.Status = entity.OrderStatusCompleted
+		order.CompletedAt = &now
+
+		if err := s.tripRouteService.BuildTx(ctx, tx, order); err != nil {
+			return err
+		}
+		if err := s.settleFare(ctx, tx, order); err != nil {
+			return err
+		}
+		if err := s.promoService.RedeemTx(ctx, tx, order.ID); err != nil {
+			return err
+		}
+
+		if err := s.driverRepo.WithTx(tx).IncrementCompletedOrders(ctx, driverID); err != nil {
+			return err
+		}
+		if err := s.passengerRepo.WithTx(tx).IncrementTotalOrders(ctx, order.PassengerID); err != nil {
+			return err
+		}
+
+		if err := s.notificationService.NotifyUserTx(ctx, tx, order.PassengerID, push.TemplateTripCompleted, map[string]string{
+			"order_id": strconv.Itoa(order.ID),
+			"fare":     strconv.Itoa(order.AmountDue()),
+		}); err != nil {
+			return err
+		}
+		return jobqueue.WriteEvent(ctx, tx, constants.AggregateOrder, strconv.Itoa(order.ID), constants.EventOrderCompleted, map[string]any{
+			"order_id":     order.ID,
+			"driver_id":    driverID,
+			"passenger_id": order.PassengerID,
+			"fare":         order.Fare,
+			"discount":     order.Discount,
+			"distance_km":  order.DistanceKm,
+			"payment":      order.PaymentMethod,
+		})
+	})
+}
+
+// settleFare debits wallet-paid fares. When the wallet no longer covers the amount due,
+// the trip falls back to cash so the passenger is never driven below zero. Promo
+// discounts on cash trips are paid to the driver from the promo funding account.
+func (s *orderService) settleFare(ctx context.Context, tx pgx.Tx, order *entity.Order) error {
+	if order.PaymentMethod != entity.PaymentMethodWallet {
+		return s.walletService.SubsidizeCashTripTx(ctx, tx, order)
+	}
+
+	err := s.walletService.SettleTripTx(ctx, tx, order)
+	if !errors.Is(err, ledger.ErrInsufficientFunds) {
+		return err
+	}
+
+	if err := s.orderRepo.WithTx(tx).UpdatePaymentMethod(ctx, order.ID, entity.PaymentMethodCash); err != nil {
+		return err
+	}
+	order.PaymentMethod = entity.PaymentMethodCash
+
+	logger.Log.Warn().Int("order_id", order.ID).Int("passenger_id", order.PassengerID).Msg("Wallet balance too low at completion, fare falls back to cash")
+	if err := s.walletService.SubsidizeCashTripTx(ctx, tx, order); err != nil {
+		return err
+	}
+	return jobqueue.WriteEvent(ctx, tx, constants.AggregateOrder, strconv.Itoa(order.ID), constants.EventOrderPaymentFallback, map[string]any{
+		"order_id":     order.ID,
+		"passenger_id": order.PassengerID,
+		"fare":         order.Fare,
+		"amount_due":   order.AmountDue(),
+	})
+}
+
+// advanceTrip locks the driver's order, checks it is in the expected status and runs the
+// transition, publishing the new status in the same transaction
+func (s *orderService) advanceTrip(
+	ctx context.Context,
+	driverID, orderID int,
+	from entity.OrderStatus,
+	transition func(ctx context.Context, tx pgx.Tx, order *entity.Order, now time.Time) error,
+) (*dto.OrderResponse, error) {
+	var order *entity.Order
+
+	err := database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		var err error
+		order, err = s.orderRepo.WithTx(tx).FindByIDForUpdate(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil || order.DriverID == nil || *order.DriverID != driverID {
+			return apperror.ErrOrderNotFound
+		}
+		if order.Status != from {
+			return apperror.ErrInvalidOrderStatus
+		}
+
+		if err := transition(ctx, tx, order, time.Now()); err != nil {
+			return err
+		}
+		return publishOrderStatus(ctx, s.publisher, tx, order)
+	})
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return nil, err
+		}
+		logger.Log.Error().Err(err).Int("order_id", orderID).Int("driver_id", driverID).Msg("Failed to update trip status")
+		return nil, apperror.Internal(err)
+	}
+
+	logger.Log.Info().Int("order_id", order.ID).Int("driver_id", driverID).Str("status", string(order.Status)).Msg("Trip status updated")
+	return mapper.ToOrderResponse(order), nil
+}
+
+// quoteTrip checks a trip against the geofence and returns the plan with its rounded
+// distance and fare, zone surcharge included
+func (s *orderService) quoteTrip(ctx context.Context, pickup, dropoff entity.TripStop) (*entity.TripPlan, float64, int, error) {
+	plan, err := s.geofenceService.PlanTrip(ctx, pickup, dropoff)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	distance := geo.DistanceKm(
+		geo.Point{Lat: plan.Pickup.Lat, Long: plan.Pickup.Long},
+		geo.Point{Lat: plan.Dropoff.Lat, Long: plan.Dropoff.Long},
+	)
+	if distance < constants.MinTripDistanceKm {
+		return nil, 0, 0, apperror.ErrInvalidTripRoute
+	}
+	distance = math.Round(distance*100) / 100
+	return plan, distance, utils.CalculateFare(distance) + plan.Surcharge, nil
+}
+
+func isOrderParticipant(order *entity.Order, userID int) bool {
+	return order.PassengerID == userID || (order.DriverID != nil && *order.DriverID == userID)
+}
+
+// enqueueDispatch queues the dispatch step that makes the given offer attempt.
+// The idempotency key makes a duplicate trigger for the same attempt a no-op.
+func enqueueDispatch(ctx context.Context, tx database.DBTX, orderID, attempt int) error {
+	_, err := jobqueue.Enqueue(ctx, tx, jobqueue.NewJob{
+		Type:           constants.JobTypeDispatchOrder,
+		Payload:        dispatchOrderPayload{OrderID: orderID},
+		IdempotencyKey: fmt.Sprintf("dispatch:%d:%d", orderID, attempt),
+	})
+	return err
+}
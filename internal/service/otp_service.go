@@ -5,13 +5,20 @@ import (
 	"crypto/rand"
 	"fmt"
 	"math/big"
+	"strconv"
 	"time"
 
 	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
 	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
 	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/i18n"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/jobqueue"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
-	"github.com/AnggaKay/ojek-kampus-backend/pkg/whatsapp"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 const (
@@ -25,18 +32,42 @@ type OTPService interface {
 	SendOTP(ctx context.Context, req dto.SendOTPRequest, ipAddress, userAgent string) (*dto.SendOTPResponse, error)
 	VerifyOTP(ctx context.Context, req dto.VerifyOTPRequest) (*dto.VerifyOTPResponse, error)
 	ResendOTP(ctx context.Context, req dto.ResendOTPRequest, ipAddress, userAgent string) (*dto.SendOTPResponse, error)
+	ConsumeOTP(ctx context.Context, phoneNumber string, purpose entity.OTPPurpose, code string) error
+	HandleSendOTPJob(ctx context.Context, job *jobqueue.Job) error
+	HandleRedeliverOTPJob(ctx context.Context, job *jobqueue.Job) error
+}
+
+// sendOTPJobPayload is the payload of the otp.send job (the code itself stays in otp_codes)
+type sendOTPJobPayload struct {
+	OTPID  int         `json:"otp_id"`
+	Locale i18n.Locale `json:"locale"`
+}
+
+// redeliverOTPJobPayload is the payload of the otp.redeliver job, queued when WhatsApp
+// reports that an OTP message failed
+type redeliverOTPJobPayload struct {
+	OTPID int `json:"otp_id"`
 }
 
 type otpService struct {
-	otpRepo        repository.OTPRepository
-	whatsappClient *whatsapp.WhatsAppClient
+	db              *pgxpool.Pool
+	otpRepo         repository.OTPRepository
+	messageRepo     repository.WhatsAppMessageRepository
+	whatsAppService WhatsAppService
 }
 
 // NewOTPService creates a new OTP service
-func NewOTPService(otpRepo repository.OTPRepository, whatsappClient *whatsapp.WhatsAppClient) OTPService {
+func NewOTPService(
+	db *pgxpool.Pool,
+	otpRepo repository.OTPRepository,
+	messageRepo repository.WhatsAppMessageRepository,
+	whatsAppService WhatsAppService,
+) OTPService {
 	return &otpService{
-		otpRepo:        otpRepo,
-		whatsappClient: whatsappClient,
+		db:              db,
+		otpRepo:         otpRepo,
+		messageRepo:     messageRepo,
+		whatsAppService: whatsAppService,
 	}
 }
 
@@ -47,6 +78,8 @@ func (s *otpService) SendOTP(ctx context.Context, req dto.SendOTPRequest, ipAddr
 		Str("purpose", string(req.Purpose)).
 		Msg("Sending OTP")
 
+	locale := i18n.FromContext(ctx)
+
 	// Check if there's a recent OTP (rate limiting)
 	existingOTP, err := s.otpRepo.FindLatestByPhoneAndPurpose(ctx, req.PhoneNumber, req.Purpose)
 	if err != nil {
@@ -54,19 +87,20 @@ func (s *otpService) SendOTP(ctx context.Context, req dto.SendOTPRequest, ipAddr
 	}
 
 	if existingOTP != nil && !existingOTP.IsExpired() {
-		// Check cooldown period
+		// Check cooldown period, waived when WhatsApp reported the last code undeliverable
+		delivery, err := s.messageRepo.FindLatestByReference(ctx, entity.WhatsAppPurposeOTP, existingOTP.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check OTP delivery: %w", err)
+		}
+		failed := delivery != nil && delivery.Status == entity.WhatsAppStatusFailed
+
 		timeSinceCreation := time.Since(existingOTP.CreatedAt).Seconds()
-		if timeSinceCreation < OTPResendCooldown {
+		if !failed && timeSinceCreation < OTPResendCooldown {
 			remainingTime := int(OTPResendCooldown - timeSinceCreation)
-			return nil, fmt.Errorf("please wait %d seconds before requesting new OTP", remainingTime)
+			return nil, apperror.ErrOTPCooldown.WithVars(map[string]string{"seconds": strconv.Itoa(remainingTime)})
 		}
 	}
 
-	// Invalidate old OTPs
-	if err := s.otpRepo.InvalidateOldOTPs(ctx, req.PhoneNumber, req.Purpose); err != nil {
-		logger.Log.Warn().Err(err).Msg("Failed to invalidate old OTPs")
-	}
-
 	// Generate 6-digit OTP
 	otpCode, err := s.generateOTP()
 	if err != nil {
@@ -84,26 +118,43 @@ func (s *otpService) SendOTP(ctx context.Context, req dto.SendOTPRequest, ipAddr
 		Attempts:    0,
 		IPAddress:   &ipAddress,
 		UserAgent:   &userAgent,
+		Locale:      string(locale),
 		CreatedAt:   now,
 	}
 
-	if err := s.otpRepo.Create(ctx, otp); err != nil {
-		return nil, fmt.Errorf("failed to save OTP: %w", err)
-	}
+	// Save OTP and queue its WhatsApp delivery atomically, so a saved OTP is always delivered
+	err = database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		otpRepo := s.otpRepo.WithTx(tx)
+
+		// Invalidate old OTPs
+		if err := otpRepo.InvalidateOldOTPs(ctx, req.PhoneNumber, req.Purpose); err != nil {
+			return fmt.Errorf("failed to invalidate old OTPs: %w", err)
+		}
+
+		if err := otpRepo.Create(ctx, otp); err != nil {
+			return fmt.Errorf("failed to save OTP: %w", err)
+		}
 
-	// Send OTP via WhatsApp
-	if err := s.whatsappClient.SendOTP(req.PhoneNumber, otpCode); err != nil {
+		_, err := jobqueue.Enqueue(ctx, tx, jobqueue.NewJob{
+			Type:           constants.JobTypeSendOTP,
+			Payload:        sendOTPJobPayload{OTPID: otp.ID, Locale: locale},
+			IdempotencyKey: fmt.Sprintf("otp:%d", otp.ID),
+			MaxAttempts:    constants.OTPDeliveryMaxAttempts,
+		})
+		return err
+	})
+	if err != nil {
 		logger.Log.Error().
 			Err(err).
 			Str("phone", req.PhoneNumber).
-			Msg("Failed to send WhatsApp message")
-		return nil, fmt.Errorf("failed to send OTP: %w", err)
+			Msg("Failed to create OTP")
+		return nil, err
 	}
 
 	return &dto.SendOTPResponse{
 		PhoneNumber: req.PhoneNumber,
 		ExpiresIn:   OTPExpireMinutes * 60, // in seconds
-		Message:     "Kode OTP telah dikirim ke WhatsApp Anda",
+		Message:     i18n.T(locale, "otp.sent", nil),
 	}, nil
 }
 
@@ -113,6 +164,8 @@ func (s *otpService) VerifyOTP(ctx context.Context, req dto.VerifyOTPRequest) (*
 		Str("phone", req.PhoneNumber).
 		Msg("Verifying OTP")
 
+	locale := i18n.FromContext(ctx)
+
 	// Find OTP by phone and code
 	otp, err := s.otpRepo.FindByPhoneAndCode(ctx, req.PhoneNumber, req.OTPCode)
 	if err != nil {
@@ -123,7 +176,7 @@ func (s *otpService) VerifyOTP(ctx context.Context, req dto.VerifyOTPRequest) (*
 		return &dto.VerifyOTPResponse{
 			PhoneNumber: req.PhoneNumber,
 			Verified:    false,
-			Message:     "Kode OTP tidak valid",
+			Message:     i18n.T(locale, "otp.invalid", nil),
 		}, nil
 	}
 
@@ -137,7 +190,7 @@ func (s *otpService) VerifyOTP(ctx context.Context, req dto.VerifyOTPRequest) (*
 		return &dto.VerifyOTPResponse{
 			PhoneNumber: req.PhoneNumber,
 			Verified:    false,
-			Message:     "Kode OTP sudah pernah digunakan",
+			Message:     i18n.T(locale, "otp.used", nil),
 		}, nil
 	}
 
@@ -146,7 +199,7 @@ func (s *otpService) VerifyOTP(ctx context.Context, req dto.VerifyOTPRequest) (*
 		return &dto.VerifyOTPResponse{
 			PhoneNumber: req.PhoneNumber,
 			Verified:    false,
-			Message:     "Kode OTP telah kadaluarsa",
+			Message:     i18n.T(locale, "otp.expired", nil),
 		}, nil
 	}
 
@@ -155,7 +208,7 @@ func (s *otpService) VerifyOTP(ctx context.Context, req dto.VerifyOTPRequest) (*
 		return &dto.VerifyOTPResponse{
 			PhoneNumber: req.PhoneNumber,
 			Verified:    false,
-			Message:     "Terlalu banyak percobaan. Silakan minta kode baru",
+			Message:     i18n.T(locale, "otp.too_many_attempts", nil),
 		}, nil
 	}
 
@@ -171,7 +224,7 @@ func (s *otpService) VerifyOTP(ctx context.Context, req dto.VerifyOTPRequest) (*
 	return &dto.VerifyOTPResponse{
 		PhoneNumber: req.PhoneNumber,
 		Verified:    true,
-		Message:     "Verifikasi berhasil",
+		Message:     i18n.T(locale, "otp.verified", nil),
 	}, nil
 }
 
@@ -183,6 +236,118 @@ func (s *otpService) ResendOTP(ctx context.Context, req dto.ResendOTPRequest, ip
 	}, ipAddress, userAgent)
 }
 
+// ConsumeOTP checks a code sent for a specific purpose and marks it used. Unlike VerifyOTP
+// it fails with an error, for flows that continue only on a valid code.
+func (s *otpService) ConsumeOTP(ctx context.Context, phoneNumber string, purpose entity.OTPPurpose, code string) error {
+	otp, err := s.otpRepo.FindByPhoneAndCode(ctx, phoneNumber, code)
+	if err != nil {
+		return apperror.ErrOTPVerifyFailed.Wrap(err)
+	}
+	if otp == nil || otp.Purpose != purpose {
+		return apperror.ErrOTPInvalid
+	}
+
+	if err := s.otpRepo.IncrementAttempts(ctx, otp.ID); err != nil {
+		logger.Log.Warn().Err(err).Msg("Failed to increment attempts")
+	}
+
+	switch {
+	case otp.IsUsed:
+		return apperror.ErrOTPUsed
+	case otp.IsExpired():
+		return apperror.ErrOTPExpired
+	case otp.Attempts >= MaxVerifyAttempts:
+		return apperror.ErrOTPTooManyAttempts
+	}
+
+	if err := s.otpRepo.MarkAsUsed(ctx, otp.ID); err != nil {
+		return apperror.ErrOTPVerifyFailed.Wrap(err)
+	}
+	return nil
+}
+
+// HandleSendOTPJob delivers a saved OTP via WhatsApp (otp.send job).
+// OTPs that were used, superseded or expired meanwhile are skipped instead of retried.
+func (s *otpService) HandleSendOTPJob(ctx context.Context, job *jobqueue.Job) error {
+	var payload sendOTPJobPayload
+	if err := job.Decode(&payload); err != nil {
+		return jobqueue.Permanent(fmt.Errorf("invalid otp.send payload: %w", err))
+	}
+
+	otp, err := s.otpRepo.FindByID(ctx, payload.OTPID)
+	if err != nil {
+		return err
+	}
+	if otp == nil {
+		return jobqueue.Permanent(fmt.Errorf("OTP %d not found", payload.OTPID))
+	}
+
+	if !otp.IsValid() {
+		logger.Log.Info().Int("otp_id", otp.ID).Msg("Skipping delivery of used or expired OTP")
+		return nil
+	}
+
+	return s.deliver(ctx, otp, payload.Locale, job.Attempts)
+}
+
+// HandleRedeliverOTPJob sends an OTP again after WhatsApp reported its last message
+// failed (otp.redeliver job), at most OTPMaxRedeliveries times. The user can still ask
+// for a new code; the resend cooldown is waived while the last delivery failed.
+func (s *otpService) HandleRedeliverOTPJob(ctx context.Context, job *jobqueue.Job) error {
+	var payload redeliverOTPJobPayload
+	if err := job.Decode(&payload); err != nil {
+		return jobqueue.Permanent(fmt.Errorf("invalid otp.redeliver payload: %w", err))
+	}
+
+	otp, err := s.otpRepo.FindByID(ctx, payload.OTPID)
+	if err != nil {
+		return err
+	}
+	if otp == nil {
+		return jobqueue.Permanent(fmt.Errorf("OTP %d not found", payload.OTPID))
+	}
+
+	if !otp.IsValid() {
+		logger.Log.Info().Int("otp_id", otp.ID).Msg("Skipping redelivery of used or expired OTP")
+		return nil
+	}
+
+	sent, err := s.messageRepo.CountByReference(ctx, entity.WhatsAppPurposeOTP, otp.ID)
+	if err != nil {
+		return fmt.Errorf("failed to count OTP deliveries: %w", err)
+	}
+	if sent > constants.OTPMaxRedeliveries {
+		logger.Log.Warn().Int("otp_id", otp.ID).Int("sent", sent).Msg("Giving up redelivering OTP")
+		return nil
+	}
+
+	logger.Log.Info().Int("otp_id", otp.ID).Int("sent", sent).Msg("Redelivering OTP after failed delivery")
+	return s.deliver(ctx, otp, i18n.OrDefault(otp.Locale), job.Attempts)
+}
+
+// deliver renders an OTP in locale and sends it via WhatsApp, tracked against the OTP
+func (s *otpService) deliver(ctx context.Context, otp *entity.OTPCode, locale i18n.Locale, attempt int) error {
+	key := "whatsapp.otp"
+	if otp.Purpose == entity.OTPPurposeTrustedContact {
+		key = "whatsapp.otp_trusted_contact"
+	}
+	message := i18n.T(locale, key, map[string]string{
+		"code":    otp.OTPCode,
+		"minutes": strconv.Itoa(OTPExpireMinutes),
+	})
+	if err := s.whatsAppService.Send(ctx, otp.PhoneNumber, message, entity.WhatsAppPurposeOTP, &otp.ID); err != nil {
+		logger.Log.Error().
+			Err(err).
+			Int("otp_id", otp.ID).
+			Str("phone", otp.PhoneNumber).
+			Int("attempt", attempt).
+			Msg("Failed to send WhatsApp message")
+		return fmt.Errorf("failed to send OTP: %w", err)
+	}
+
+	return nil
+}
+
 // generateOTP generates a secure 6-digit OTP code
 func (s *otpService) generateOTP() (string, error) {
 	// Generate random number between 100000 and 999999
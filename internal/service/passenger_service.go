@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"mime/multipart"
+	"strings"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/mapper"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/storage"
+)
+
+type PassengerService interface {
+	GetProfile(ctx context.Context, userID int) (*dto.PassengerProfileResponse, error)
+	UpdateProfile(ctx context.Context, userID int, req dto.UpdatePassengerProfileRequest) (*dto.PassengerProfileResponse, error)
+	UpdateProfilePicture(ctx context.Context, userID int, file *multipart.FileHeader) (*dto.PassengerProfileResponse, error)
+}
+
+type passengerService struct {
+	passengerRepo   repository.PassengerRepository
+	deviceTokenRepo repository.DeviceTokenRepository
+	fileStorage     storage.FileStorage
+}
+
+func NewPassengerService(
+	passengerRepo repository.PassengerRepository,
+	deviceTokenRepo repository.DeviceTokenRepository,
+	fileStorage storage.FileStorage,
+) PassengerService {
+	return &passengerService{
+		passengerRepo:   passengerRepo,
+		deviceTokenRepo: deviceTokenRepo,
+		fileStorage:     fileStorage,
+	}
+}
+
+func (s *passengerService) GetProfile(ctx context.Context, userID int) (*dto.PassengerProfileResponse, error) {
+	profile, err := s.passengerRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperror.ErrPassengerProfileNotFound
+	}
+	return mapper.ToPassengerProfileResponse(profile), nil
+}
+
+// UpdateProfile applies a partial passenger profile update (currently FCM token registration)
+func (s *passengerService) UpdateProfile(ctx context.Context, userID int, req dto.UpdatePassengerProfileRequest) (*dto.PassengerProfileResponse, error) {
+	profile, err := s.passengerRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperror.ErrPassengerProfileNotFound
+	}
+
+	if req.FCMToken == nil {
+		return nil, apperror.ErrNoProfileChanges
+	}
+
+	// Empty token unregisters the device
+	previousToken := profile.FCMToken
+	token := strings.TrimSpace(*req.FCMToken)
+	if token == "" {
+		profile.FCMToken = nil
+	} else {
+		profile.FCMToken = &token
+	}
+
+	if err := s.passengerRepo.Update(ctx, profile); err != nil {
+		return nil, fmt.Errorf("failed to update passenger profile: %w", err)
+	}
+
+	// Keep the multi-device registry in sync with the profile's primary token
+	if previousToken != nil && *previousToken != "" && *previousToken != token {
+		if err := s.deviceTokenRepo.DeleteByUserAndToken(ctx, userID, *previousToken); err != nil {
+			logger.Log.Warn().Err(err).Int("user_id", userID).Msg("Failed to remove previous push device")
+		}
+	}
+	if profile.FCMToken != nil {
+		device := &entity.DeviceToken{UserID: userID, Token: token, Platform: entity.PlatformAndroid}
+		if err := s.deviceTokenRepo.Upsert(ctx, device); err != nil {
+			logger.Log.Warn().Err(err).Int("user_id", userID).Msg("Failed to register push device")
+		}
+	}
+
+	logger.Log.Info().Int("user_id", userID).Bool("fcm_registered", profile.FCMToken != nil).Msg("Passenger profile updated")
+
+	return mapper.ToPassengerProfileResponse(profile), nil
+}
+
+// UpdateProfilePicture stores a new avatar under the passengers storage namespace
+func (s *passengerService) UpdateProfilePicture(ctx context.Context, userID int, file *multipart.FileHeader) (*dto.PassengerProfileResponse, error) {
+	profile, err := s.passengerRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperror.ErrPassengerProfileNotFound
+	}
+
+	if err := storage.ValidateImage(file); err != nil {
+		logger.Log.Warn().Err(err).Int("user_id", userID).Msg("Invalid passenger avatar")
+		return nil, err
+	}
+
+	newPath, err := s.fileStorage.Upload(file, constants.StorageNamespacePassengers, userID, constants.DocumentTypeProfilePicture)
+	if err != nil {
+		logger.Log.Error().Err(err).Int("user_id", userID).Msg("Failed to upload passenger avatar")
+		return nil, err
+	}
+
+	oldPath := profile.ProfilePicture
+	profile.ProfilePicture = &newPath
+
+	if err := s.passengerRepo.Update(ctx, profile); err != nil {
+		_ = s.fileStorage.Delete(newPath)
+		return nil, fmt.Errorf("failed to update passenger profile: %w", err)
+	}
+
+	if oldPath != nil && *oldPath != "" {
+		_ = s.fileStorage.Delete(*oldPath)
+	}
+
+	logger.Log.Info().Int("user_id", userID).Str("path", newPath).Msg("Passenger avatar updated")
+
+	return mapper.ToPassengerProfileResponse(profile), nil
+}
@@ -0,0 +1,386 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/mapper"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/clock"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/jobqueue"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/payment"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/push"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PaymentService takes wallet top-ups through the payment gateway: it creates charges,
+// applies verified gateway notifications and polls charges whose notification never came
+type PaymentService interface {
+	CreateTopUpCharge(ctx context.Context, passengerID int, req dto.CreateTopUpChargeRequest) (*dto.PaymentChargeResponse, error)
+	GetTopUpCharge(ctx context.Context, passengerID int, chargeID int64) (*dto.PaymentChargeResponse, error)
+	ListTopUpCharges(ctx context.Context, passengerID, limit, offset int) ([]*dto.PaymentChargeResponse, error)
+	HandleNotification(ctx context.Context, header http.Header, body []byte) error
+	HandleCheckStatusJob(ctx context.Context, job *jobqueue.Job) error
+}
+
+// paymentCheckPayload is the payload of the payment.check_status job
+type paymentCheckPayload struct {
+	ChargeID int64 `json:"charge_id"`
+}
+
+type paymentService struct {
+	db                  *pgxpool.Pool
+	clock               clock.Clock
+	provider            payment.Provider
+	chargeRepo          repository.PaymentChargeRepository
+	userRepo            repository.UserRepository
+	walletService       WalletService
+	notificationService NotificationService
+}
+
+func NewPaymentService(
+	db *pgxpool.Pool,
+	clk clock.Clock,
+	provider payment.Provider,
+	chargeRepo repository.PaymentChargeRepository,
+	userRepo repository.UserRepository,
+	walletService WalletService,
+	notificationService NotificationService,
+) PaymentService {
+	return &paymentService{
+		db:                  db,
+		clock:               clk,
+		provider:            provider,
+		chargeRepo:          chargeRepo,
+		userRepo:            userRepo,
+		walletService:       walletService,
+		notificationService: notificationService,
+	}
+}
+
+// CreateTopUpCharge records the charge first, so a gateway notification can never arrive
+// for a reference we do not know, then asks the gateway for the QR code or virtual account
+func (s *paymentService) CreateTopUpCharge(ctx context.Context, passengerID int, req dto.CreateTopUpChargeRequest) (*dto.PaymentChargeResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, passengerID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if user == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+
+	reference, err := newChargeReference(passengerID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+
+	charge := &entity.PaymentCharge{
+		UserID:    passengerID,
+		Provider:  s.provider.Name(),
+		Reference: reference,
+		Channel:   req.Channel,
+		Amount:    req.Amount,
+		Status:    entity.PaymentChargePending,
+		ExpiresAt: s.clock.Now().Add(constants.PaymentChargeExpiry),
+	}
+	if payment.Channel(req.Channel) == payment.ChannelVA {
+		charge.Bank = &req.Bank
+	}
+	if err := s.chargeRepo.Create(ctx, charge); err != nil {
+		logger.Log.Error().Err(err).Int("user_id", passengerID).Msg("Failed to create payment charge")
+		return nil, apperror.Internal(err)
+	}
+
+	created, err := s.provider.CreateCharge(ctx, payment.ChargeRequest{
+		Reference:     charge.Reference,
+		Amount:        charge.Amount,
+		Channel:       payment.Channel(charge.Channel),
+		Bank:          req.Bank,
+		CustomerName:  user.FullName,
+		CustomerPhone: user.PhoneNumber,
+		ExpiresIn:     constants.PaymentChargeExpiry,
+	})
+	if err != nil {
+		logger.Log.Error().Err(err).Str("reference", charge.Reference).Str("provider", charge.Provider).Msg("Payment provider rejected charge")
+		if err := s.chargeRepo.MarkClosed(ctx, charge.ID, entity.PaymentChargeFailed); err != nil {
+			logger.Log.Error().Err(err).Int64("charge_id", charge.ID).Msg("Failed to mark payment charge failed")
+		}
+		return nil, apperror.ErrPaymentProviderFailed
+	}
+
+	charge.ProviderRef = nullableString(created.ProviderRef)
+	charge.QRString = nullableString(created.QRString)
+	charge.QRImageURL = nullableString(created.QRImageURL)
+	charge.VANumber = nullableString(created.VANumber)
+	if created.Bank != "" {
+		charge.Bank = &created.Bank
+	}
+	if !created.ExpiresAt.IsZero() {
+		charge.ExpiresAt = created.ExpiresAt
+	}
+
+	err = database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		if err := s.chargeRepo.WithTx(tx).UpdateInstructions(ctx, charge); err != nil {
+			return err
+		}
+		return s.scheduleCheck(ctx, tx, charge, s.clock.Now().Add(constants.PaymentStatusPollDelay))
+	})
+	if err != nil {
+		logger.Log.Error().Err(err).Int64("charge_id", charge.ID).Msg("Failed to store payment instructions")
+		return nil, apperror.Internal(err)
+	}
+
+	logger.Log.Info().
+		Int64("charge_id", charge.ID).
+		Int("user_id", passengerID).
+		Str("reference", charge.Reference).
+		Str("channel", charge.Channel).
+		Int64("amount", charge.Amount).
+		Msg("Top-up charge created")
+
+	return mapper.ToPaymentChargeResponse(charge), nil
+}
+
+// GetTopUpCharge returns one of the passenger's charges (clients poll it while the QR is shown)
+func (s *paymentService) GetTopUpCharge(ctx context.Context, passengerID int, chargeID int64) (*dto.PaymentChargeResponse, error) {
+	charge, err := s.chargeRepo.FindByID(ctx, chargeID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if charge == nil || charge.UserID != passengerID {
+		return nil, apperror.ErrPaymentChargeNotFound
+	}
+	return mapper.ToPaymentChargeResponse(charge), nil
+}
+
+// ListTopUpCharges returns the passenger's charges, newest first
+func (s *paymentService) ListTopUpCharges(ctx context.Context, passengerID, limit, offset int) ([]*dto.PaymentChargeResponse, error) {
+	charges, err := s.chargeRepo.FindByUser(ctx, passengerID, limit, offset)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return mapper.ToPaymentChargeResponses(charges), nil
+}
+
+// HandleNotification verifies a gateway webhook and applies it. Gateways retry until they
+// get a 2xx, so duplicates and out-of-order deliveries are expected and harmless.
+func (s *paymentService) HandleNotification(ctx context.Context, header http.Header, body []byte) error {
+	notification, err := s.provider.ParseNotification(header, body)
+	if err != nil {
+		if errors.Is(err, payment.ErrInvalidSignature) {
+			logger.Log.Warn().Str("provider", s.provider.Name()).Msg("Rejected payment notification with invalid signature")
+			return apperror.ErrInvalidPaymentSignature
+		}
+		logger.Log.Warn().Err(err).Str("provider", s.provider.Name()).Msg("Rejected malformed payment notification")
+		return apperror.ErrInvalidRequest
+	}
+
+	_, err = s.applyResult(ctx, &notification.StatusResult)
+	return err
+}
+
+// HandleCheckStatusJob polls the gateway for a charge still pending, in case its webhook
+// was missed. Once the charge is past expiry it is cancelled at the gateway and closed.
+func (s *paymentService) HandleCheckStatusJob(ctx context.Context, job *jobqueue.Job) error {
+	var payload paymentCheckPayload
+	if err := job.Decode(&payload); err != nil {
+		return jobqueue.Permanent(fmt.Errorf("invalid payment.check_status payload: %w", err))
+	}
+
+	charge, err := s.chargeRepo.FindByID(ctx, payload.ChargeID)
+	if err != nil {
+		return err
+	}
+	if charge == nil {
+		return jobqueue.Permanent(fmt.Errorf("payment charge %d not found", payload.ChargeID))
+	}
+	if charge.Status != entity.PaymentChargePending {
+		return nil
+	}
+
+	now := s.clock.Now()
+	deadline := charge.ExpiresAt.Add(constants.PaymentExpiryGrace)
+	if err := s.chargeRepo.MarkChecked(ctx, charge.ID, now); err != nil {
+		return err
+	}
+
+	if now.Before(deadline) {
+		result, err := s.provider.GetStatus(ctx, charge.Reference)
+		if err != nil {
+			return err
+		}
+		updated, err := s.applyResult(ctx, result)
+		if err != nil {
+			return jobFailure(err)
+		}
+		if updated.Status != entity.PaymentChargePending {
+			return nil
+		}
+
+		next := now.Add(constants.PaymentStatusPollInterval)
+		if next.After(deadline) {
+			next = deadline
+		}
+		return s.scheduleCheck(ctx, s.db, charge, next)
+	}
+
+	// Stop the gateway from accepting payment first, then take its final word: a payment
+	// that landed just before the cut-off is still credited
+	if err := s.provider.Expire(ctx, charge.Reference); err != nil && !errors.Is(err, payment.ErrChargeNotFound) {
+		return err
+	}
+	result, err := s.provider.GetStatus(ctx, charge.Reference)
+	if errors.Is(err, payment.ErrChargeNotFound) {
+		result = &payment.StatusResult{Reference: charge.Reference, Status: payment.StatusExpired, Amount: charge.Amount}
+	} else if err != nil {
+		return err
+	}
+	if result.Status == payment.StatusPending {
+		result.Status = payment.StatusExpired
+	}
+
+	if _, err := s.applyResult(ctx, result); err != nil {
+		return jobFailure(err)
+	}
+	return nil
+}
+
+// applyResult brings the charge in line with the gateway's view. A payment is credited
+// whatever the local status says, since the money has arrived even if we already expired
+// the charge; the ledger idempotency key makes repeated credits impossible.
+func (s *paymentService) applyResult(ctx context.Context, result *payment.StatusResult) (*entity.PaymentCharge, error) {
+	var charge *entity.PaymentCharge
+	var credit *dto.TopUpResponse
+
+	err := database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		chargeRepo := s.chargeRepo.WithTx(tx)
+
+		var err error
+		charge, err = chargeRepo.FindByReferenceForUpdate(ctx, result.Reference)
+		if err != nil {
+			return err
+		}
+		if charge == nil {
+			return apperror.ErrPaymentChargeNotFound
+		}
+		if charge.Status == entity.PaymentChargePaid || result.Status == payment.StatusPending {
+			return nil
+		}
+
+		if result.Status != payment.StatusPaid {
+			if charge.Status != entity.PaymentChargePending {
+				return nil
+			}
+			charge.Status = entity.PaymentChargeStatus(result.Status)
+			return chargeRepo.MarkClosed(ctx, charge.ID, charge.Status)
+		}
+
+		if result.Amount != charge.Amount {
+			logger.Log.Error().
+				Int64("charge_id", charge.ID).
+				Int64("expected", charge.Amount).
+				Int64("paid", result.Amount).
+				Msg("Payment amount does not match charge")
+			return apperror.ErrPaymentAmountMismatch
+		}
+
+		credit, err = s.walletService.CreditTopUpTx(ctx, tx, charge.UserID, charge.Amount,
+			constants.TopUpRefPaymentCharge+charge.Reference,
+			fmt.Sprintf("Top-up via %s", charge.Channel), nil)
+		if err != nil {
+			return err
+		}
+
+		paidAt := s.clock.Now()
+		if result.PaidAt != nil {
+			paidAt = *result.PaidAt
+		}
+		if err := chargeRepo.MarkPaid(ctx, charge.ID, paidAt, credit.TransactionID); err != nil {
+			return err
+		}
+		charge.Status = entity.PaymentChargePaid
+		charge.PaidAt = &paidAt
+		charge.LedgerTransactionID = &credit.TransactionID
+
+		if err := jobqueue.WriteEvent(ctx, tx, constants.AggregatePaymentCharge, strconv.FormatInt(charge.ID, 10), constants.EventPaymentChargePaid, map[string]any{
+			"charge_id": charge.ID,
+			"user_id":   charge.UserID,
+			"reference": charge.Reference,
+			"channel":   charge.Channel,
+			"amount":    charge.Amount,
+		}); err != nil {
+			return err
+		}
+
+		return s.notificationService.NotifyUserTx(ctx, tx, charge.UserID, push.TemplateWalletToppedUp, map[string]string{
+			"amount":  strconv.FormatInt(credit.Amount, 10),
+			"balance": strconv.FormatInt(credit.Balance, 10),
+		})
+	})
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return nil, err
+		}
+		logger.Log.Error().Err(err).Str("reference", result.Reference).Msg("Failed to apply payment status")
+		return nil, apperror.Internal(err)
+	}
+
+	if credit != nil {
+		logger.Log.Info().
+			Int64("charge_id", charge.ID).
+			Int("user_id", charge.UserID).
+			Int64("transaction_id", credit.TransactionID).
+			Int64("amount", credit.Amount).
+			Msg("Top-up charge paid")
+	}
+	return charge, nil
+}
+
+// scheduleCheck queues the next status poll of a charge
+func (s *paymentService) scheduleCheck(ctx context.Context, db database.DBTX, charge *entity.PaymentCharge, runAt time.Time) error {
+	_, err := jobqueue.Enqueue(ctx, db, jobqueue.NewJob{
+		Type:           constants.JobTypePaymentCheckStatus,
+		Payload:        paymentCheckPayload{ChargeID: charge.ID},
+		IdempotencyKey: fmt.Sprintf("payment.check:%d:%d", charge.ID, runAt.Unix()),
+		RunAt:          runAt,
+	})
+	return err
+}
+
+// jobFailure keeps business rejections (unknown charge, amount mismatch) from being
+// retried; internal errors are retried with backoff
+func jobFailure(err error) error {
+	if appErr, ok := apperror.As(err); ok && appErr.Status < http.StatusInternalServerError {
+		return jobqueue.Permanent(err)
+	}
+	return err
+}
+
+// newChargeReference builds the gateway order id, e.g. TOPUP-42-3f9a1c0d7e2b4a65
+func newChargeReference(userID int) (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("TOPUP-%d-%s", userID, hex.EncodeToString(b)), nil
+}
+
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,362 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/mapper"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/clock"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+// PromoService manages promo campaigns and their redemptions. A redemption is reserved
+// with the order, redeemed when the trip completes and released when the order is
+// cancelled or expires, so an abandoned order never uses up a promo.
+type PromoService interface {
+	CreatePromo(ctx context.Context, adminID int, req dto.CreatePromoRequest) (*dto.PromoResponse, error)
+	UpdatePromo(ctx context.Context, promoID int, req dto.UpdatePromoRequest) (*dto.PromoResponse, error)
+	GetPromo(ctx context.Context, promoID int) (*dto.PromoResponse, error)
+	ListPromos(ctx context.Context, activeOnly bool, limit, offset int) ([]*dto.PromoResponse, error)
+	Quote(ctx context.Context, userID int, code string, fare int) (*entity.Promo, int, error)
+	ReserveTx(ctx context.Context, tx pgx.Tx, order *entity.Order) error
+	RedeemTx(ctx context.Context, tx pgx.Tx, orderID int) error
+	ReleaseTx(ctx context.Context, tx pgx.Tx, orderID int) error
+}
+
+type promoService struct {
+	clock         clock.Clock
+	promoRepo     repository.PromoRepository
+	userRepo      repository.UserRepository
+	passengerRepo repository.PassengerRepository
+}
+
+func NewPromoService(clk clock.Clock, promoRepo repository.PromoRepository, userRepo repository.UserRepository, passengerRepo repository.PassengerRepository) PromoService {
+	return &promoService{
+		clock:         clk,
+		promoRepo:     promoRepo,
+		userRepo:      userRepo,
+		passengerRepo: passengerRepo,
+	}
+}
+
+// NormalizePromoCode makes codes case-insensitive
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// CreatePromo defines a new campaign
+func (s *promoService) CreatePromo(ctx context.Context, adminID int, req dto.CreatePromoRequest) (*dto.PromoResponse, error) {
+	promo := &entity.Promo{
+		Code:          NormalizePromoCode(req.Code),
+		Name:          req.Name,
+		Description:   req.Description,
+		DiscountType:  entity.DiscountType(req.DiscountType),
+		DiscountValue: req.DiscountValue,
+		MaxDiscount:   req.MaxDiscount,
+		MinFare:       req.MinFare,
+		UsageLimit:    req.UsageLimit,
+		PerUserLimit:  1,
+		EligibleRoles: req.EligibleRoles,
+		NewUsersOnly:  req.NewUsersOnly,
+		CampusOnly:    req.CampusOnly,
+		EmailDomains:  req.EmailDomains,
+		StartsAt:      req.StartsAt,
+		EndsAt:        req.EndsAt,
+		IsActive:      true,
+		CreatedBy:     &adminID,
+	}
+	if req.PerUserLimit > 0 {
+		promo.PerUserLimit = req.PerUserLimit
+	}
+	if req.IsActive != nil {
+		promo.IsActive = *req.IsActive
+	}
+	if promo.EligibleRoles == nil {
+		promo.EligibleRoles = []string{}
+	}
+	if promo.EmailDomains == nil {
+		promo.EmailDomains = []string{}
+	}
+	if err := validatePromoRules(promo); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.promoRepo.ExistsByCode(ctx, promo.Code)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if exists {
+		return nil, apperror.ErrPromoCodeExists
+	}
+
+	if err := s.promoRepo.Create(ctx, promo); err != nil {
+		logger.Log.Error().Err(err).Str("code", promo.Code).Msg("Failed to create promo")
+		return nil, apperror.Internal(err)
+	}
+
+	logger.Log.Info().Int("promo_id", promo.ID).Str("code", promo.Code).Int("admin_id", adminID).Msg("Promo created")
+	return mapper.ToPromoResponse(promo), nil
+}
+
+// UpdatePromo changes a campaign's window, caps, eligibility or active flag
+func (s *promoService) UpdatePromo(ctx context.Context, promoID int, req dto.UpdatePromoRequest) (*dto.PromoResponse, error) {
+	promo, err := s.promoRepo.FindByID(ctx, promoID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if promo == nil {
+		return nil, apperror.ErrPromoNotFound
+	}
+
+	if req.Name != nil {
+		promo.Name = *req.Name
+	}
+	if req.Description != nil {
+		promo.Description = req.Description
+	}
+	if req.UsageLimit != nil {
+		promo.UsageLimit = req.UsageLimit
+	}
+	if req.PerUserLimit != nil {
+		promo.PerUserLimit = *req.PerUserLimit
+	}
+	if req.EligibleRoles != nil {
+		promo.EligibleRoles = *req.EligibleRoles
+	}
+	if req.NewUsersOnly != nil {
+		promo.NewUsersOnly = *req.NewUsersOnly
+	}
+	if req.CampusOnly != nil {
+		promo.CampusOnly = *req.CampusOnly
+	}
+	if req.EmailDomains != nil {
+		promo.EmailDomains = *req.EmailDomains
+	}
+	if req.StartsAt != nil {
+		promo.StartsAt = *req.StartsAt
+	}
+	if req.EndsAt != nil {
+		promo.EndsAt = *req.EndsAt
+	}
+	if req.IsActive != nil {
+		promo.IsActive = *req.IsActive
+	}
+	if err := validatePromoRules(promo); err != nil {
+		return nil, err
+	}
+
+	if err := s.promoRepo.Update(ctx, promo); err != nil {
+		logger.Log.Error().Err(err).Int("promo_id", promoID).Msg("Failed to update promo")
+		return nil, apperror.Internal(err)
+	}
+
+	logger.Log.Info().Int("promo_id", promo.ID).Str("code", promo.Code).Msg("Promo updated")
+	return mapper.ToPromoResponse(promo), nil
+}
+
+// GetPromo returns a promo with its usage
+func (s *promoService) GetPromo(ctx context.Context, promoID int) (*dto.PromoResponse, error) {
+	promo, err := s.promoRepo.FindByID(ctx, promoID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if promo == nil {
+		return nil, apperror.ErrPromoNotFound
+	}
+	return mapper.ToPromoResponse(promo), nil
+}
+
+// ListPromos returns promos, optionally only those still running
+func (s *promoService) ListPromos(ctx context.Context, activeOnly bool, limit, offset int) ([]*dto.PromoResponse, error) {
+	promos, err := s.promoRepo.List(ctx, activeOnly, limit, offset)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return mapper.ToPromoResponses(promos), nil
+}
+
+// Quote validates a code for the user and fare and returns the discount it gives. It does
+// not reserve anything; ReserveTx repeats the checks under the promo lock.
+func (s *promoService) Quote(ctx context.Context, userID int, code string, fare int) (*entity.Promo, int, error) {
+	promo, err := s.promoRepo.FindByCode(ctx, NormalizePromoCode(code))
+	if err != nil {
+		return nil, 0, apperror.Internal(err)
+	}
+	if err := s.checkEligibility(ctx, s.promoRepo, s.passengerRepo, promo, userID, fare); err != nil {
+		return nil, 0, err
+	}
+	return promo, promo.DiscountFor(fare), nil
+}
+
+// ReserveTx takes one use of the order's promo inside the order transaction. The promo
+// row stays locked until the transaction ends, so concurrent orders cannot overrun the
+// global or per-user caps.
+func (s *promoService) ReserveTx(ctx context.Context, tx pgx.Tx, order *entity.Order) error {
+	if order.PromoCode == nil {
+		return nil
+	}
+	promoRepo := s.promoRepo.WithTx(tx)
+
+	promo, err := promoRepo.FindByCodeForUpdate(ctx, *order.PromoCode)
+	if err != nil {
+		return err
+	}
+	if err := s.checkEligibility(ctx, promoRepo, s.passengerRepo.WithTx(tx), promo, order.PassengerID, order.Fare); err != nil {
+		return err
+	}
+
+	// The discount rule cannot change after creation, so this only trips on a bug
+	if discount := promo.DiscountFor(order.Fare); discount != order.Discount {
+		return fmt.Errorf("promo %s gives %d on order %d, quoted %d", promo.Code, discount, order.ID, order.Discount)
+	}
+	if order.Discount == 0 {
+		return nil
+	}
+
+	redemption := &entity.PromoRedemption{
+		PromoID:    promo.ID,
+		UserID:     order.PassengerID,
+		OrderID:    order.ID,
+		Discount:   order.Discount,
+		Status:     entity.RedemptionReserved,
+		ReservedAt: s.clock.Now(),
+	}
+	if err := promoRepo.CreateRedemption(ctx, redemption); err != nil {
+		return err
+	}
+	if err := promoRepo.AdjustUsage(ctx, promo.ID, 1); err != nil {
+		return err
+	}
+
+	logger.Log.Info().Int("promo_id", promo.ID).Int("order_id", order.ID).Int("discount", order.Discount).Msg("Promo reserved")
+	return nil
+}
+
+// RedeemTx confirms the order's reservation once the trip completes
+func (s *promoService) RedeemTx(ctx context.Context, tx pgx.Tx, orderID int) error {
+	promoRepo := s.promoRepo.WithTx(tx)
+
+	redemption, err := promoRepo.FindRedemptionByOrderForUpdate(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	if redemption == nil || redemption.Status != entity.RedemptionReserved {
+		return nil
+	}
+	return promoRepo.MarkRedeemed(ctx, redemption.ID, s.clock.Now())
+}
+
+// ReleaseTx gives the order's promo use back when the order will not be completed
+func (s *promoService) ReleaseTx(ctx context.Context, tx pgx.Tx, orderID int) error {
+	promoRepo := s.promoRepo.WithTx(tx)
+
+	redemption, err := promoRepo.FindRedemptionByOrderForUpdate(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	if redemption == nil || redemption.Status != entity.RedemptionReserved {
+		return nil
+	}
+
+	if _, err := promoRepo.FindByIDForUpdate(ctx, redemption.PromoID); err != nil {
+		return err
+	}
+	if err := promoRepo.MarkReleased(ctx, redemption.ID, s.clock.Now()); err != nil {
+		return err
+	}
+	if err := promoRepo.AdjustUsage(ctx, redemption.PromoID, -1); err != nil {
+		return err
+	}
+
+	logger.Log.Info().Int("promo_id", redemption.PromoID).Int("order_id", orderID).Msg("Promo released")
+	return nil
+}
+
+// checkEligibility applies every promo rule for the user and fare
+func (s *promoService) checkEligibility(
+	ctx context.Context,
+	promoRepo repository.PromoRepository,
+	passengerRepo repository.PassengerRepository,
+	promo *entity.Promo,
+	userID, fare int,
+) error {
+	if promo == nil {
+		return apperror.ErrPromoNotFound
+	}
+	if !promo.IsOpen(s.clock.Now()) {
+		return apperror.ErrPromoNotActive
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if user == nil {
+		return apperror.ErrUserNotFound
+	}
+	if !promo.AllowsRole(user.Role) || !promo.AllowsEmail(user.VerifiedEmail()) {
+		return apperror.ErrPromoNotEligible
+	}
+	if promo.CampusOnly && !user.CampusEligible {
+		return apperror.ErrPromoNotEligible
+	}
+	if promo.NewUsersOnly {
+		if user.Role != entity.RolePassenger {
+			return apperror.ErrPromoNotEligible
+		}
+		profile, err := passengerRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if profile.TotalOrders > 0 {
+			return apperror.ErrPromoNotEligible
+		}
+	}
+
+	if fare < promo.MinFare {
+		return apperror.ErrPromoMinFare.WithVars(map[string]string{"min_fare": strconv.Itoa(promo.MinFare)})
+	}
+	if promo.IsExhausted() {
+		return apperror.ErrPromoExhausted
+	}
+
+	used, err := promoRepo.CountLiveRedemptions(ctx, promo.ID, userID)
+	if err != nil {
+		return err
+	}
+	if used >= promo.PerUserLimit {
+		return apperror.ErrPromoUserLimit
+	}
+	return nil
+}
+
+// validatePromoRules checks the rules the request validator cannot express
+func validatePromoRules(promo *entity.Promo) error {
+	invalid := func(reason string) error {
+		return apperror.ErrInvalidPromoRules.WithVars(map[string]string{"reason": reason})
+	}
+
+	if !promo.EndsAt.After(promo.StartsAt) {
+		return invalid("ends_at must be after starts_at")
+	}
+	if promo.DiscountType == entity.DiscountPercent && promo.DiscountValue > 100 {
+		return invalid("a percentage discount cannot exceed 100")
+	}
+	if promo.DiscountType == entity.DiscountFlat && promo.MaxDiscount != nil {
+		return invalid("max_discount only applies to PERCENT promos")
+	}
+	for _, domain := range promo.EmailDomains {
+		if strings.Contains(domain, "@") || strings.Count(domain, "*") > 1 ||
+			(strings.Contains(domain, "*") && !strings.HasPrefix(domain, "*.")) {
+			return invalid(fmt.Sprintf("invalid email domain %q", domain))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/mapper"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/clock"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/jobqueue"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RatingService handles the two-way ratings after a completed trip. Profile averages
+// are Bayesian-smoothed so a single early rating cannot swing them to the extremes.
+type RatingService interface {
+	RateOrder(ctx context.Context, userID, orderID int, req dto.CreateRatingRequest) (*dto.RatingResponse, error)
+	GetOrderRatings(ctx context.Context, userID, orderID int) (*dto.OrderRatingsResponse, error)
+	GetRatingTags(userType string) *dto.RatingTagsResponse
+	ListFlaggedDrivers(ctx context.Context, limit, offset int) ([]*dto.FlaggedDriverResponse, error)
+	ClearDriverFlag(ctx context.Context, driverID int) error
+}
+
+type ratingService struct {
+	db         *pgxpool.Pool
+	clock      clock.Clock
+	orderRepo  repository.OrderRepository
+	ratingRepo repository.RatingRepository
+}
+
+func NewRatingService(db *pgxpool.Pool, clk clock.Clock, orderRepo repository.OrderRepository, ratingRepo repository.RatingRepository) RatingService {
+	return &ratingService{
+		db:         db,
+		clock:      clk,
+		orderRepo:  orderRepo,
+		ratingRepo: ratingRepo,
+	}
+}
+
+var ratingPrior = repository.RatingPrior{
+	Mean:   constants.RatingPriorMean,
+	Weight: constants.RatingPriorWeight,
+}
+
+// RateOrder records the user's rating of the other participant, once per order and only
+// within the rating window, and updates the ratee's average in the same transaction
+func (s *ratingService) RateOrder(ctx context.Context, userID, orderID int, req dto.CreateRatingRequest) (*dto.RatingResponse, error) {
+	var rating *entity.Rating
+
+	err := database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		ratingRepo := s.ratingRepo.WithTx(tx)
+
+		// The row lock serializes both participants rating the same order
+		order, err := s.orderRepo.WithTx(tx).FindByIDForUpdate(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil || !isOrderParticipant(order, userID) {
+			return apperror.ErrOrderNotFound
+		}
+		if order.Status != entity.OrderStatusCompleted || order.CompletedAt == nil || order.DriverID == nil {
+			return apperror.ErrOrderNotCompleted
+		}
+		if s.clock.Now().After(order.CompletedAt.Add(constants.RatingWindow)) {
+			return apperror.ErrRatingWindowClosed
+		}
+
+		existing, err := ratingRepo.FindByOrderAndRater(ctx, orderID, userID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return apperror.ErrAlreadyRated
+		}
+
+		rating = &entity.Rating{
+			OrderID: orderID,
+			RaterID: userID,
+			Stars:   req.Stars,
+			Tags:    req.Tags,
+			Comment: req.Comment,
+		}
+		if userID == order.PassengerID {
+			rating.RaterRole = entity.RolePassenger
+			rating.RateeID = *order.DriverID
+		} else {
+			rating.RaterRole = entity.RoleDriver
+			rating.RateeID = order.PassengerID
+		}
+		if rating.Tags == nil {
+			rating.Tags = []string{}
+		}
+		allowed := ratingTagsFor(rating.RaterRole)
+		for _, tag := range rating.Tags {
+			if !slices.Contains(allowed, tag) {
+				return apperror.ErrInvalidRatingTag.WithVars(map[string]string{"tag": tag})
+			}
+		}
+
+		if err := ratingRepo.Create(ctx, rating); err != nil {
+			return err
+		}
+
+		if rating.RaterRole == entity.RolePassenger {
+			stats, err := ratingRepo.ApplyToDriver(ctx, rating.RateeID, rating.Stars, ratingPrior)
+			if err != nil {
+				return err
+			}
+			if err := s.flagLowRatedDriver(ctx, tx, rating.RateeID, stats); err != nil {
+				return err
+			}
+		} else {
+			if _, err := ratingRepo.ApplyToPassenger(ctx, rating.RateeID, rating.Stars, ratingPrior); err != nil {
+				return err
+			}
+		}
+
+		return jobqueue.WriteEvent(ctx, tx, constants.AggregateOrder, strconv.Itoa(orderID), constants.EventOrderRated, map[string]any{
+			"order_id":   orderID,
+			"rating_id":  rating.ID,
+			"rater_id":   rating.RaterID,
+			"ratee_id":   rating.RateeID,
+			"rater_role": rating.RaterRole,
+			"stars":      rating.Stars,
+		})
+	})
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return nil, err
+		}
+		logger.Log.Error().Err(err).Int("order_id", orderID).Int("user_id", userID).Msg("Failed to rate order")
+		return nil, apperror.Internal(err)
+	}
+
+	logger.Log.Info().
+		Int("order_id", orderID).
+		Int("rater_id", rating.RaterID).
+		Int("ratee_id", rating.RateeID).
+		Int("stars", rating.Stars).
+		Msg("Order rated")
+
+	return mapper.ToRatingResponse(rating), nil
+}
+
+// flagLowRatedDriver puts the driver up for admin review once their smoothed average
+// drops below the threshold with enough ratings behind it
+func (s *ratingService) flagLowRatedDriver(ctx context.Context, tx pgx.Tx, driverID int, stats *entity.RatingStats) error {
+	if stats.Count < constants.DriverRatingReviewMinRatings || stats.Average >= constants.DriverRatingReviewThreshold {
+		return nil
+	}
+
+	flagged, err := s.ratingRepo.WithTx(tx).FlagDriver(ctx, driverID, s.clock.Now())
+	if err != nil || !flagged {
+		return err
+	}
+
+	logger.Log.Warn().
+		Int("driver_id", driverID).
+		Float64("rating_avg", stats.Average).
+		Int("rating_count", stats.Count).
+		Msg("Driver flagged for rating review")
+
+	return jobqueue.WriteEvent(ctx, tx, constants.AggregateDriver, strconv.Itoa(driverID), constants.EventDriverRatingFlagged, map[string]any{
+		"driver_id":    driverID,
+		"rating_avg":   stats.Average,
+		"rating_count": stats.Count,
+	})
+}
+
+// GetOrderRatings shows a participant the rating they gave and the one they received
+func (s *ratingService) GetOrderRatings(ctx context.Context, userID, orderID int) (*dto.OrderRatingsResponse, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if order == nil || !isOrderParticipant(order, userID) {
+		return nil, apperror.ErrOrderNotFound
+	}
+
+	ratings, err := s.ratingRepo.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+
+	response := &dto.OrderRatingsResponse{OrderID: orderID}
+	if order.CompletedAt != nil {
+		till := order.CompletedAt.Add(constants.RatingWindow)
+		response.RateableTill = &till
+	}
+	for _, rating := range ratings {
+		if rating.RaterID == userID {
+			response.Given = mapper.ToRatingResponse(rating)
+		} else {
+			response.Received = mapper.ToRatingResponse(rating)
+		}
+	}
+	return response, nil
+}
+
+// GetRatingTags returns the tags the user can attach when rating the other side
+func (s *ratingService) GetRatingTags(userType string) *dto.RatingTagsResponse {
+	return &dto.RatingTagsResponse{Tags: ratingTagsFor(entity.UserRole(userType))}
+}
+
+// ListFlaggedDrivers returns drivers awaiting rating review (admin)
+func (s *ratingService) ListFlaggedDrivers(ctx context.Context, limit, offset int) ([]*dto.FlaggedDriverResponse, error) {
+	drivers, err := s.ratingRepo.FindFlaggedDrivers(ctx, limit, offset)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return mapper.ToFlaggedDriverResponses(drivers), nil
+}
+
+// ClearDriverFlag closes a rating review after an admin has looked at the driver
+func (s *ratingService) ClearDriverFlag(ctx context.Context, driverID int) error {
+	cleared, err := s.ratingRepo.ClearDriverFlag(ctx, driverID)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if !cleared {
+		return apperror.ErrDriverNotFlagged
+	}
+
+	logger.Log.Info().Int("driver_id", driverID).Msg("Driver rating review cleared")
+	return nil
+}
+
+// ratingTagsFor returns the tags a rater of the given role may use
+func ratingTagsFor(raterRole entity.UserRole) []string {
+	if raterRole == entity.RoleDriver {
+		return constants.PassengerRatingTags
+	}
+	return constants.DriverRatingTags
+}
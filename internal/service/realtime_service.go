@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/mapper"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/realtime"
+)
+
+// RealtimeService decides which realtime topics a user may subscribe to
+type RealtimeService interface {
+	AuthorizeTopic(ctx context.Context, userID int, userType, topic string) error
+}
+
+type realtimeService struct {
+	orderRepo repository.OrderRepository
+}
+
+func NewRealtimeService(orderRepo repository.OrderRepository) RealtimeService {
+	return &realtimeService{orderRepo: orderRepo}
+}
+
+// AuthorizeTopic allows a user's own topic and the topics of orders they take part in.
+// Admins may subscribe to any topic.
+func (s *realtimeService) AuthorizeTopic(ctx context.Context, userID int, userType, topic string) error {
+	kind, id, err := realtime.ParseTopic(topic)
+	if err != nil {
+		return apperror.ErrInvalidTopic.Wrap(err)
+	}
+	if userType == string(entity.RoleAdmin) {
+		return nil
+	}
+
+	switch kind {
+	case realtime.TopicUser:
+		if id == userID {
+			return nil
+		}
+	case realtime.TopicOrder:
+		order, err := s.orderRepo.FindByID(ctx, id)
+		if err != nil {
+			return apperror.Internal(err)
+		}
+		if order != nil && isOrderParticipant(order, userID) {
+			return nil
+		}
+	}
+	return apperror.ErrForbidden
+}
+
+// publishOrderStatus pushes the order's current state to the order topic and to its participants
+func publishOrderStatus(ctx context.Context, publisher realtime.Publisher, db database.DBTX, order *entity.Order) error {
+	topics := []string{realtime.OrderTopic(order.ID), realtime.UserTopic(order.PassengerID)}
+	if order.DriverID != nil {
+		topics = append(topics, realtime.UserTopic(*order.DriverID))
+	}
+
+	data := mapper.ToOrderResponse(order)
+	for _, topic := range topics {
+		if err := publisher.Publish(ctx, db, topic, constants.RealtimeOrderStatus, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/mapper"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/clock"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/i18n"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/jobqueue"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/profanity"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RelayService lets the passenger and driver of an order reach each other on WhatsApp
+// without exchanging numbers. Both message our sender number; the WhatsApp webhook queues
+// each message (see WhatsAppService) and it is passed on to the other party of the sender's latest session.
+// Once the order ends, or a different driver takes it, the session stops relaying.
+type RelayService interface {
+	OpenSession(ctx context.Context, userID, orderID int) (*dto.RelayContactResponse, error)
+	HandleForwardJob(ctx context.Context, job *jobqueue.Job) error
+}
+
+// relayForwardPayload is the payload of the relay.forward job, one per inbound message
+type relayForwardPayload struct {
+	MessageID string `json:"message_id"`
+	From      string `json:"from"`
+	Body      string `json:"body"`
+}
+
+type relayService struct {
+	db              *pgxpool.Pool
+	clock           clock.Clock
+	whatsAppService WhatsAppService
+	sessionRepo     repository.RelaySessionRepository
+	orderRepo       repository.OrderRepository
+	userRepo        repository.UserRepository
+}
+
+func NewRelayService(
+	db *pgxpool.Pool,
+	clk clock.Clock,
+	whatsAppService WhatsAppService,
+	sessionRepo repository.RelaySessionRepository,
+	orderRepo repository.OrderRepository,
+	userRepo repository.UserRepository,
+) RelayService {
+	return &relayService{
+		db:              db,
+		clock:           clk,
+		whatsAppService: whatsAppService,
+		sessionRepo:     sessionRepo,
+		orderRepo:       orderRepo,
+		userRepo:        userRepo,
+	}
+}
+
+// OpenSession returns the relay of a ride with a driver, opening it on first use. A
+// session left over from a driver who dropped the order is closed and replaced.
+func (s *relayService) OpenSession(ctx context.Context, userID, orderID int) (*dto.RelayContactResponse, error) {
+	var session *entity.RelaySession
+	var counterpart *entity.User
+
+	err := database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		sessionRepo := s.sessionRepo.WithTx(tx)
+
+		order, err := s.orderRepo.WithTx(tx).FindByIDForUpdate(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil || !isOrderParticipant(order, userID) {
+			return apperror.ErrOrderNotFound
+		}
+		if !isTripInProgress(order) {
+			return apperror.ErrTripNotInProgress
+		}
+
+		counterpartID := order.PassengerID
+		if userID == order.PassengerID {
+			counterpartID = *order.DriverID
+		}
+		counterpart, err = s.userRepo.FindByID(ctx, counterpartID)
+		if err != nil {
+			return fmt.Errorf("failed to load counterpart: %w", err)
+		}
+		if counterpart == nil {
+			return fmt.Errorf("counterpart %d not found", counterpartID)
+		}
+
+		session, err = sessionRepo.FindOpenByOrderID(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+		if session != nil && session.DriverID == *order.DriverID {
+			return nil
+		}
+		if session != nil {
+			if err := sessionRepo.Close(ctx, session.ID, s.clock.Now()); err != nil {
+				return err
+			}
+		}
+
+		passenger, err := s.userRepo.FindByID(ctx, order.PassengerID)
+		if err != nil {
+			return fmt.Errorf("failed to load passenger: %w", err)
+		}
+		if passenger == nil {
+			return fmt.Errorf("passenger %d not found", order.PassengerID)
+		}
+		driver, err := s.userRepo.FindByID(ctx, *order.DriverID)
+		if err != nil {
+			return fmt.Errorf("failed to load driver: %w", err)
+		}
+		if driver == nil {
+			return fmt.Errorf("driver %d not found", *order.DriverID)
+		}
+		session = &entity.RelaySession{
+			OrderID:        order.ID,
+			PassengerID:    passenger.ID,
+			DriverID:       driver.ID,
+			PassengerPhone: utils.NormalizePhoneNumber(passenger.PhoneNumber),
+			DriverPhone:    utils.NormalizePhoneNumber(driver.PhoneNumber),
+		}
+		if err := sessionRepo.Create(ctx, session); err != nil {
+			return err
+		}
+		logger.Log.Info().Int("order_id", order.ID).Int("session_id", session.ID).Msg("Relay session opened")
+		return nil
+	})
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return nil, err
+		}
+		logger.Log.Error().Err(err).Int("order_id", orderID).Int("user_id", userID).Msg("Failed to open relay session")
+		return nil, apperror.Internal(err)
+	}
+
+	return mapper.ToRelayContactResponse(session, s.whatsAppService.Number(), counterpart), nil
+}
+
+// HandleForwardJob passes an incoming message on to the other party of the sender's
+// latest session. Senders without a session are ignored; senders whose trip has ended
+// are told their message was not passed on.
+func (s *relayService) HandleForwardJob(ctx context.Context, job *jobqueue.Job) error {
+	var payload relayForwardPayload
+	if err := job.Decode(&payload); err != nil {
+		return jobqueue.Permanent(fmt.Errorf("invalid relay.forward payload: %w", err))
+	}
+
+	session, err := s.sessionRepo.FindLatestByPhone(ctx, payload.From)
+	if err != nil {
+		return fmt.Errorf("failed to load relay session: %w", err)
+	}
+	if session == nil {
+		logger.Log.Info().Str("message_id", payload.MessageID).Msg("Dropped WhatsApp message from a number without a relay session")
+		return nil
+	}
+	senderID, senderRole, recipientID, recipientPhone, _ := session.Route(payload.From)
+
+	sender, err := s.userRepo.FindByID(ctx, senderID)
+	if err != nil {
+		return fmt.Errorf("failed to load relay sender: %w", err)
+	}
+	if sender == nil {
+		return jobqueue.Permanent(fmt.Errorf("relay sender %d not found", senderID))
+	}
+	vars := map[string]string{"order_id": strconv.Itoa(session.OrderID)}
+
+	open, err := s.isSessionOpen(ctx, session)
+	if err != nil {
+		return err
+	}
+	if !open {
+		message := i18n.T(i18n.OrDefault(sender.PreferredLocale), "whatsapp.relay_closed", vars)
+		if err := s.whatsAppService.Send(ctx, payload.From, message, entity.WhatsAppPurposeRelay, &session.ID); err != nil {
+			return fmt.Errorf("failed to send relay closed notice: %w", err)
+		}
+		return nil
+	}
+
+	recipient, err := s.userRepo.FindByID(ctx, recipientID)
+	if err != nil {
+		return fmt.Errorf("failed to load relay recipient: %w", err)
+	}
+	if recipient == nil {
+		return jobqueue.Permanent(fmt.Errorf("relay recipient %d not found", recipientID))
+	}
+	vars["name"] = sender.FirstName()
+	vars["body"], _ = profanity.Mask(strings.TrimSpace(payload.Body))
+	key := "whatsapp.relay_from_" + strings.ToLower(string(senderRole))
+	message := i18n.T(i18n.OrDefault(recipient.PreferredLocale), key, vars)
+
+	if err := s.whatsAppService.Send(ctx, recipientPhone, message, entity.WhatsAppPurposeRelay, &session.ID); err != nil {
+		logger.Log.Error().
+			Err(err).
+			Int("session_id", session.ID).
+			Int("attempt", job.Attempts).
+			Msg("Failed to relay WhatsApp message")
+		return fmt.Errorf("failed to relay whatsapp message: %w", err)
+	}
+	if err := s.sessionRepo.IncrementRelayed(ctx, session.ID); err != nil {
+		logger.Log.Warn().Err(err).Int("session_id", session.ID).Msg("Failed to count relayed message")
+	}
+	return nil
+}
+
+// isSessionOpen reports whether a session still relays, closing it once its order has
+// ended or moved to another driver
+func (s *relayService) isSessionOpen(ctx context.Context, session *entity.RelaySession) (bool, error) {
+	if session.ClosedAt != nil {
+		return false, nil
+	}
+
+	order, err := s.orderRepo.FindByID(ctx, session.OrderID)
+	if err != nil {
+		return false, fmt.Errorf("failed to load relay order: %w", err)
+	}
+	if order != nil && isTripInProgress(order) && *order.DriverID == session.DriverID {
+		return true, nil
+	}
+
+	if err := s.sessionRepo.Close(ctx, session.ID, s.clock.Now()); err != nil {
+		return false, fmt.Errorf("failed to close relay session: %w", err)
+	}
+	logger.Log.Info().Int("order_id", session.OrderID).Int("session_id", session.ID).Msg("Relay session closed")
+	return false, nil
+}
@@ -0,0 +1,607 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/mapper"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/clock"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/i18n"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/jobqueue"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/push"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SafetyService manages trusted contacts and SOS incidents. Contacts confirm their number
+// with an OTP before they receive anything; an SOS alerts them and campus security over
+// WhatsApp and opens an incident that admins acknowledge and then resolve or dismiss.
+type SafetyService interface {
+	ListContacts(ctx context.Context, userID int) ([]*dto.TrustedContactResponse, error)
+	AddContact(ctx context.Context, userID int, req dto.AddTrustedContactRequest, ipAddress, userAgent string) (*dto.TrustedContactResponse, error)
+	ResendContactOTP(ctx context.Context, userID, contactID int, ipAddress, userAgent string) (*dto.TrustedContactResponse, error)
+	VerifyContact(ctx context.Context, userID, contactID int, req dto.VerifyTrustedContactRequest) (*dto.TrustedContactResponse, error)
+	DeleteContact(ctx context.Context, userID, contactID int) error
+	ShareTrip(ctx context.Context, userID, orderID int) (*dto.ShareTripResponse, error)
+	RaiseSOS(ctx context.Context, userID, orderID int, req dto.RaiseSOSRequest) (*dto.SOSResponse, error)
+	ListIncidents(ctx context.Context, status string, limit, offset int) ([]*dto.IncidentResponse, error)
+	GetIncident(ctx context.Context, incidentID int) (*dto.IncidentResponse, error)
+	AcknowledgeIncident(ctx context.Context, adminID, incidentID int) (*dto.IncidentResponse, error)
+	CloseIncident(ctx context.Context, adminID, incidentID int, req dto.CloseIncidentRequest) (*dto.IncidentResponse, error)
+}
+
+type safetyService struct {
+	db                  *pgxpool.Pool
+	clock               clock.Clock
+	securityPhones      []string
+	contactRepo         repository.TrustedContactRepository
+	incidentRepo        repository.SOSIncidentRepository
+	orderRepo           repository.OrderRepository
+	driverRepo          repository.DriverRepository
+	userRepo            repository.UserRepository
+	otpService          OTPService
+	notificationService NotificationService
+}
+
+func NewSafetyService(
+	db *pgxpool.Pool,
+	clk clock.Clock,
+	securityPhones []string,
+	contactRepo repository.TrustedContactRepository,
+	incidentRepo repository.SOSIncidentRepository,
+	orderRepo repository.OrderRepository,
+	driverRepo repository.DriverRepository,
+	userRepo repository.UserRepository,
+	otpService OTPService,
+	notificationService NotificationService,
+) SafetyService {
+	return &safetyService{
+		db:                  db,
+		clock:               clk,
+		securityPhones:      securityPhones,
+		contactRepo:         contactRepo,
+		incidentRepo:        incidentRepo,
+		orderRepo:           orderRepo,
+		driverRepo:          driverRepo,
+		userRepo:            userRepo,
+		otpService:          otpService,
+		notificationService: notificationService,
+	}
+}
+
+// ListContacts returns the user's trusted contacts
+func (s *safetyService) ListContacts(ctx context.Context, userID int) ([]*dto.TrustedContactResponse, error) {
+	contacts, err := s.contactRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		logger.Log.Error().Err(err).Int("user_id", userID).Msg("Failed to list trusted contacts")
+		return nil, apperror.Internal(err)
+	}
+	return mapper.ToTrustedContactResponses(contacts), nil
+}
+
+// AddContact saves a trusted contact and sends the OTP they confirm the number with.
+// Adding a number that is still unverified sends a fresh OTP instead.
+func (s *safetyService) AddContact(ctx context.Context, userID int, req dto.AddTrustedContactRequest, ipAddress, userAgent string) (*dto.TrustedContactResponse, error) {
+	phoneNumber := utils.NormalizePhoneNumber(req.PhoneNumber)
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if user == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+	if user.PhoneNumber == phoneNumber {
+		return nil, apperror.ErrTrustedContactSelf
+	}
+
+	contact, err := s.contactRepo.FindByUserAndPhone(ctx, userID, phoneNumber)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if contact != nil && contact.IsVerified() {
+		return nil, apperror.ErrTrustedContactExists
+	}
+
+	if contact == nil {
+		contacts, err := s.contactRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			return nil, apperror.Internal(err)
+		}
+		if len(contacts) >= constants.MaxTrustedContacts {
+			return nil, apperror.ErrTrustedContactLimit.WithVars(map[string]string{"max": strconv.Itoa(constants.MaxTrustedContacts)})
+		}
+
+		contact = &entity.TrustedContact{UserID: userID, Name: req.Name, PhoneNumber: phoneNumber}
+		if err := s.contactRepo.Create(ctx, contact); err != nil {
+			logger.Log.Error().Err(err).Int("user_id", userID).Msg("Failed to save trusted contact")
+			return nil, apperror.Internal(err)
+		}
+		logger.Log.Info().Int("user_id", userID).Int("contact_id", contact.ID).Msg("Trusted contact added")
+	}
+
+	if err := s.sendContactOTP(ctx, contact, ipAddress, userAgent); err != nil {
+		return nil, err
+	}
+	return mapper.ToTrustedContactResponse(contact), nil
+}
+
+// ResendContactOTP sends a new OTP to a contact who has not confirmed yet
+func (s *safetyService) ResendContactOTP(ctx context.Context, userID, contactID int, ipAddress, userAgent string) (*dto.TrustedContactResponse, error) {
+	contact, err := s.findContact(ctx, userID, contactID)
+	if err != nil {
+		return nil, err
+	}
+	if contact.IsVerified() {
+		return nil, apperror.ErrTrustedContactVerified
+	}
+
+	if err := s.sendContactOTP(ctx, contact, ipAddress, userAgent); err != nil {
+		return nil, err
+	}
+	return mapper.ToTrustedContactResponse(contact), nil
+}
+
+// VerifyContact confirms a contact with the OTP sent to their number
+func (s *safetyService) VerifyContact(ctx context.Context, userID, contactID int, req dto.VerifyTrustedContactRequest) (*dto.TrustedContactResponse, error) {
+	contact, err := s.findContact(ctx, userID, contactID)
+	if err != nil {
+		return nil, err
+	}
+	if contact.IsVerified() {
+		return nil, apperror.ErrTrustedContactVerified
+	}
+
+	if err := s.otpService.ConsumeOTP(ctx, contact.PhoneNumber, entity.OTPPurposeTrustedContact, req.OTPCode); err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	if err := s.contactRepo.MarkVerified(ctx, contact.ID, now); err != nil {
+		logger.Log.Error().Err(err).Int("contact_id", contact.ID).Msg("Failed to verify trusted contact")
+		return nil, apperror.Internal(err)
+	}
+	contact.VerifiedAt = &now
+
+	logger.Log.Info().Int("user_id", userID).Int("contact_id", contact.ID).Msg("Trusted contact verified")
+	return mapper.ToTrustedContactResponse(contact), nil
+}
+
+// DeleteContact removes one of the user's trusted contacts
+func (s *safetyService) DeleteContact(ctx context.Context, userID, contactID int) error {
+	if _, err := s.findContact(ctx, userID, contactID); err != nil {
+		return err
+	}
+
+	deleted, err := s.contactRepo.Delete(ctx, contactID)
+	if err != nil {
+		logger.Log.Error().Err(err).Int("contact_id", contactID).Msg("Failed to delete trusted contact")
+		return apperror.Internal(err)
+	}
+	if !deleted {
+		return apperror.ErrTrustedContactNotFound
+	}
+	return nil
+}
+
+// ShareTrip sends the trip details and the driver's position to the user's verified
+// trusted contacts over WhatsApp
+func (s *safetyService) ShareTrip(ctx context.Context, userID, orderID int) (*dto.ShareTripResponse, error) {
+	order, err := s.findTripInProgress(ctx, userID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts, err := s.verifiedContacts(ctx, s.contactRepo, userID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if len(contacts) == 0 {
+		return nil, apperror.ErrNoTrustedContacts
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if user == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+	trip, err := s.snapshotTrip(ctx, order)
+	if err != nil {
+		logger.Log.Error().Err(err).Int("order_id", order.ID).Msg("Failed to load trip for sharing")
+		return nil, apperror.Internal(err)
+	}
+
+	location := mapsLink(order.PickupLat, order.PickupLong)
+	if trip.DriverLat != nil && trip.DriverLong != nil {
+		location = mapsLink(*trip.DriverLat, *trip.DriverLong)
+	}
+	vars := map[string]string{
+		"name":          user.FullName,
+		"driver_name":   trip.DriverName,
+		"vehicle_plate": trip.VehiclePlate,
+		"vehicle":       trip.Vehicle,
+		"pickup":        trip.PickupAddress,
+		"dropoff":       trip.DropoffAddress,
+		"location":      location,
+	}
+
+	err = database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		for _, contact := range contacts {
+			if err := s.notificationService.NotifyPhoneWhatsAppTx(ctx, tx, contact.PhoneNumber, i18n.OrDefault(user.PreferredLocale), push.TemplateTripShared, vars); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Log.Error().Err(err).Int("order_id", order.ID).Msg("Failed to share trip")
+		return nil, apperror.Internal(err)
+	}
+
+	logger.Log.Info().Int("order_id", order.ID).Int("user_id", userID).Int("contacts", len(contacts)).Msg("Trip shared with trusted contacts")
+	return &dto.ShareTripResponse{OrderID: order.ID, ContactsNotified: len(contacts)}, nil
+}
+
+// RaiseSOS opens an incident for the user's ride, capturing where they are and who they
+// ride with, and alerts their verified contacts and campus security. Pressing SOS again
+// while the incident is being handled returns it without alerting anyone twice.
+func (s *safetyService) RaiseSOS(ctx context.Context, userID, orderID int, req dto.RaiseSOSRequest) (*dto.SOSResponse, error) {
+	var incident *entity.SOSIncident
+	raised := false
+
+	err := database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		orderRepo := s.orderRepo.WithTx(tx)
+		incidentRepo := s.incidentRepo.WithTx(tx)
+
+		order, err := orderRepo.FindByIDForUpdate(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil || !isOrderParticipant(order, userID) {
+			return apperror.ErrOrderNotFound
+		}
+
+		incident, err = incidentRepo.FindOpen(ctx, order.ID, userID)
+		if err != nil || incident != nil {
+			return err
+		}
+		if !isTripInProgress(order) {
+			return apperror.ErrTripNotInProgress
+		}
+
+		reporter, err := s.userRepo.FindByID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to load reporter: %w", err)
+		}
+		if reporter == nil {
+			return fmt.Errorf("reporter %d not found", userID)
+		}
+		trip, err := s.snapshotTrip(ctx, order)
+		if err != nil {
+			return err
+		}
+
+		role := entity.RolePassenger
+		if userID != order.PassengerID {
+			role = entity.RoleDriver
+		}
+		lat, long := order.PickupLat, order.PickupLong
+		switch {
+		case req.Lat != nil && req.Long != nil:
+			lat, long = *req.Lat, *req.Long
+		case trip.DriverLat != nil && trip.DriverLong != nil:
+			lat, long = *trip.DriverLat, *trip.DriverLong
+		}
+
+		incident = &entity.SOSIncident{
+			OrderID:     order.ID,
+			ReportedBy:  userID,
+			Role:        role,
+			Lat:         lat,
+			Long:        long,
+			OrderStatus: order.Status,
+			Trip:        *trip,
+			Note:        req.Note,
+			Status:      entity.IncidentStatusOpen,
+		}
+		if err := incidentRepo.Create(ctx, incident); err != nil {
+			return err
+		}
+		raised = true
+
+		contacts, err := s.verifiedContacts(ctx, s.contactRepo.WithTx(tx), userID)
+		if err != nil {
+			return err
+		}
+		vars := sosAlertVars(reporter, incident, s.clock)
+		for _, contact := range contacts {
+			if err := s.notificationService.NotifyPhoneWhatsAppTx(ctx, tx, contact.PhoneNumber, i18n.OrDefault(reporter.PreferredLocale), push.TemplateSOSAlert, vars); err != nil {
+				return err
+			}
+		}
+		for _, phone := range s.securityPhones {
+			if err := s.notificationService.NotifyPhoneWhatsAppTx(ctx, tx, phone, i18n.DefaultLocale, push.TemplateSOSAlert, vars); err != nil {
+				return err
+			}
+		}
+		if err := incidentRepo.SetContactsAlerted(ctx, incident.ID, len(contacts)); err != nil {
+			return err
+		}
+		incident.ContactsAlerted = len(contacts)
+
+		return jobqueue.WriteEvent(ctx, tx, constants.AggregateSOSIncident, strconv.Itoa(incident.ID), constants.EventSOSRaised, map[string]any{
+			"incident_id":      incident.ID,
+			"order_id":         order.ID,
+			"reported_by":      userID,
+			"role":             role,
+			"lat":              lat,
+			"long":             long,
+			"contacts_alerted": len(contacts),
+		})
+	})
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return nil, err
+		}
+		logger.Log.Error().Err(err).Int("order_id", orderID).Int("user_id", userID).Msg("Failed to raise SOS")
+		return nil, apperror.Internal(err)
+	}
+
+	if raised {
+		logger.Log.Warn().
+			Int("incident_id", incident.ID).
+			Int("order_id", orderID).
+			Int("user_id", userID).
+			Float64("lat", incident.Lat).
+			Float64("long", incident.Long).
+			Msg("SOS raised")
+	}
+	return mapper.ToSOSResponse(incident), nil
+}
+
+// ListIncidents returns incidents in a status, oldest first; without a status it returns
+// the ones still being handled (admin only)
+func (s *safetyService) ListIncidents(ctx context.Context, status string, limit, offset int) ([]*dto.IncidentResponse, error) {
+	statuses := []entity.IncidentStatus{entity.IncidentStatusOpen, entity.IncidentStatusAcknowledged}
+	if status != "" {
+		statuses = []entity.IncidentStatus{entity.IncidentStatus(status)}
+	}
+
+	incidents, err := s.incidentRepo.FindByStatuses(ctx, statuses, limit, offset)
+	if err != nil {
+		logger.Log.Error().Err(err).Msg("Failed to list incidents")
+		return nil, apperror.Internal(err)
+	}
+	return mapper.ToIncidentResponses(incidents), nil
+}
+
+// GetIncident returns an incident with its trip snapshot (admin only)
+func (s *safetyService) GetIncident(ctx context.Context, incidentID int) (*dto.IncidentResponse, error) {
+	incident, err := s.incidentRepo.FindByID(ctx, incidentID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if incident == nil {
+		return nil, apperror.ErrIncidentNotFound
+	}
+	return mapper.ToIncidentResponse(incident), nil
+}
+
+// AcknowledgeIncident marks that an admin is handling an open incident and tells the
+// reporter help is on the way
+func (s *safetyService) AcknowledgeIncident(ctx context.Context, adminID, incidentID int) (*dto.IncidentResponse, error) {
+	return s.updateIncident(ctx, adminID, incidentID, func(ctx context.Context, tx pgx.Tx, incident *entity.SOSIncident, now time.Time) error {
+		if incident.Status != entity.IncidentStatusOpen {
+			return apperror.ErrInvalidIncidentStatus
+		}
+		if err := s.incidentRepo.WithTx(tx).Acknowledge(ctx, incident.ID, adminID, now); err != nil {
+			return err
+		}
+		incident.Status = entity.IncidentStatusAcknowledged
+		incident.AcknowledgedBy = &adminID
+		incident.AcknowledgedAt = &now
+
+		if err := s.notificationService.NotifyUserTx(ctx, tx, incident.ReportedBy, push.TemplateSOSAcknowledged, map[string]string{
+			"order_id": strconv.Itoa(incident.OrderID),
+		}); err != nil {
+			return err
+		}
+		return jobqueue.WriteEvent(ctx, tx, constants.AggregateSOSIncident, strconv.Itoa(incident.ID), constants.EventSOSAcknowledged, map[string]any{
+			"incident_id": incident.ID,
+			"order_id":    incident.OrderID,
+			"admin_id":    adminID,
+		})
+	})
+}
+
+// CloseIncident resolves or dismisses an incident with a note on how it was handled
+func (s *safetyService) CloseIncident(ctx context.Context, adminID, incidentID int, req dto.CloseIncidentRequest) (*dto.IncidentResponse, error) {
+	return s.updateIncident(ctx, adminID, incidentID, func(ctx context.Context, tx pgx.Tx, incident *entity.SOSIncident, now time.Time) error {
+		if incident.Status.IsClosed() {
+			return apperror.ErrInvalidIncidentStatus
+		}
+		status := entity.IncidentStatus(req.Outcome)
+		if err := s.incidentRepo.WithTx(tx).Close(ctx, incident.ID, status, adminID, req.Note, now); err != nil {
+			return err
+		}
+		incident.Status = status
+		incident.ResolvedBy = &adminID
+		incident.ResolvedAt = &now
+		incident.ResolutionNote = &req.Note
+
+		return jobqueue.WriteEvent(ctx, tx, constants.AggregateSOSIncident, strconv.Itoa(incident.ID), constants.EventSOSClosed, map[string]any{
+			"incident_id": incident.ID,
+			"order_id":    incident.OrderID,
+			"admin_id":    adminID,
+			"outcome":     status,
+		})
+	})
+}
+
+// updateIncident locks an incident and runs an admin transition on it
+func (s *safetyService) updateIncident(
+	ctx context.Context,
+	adminID, incidentID int,
+	transition func(ctx context.Context, tx pgx.Tx, incident *entity.SOSIncident, now time.Time) error,
+) (*dto.IncidentResponse, error) {
+	var incident *entity.SOSIncident
+
+	err := database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		var err error
+		incident, err = s.incidentRepo.WithTx(tx).FindByIDForUpdate(ctx, incidentID)
+		if err != nil {
+			return err
+		}
+		if incident == nil {
+			return apperror.ErrIncidentNotFound
+		}
+		return transition(ctx, tx, incident, s.clock.Now())
+	})
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return nil, err
+		}
+		logger.Log.Error().Err(err).Int("incident_id", incidentID).Int("admin_id", adminID).Msg("Failed to update incident")
+		return nil, apperror.Internal(err)
+	}
+
+	logger.Log.Info().Int("incident_id", incident.ID).Int("admin_id", adminID).Str("status", string(incident.Status)).Msg("Incident updated")
+	return mapper.ToIncidentResponse(incident), nil
+}
+
+// sendContactOTP sends the confirmation code to a contact's WhatsApp
+func (s *safetyService) sendContactOTP(ctx context.Context, contact *entity.TrustedContact, ipAddress, userAgent string) error {
+	_, err := s.otpService.SendOTP(ctx, dto.SendOTPRequest{
+		PhoneNumber: contact.PhoneNumber,
+		Purpose:     entity.OTPPurposeTrustedContact,
+	}, ipAddress, userAgent)
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return err
+		}
+		return apperror.ErrOTPSendFailed.Wrap(err)
+	}
+	return nil
+}
+
+// findContact returns one of the user's contacts; other users' contacts are not found
+func (s *safetyService) findContact(ctx context.Context, userID, contactID int) (*entity.TrustedContact, error) {
+	contact, err := s.contactRepo.FindByID(ctx, contactID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if contact == nil || contact.UserID != userID {
+		return nil, apperror.ErrTrustedContactNotFound
+	}
+	return contact, nil
+}
+
+// findTripInProgress returns the user's order while a driver is assigned to it
+func (s *safetyService) findTripInProgress(ctx context.Context, userID, orderID int) (*entity.Order, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if order == nil || !isOrderParticipant(order, userID) {
+		return nil, apperror.ErrOrderNotFound
+	}
+	if !isTripInProgress(order) {
+		return nil, apperror.ErrTripNotInProgress
+	}
+	return order, nil
+}
+
+func (s *safetyService) verifiedContacts(ctx context.Context, contactRepo repository.TrustedContactRepository, userID int) ([]*entity.TrustedContact, error) {
+	contacts, err := contactRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	verified := make([]*entity.TrustedContact, 0, len(contacts))
+	for _, contact := range contacts {
+		if contact.IsVerified() {
+			verified = append(verified, contact)
+		}
+	}
+	return verified, nil
+}
+
+// snapshotTrip collects the participants, vehicle and route of an order with a driver
+func (s *safetyService) snapshotTrip(ctx context.Context, order *entity.Order) (*entity.TripSnapshot, error) {
+	passenger, err := s.userRepo.FindByID(ctx, order.PassengerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load passenger: %w", err)
+	}
+	if passenger == nil {
+		return nil, fmt.Errorf("passenger %d not found", order.PassengerID)
+	}
+	driver, err := s.userRepo.FindByID(ctx, *order.DriverID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load driver: %w", err)
+	}
+	if driver == nil {
+		return nil, fmt.Errorf("driver %d not found", *order.DriverID)
+	}
+	profile, err := s.driverRepo.FindByUserID(ctx, *order.DriverID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load driver profile: %w", err)
+	}
+
+	return &entity.TripSnapshot{
+		PassengerID:    passenger.ID,
+		PassengerName:  passenger.FullName,
+		PassengerPhone: passenger.PhoneNumber,
+		DriverID:       driver.ID,
+		DriverName:     driver.FullName,
+		DriverPhone:    driver.PhoneNumber,
+		VehiclePlate:   profile.VehiclePlate,
+		Vehicle:        profile.VehicleDescription(),
+		PickupAddress:  order.PickupAddress,
+		DropoffAddress: order.DropoffAddress,
+		DriverLat:      profile.CurrentLat,
+		DriverLong:     profile.CurrentLong,
+	}, nil
+}
+
+// isTripInProgress reports whether a driver is assigned and the ride has not ended
+func isTripInProgress(order *entity.Order) bool {
+	return order.DriverID != nil && order.Status.IsActive()
+}
+
+// sosAlertVars renders an incident for the SOS_ALERT WhatsApp template
+func sosAlertVars(reporter *entity.User, incident *entity.SOSIncident, clk clock.Clock) map[string]string {
+	note := "-"
+	if incident.Note != nil && *incident.Note != "" {
+		note = *incident.Note
+	}
+	return map[string]string{
+		"name":           reporter.FullName,
+		"time":           formatLocalTime(clk.Now()),
+		"passenger_name": incident.Trip.PassengerName,
+		"driver_name":    incident.Trip.DriverName,
+		"vehicle_plate":  incident.Trip.VehiclePlate,
+		"vehicle":        incident.Trip.Vehicle,
+		"pickup":         incident.Trip.PickupAddress,
+		"dropoff":        incident.Trip.DropoffAddress,
+		"location":       mapsLink(incident.Lat, incident.Long),
+		"note":           note,
+		"incident_id":    strconv.Itoa(incident.ID),
+	}
+}
+
+// mapsLink links a position on Google Maps, which opens in any phone's browser
+func mapsLink(lat, long float64) string {
+	return fmt.Sprintf("https://maps.google.com/?q=%.6f,%.6f", lat, long)
+}
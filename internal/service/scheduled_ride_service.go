@@ -0,0 +1,572 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/mapper"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/clock"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/jobqueue"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/push"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/realtime"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/schedule"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ScheduledRideService handles rides booked in advance. A scheduled order waits in
+// SCHEDULED, where drivers can pre-accept it, until scheduled.dispatch runs shortly before
+// pickup: a pre-accepted driver who is still available gets the order directly, otherwise
+// it goes to the dispatcher like any other order. Passengers cancel scheduled rides
+// through CancellationService like any other order.
+type ScheduledRideService interface {
+	CheckBooking(ctx context.Context, passengerID int, pickupAt time.Time) error
+	ScheduleTx(ctx context.Context, tx pgx.Tx, order *entity.Order) error
+	ListPassengerRides(ctx context.Context, passengerID int) ([]*dto.OrderResponse, error)
+	ListOpenRides(ctx context.Context, limit, offset int) ([]*dto.OrderResponse, error)
+	ListAgenda(ctx context.Context, driverID int) ([]*dto.OrderResponse, error)
+	PreAccept(ctx context.Context, driverID, orderID int) (*dto.OrderResponse, error)
+	Withdraw(ctx context.Context, driverID, orderID int) (*dto.OrderResponse, error)
+	HandleDispatchJob(ctx context.Context, job *jobqueue.Job) error
+	HandleReminderJob(ctx context.Context, job *jobqueue.Job) error
+}
+
+// scheduledRidePayload is the payload of the scheduled.dispatch and scheduled.reminder jobs
+type scheduledRidePayload struct {
+	OrderID int `json:"order_id"`
+}
+
+type scheduledRideService struct {
+	db                  *pgxpool.Pool
+	clock               clock.Clock
+	policy              schedule.Policy
+	orderRepo           repository.OrderRepository
+	driverRepo          repository.DriverRepository
+	offerRepo           repository.DispatchOfferRepository
+	userRepo            repository.UserRepository
+	notificationService NotificationService
+	promoService        PromoService
+	campusService       CampusService
+	cancellationService CancellationService
+	publisher           realtime.Publisher
+}
+
+func NewScheduledRideService(
+	db *pgxpool.Pool,
+	clk clock.Clock,
+	policy schedule.Policy,
+	orderRepo repository.OrderRepository,
+	driverRepo repository.DriverRepository,
+	offerRepo repository.DispatchOfferRepository,
+	userRepo repository.UserRepository,
+	notificationService NotificationService,
+	promoService PromoService,
+	campusService CampusService,
+	cancellationService CancellationService,
+	publisher realtime.Publisher,
+) ScheduledRideService {
+	return &scheduledRideService{
+		db:                  db,
+		clock:               clk,
+		policy:              policy.WithDefaults(),
+		orderRepo:           orderRepo,
+		driverRepo:          driverRepo,
+		offerRepo:           offerRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+		promoService:        promoService,
+		campusService:       campusService,
+		cancellationService: cancellationService,
+		publisher:           publisher,
+	}
+}
+
+// CheckBooking validates a new scheduled pickup against the lead times and the
+// passenger's other scheduled rides
+func (s *scheduledRideService) CheckBooking(ctx context.Context, passengerID int, pickupAt time.Time) error {
+	switch err := s.policy.CheckPickup(s.clock.Now(), pickupAt); {
+	case errors.Is(err, schedule.ErrPickupTooSoon):
+		return apperror.ErrPickupTooSoon.WithVars(map[string]string{
+			"minutes": strconv.Itoa(int(s.policy.MinLead.Minutes())),
+		})
+	case errors.Is(err, schedule.ErrPickupTooFar):
+		return apperror.ErrPickupTooFar.WithVars(map[string]string{
+			"days": strconv.Itoa(int(s.policy.MaxLead.Hours() / 24)),
+		})
+	}
+
+	rides, err := s.orderRepo.FindScheduledByPassenger(ctx, passengerID)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if len(rides) >= s.policy.MaxPending {
+		return apperror.ErrScheduleLimitReached.WithVars(map[string]string{
+			"max": strconv.Itoa(s.policy.MaxPending),
+		})
+	}
+	for _, ride := range rides {
+		if s.policy.Overlaps(*ride.ScheduledAt, pickupAt) {
+			return apperror.ErrScheduleConflict
+		}
+	}
+	return nil
+}
+
+// ScheduleTx queues the dispatch and reminder jobs of a newly booked scheduled order
+func (s *scheduledRideService) ScheduleTx(ctx context.Context, tx pgx.Tx, order *entity.Order) error {
+	pickupAt := *order.ScheduledAt
+
+	if _, err := jobqueue.Enqueue(ctx, tx, jobqueue.NewJob{
+		Type:           constants.JobTypeScheduledDispatch,
+		Payload:        scheduledRidePayload{OrderID: order.ID},
+		IdempotencyKey: fmt.Sprintf("scheduled-dispatch:%d", order.ID),
+		RunAt:          s.policy.DispatchAt(pickupAt),
+	}); err != nil {
+		return err
+	}
+
+	// Rides booked inside the reminder window need no reminder
+	reminderAt := s.policy.ReminderAt(pickupAt)
+	if !reminderAt.After(s.clock.Now()) {
+		return nil
+	}
+	_, err := jobqueue.Enqueue(ctx, tx, jobqueue.NewJob{
+		Type:           constants.JobTypeScheduledReminder,
+		Payload:        scheduledRidePayload{OrderID: order.ID},
+		IdempotencyKey: fmt.Sprintf("scheduled-reminder:%d", order.ID),
+		RunAt:          reminderAt,
+	})
+	return err
+}
+
+// ListPassengerRides returns the passenger's upcoming scheduled rides
+func (s *scheduledRideService) ListPassengerRides(ctx context.Context, passengerID int) ([]*dto.OrderResponse, error) {
+	rides, err := s.orderRepo.FindScheduledByPassenger(ctx, passengerID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return mapper.ToOrderResponses(rides), nil
+}
+
+// ListOpenRides returns scheduled rides still waiting for a driver to pre-accept them
+func (s *scheduledRideService) ListOpenRides(ctx context.Context, limit, offset int) ([]*dto.OrderResponse, error) {
+	// Rides about to be dispatched are left to the dispatcher
+	after := s.clock.Now().Add(s.policy.DispatchLead)
+	rides, err := s.orderRepo.FindOpenScheduled(ctx, after, limit, offset)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return mapper.ToOrderResponses(rides), nil
+}
+
+// ListAgenda returns the scheduled rides the driver pre-accepted
+func (s *scheduledRideService) ListAgenda(ctx context.Context, driverID int) ([]*dto.OrderResponse, error) {
+	rides, err := s.orderRepo.FindScheduledByDriver(ctx, driverID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return mapper.ToOrderResponses(rides), nil
+}
+
+// PreAccept commits the driver to a scheduled ride. The order stays SCHEDULED and is
+// handed to the driver when its dispatch starts.
+func (s *scheduledRideService) PreAccept(ctx context.Context, driverID, orderID int) (*dto.OrderResponse, error) {
+	profile, err := s.driverRepo.FindByUserID(ctx, driverID)
+	if err != nil {
+		return nil, apperror.ErrDriverProfileNotFound
+	}
+	if !profile.IsVerified || !profile.IsActive {
+		return nil, apperror.ErrDriverNotVerified
+	}
+	if err := s.campusService.CheckCanDrive(ctx, driverID, profile); err != nil {
+		return nil, err
+	}
+	if err := s.cancellationService.CheckCanDrive(ctx, driverID); err != nil {
+		return nil, err
+	}
+
+	var order *entity.Order
+	err = database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		orderRepo := s.orderRepo.WithTx(tx)
+
+		// Lock the driver before reading their agenda so two pre-accepts by the same
+		// driver cannot both find the slot free
+		if err := s.driverRepo.WithTx(tx).LockByUserID(ctx, driverID); err != nil {
+			return fmt.Errorf("failed to lock driver profile: %w", err)
+		}
+
+		var err error
+		order, err = orderRepo.FindByIDForUpdate(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil || order.Status != entity.OrderStatusScheduled {
+			return apperror.ErrScheduledRideNotFound
+		}
+		if order.DriverID != nil {
+			return apperror.ErrScheduledRideTaken
+		}
+
+		now := s.clock.Now()
+		if !now.Before(s.policy.DispatchAt(*order.ScheduledAt)) {
+			return apperror.ErrInvalidOrderStatus
+		}
+
+		agenda, err := orderRepo.FindScheduledByDriver(ctx, driverID)
+		if err != nil {
+			return err
+		}
+		for _, ride := range agenda {
+			if s.policy.Overlaps(*ride.ScheduledAt, *order.ScheduledAt) {
+				return apperror.ErrScheduleConflict
+			}
+		}
+
+		if err := orderRepo.PreAssign(ctx, order.ID, driverID, now); err != nil {
+			return err
+		}
+		order.DriverID = &driverID
+		order.AcceptedAt = &now
+
+		vars, err := s.driverVars(ctx, tx, order, driverID)
+		if err != nil {
+			return err
+		}
+		if err := s.notificationService.NotifyUserTx(ctx, tx, order.PassengerID, push.TemplateScheduledRideConfirmed, vars); err != nil {
+			return err
+		}
+		if err := publishOrderStatus(ctx, s.publisher, tx, order); err != nil {
+			return err
+		}
+
+		return jobqueue.WriteEvent(ctx, tx, constants.AggregateOrder, strconv.Itoa(order.ID), constants.EventOrderPreAccepted, map[string]any{
+			"order_id":     order.ID,
+			"driver_id":    driverID,
+			"scheduled_at": order.ScheduledAt,
+		})
+	})
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return nil, err
+		}
+		logger.Log.Error().Err(err).Int("order_id", orderID).Int("driver_id", driverID).Msg("Failed to pre-accept scheduled ride")
+		return nil, apperror.Internal(err)
+	}
+
+	logger.Log.Info().Int("order_id", orderID).Int("driver_id", driverID).Msg("Scheduled ride pre-accepted")
+	return mapper.ToOrderResponse(order), nil
+}
+
+// Withdraw gives a pre-accepted ride back to the open pool. Every withdrawal is recorded
+// as a cancellation; withdrawing close to pickup counts against the driver.
+func (s *scheduledRideService) Withdraw(ctx context.Context, driverID, orderID int) (*dto.OrderResponse, error) {
+	var order *entity.Order
+	late := false
+
+	err := database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		var err error
+		order, err = s.orderRepo.WithTx(tx).FindByIDForUpdate(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil || order.Status != entity.OrderStatusScheduled || order.DriverID == nil || *order.DriverID != driverID {
+			return apperror.ErrScheduledRideNotFound
+		}
+
+		late = s.policy.IsLateWithdrawal(s.clock.Now(), *order.ScheduledAt)
+		record := &entity.Cancellation{
+			OrderID:     order.ID,
+			CancelledBy: driverID,
+			Role:        entity.RoleDriver,
+			ReasonCode:  constants.CancelReasonScheduleWithdrawn,
+			OrderStatus: order.Status,
+		}
+		if late {
+			record.ChargedUserID = &driverID
+		}
+		if err := s.cancellationService.RecordTx(ctx, tx, order, record); err != nil {
+			return err
+		}
+
+		if err := s.orderRepo.WithTx(tx).ReleaseDriver(ctx, order.ID); err != nil {
+			return err
+		}
+		order.DriverID = nil
+		order.AcceptedAt = nil
+
+		if err := s.notificationService.NotifyUserTx(ctx, tx, order.PassengerID, push.TemplateScheduledDriverWithdrew, map[string]string{
+			"order_id": strconv.Itoa(order.ID),
+			"time":     formatLocalTime(*order.ScheduledAt),
+		}); err != nil {
+			return err
+		}
+		if err := publishOrderStatus(ctx, s.publisher, tx, order); err != nil {
+			return err
+		}
+
+		return jobqueue.WriteEvent(ctx, tx, constants.AggregateOrder, strconv.Itoa(order.ID), constants.EventOrderDriverWithdrew, map[string]any{
+			"order_id":     order.ID,
+			"driver_id":    driverID,
+			"scheduled_at": order.ScheduledAt,
+			"late":         late,
+		})
+	})
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return nil, err
+		}
+		logger.Log.Error().Err(err).Int("order_id", orderID).Int("driver_id", driverID).Msg("Failed to withdraw from scheduled ride")
+		return nil, apperror.Internal(err)
+	}
+
+	logger.Log.Info().Int("order_id", orderID).Int("driver_id", driverID).Bool("late", late).Msg("Driver withdrew from scheduled ride")
+	return mapper.ToOrderResponse(order), nil
+}
+
+// HandleDispatchJob takes a scheduled order out of the schedule. The pre-accepted driver
+// gets it when still able to drive and not serving another order; otherwise the driver is
+// released and the dispatcher takes over.
+func (s *scheduledRideService) HandleDispatchJob(ctx context.Context, job *jobqueue.Job) error {
+	var payload scheduledRidePayload
+	if err := job.Decode(&payload); err != nil {
+		return jobqueue.Permanent(fmt.Errorf("invalid scheduled.dispatch payload: %w", err))
+	}
+
+	return database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		orderRepo := s.orderRepo.WithTx(tx)
+
+		order, err := orderRepo.FindByIDForUpdate(ctx, payload.OrderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return jobqueue.Permanent(fmt.Errorf("order %d not found", payload.OrderID))
+		}
+		if order.Status != entity.OrderStatusScheduled {
+			return nil
+		}
+
+		// A passenger has one order in progress at a time
+		active, err := orderRepo.FindActiveByPassenger(ctx, order.PassengerID)
+		if err != nil {
+			return err
+		}
+		if active != nil {
+			return s.expireScheduled(ctx, tx, order, active.ID)
+		}
+
+		if order.DriverID != nil {
+			driverID := *order.DriverID
+			available, err := s.driverAvailable(ctx, tx, driverID)
+			if err != nil {
+				return err
+			}
+			if available {
+				return s.startWithDriver(ctx, tx, order, driverID)
+			}
+
+			logger.Log.Info().Int("order_id", order.ID).Int("driver_id", driverID).Msg("Pre-accepted driver unavailable, scheduled ride goes to dispatch")
+			if err := s.notificationService.NotifyUserTx(ctx, tx, driverID, push.TemplateScheduledJobReleased, map[string]string{
+				"order_id": strconv.Itoa(order.ID),
+				"time":     formatLocalTime(*order.ScheduledAt),
+			}); err != nil {
+				return err
+			}
+		}
+
+		if err := orderRepo.MarkSearching(ctx, order.ID); err != nil {
+			return err
+		}
+		releasedDriverID := order.DriverID
+		order.Status = entity.OrderStatusSearching
+		order.DriverID = nil
+		order.AcceptedAt = nil
+
+		if err := publishOrderStatus(ctx, s.publisher, tx, order); err != nil {
+			return err
+		}
+		if err := jobqueue.WriteEvent(ctx, tx, constants.AggregateOrder, strconv.Itoa(order.ID), constants.EventOrderDispatchStarted, map[string]any{
+			"order_id":           order.ID,
+			"scheduled_at":       order.ScheduledAt,
+			"released_driver_id": releasedDriverID,
+		}); err != nil {
+			return err
+		}
+
+		logger.Log.Info().Int("order_id", order.ID).Msg("Scheduled ride dispatch started")
+		return enqueueDispatch(ctx, tx, order.ID, 1)
+	})
+}
+
+// HandleReminderJob reminds the passenger and any pre-accepted driver of the pickup
+func (s *scheduledRideService) HandleReminderJob(ctx context.Context, job *jobqueue.Job) error {
+	var payload scheduledRidePayload
+	if err := job.Decode(&payload); err != nil {
+		return jobqueue.Permanent(fmt.Errorf("invalid scheduled.reminder payload: %w", err))
+	}
+
+	order, err := s.orderRepo.FindByID(ctx, payload.OrderID)
+	if err != nil {
+		return err
+	}
+	if order == nil {
+		return jobqueue.Permanent(fmt.Errorf("order %d not found", payload.OrderID))
+	}
+	if order.Status != entity.OrderStatusScheduled {
+		return nil
+	}
+
+	vars := map[string]string{
+		"order_id": strconv.Itoa(order.ID),
+		"pickup":   order.PickupAddress,
+		"time":     formatLocalTime(*order.ScheduledAt),
+	}
+	return database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		if err := s.notificationService.NotifyUserTx(ctx, tx, order.PassengerID, push.TemplateScheduledRideReminder, vars); err != nil {
+			return err
+		}
+		if order.DriverID == nil {
+			return nil
+		}
+		return s.notificationService.NotifyUserTx(ctx, tx, *order.DriverID, push.TemplateScheduledJobReminder, vars)
+	})
+}
+
+// startWithDriver hands the order to its pre-accepted driver
+func (s *scheduledRideService) startWithDriver(ctx context.Context, tx pgx.Tx, order *entity.Order, driverID int) error {
+	now := s.clock.Now()
+	if err := s.orderRepo.WithTx(tx).Assign(ctx, order.ID, driverID, now); err != nil {
+		return err
+	}
+	order.Status = entity.OrderStatusAccepted
+	order.AcceptedAt = &now
+
+	vars, err := s.driverVars(ctx, tx, order, driverID)
+	if err != nil {
+		return err
+	}
+	vars["pickup"] = order.PickupAddress
+	if err := s.notificationService.NotifyUserTx(ctx, tx, order.PassengerID, push.TemplateOrderAccepted, vars); err != nil {
+		return err
+	}
+	if err := s.notificationService.NotifyUserTx(ctx, tx, driverID, push.TemplateScheduledJobStarting, vars); err != nil {
+		return err
+	}
+	if err := publishOrderStatus(ctx, s.publisher, tx, order); err != nil {
+		return err
+	}
+
+	logger.Log.Info().Int("order_id", order.ID).Int("driver_id", driverID).Msg("Scheduled ride handed to pre-accepted driver")
+	return jobqueue.WriteEvent(ctx, tx, constants.AggregateOrder, strconv.Itoa(order.ID), constants.EventOrderAccepted, map[string]any{
+		"order_id":     order.ID,
+		"driver_id":    driverID,
+		"pre_accepted": true,
+	})
+}
+
+// expireScheduled gives up on a scheduled ride whose passenger is already riding
+func (s *scheduledRideService) expireScheduled(ctx context.Context, tx pgx.Tx, order *entity.Order, activeOrderID int) error {
+	now := s.clock.Now()
+	if err := s.orderRepo.WithTx(tx).MarkExpired(ctx, order.ID, now); err != nil {
+		return fmt.Errorf("failed to expire order: %w", err)
+	}
+	order.Status = entity.OrderStatusExpired
+	order.ExpiredAt = &now
+
+	if err := s.promoService.ReleaseTx(ctx, tx, order.ID); err != nil {
+		return fmt.Errorf("failed to release promo: %w", err)
+	}
+	if err := publishOrderStatus(ctx, s.publisher, tx, order); err != nil {
+		return err
+	}
+	if err := s.notificationService.NotifyUserTx(ctx, tx, order.PassengerID, push.TemplateOrderExpired, map[string]string{
+		"order_id": strconv.Itoa(order.ID),
+	}); err != nil {
+		return err
+	}
+	if order.DriverID != nil {
+		if err := s.notificationService.NotifyUserTx(ctx, tx, *order.DriverID, push.TemplateOrderCancelled, map[string]string{
+			"order_id": strconv.Itoa(order.ID),
+		}); err != nil {
+			return err
+		}
+	}
+
+	logger.Log.Info().Int("order_id", order.ID).Int("active_order_id", activeOrderID).Msg("Scheduled ride expired, passenger has an order in progress")
+	return jobqueue.WriteEvent(ctx, tx, constants.AggregateOrder, strconv.Itoa(order.ID), constants.EventOrderExpired, map[string]any{
+		"order_id":        order.ID,
+		"reason":          "passenger_busy",
+		"active_order_id": activeOrderID,
+	})
+}
+
+// driverAvailable reports whether a pre-accepted driver can still take the ride now: online,
+// in good standing, and neither serving an order nor holding an open dispatch offer. The
+// driver row stays locked until the handoff commits, the same lock offer acceptance takes.
+func (s *scheduledRideService) driverAvailable(ctx context.Context, tx pgx.Tx, driverID int) (bool, error) {
+	driverRepo := s.driverRepo.WithTx(tx)
+	if err := driverRepo.LockByUserID(ctx, driverID); err != nil {
+		return false, fmt.Errorf("failed to lock driver profile: %w", err)
+	}
+	profile, err := driverRepo.FindByUserID(ctx, driverID)
+	if err != nil {
+		return false, fmt.Errorf("failed to load driver profile: %w", err)
+	}
+	if !profile.IsVerified || !profile.IsActive || !profile.IsOnline {
+		return false, nil
+	}
+	switch err := s.cancellationService.CheckCanDrive(ctx, driverID); {
+	case errors.Is(err, apperror.ErrDrivingCooldown):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	active, err := s.orderRepo.WithTx(tx).FindActiveByDriver(ctx, driverID)
+	if err != nil {
+		return false, err
+	}
+	if active != nil {
+		return false, nil
+	}
+	offer, err := s.offerRepo.WithTx(tx).FindPendingByDriver(ctx, driverID)
+	if err != nil {
+		return false, err
+	}
+	return offer == nil || !offer.ExpiresAt.After(s.clock.Now()), nil
+}
+
+// driverVars returns the notification variables that introduce the driver to the passenger
+func (s *scheduledRideService) driverVars(ctx context.Context, tx pgx.Tx, order *entity.Order, driverID int) (map[string]string, error) {
+	driver, err := s.userRepo.FindByID(ctx, driverID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load driver: %w", err)
+	}
+	if driver == nil {
+		return nil, fmt.Errorf("driver %d not found", driverID)
+	}
+	profile, err := s.driverRepo.WithTx(tx).FindByUserID(ctx, driverID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load driver profile: %w", err)
+	}
+	return map[string]string{
+		"order_id":      strconv.Itoa(order.ID),
+		"driver_name":   driver.FullName,
+		"vehicle_plate": profile.VehiclePlate,
+		"time":          formatLocalTime(*order.ScheduledAt),
+	}, nil
+}
+
+// formatLocalTime renders a pickup time or deadline in the server's local time zone
+func formatLocalTime(t time.Time) string {
+	return t.Local().Format(constants.ScheduledTimeLayout)
+}
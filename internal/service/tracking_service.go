@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/mapper"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/clock"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/geo"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TrackingService manages the read-only trip links passengers share with friends. A link
+// is an opaque token scoped to one order; it stops working when the passenger revokes
+// it or the trip ends, and shows only the driver's first name, vehicle, position and ETA.
+type TrackingService interface {
+	CreateLink(ctx context.Context, userID, orderID int) (*dto.TrackingLinkResponse, error)
+	ListLinks(ctx context.Context, userID, orderID int) ([]*dto.TrackingLinkResponse, error)
+	RevokeLink(ctx context.Context, userID, orderID, linkID int) error
+	GetTrackedTrip(ctx context.Context, token string) (*dto.TrackedTripResponse, error)
+}
+
+type trackingService struct {
+	db          *pgxpool.Pool
+	clock       clock.Clock
+	linkBaseURL string
+	linkRepo    repository.TrackingLinkRepository
+	orderRepo   repository.OrderRepository
+	driverRepo  repository.DriverRepository
+	userRepo    repository.UserRepository
+}
+
+func NewTrackingService(
+	db *pgxpool.Pool,
+	clk clock.Clock,
+	linkBaseURL string,
+	linkRepo repository.TrackingLinkRepository,
+	orderRepo repository.OrderRepository,
+	driverRepo repository.DriverRepository,
+	userRepo repository.UserRepository,
+) TrackingService {
+	return &trackingService{
+		db:          db,
+		clock:       clk,
+		linkBaseURL: strings.TrimSuffix(linkBaseURL, "/"),
+		linkRepo:    linkRepo,
+		orderRepo:   orderRepo,
+		driverRepo:  driverRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// CreateLink issues a new tracking link for the passenger's ride. The token is returned
+// only here; the database keeps its hash.
+func (s *trackingService) CreateLink(ctx context.Context, userID, orderID int) (*dto.TrackingLinkResponse, error) {
+	tokenBytes := make([]byte, constants.TrackingTokenBytes)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		logger.Log.Error().Err(err).Msg("Failed to generate random bytes for tracking link")
+		return nil, apperror.Internal(err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	var link *entity.TrackingLink
+	err := database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		linkRepo := s.linkRepo.WithTx(tx)
+
+		order, err := s.orderRepo.WithTx(tx).FindByIDForUpdate(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil || order.PassengerID != userID {
+			return apperror.ErrOrderNotFound
+		}
+		if !isTripInProgress(order) {
+			return apperror.ErrTripNotInProgress
+		}
+
+		now := s.clock.Now()
+		links, err := linkRepo.FindByOrderID(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+		active := 0
+		for _, existing := range links {
+			if existing.IsActive(order.Status, now) {
+				active++
+			}
+		}
+		if active >= constants.MaxTrackingLinksPerOrder {
+			return apperror.ErrTrackingLinkLimit.WithVars(map[string]string{"max": strconv.Itoa(constants.MaxTrackingLinksPerOrder)})
+		}
+
+		link = &entity.TrackingLink{
+			OrderID:   order.ID,
+			CreatedBy: userID,
+			TokenHash: HashToken(token),
+			ExpiresAt: now.Add(constants.TrackingLinkTTL),
+		}
+		return linkRepo.Create(ctx, link)
+	})
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return nil, err
+		}
+		logger.Log.Error().Err(err).Int("order_id", orderID).Int("user_id", userID).Msg("Failed to create tracking link")
+		return nil, apperror.Internal(err)
+	}
+
+	logger.Log.Info().Int("order_id", orderID).Int("link_id", link.ID).Msg("Tracking link created")
+	response := mapper.ToTrackingLinkResponse(link, true)
+	response.Token = token
+	response.URL = s.linkBaseURL + "/api/track/" + token
+	return response, nil
+}
+
+// ListLinks returns the links the passenger has issued for an order, newest first
+func (s *trackingService) ListLinks(ctx context.Context, userID, orderID int) ([]*dto.TrackingLinkResponse, error) {
+	order, err := s.findPassengerOrder(ctx, userID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.linkRepo.FindByOrderID(ctx, order.ID)
+	if err != nil {
+		logger.Log.Error().Err(err).Int("order_id", order.ID).Msg("Failed to list tracking links")
+		return nil, apperror.Internal(err)
+	}
+
+	now := s.clock.Now()
+	responses := make([]*dto.TrackingLinkResponse, 0, len(links))
+	for _, link := range links {
+		responses = append(responses, mapper.ToTrackingLinkResponse(link, link.IsActive(order.Status, now)))
+	}
+	return responses, nil
+}
+
+// RevokeLink switches one of the order's links off straight away
+func (s *trackingService) RevokeLink(ctx context.Context, userID, orderID, linkID int) error {
+	order, err := s.findPassengerOrder(ctx, userID, orderID)
+	if err != nil {
+		return err
+	}
+
+	link, err := s.linkRepo.FindByID(ctx, linkID)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if link == nil || link.OrderID != order.ID || link.RevokedAt != nil {
+		return apperror.ErrTrackingLinkNotFound
+	}
+
+	revoked, err := s.linkRepo.Revoke(ctx, link.ID, s.clock.Now())
+	if err != nil {
+		logger.Log.Error().Err(err).Int("link_id", link.ID).Msg("Failed to revoke tracking link")
+		return apperror.Internal(err)
+	}
+	if !revoked {
+		return apperror.ErrTrackingLinkNotFound
+	}
+
+	logger.Log.Info().Int("order_id", order.ID).Int("link_id", link.ID).Msg("Tracking link revoked")
+	return nil
+}
+
+// GetTrackedTrip is what an unauthenticated visitor with a link sees. Unknown and
+// revoked tokens are not found; links whose trip has ended have expired.
+func (s *trackingService) GetTrackedTrip(ctx context.Context, token string) (*dto.TrackedTripResponse, error) {
+	link, err := s.linkRepo.FindByTokenHash(ctx, HashToken(token))
+	if err != nil {
+		logger.Log.Error().Err(err).Msg("Failed to look up tracking link")
+		return nil, apperror.Internal(err)
+	}
+	if link == nil || link.RevokedAt != nil {
+		return nil, apperror.ErrTrackingLinkNotFound
+	}
+
+	order, err := s.orderRepo.FindByID(ctx, link.OrderID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if order == nil {
+		return nil, apperror.ErrTrackingLinkNotFound
+	}
+	if !link.IsActive(order.Status, s.clock.Now()) || order.DriverID == nil {
+		return nil, apperror.ErrTrackingLinkExpired
+	}
+
+	driver, err := s.userRepo.FindByID(ctx, *order.DriverID)
+	if err != nil {
+		logger.Log.Error().Err(err).Int("order_id", order.ID).Msg("Failed to load driver for tracking link")
+		return nil, apperror.Internal(err)
+	}
+	if driver == nil {
+		return nil, apperror.ErrTrackingLinkNotFound
+	}
+	profile, err := s.driverRepo.FindByUserID(ctx, *order.DriverID)
+	if err != nil {
+		logger.Log.Error().Err(err).Int("order_id", order.ID).Msg("Failed to load driver profile for tracking link")
+		return nil, apperror.Internal(err)
+	}
+
+	response := &dto.TrackedTripResponse{
+		Status: string(order.Status),
+		Driver: mapper.ToTrackedDriverResponse(driver, profile),
+	}
+	if profile.CurrentLat != nil && profile.CurrentLong != nil {
+		position := geo.Point{Lat: *profile.CurrentLat, Long: *profile.CurrentLong}
+		response.Position = &dto.TrackedPosition{
+			Lat:       position.Lat,
+			Long:      position.Long,
+			UpdatedAt: profile.LastLocationUpdate,
+		}
+		response.ETA = estimateArrival(order, position)
+	}
+	return response, nil
+}
+
+// findPassengerOrder returns the passenger's own order; other orders are not found
+func (s *trackingService) findPassengerOrder(ctx context.Context, userID, orderID int) (*entity.Order, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if order == nil || order.PassengerID != userID {
+		return nil, apperror.ErrOrderNotFound
+	}
+	return order, nil
+}
+
+// estimateArrival estimates the minutes until the driver reaches the pickup or, once the
+// trip has started, the dropoff, at the average campus traffic speed
+func estimateArrival(order *entity.Order, driverAt geo.Point) *dto.TrackedETA {
+	destination := constants.TrackingDestinationPickup
+	target := geo.Point{Lat: order.PickupLat, Long: order.PickupLong}
+	switch order.Status {
+	case entity.OrderStatusArrived:
+		return &dto.TrackedETA{Destination: destination, Minutes: 0}
+	case entity.OrderStatusOnTrip:
+		destination = constants.TrackingDestinationDropoff
+		target = geo.Point{Lat: order.DropoffLat, Long: order.DropoffLong}
+	}
+
+	hours := geo.DistanceKm(driverAt, target) / constants.TrackingAverageSpeedKmh
+	return &dto.TrackedETA{Destination: destination, Minutes: int(math.Ceil(hours * 60))}
+}
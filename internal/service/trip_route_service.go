@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/mapper"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/geo"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TripRouteService records where a trip actually went and measures it for fares and
+// disputes
+type TripRouteService interface {
+	RecordPing(ctx context.Context, orderID int, p geo.Point, at time.Time) error
+	BuildTx(ctx context.Context, tx pgx.Tx, order *entity.Order) error
+	GetRoute(ctx context.Context, userID int, userType string, orderID int, format string) (*dto.TripRouteResponse, error)
+	Rebuild(ctx context.Context, orderID int, format string) (*dto.TripRouteResponse, error)
+}
+
+type tripRouteService struct {
+	db            *pgxpool.Pool
+	tripRouteRepo repository.TripRouteRepository
+	orderRepo     repository.OrderRepository
+}
+
+func NewTripRouteService(db *pgxpool.Pool, tripRouteRepo repository.TripRouteRepository, orderRepo repository.OrderRepository) TripRouteService {
+	return &tripRouteService{
+		db:            db,
+		tripRouteRepo: tripRouteRepo,
+		orderRepo:     orderRepo,
+	}
+}
+
+// RecordPing stores a driver location received while the order is ON_TRIP
+func (s *tripRouteService) RecordPing(ctx context.Context, orderID int, p geo.Point, at time.Time) error {
+	return s.tripRouteRepo.AddPoint(ctx, orderID, p, at)
+}
+
+// BuildTx measures the trip from its recorded pings and stores the route. A trip with
+// fewer than two usable pings has no route; it is logged and the fare stays as quoted.
+func (s *tripRouteService) BuildTx(ctx context.Context, tx pgx.Tx, order *entity.Order) error {
+	repo := s.tripRouteRepo.WithTx(tx)
+	track, err := repo.FindPoints(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+
+	route := measureRoute(order, track)
+	if route == nil {
+		logger.Log.Warn().Int("order_id", order.ID).Int("points", len(track)).Msg("Too few location pings to build trip route")
+		return nil
+	}
+	if err := repo.Upsert(ctx, route); err != nil {
+		return err
+	}
+
+	logger.Log.Info().
+		Int("order_id", order.ID).
+		Float64("distance_km", route.DistanceKm).
+		Float64("quoted_km", order.DistanceKm).
+		Int("kept_points", route.KeptPoints).
+		Int("raw_points", route.RawPoints).
+		Msg("Trip route built")
+	return nil
+}
+
+// GetRoute returns the route of an order to its participants or an admin. While the trip
+// is running the route is measured live from the pings so far.
+func (s *tripRouteService) GetRoute(ctx context.Context, userID int, userType string, orderID int, format string) (*dto.TripRouteResponse, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if order == nil || (userType != string(entity.RoleAdmin) && !isOrderParticipant(order, userID)) {
+		return nil, apperror.ErrOrderNotFound
+	}
+
+	if order.Status == entity.OrderStatusOnTrip {
+		track, err := s.tripRouteRepo.FindPoints(ctx, order.ID)
+		if err != nil {
+			return nil, apperror.Internal(err)
+		}
+		route := measureRoute(order, track)
+		if route == nil {
+			return nil, apperror.ErrTripRouteNotFound
+		}
+		return s.toResponse(order, route, format, true)
+	}
+
+	route, err := s.tripRouteRepo.FindByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if route == nil {
+		return nil, apperror.ErrTripRouteNotFound
+	}
+	return s.toResponse(order, route, format, false)
+}
+
+// Rebuild measures a completed trip again from its raw pings, for disputes and after the
+// cleaning rules change (admin only)
+func (s *tripRouteService) Rebuild(ctx context.Context, orderID int, format string) (*dto.TripRouteResponse, error) {
+	var order *entity.Order
+	var route *entity.TripRoute
+
+	err := database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		var err error
+		order, err = s.orderRepo.WithTx(tx).FindByID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return apperror.ErrOrderNotFound
+		}
+		if order.Status != entity.OrderStatusCompleted {
+			return apperror.ErrInvalidOrderStatus
+		}
+
+		if err := s.BuildTx(ctx, tx, order); err != nil {
+			return err
+		}
+		route, err = s.tripRouteRepo.WithTx(tx).FindByOrderID(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+		if route == nil {
+			return apperror.ErrTripRouteNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return nil, err
+		}
+		logger.Log.Error().Err(err).Int("order_id", orderID).Msg("Failed to rebuild trip route")
+		return nil, apperror.Internal(err)
+	}
+
+	return s.toResponse(order, route, format, false)
+}
+
+func (s *tripRouteService) toResponse(order *entity.Order, route *entity.TripRoute, format string, live bool) (*dto.TripRouteResponse, error) {
+	response, err := mapper.ToTripRouteResponse(order, route, format, live)
+	if err != nil {
+		logger.Log.Error().Err(err).Int("order_id", order.ID).Msg("Failed to encode trip route")
+		return nil, apperror.Internal(err)
+	}
+	return response, nil
+}
+
+// measureRoute cleans a raw track and measures it: GPS jumps are dropped before the
+// distance is taken, and the shape is simplified only for storage, so simplification never
+// shortens the measured distance. It returns nil when fewer than two pings survive.
+func measureRoute(order *entity.Order, track []geo.TrackPoint) *entity.TripRoute {
+	kept := geo.FilterJumps(track, constants.TripMaxSpeedKmh)
+	if len(kept) < 2 {
+		return nil
+	}
+	simplified := geo.Simplify(kept, constants.TripSimplifyToleranceKm)
+
+	first, last := kept[0], kept[len(kept)-1]
+	distance := math.Round(geo.PathLengthKm(kept)*100) / 100
+	return &entity.TripRoute{
+		OrderID:          order.ID,
+		Polyline:         geo.EncodePolyline(geo.Points(simplified)),
+		DistanceKm:       distance,
+		DurationSeconds:  int(last.At.Sub(first.At).Seconds()),
+		RawPoints:        len(track),
+		KeptPoints:       len(kept),
+		SimplifiedPoints: len(simplified),
+		MeasuredFare:     utils.CalculateFare(distance) + order.ZoneSurcharge,
+		StartedAt:        first.At,
+		EndedAt:          last.At,
+	}
+}
@@ -0,0 +1,590 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/dto"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/mapper"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/clock"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/ledger"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// WalletService moves money through the ledger: admin top-ups into passenger wallets,
+// fare settlement with the commission split, cancellation fees, and driver payouts
+type WalletService interface {
+	GetWallet(ctx context.Context, userID int, userType string) (*dto.WalletResponse, error)
+	GetStatement(ctx context.Context, userID int, userType string, limit, offset int) ([]*dto.WalletEntryResponse, error)
+	CheckBalance(ctx context.Context, passengerID int, amount int64) error
+	SettleTripTx(ctx context.Context, tx pgx.Tx, order *entity.Order) error
+	SubsidizeCashTripTx(ctx context.Context, tx pgx.Tx, order *entity.Order) error
+	ChargeCancellationFeeTx(ctx context.Context, tx pgx.Tx, order *entity.Order, fee int64) (entity.FeeSettlement, error)
+	OutstandingDebt(ctx context.Context, passengerID int) (int64, error)
+	TopUp(ctx context.Context, adminID, userID int, req dto.TopUpRequest) (*dto.TopUpResponse, error)
+	CreditTopUpTx(ctx context.Context, tx pgx.Tx, userID int, amount int64, reference, description string, createdBy *int) (*dto.TopUpResponse, error)
+	RequestPayout(ctx context.Context, driverID int, req dto.CreatePayoutRequest) (*dto.PayoutResponse, error)
+	ListDriverPayouts(ctx context.Context, driverID, limit, offset int) ([]*dto.PayoutResponse, error)
+	ListPayouts(ctx context.Context, status entity.PayoutStatus, limit, offset int) ([]*dto.PayoutResponse, error)
+	ApprovePayout(ctx context.Context, adminID int, payoutID int64) (*dto.PayoutResponse, error)
+	RejectPayout(ctx context.Context, adminID int, payoutID int64, req dto.RejectPayoutRequest) (*dto.PayoutResponse, error)
+	Reconcile(ctx context.Context) (*dto.ReconciliationResponse, error)
+}
+
+type walletService struct {
+	db         *pgxpool.Pool
+	clock      clock.Clock
+	userRepo   repository.UserRepository
+	payoutRepo repository.PayoutRepository
+}
+
+func NewWalletService(db *pgxpool.Pool, clk clock.Clock, userRepo repository.UserRepository, payoutRepo repository.PayoutRepository) WalletService {
+	return &walletService{
+		db:         db,
+		clock:      clk,
+		userRepo:   userRepo,
+		payoutRepo: payoutRepo,
+	}
+}
+
+// walletAccountType returns the spendable account of a user type
+func walletAccountType(userType string) (ledger.AccountType, error) {
+	switch entity.UserRole(userType) {
+	case entity.RolePassenger:
+		return ledger.AccountPassengerWallet, nil
+	case entity.RoleDriver:
+		return ledger.AccountDriverEarnings, nil
+	}
+	return "", apperror.ErrWalletUnavailable
+}
+
+// GetWallet returns the caller's balance; accounts that were never used read as zero
+func (s *walletService) GetWallet(ctx context.Context, userID int, userType string) (*dto.WalletResponse, error) {
+	accountType, err := walletAccountType(userType)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.WalletResponse{AccountType: string(accountType)}
+
+	account, err := ledger.FindAccount(ctx, s.db, accountType, &userID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if account != nil {
+		response.Balance = account.Balance
+	}
+
+	if accountType == ledger.AccountPassengerWallet {
+		debt, err := s.OutstandingDebt(ctx, userID)
+		if err != nil {
+			return nil, apperror.Internal(err)
+		}
+		response.Debt = debt
+	}
+	if accountType == ledger.AccountDriverEarnings {
+		hold, err := ledger.FindAccount(ctx, s.db, ledger.AccountPayoutHold, &userID)
+		if err != nil {
+			return nil, apperror.Internal(err)
+		}
+		if hold != nil {
+			response.OnHold = hold.Balance
+		}
+	}
+	return response, nil
+}
+
+// GetStatement returns the caller's wallet history, newest first
+func (s *walletService) GetStatement(ctx context.Context, userID int, userType string, limit, offset int) ([]*dto.WalletEntryResponse, error) {
+	accountType, err := walletAccountType(userType)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := ledger.FindAccount(ctx, s.db, accountType, &userID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if account == nil {
+		return []*dto.WalletEntryResponse{}, nil
+	}
+
+	entries, err := ledger.Statement(ctx, s.db, account.ID, limit, offset)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return mapper.ToWalletEntryResponses(entries), nil
+}
+
+// CheckBalance is the early check when a wallet order is placed; the binding check
+// happens when the fare is posted at completion
+func (s *walletService) CheckBalance(ctx context.Context, passengerID int, amount int64) error {
+	account, err := ledger.FindAccount(ctx, s.db, ledger.AccountPassengerWallet, &passengerID)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if account == nil || account.Balance < amount {
+		return apperror.ErrInsufficientBalance
+	}
+	return nil
+}
+
+// SettleTripTx debits the amount due from the passenger wallet and splits the full fare
+// between the driver's earnings and the platform commission, with any promo discount
+// paid from PROMO_FUNDING. It returns ledger.ErrInsufficientFunds without writing
+// anything when the wallet cannot cover the amount due.
+func (s *walletService) SettleTripTx(ctx context.Context, tx pgx.Tx, order *entity.Order) error {
+	if order.DriverID == nil {
+		return fmt.Errorf("order %d has no driver", order.ID)
+	}
+
+	wallet, err := ledger.EnsureAccount(ctx, tx, ledger.AccountPassengerWallet, &order.PassengerID)
+	if err != nil {
+		return err
+	}
+	earnings, err := ledger.EnsureAccount(ctx, tx, ledger.AccountDriverEarnings, order.DriverID)
+	if err != nil {
+		return err
+	}
+	platform, err := ledger.EnsureAccount(ctx, tx, ledger.AccountPlatformCommission, nil)
+	if err != nil {
+		return err
+	}
+
+	fare := int64(order.Fare)
+	discount := int64(order.Discount)
+	commission := fare * constants.PlatformCommissionPercent / 100
+	lines := []ledger.Line{
+		{AccountID: earnings.ID, Amount: fare - commission},
+	}
+	if fare > discount {
+		lines = append(lines, ledger.Line{AccountID: wallet.ID, Amount: discount - fare})
+	}
+	if commission > 0 {
+		lines = append(lines, ledger.Line{AccountID: platform.ID, Amount: commission})
+	}
+	if discount > 0 {
+		promo, err := ledger.EnsureAccount(ctx, tx, ledger.AccountPromoFunding, nil)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, ledger.Line{AccountID: promo.ID, Amount: -discount})
+	}
+
+	txn, err := ledger.Post(ctx, tx, ledger.Posting{
+		Kind:           ledger.KindTripFare,
+		IdempotencyKey: fmt.Sprintf("trip-fare:%d", order.ID),
+		ReferenceType:  constants.LedgerRefOrder,
+		ReferenceID:    strconv.Itoa(order.ID),
+		Description:    fmt.Sprintf("Trip fare for order #%d", order.ID),
+		Lines:          lines,
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Log.Info().
+		Int("order_id", order.ID).
+		Int64("transaction_id", txn.ID).
+		Int64("fare", fare).
+		Int64("discount", discount).
+		Int64("commission", commission).
+		Msg("Trip fare settled from wallet")
+	return nil
+}
+
+// SubsidizeCashTripTx credits the promo discount of a cash trip to the driver's earnings:
+// the passenger paid the discounted amount in cash and the platform makes up the rest
+func (s *walletService) SubsidizeCashTripTx(ctx context.Context, tx pgx.Tx, order *entity.Order) error {
+	if order.Discount <= 0 {
+		return nil
+	}
+	if order.DriverID == nil {
+		return fmt.Errorf("order %d has no driver", order.ID)
+	}
+
+	earnings, err := ledger.EnsureAccount(ctx, tx, ledger.AccountDriverEarnings, order.DriverID)
+	if err != nil {
+		return err
+	}
+	promo, err := ledger.EnsureAccount(ctx, tx, ledger.AccountPromoFunding, nil)
+	if err != nil {
+		return err
+	}
+
+	discount := int64(order.Discount)
+	_, err = ledger.Post(ctx, tx, ledger.Posting{
+		Kind:           ledger.KindPromoSubsidy,
+		IdempotencyKey: fmt.Sprintf("promo-subsidy:%d", order.ID),
+		ReferenceType:  constants.LedgerRefOrder,
+		ReferenceID:    strconv.Itoa(order.ID),
+		Description:    fmt.Sprintf("Promo discount for order #%d", order.ID),
+		Lines: []ledger.Line{
+			{AccountID: promo.ID, Amount: -discount},
+			{AccountID: earnings.ID, Amount: discount},
+		},
+	})
+	return err
+}
+
+// ChargeCancellationFeeTx pays a cancellation fee from the passenger to the order's
+// driver, less the platform commission. The fee comes from the wallet when it covers it
+// and is otherwise booked against the passenger's debt, which the next top-up repays.
+func (s *walletService) ChargeCancellationFeeTx(ctx context.Context, tx pgx.Tx, order *entity.Order, fee int64) (entity.FeeSettlement, error) {
+	if order.DriverID == nil {
+		return "", fmt.Errorf("order %d has no driver", order.ID)
+	}
+
+	earnings, err := ledger.EnsureAccount(ctx, tx, ledger.AccountDriverEarnings, order.DriverID)
+	if err != nil {
+		return "", err
+	}
+	platform, err := ledger.EnsureAccount(ctx, tx, ledger.AccountPlatformCommission, nil)
+	if err != nil {
+		return "", err
+	}
+
+	commission := fee * constants.PlatformCommissionPercent / 100
+	post := func(payerType ledger.AccountType) error {
+		payer, err := ledger.EnsureAccount(ctx, tx, payerType, &order.PassengerID)
+		if err != nil {
+			return err
+		}
+		lines := []ledger.Line{
+			{AccountID: payer.ID, Amount: -fee},
+			{AccountID: earnings.ID, Amount: fee - commission},
+		}
+		if commission > 0 {
+			lines = append(lines, ledger.Line{AccountID: platform.ID, Amount: commission})
+		}
+		_, err = ledger.Post(ctx, tx, ledger.Posting{
+			Kind:           ledger.KindCancelFee,
+			IdempotencyKey: fmt.Sprintf("cancellation-fee:%d", order.ID),
+			ReferenceType:  constants.LedgerRefOrder,
+			ReferenceID:    strconv.Itoa(order.ID),
+			Description:    fmt.Sprintf("Cancellation fee for order #%d", order.ID),
+			Lines:          lines,
+		})
+		return err
+	}
+
+	settlement := entity.FeeSettledWallet
+	err = post(ledger.AccountPassengerWallet)
+	if errors.Is(err, ledger.ErrInsufficientFunds) {
+		settlement = entity.FeeSettledDebt
+		err = post(ledger.AccountPassengerDebt)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	logger.Log.Info().
+		Int("order_id", order.ID).
+		Int("passenger_id", order.PassengerID).
+		Int64("fee", fee).
+		Str("settled_by", string(settlement)).
+		Msg("Cancellation fee charged")
+	return settlement, nil
+}
+
+// OutstandingDebt returns what the passenger still owes in cancellation fees
+func (s *walletService) OutstandingDebt(ctx context.Context, passengerID int) (int64, error) {
+	debt, err := ledger.FindAccount(ctx, s.db, ledger.AccountPassengerDebt, &passengerID)
+	if err != nil || debt == nil {
+		return 0, err
+	}
+	return -debt.Balance, nil
+}
+
+// TopUp credits a passenger wallet. The payment reference is the idempotency key, so the
+// same incoming payment is never credited twice.
+func (s *walletService) TopUp(ctx context.Context, adminID, userID int, req dto.TopUpRequest) (*dto.TopUpResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if user == nil {
+		return nil, apperror.ErrUserNotFound
+	}
+	if user.Role != entity.RolePassenger {
+		return nil, apperror.ErrTopUpTarget
+	}
+
+	description := "Top-up"
+	if req.Note != nil {
+		description = *req.Note
+	}
+
+	var response *dto.TopUpResponse
+	err = database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		var err error
+		response, err = s.CreditTopUpTx(ctx, tx, userID, req.Amount, req.Reference, description, &adminID)
+		return err
+	})
+	if err != nil {
+		logger.Log.Error().Err(err).Int("user_id", userID).Str("reference", req.Reference).Msg("Failed to top up wallet")
+		return nil, apperror.Internal(err)
+	}
+
+	logger.Log.Info().
+		Int("user_id", userID).
+		Int("admin_id", adminID).
+		Int64("transaction_id", response.TransactionID).
+		Int64("amount", response.Amount).
+		Msg("Wallet topped up")
+
+	return response, nil
+}
+
+// CreditTopUpTx moves received money from TOPUP_FUNDING into the passenger wallet.
+// The reference is the idempotency key: crediting it again returns the first transaction.
+func (s *walletService) CreditTopUpTx(ctx context.Context, tx pgx.Tx, userID int, amount int64, reference, description string, createdBy *int) (*dto.TopUpResponse, error) {
+	wallet, err := ledger.EnsureAccount(ctx, tx, ledger.AccountPassengerWallet, &userID)
+	if err != nil {
+		return nil, err
+	}
+	funding, err := ledger.EnsureAccount(ctx, tx, ledger.AccountTopUpFunding, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	txn, err := ledger.Post(ctx, tx, ledger.Posting{
+		Kind:           ledger.KindTopUp,
+		IdempotencyKey: "topup:" + reference,
+		ReferenceType:  constants.LedgerRefTopUp,
+		ReferenceID:    reference,
+		Description:    description,
+		CreatedBy:      createdBy,
+		Lines: []ledger.Line{
+			{AccountID: funding.ID, Amount: -amount},
+			{AccountID: wallet.ID, Amount: amount},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.TopUpResponse{TransactionID: txn.ID, UserID: userID, CreatedAt: txn.CreatedAt}
+	for _, entry := range txn.Entries {
+		if entry.AccountID == wallet.ID {
+			response.Amount = entry.Amount
+			response.Balance = entry.BalanceAfter
+		}
+	}
+
+	repaid, err := s.repayDebtTx(ctx, tx, userID, wallet.ID, response.Balance, reference)
+	if err != nil {
+		return nil, err
+	}
+	response.DebtRepaid = repaid
+	response.Balance -= repaid
+	return response, nil
+}
+
+// repayDebtTx settles as much of the passenger's outstanding fees as the wallet balance
+// covers. It is keyed by the top-up reference, so a replayed top-up repays nothing twice.
+func (s *walletService) repayDebtTx(ctx context.Context, tx pgx.Tx, userID int, walletID, balance int64, reference string) (int64, error) {
+	debt, err := ledger.FindAccount(ctx, tx, ledger.AccountPassengerDebt, &userID)
+	if err != nil || debt == nil || debt.Balance >= 0 {
+		return 0, err
+	}
+
+	amount := min(-debt.Balance, balance)
+	if amount <= 0 {
+		return 0, nil
+	}
+
+	txn, err := ledger.Post(ctx, tx, ledger.Posting{
+		Kind:           ledger.KindDebtRepayment,
+		IdempotencyKey: "debt-repayment:" + reference,
+		ReferenceType:  constants.LedgerRefTopUp,
+		ReferenceID:    reference,
+		Description:    "Outstanding cancellation fees",
+		Lines: []ledger.Line{
+			{AccountID: walletID, Amount: -amount},
+			{AccountID: debt.ID, Amount: amount},
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// A replayed reference returns the first repayment, whose amount may differ
+	for _, entry := range txn.Entries {
+		if entry.AccountID == debt.ID {
+			amount = entry.Amount
+		}
+	}
+	logger.Log.Info().Int("user_id", userID).Int64("transaction_id", txn.ID).Int64("amount", amount).Msg("Passenger debt repaid from top-up")
+	return amount, nil
+}
+
+// RequestPayout moves the amount from the driver's earnings into their payout hold, so it
+// cannot be requested twice while the admin reviews it
+func (s *walletService) RequestPayout(ctx context.Context, driverID int, req dto.CreatePayoutRequest) (*dto.PayoutResponse, error) {
+	payout := &entity.PayoutRequest{
+		DriverID:      driverID,
+		Amount:        req.Amount,
+		Status:        entity.PayoutStatusPending,
+		BankName:      req.BankName,
+		AccountNumber: req.AccountNumber,
+		AccountHolder: req.AccountHolder,
+	}
+
+	err := database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		if err := s.payoutRepo.WithTx(tx).Create(ctx, payout); err != nil {
+			return err
+		}
+		return s.postPayout(ctx, tx, payout, ledger.KindPayoutHold, ledger.AccountDriverEarnings, ledger.AccountPayoutHold)
+	})
+	if err != nil {
+		if errors.Is(err, ledger.ErrInsufficientFunds) {
+			return nil, apperror.ErrInsufficientBalance
+		}
+		logger.Log.Error().Err(err).Int("driver_id", driverID).Msg("Failed to request payout")
+		return nil, apperror.Internal(err)
+	}
+
+	logger.Log.Info().Int64("payout_id", payout.ID).Int("driver_id", driverID).Int64("amount", payout.Amount).Msg("Payout requested")
+	return mapper.ToPayoutResponse(payout), nil
+}
+
+// ListDriverPayouts returns the driver's own payout requests
+func (s *walletService) ListDriverPayouts(ctx context.Context, driverID, limit, offset int) ([]*dto.PayoutResponse, error) {
+	payouts, err := s.payoutRepo.FindByDriver(ctx, driverID, limit, offset)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return mapper.ToPayoutResponses(payouts), nil
+}
+
+// ListPayouts returns payout requests in a status (admin review queue)
+func (s *walletService) ListPayouts(ctx context.Context, status entity.PayoutStatus, limit, offset int) ([]*dto.PayoutResponse, error) {
+	payouts, err := s.payoutRepo.FindByStatus(ctx, status, limit, offset)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return mapper.ToPayoutResponses(payouts), nil
+}
+
+// ApprovePayout records that the money was transferred to the driver's bank account
+func (s *walletService) ApprovePayout(ctx context.Context, adminID int, payoutID int64) (*dto.PayoutResponse, error) {
+	return s.reviewPayout(ctx, adminID, payoutID, entity.PayoutStatusPaid, nil)
+}
+
+// RejectPayout returns the held amount to the driver's earnings
+func (s *walletService) RejectPayout(ctx context.Context, adminID int, payoutID int64, req dto.RejectPayoutRequest) (*dto.PayoutResponse, error) {
+	return s.reviewPayout(ctx, adminID, payoutID, entity.PayoutStatusRejected, &req.Reason)
+}
+
+func (s *walletService) reviewPayout(ctx context.Context, adminID int, payoutID int64, status entity.PayoutStatus, reason *string) (*dto.PayoutResponse, error) {
+	var payout *entity.PayoutRequest
+
+	err := database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		payoutRepo := s.payoutRepo.WithTx(tx)
+
+		var err error
+		payout, err = payoutRepo.FindByIDForUpdate(ctx, payoutID)
+		if err != nil {
+			return err
+		}
+		if payout == nil {
+			return apperror.ErrPayoutNotFound
+		}
+		if payout.Status != entity.PayoutStatusPending {
+			return apperror.ErrPayoutClosed
+		}
+
+		now := s.clock.Now()
+		if err := payoutRepo.MarkReviewed(ctx, payout.ID, status, adminID, reason, now); err != nil {
+			return err
+		}
+		payout.Status = status
+		payout.ReviewedBy = &adminID
+		payout.RejectionReason = reason
+		payout.ReviewedAt = &now
+
+		if status == entity.PayoutStatusPaid {
+			return s.postPayout(ctx, tx, payout, ledger.KindPayoutPaid, ledger.AccountPayoutHold, ledger.AccountPayoutSettled)
+		}
+		return s.postPayout(ctx, tx, payout, ledger.KindPayoutRelease, ledger.AccountPayoutHold, ledger.AccountDriverEarnings)
+	})
+	if err != nil {
+		if _, ok := apperror.As(err); ok {
+			return nil, err
+		}
+		logger.Log.Error().Err(err).Int64("payout_id", payoutID).Msg("Failed to review payout")
+		return nil, apperror.Internal(err)
+	}
+
+	logger.Log.Info().Int64("payout_id", payoutID).Int("admin_id", adminID).Str("status", string(status)).Msg("Payout reviewed")
+	return mapper.ToPayoutResponse(payout), nil
+}
+
+// postPayout moves the payout amount between two accounts. Driver-owned account types
+// belong to the payout's driver; PAYOUT_SETTLED is the platform's.
+func (s *walletService) postPayout(ctx context.Context, tx pgx.Tx, payout *entity.PayoutRequest, kind string, from, to ledger.AccountType) error {
+	owner := func(accountType ledger.AccountType) *int {
+		if accountType == ledger.AccountPayoutSettled {
+			return nil
+		}
+		return &payout.DriverID
+	}
+
+	source, err := ledger.EnsureAccount(ctx, tx, from, owner(from))
+	if err != nil {
+		return err
+	}
+	target, err := ledger.EnsureAccount(ctx, tx, to, owner(to))
+	if err != nil {
+		return err
+	}
+
+	_, err = ledger.Post(ctx, tx, ledger.Posting{
+		Kind:           kind,
+		IdempotencyKey: fmt.Sprintf("%s:%d", kind, payout.ID),
+		ReferenceType:  constants.LedgerRefPayout,
+		ReferenceID:    strconv.FormatInt(payout.ID, 10),
+		Description:    fmt.Sprintf("Payout #%d to %s %s", payout.ID, payout.BankName, payout.AccountNumber),
+		CreatedBy:      payout.ReviewedBy,
+		Lines: []ledger.Line{
+			{AccountID: source.ID, Amount: -payout.Amount},
+			{AccountID: target.ID, Amount: payout.Amount},
+		},
+	})
+	return err
+}
+
+// Reconcile checks the ledger invariants on a consistent snapshot
+func (s *walletService) Reconcile(ctx context.Context) (*dto.ReconciliationResponse, error) {
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	defer tx.Rollback(ctx)
+
+	report, err := ledger.Reconcile(ctx, tx, s.clock.Now())
+	if err != nil {
+		logger.Log.Error().Err(err).Msg("Failed to reconcile ledger")
+		return nil, apperror.Internal(err)
+	}
+
+	if !report.Healthy() {
+		logger.Log.Error().
+			Int("balance_mismatches", len(report.BalanceMismatches)).
+			Int("negative_accounts", len(report.NegativeAccounts)).
+			Int("unbalanced_transactions", len(report.UnbalancedTransactions)).
+			Int64("ledger_sum", report.LedgerSum).
+			Msg("Ledger reconciliation found problems")
+	}
+	return mapper.ToReconciliationResponse(report), nil
+}
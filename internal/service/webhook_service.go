@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/jobqueue"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/webhook"
+)
+
+// WebhookService fans outbox events out to external webhook subscribers
+type WebhookService interface {
+	RouteEvent(event *jobqueue.Event) ([]jobqueue.NewJob, error)
+	HandleDeliverJob(ctx context.Context, job *jobqueue.Job) error
+}
+
+// webhookDeliverPayload is the payload of the webhook.deliver job (one per subscriber)
+type webhookDeliverPayload struct {
+	URL       string          `json:"url"`
+	EventID   int64           `json:"event_id"`
+	EventType string          `json:"event_type"`
+	Body      json.RawMessage `json:"body"`
+}
+
+// webhookBody is what subscribers receive
+type webhookBody struct {
+	ID            int64           `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Data          json.RawMessage `json:"data"`
+}
+
+type webhookService struct {
+	urls   []string
+	sender *webhook.Sender
+}
+
+func NewWebhookService(urls []string, sender *webhook.Sender) WebhookService {
+	return &webhookService{
+		urls:   urls,
+		sender: sender,
+	}
+}
+
+// RouteEvent creates one delivery job per subscriber URL
+func (s *webhookService) RouteEvent(event *jobqueue.Event) ([]jobqueue.NewJob, error) {
+	if len(s.urls) == 0 {
+		return nil, nil
+	}
+
+	body, err := json.Marshal(webhookBody{
+		ID:            event.ID,
+		Type:          event.EventType,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		OccurredAt:    event.CreatedAt,
+		Data:          event.Payload,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]jobqueue.NewJob, 0, len(s.urls))
+	for i, url := range s.urls {
+		jobs = append(jobs, jobqueue.NewJob{
+			Type: constants.JobTypeWebhookDeliver,
+			Payload: webhookDeliverPayload{
+				URL:       url,
+				EventID:   event.ID,
+				EventType: event.EventType,
+				Body:      body,
+			},
+			IdempotencyKey: fmt.Sprintf("webhook:%d:%d", event.ID, i),
+			MaxAttempts:    10,
+		})
+	}
+	return jobs, nil
+}
+
+// HandleDeliverJob posts the event to a subscriber; 4xx answers (other than 408/429) are not retried
+func (s *webhookService) HandleDeliverJob(ctx context.Context, job *jobqueue.Job) error {
+	var payload webhookDeliverPayload
+	if err := job.Decode(&payload); err != nil {
+		return jobqueue.Permanent(fmt.Errorf("invalid webhook.deliver payload: %w", err))
+	}
+
+	err := s.sender.Send(ctx, payload.URL, payload.EventType, strconv.FormatInt(payload.EventID, 10), payload.Body)
+	if err == nil {
+		return nil
+	}
+
+	logger.Log.Warn().
+		Err(err).
+		Str("url", payload.URL).
+		Int64("event_id", payload.EventID).
+		Int("attempt", job.Attempts).
+		Msg("Webhook delivery failed")
+
+	var statusErr *webhook.StatusError
+	if errors.As(err, &statusErr) && !statusErr.Retryable() {
+		return jobqueue.Permanent(err)
+	}
+	return err
+}
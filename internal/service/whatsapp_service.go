@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/AnggaKay/ojek-kampus-backend/internal/entity"
+	"github.com/AnggaKay/ojek-kampus-backend/internal/repository"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/clock"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/jobqueue"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/utils"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/whatsapp"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// WhatsAppService sends every WhatsApp message through the configured provider and keeps
+// track of it: each accepted message is recorded under the provider's id, and the
+// provider webhook moves it through delivered, read or failed. The same webhook carries
+// messages people send to our number, which are queued for the rider relay.
+type WhatsAppService interface {
+	// Number is the sender number people message to reach us
+	Number() string
+	// Send sends a message and records it for delivery tracking. referenceID ties the
+	// message to what it was sent for, see entity.WhatsAppPurpose.
+	Send(ctx context.Context, to, body string, purpose entity.WhatsAppPurpose, referenceID *int) error
+	HandleWebhook(ctx context.Context, token string, body []byte) error
+}
+
+type whatsAppService struct {
+	db          *pgxpool.Pool
+	clock       clock.Clock
+	provider    whatsapp.Provider
+	messageRepo repository.WhatsAppMessageRepository
+}
+
+func NewWhatsAppService(
+	db *pgxpool.Pool,
+	clk clock.Clock,
+	provider whatsapp.Provider,
+	messageRepo repository.WhatsAppMessageRepository,
+) WhatsAppService {
+	return &whatsAppService{
+		db:          db,
+		clock:       clk,
+		provider:    provider,
+		messageRepo: messageRepo,
+	}
+}
+
+func (s *whatsAppService) Number() string {
+	return s.provider.Number()
+}
+
+// Send returns the provider error when the message was not accepted, so callers running
+// in a job retry it. Once accepted the message is out: failing to record it is only
+// logged, since retrying would send it twice.
+func (s *whatsAppService) Send(ctx context.Context, to, body string, purpose entity.WhatsAppPurpose, referenceID *int) error {
+	providerID, err := s.provider.Send(ctx, to, body)
+	if err != nil {
+		return err
+	}
+	if providerID == "" {
+		logger.Log.Warn().Str("purpose", string(purpose)).Msg("WhatsApp provider returned no message id; delivery will not be tracked")
+		return nil
+	}
+
+	message := &entity.WhatsAppMessage{
+		Provider:          s.provider.Name(),
+		ProviderMessageID: providerID,
+		PhoneNumber:       utils.NormalizePhoneNumber(to),
+		Purpose:           purpose,
+		ReferenceID:       referenceID,
+		Status:            entity.WhatsAppStatusSent,
+		SentAt:            s.clock.Now(),
+	}
+	if err := s.messageRepo.Create(ctx, message); err != nil {
+		logger.Log.Warn().
+			Err(err).
+			Str("provider_message_id", providerID).
+			Str("purpose", string(purpose)).
+			Msg("Failed to record sent WhatsApp message")
+	}
+	return nil
+}
+
+// HandleWebhook verifies a provider webhook and handles its event: incoming messages are
+// queued for relaying, delivery updates are applied to the recorded message. Providers
+// retry until they get a 2xx; message ids and status transitions make retries a no-op.
+func (s *whatsAppService) HandleWebhook(ctx context.Context, token string, body []byte) error {
+	event, err := s.provider.ParseWebhook(token, body)
+	if err != nil {
+		if errors.Is(err, whatsapp.ErrInvalidWebhookToken) {
+			logger.Log.Warn().Str("provider", s.provider.Name()).Msg("Rejected WhatsApp webhook with invalid token")
+			return apperror.ErrInvalidWebhookToken
+		}
+		logger.Log.Warn().Err(err).Str("provider", s.provider.Name()).Msg("Rejected malformed WhatsApp webhook")
+		return apperror.ErrInvalidRequest
+	}
+
+	switch {
+	case event == nil:
+		return nil
+	case event.Message != nil:
+		return s.queueInbound(ctx, event.Message)
+	case event.Status != nil:
+		return s.applyStatus(ctx, event.Status)
+	}
+	return nil
+}
+
+// queueInbound queues an incoming message for the relay (relay.forward job)
+func (s *whatsAppService) queueInbound(ctx context.Context, message *whatsapp.InboundMessage) error {
+	if strings.TrimSpace(message.Body) == "" {
+		return nil
+	}
+
+	_, err := jobqueue.Enqueue(ctx, s.db, jobqueue.NewJob{
+		Type: constants.JobTypeRelayForward,
+		Payload: relayForwardPayload{
+			MessageID: message.ID,
+			From:      utils.NormalizePhoneNumber(message.From),
+			Body:      message.Body,
+		},
+		IdempotencyKey: constants.JobTypeRelayForward + ":" + message.ID,
+	})
+	if err != nil {
+		logger.Log.Error().Err(err).Str("message_id", message.ID).Msg("Failed to queue relayed message")
+		return apperror.Internal(err)
+	}
+	return nil
+}
+
+// applyStatus moves a recorded message to its new delivery status. Updates for messages
+// we have no record of, and stale or repeated updates, are ignored. A failed OTP queues
+// a redelivery in the same transaction.
+func (s *whatsAppService) applyStatus(ctx context.Context, update *whatsapp.StatusUpdate) error {
+	message, err := s.messageRepo.FindByProviderID(ctx, s.provider.Name(), update.MessageID)
+	if err != nil {
+		logger.Log.Error().Err(err).Str("provider_message_id", update.MessageID).Msg("Failed to load WhatsApp message")
+		return apperror.Internal(err)
+	}
+	if message == nil {
+		logger.Log.Debug().Str("provider_message_id", update.MessageID).Msg("Ignoring delivery update of untracked WhatsApp message")
+		return nil
+	}
+
+	status := entity.WhatsAppStatus(update.Status)
+	if !message.CanMoveTo(status) {
+		return nil
+	}
+	var errorText *string
+	if update.Error != "" {
+		errorText = &update.Error
+	}
+
+	err = database.WithTx(ctx, s.db, func(tx pgx.Tx) error {
+		moved, err := s.messageRepo.WithTx(tx).UpdateStatus(ctx, message.ID, message.Status, status, errorText, s.clock.Now())
+		if err != nil || !moved {
+			return err
+		}
+
+		if status != entity.WhatsAppStatusFailed || message.Purpose != entity.WhatsAppPurposeOTP || message.ReferenceID == nil {
+			return nil
+		}
+		_, err = jobqueue.Enqueue(ctx, tx, jobqueue.NewJob{
+			Type:           constants.JobTypeRedeliverOTP,
+			Payload:        redeliverOTPJobPayload{OTPID: *message.ReferenceID},
+			IdempotencyKey: fmt.Sprintf("%s:%d", constants.JobTypeRedeliverOTP, message.ID),
+			RunAt:          s.clock.Now().Add(constants.OTPRedeliveryDelay),
+			MaxAttempts:    constants.OTPDeliveryMaxAttempts,
+		})
+		return err
+	})
+	if err != nil {
+		logger.Log.Error().Err(err).Int64("message_id", message.ID).Msg("Failed to apply WhatsApp delivery update")
+		return apperror.Internal(err)
+	}
+
+	if status == entity.WhatsAppStatusFailed {
+		logger.Log.Warn().
+			Int64("message_id", message.ID).
+			Str("purpose", string(message.Purpose)).
+			Str("error", update.Error).
+			Msg("WhatsApp message delivery failed")
+	}
+	return nil
+}
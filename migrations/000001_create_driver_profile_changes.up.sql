@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS driver_profile_changes (
+    id                SERIAL PRIMARY KEY,
+    driver_profile_id INT         NOT NULL REFERENCES driver_profiles(id) ON DELETE CASCADE,
+    field             VARCHAR(50) NOT NULL,
+    old_value         TEXT,
+    new_value         TEXT,
+    changed_by        INT         NOT NULL REFERENCES users(id),
+    created_at        TIMESTAMP   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_driver_profile_changes_profile
+    ON driver_profile_changes (driver_profile_id, created_at DESC);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS email_change_requests (
+    id          SERIAL PRIMARY KEY,
+    user_id     INT          NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    new_email   VARCHAR(255) NOT NULL,
+    code_hash   VARCHAR(64)  NOT NULL,
+    expires_at  TIMESTAMP    NOT NULL,
+    attempts    INT          NOT NULL DEFAULT 0,
+    is_used     BOOLEAN      NOT NULL DEFAULT FALSE,
+    verified_at TIMESTAMP,
+    created_at  TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_change_requests_user
+    ON email_change_requests (user_id, created_at DESC);
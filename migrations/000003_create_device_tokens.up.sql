@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS device_tokens (
+    id           SERIAL PRIMARY KEY,
+    user_id      INT          NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token        TEXT         NOT NULL UNIQUE,
+    platform     VARCHAR(20)  NOT NULL DEFAULT 'ANDROID',
+    created_at   TIMESTAMP    NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_device_tokens_user ON device_tokens (user_id);
@@ -0,0 +1,34 @@
+-- Durable job queue (claimed with SELECT ... FOR UPDATE SKIP LOCKED)
+CREATE TABLE IF NOT EXISTS jobs (
+    id              BIGSERIAL PRIMARY KEY,
+    type            VARCHAR(100) NOT NULL,
+    payload         JSONB        NOT NULL DEFAULT '{}',
+    status          VARCHAR(20)  NOT NULL DEFAULT 'PENDING', -- PENDING, RUNNING, DONE, DEAD
+    attempts        INT          NOT NULL DEFAULT 0,
+    max_attempts    INT          NOT NULL DEFAULT 5,
+    run_at          TIMESTAMP    NOT NULL DEFAULT NOW(),
+    locked_at       TIMESTAMP,
+    locked_by       VARCHAR(100),
+    last_error      TEXT,
+    idempotency_key VARCHAR(255) UNIQUE,
+    created_at      TIMESTAMP    NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMP    NOT NULL DEFAULT NOW(),
+    completed_at    TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_pending ON jobs (run_at) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_jobs_running ON jobs (locked_at) WHERE status = 'RUNNING';
+CREATE INDEX IF NOT EXISTS idx_jobs_dead ON jobs (updated_at) WHERE status = 'DEAD';
+
+-- Transactional outbox (written in the same transaction as domain changes)
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id             BIGSERIAL PRIMARY KEY,
+    aggregate_type VARCHAR(50)  NOT NULL,
+    aggregate_id   VARCHAR(50)  NOT NULL,
+    event_type     VARCHAR(100) NOT NULL,
+    payload        JSONB        NOT NULL DEFAULT '{}',
+    created_at     TIMESTAMP    NOT NULL DEFAULT NOW(),
+    published_at   TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_unpublished ON outbox_events (id) WHERE published_at IS NULL;
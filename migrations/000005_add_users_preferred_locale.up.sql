@@ -0,0 +1,3 @@
+ALTER TABLE users
+    ADD COLUMN preferred_locale VARCHAR(5) NOT NULL DEFAULT 'id'
+        CHECK (preferred_locale IN ('id', 'en'));
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS dispatch_offers;
+DROP TABLE IF EXISTS orders;
+
+DROP INDEX IF EXISTS idx_driver_profiles_online_location;
+ALTER TABLE driver_profiles DROP COLUMN IF EXISTS is_online;
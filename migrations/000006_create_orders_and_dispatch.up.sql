@@ -0,0 +1,56 @@
+-- Driver availability for dispatch
+ALTER TABLE driver_profiles
+    ADD COLUMN IF NOT EXISTS is_online BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_driver_profiles_online_location
+    ON driver_profiles (current_lat, current_long) WHERE is_online = TRUE;
+
+-- Ride orders
+CREATE TABLE IF NOT EXISTS orders (
+    id              SERIAL           PRIMARY KEY,
+    passenger_id    INT              NOT NULL REFERENCES users(id),
+    driver_id       INT              REFERENCES users(id),
+    status          VARCHAR(20)      NOT NULL DEFAULT 'SEARCHING', -- SEARCHING, ACCEPTED, ARRIVED, ON_TRIP, COMPLETED, CANCELLED, EXPIRED
+    pickup_lat      DOUBLE PRECISION NOT NULL,
+    pickup_long     DOUBLE PRECISION NOT NULL,
+    pickup_address  VARCHAR(255)     NOT NULL,
+    dropoff_lat     DOUBLE PRECISION NOT NULL,
+    dropoff_long    DOUBLE PRECISION NOT NULL,
+    dropoff_address VARCHAR(255)     NOT NULL,
+    distance_km     NUMERIC(8, 2)    NOT NULL,
+    fare            INT              NOT NULL,
+    notes           VARCHAR(255),
+    accepted_at     TIMESTAMP,
+    expired_at      TIMESTAMP,
+    created_at      TIMESTAMP        NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMP        NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_orders_passenger ON orders (passenger_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_orders_driver ON orders (driver_id, created_at DESC);
+-- A passenger has at most one order in progress
+CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_active_passenger
+    ON orders (passenger_id) WHERE status IN ('SEARCHING', 'ACCEPTED', 'ARRIVED', 'ON_TRIP');
+CREATE INDEX IF NOT EXISTS idx_orders_active_driver
+    ON orders (driver_id) WHERE status IN ('ACCEPTED', 'ARRIVED', 'ON_TRIP');
+
+-- Every offer made by the dispatcher, with the ranking inputs at offer time (kept for tuning)
+CREATE TABLE IF NOT EXISTS dispatch_offers (
+    id              SERIAL           PRIMARY KEY,
+    order_id        INT              NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    driver_id       INT              NOT NULL REFERENCES users(id),
+    attempt         INT              NOT NULL,
+    status          VARCHAR(20)      NOT NULL DEFAULT 'PENDING', -- PENDING, ACCEPTED, DECLINED, TIMEOUT, CANCELLED
+    radius_km       NUMERIC(6, 2)    NOT NULL,
+    distance_km     NUMERIC(6, 2)    NOT NULL,
+    rating          NUMERIC(3, 2)    NOT NULL,
+    acceptance_rate NUMERIC(4, 3)    NOT NULL,
+    score           NUMERIC(6, 4)    NOT NULL,
+    offered_at      TIMESTAMP        NOT NULL,
+    expires_at      TIMESTAMP        NOT NULL,
+    responded_at    TIMESTAMP,
+    UNIQUE (order_id, attempt)
+);
+
+CREATE INDEX IF NOT EXISTS idx_dispatch_offers_driver ON dispatch_offers (driver_id, offered_at DESC);
+CREATE INDEX IF NOT EXISTS idx_dispatch_offers_pending ON dispatch_offers (driver_id) WHERE status = 'PENDING';
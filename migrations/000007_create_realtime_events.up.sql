@@ -0,0 +1,11 @@
+-- Events pushed over WebSocket, kept for a short time so reconnecting clients can resume
+CREATE TABLE IF NOT EXISTS realtime_events (
+    id         BIGSERIAL    PRIMARY KEY,
+    topic      VARCHAR(100) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload    JSONB        NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_realtime_events_topic ON realtime_events (topic, id);
+CREATE INDEX IF NOT EXISTS idx_realtime_events_created ON realtime_events (created_at);
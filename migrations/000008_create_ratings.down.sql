@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS ratings;
+
+DROP INDEX IF EXISTS idx_driver_profiles_rating_flagged;
+ALTER TABLE passenger_profiles
+    DROP COLUMN IF EXISTS rating_count,
+    DROP COLUMN IF EXISTS rating_sum;
+ALTER TABLE driver_profiles
+    DROP COLUMN IF EXISTS rating_count,
+    DROP COLUMN IF EXISTS rating_sum,
+    DROP COLUMN IF EXISTS rating_flagged_at;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS arrived_at,
+    DROP COLUMN IF EXISTS started_at,
+    DROP COLUMN IF EXISTS completed_at;
@@ -0,0 +1,34 @@
+-- Trip progress timestamps (the rating window starts at completed_at)
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS arrived_at   TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS started_at   TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP;
+
+-- Raw rating totals; rating_avg holds the smoothed average computed from them
+ALTER TABLE driver_profiles
+    ADD COLUMN IF NOT EXISTS rating_count      INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS rating_sum        INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS rating_flagged_at TIMESTAMP; -- set when the average falls below the review threshold
+
+ALTER TABLE passenger_profiles
+    ADD COLUMN IF NOT EXISTS rating_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS rating_sum   INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_driver_profiles_rating_flagged
+    ON driver_profiles (rating_flagged_at) WHERE rating_flagged_at IS NOT NULL;
+
+-- One rating per participant per completed order
+CREATE TABLE IF NOT EXISTS ratings (
+    id         SERIAL       PRIMARY KEY,
+    order_id   INT          NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    rater_id   INT          NOT NULL REFERENCES users(id),
+    ratee_id   INT          NOT NULL REFERENCES users(id),
+    rater_role VARCHAR(20)  NOT NULL, -- PASSENGER, DRIVER
+    stars      SMALLINT     NOT NULL CHECK (stars BETWEEN 1 AND 5),
+    tags       TEXT[]       NOT NULL DEFAULT '{}',
+    comment    VARCHAR(500),
+    created_at TIMESTAMP    NOT NULL DEFAULT NOW(),
+    UNIQUE (order_id, rater_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ratings_ratee ON ratings (ratee_id, created_at DESC);
@@ -0,0 +1,7 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS payment_method;
+
+DROP TABLE IF EXISTS payout_requests;
+DROP TABLE IF EXISTS ledger_entries;
+DROP TABLE IF EXISTS ledger_transactions;
+DROP TABLE IF EXISTS ledger_accounts;
+DROP FUNCTION IF EXISTS ledger_reject_mutation();
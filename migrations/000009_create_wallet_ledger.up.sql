@@ -0,0 +1,82 @@
+-- Double-entry ledger. Every transaction's entries sum to zero; balances are the sum of an
+-- account's entries, cached on the account row and checked by the reconciliation report.
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    id             BIGSERIAL   PRIMARY KEY,
+    type           VARCHAR(30) NOT NULL, -- PASSENGER_WALLET, DRIVER_EARNINGS, PAYOUT_HOLD (per user); PLATFORM_COMMISSION, TOPUP_FUNDING, PAYOUT_SETTLED (system)
+    owner_id       INT         REFERENCES users(id),
+    balance        BIGINT      NOT NULL DEFAULT 0,
+    allow_negative BOOLEAN     NOT NULL DEFAULT FALSE,
+    created_at     TIMESTAMP   NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMP   NOT NULL DEFAULT NOW(),
+    CHECK (allow_negative OR balance >= 0)
+);
+
+-- One account per type and owner; system accounts have no owner
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ledger_accounts_type_owner
+    ON ledger_accounts (type, COALESCE(owner_id, 0));
+
+CREATE TABLE IF NOT EXISTS ledger_transactions (
+    id              BIGSERIAL    PRIMARY KEY,
+    kind            VARCHAR(30)  NOT NULL, -- TOPUP, TRIP_FARE, PAYOUT_HOLD, PAYOUT_PAID, PAYOUT_RELEASE
+    idempotency_key VARCHAR(255) NOT NULL UNIQUE,
+    reference_type  VARCHAR(50),
+    reference_id    VARCHAR(50),
+    description     VARCHAR(255),
+    created_by      INT          REFERENCES users(id),
+    created_at      TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_transactions_reference
+    ON ledger_transactions (reference_type, reference_id);
+
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id             BIGSERIAL PRIMARY KEY,
+    transaction_id BIGINT    NOT NULL REFERENCES ledger_transactions(id),
+    account_id     BIGINT    NOT NULL REFERENCES ledger_accounts(id),
+    amount         BIGINT    NOT NULL CHECK (amount <> 0), -- positive increases the balance
+    balance_after  BIGINT    NOT NULL,
+    created_at     TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_account ON ledger_entries (account_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_transaction ON ledger_entries (transaction_id);
+
+-- Ledger rows are immutable: corrections are new, reversing transactions
+CREATE OR REPLACE FUNCTION ledger_reject_mutation() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION '% is append-only', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_ledger_transactions_immutable ON ledger_transactions;
+CREATE TRIGGER trg_ledger_transactions_immutable
+    BEFORE UPDATE OR DELETE ON ledger_transactions
+    FOR EACH ROW EXECUTE FUNCTION ledger_reject_mutation();
+
+DROP TRIGGER IF EXISTS trg_ledger_entries_immutable ON ledger_entries;
+CREATE TRIGGER trg_ledger_entries_immutable
+    BEFORE UPDATE OR DELETE ON ledger_entries
+    FOR EACH ROW EXECUTE FUNCTION ledger_reject_mutation();
+
+-- Driver requests to withdraw earnings; the amount sits in the driver's PAYOUT_HOLD account until reviewed
+CREATE TABLE IF NOT EXISTS payout_requests (
+    id               BIGSERIAL    PRIMARY KEY,
+    driver_id        INT          NOT NULL REFERENCES users(id),
+    amount           BIGINT       NOT NULL CHECK (amount > 0),
+    status           VARCHAR(20)  NOT NULL DEFAULT 'PENDING', -- PENDING, PAID, REJECTED
+    bank_name        VARCHAR(50)  NOT NULL,
+    account_number   VARCHAR(50)  NOT NULL,
+    account_holder   VARCHAR(100) NOT NULL,
+    rejection_reason VARCHAR(255),
+    reviewed_by      INT          REFERENCES users(id),
+    reviewed_at      TIMESTAMP,
+    created_at       TIMESTAMP    NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_payout_requests_driver ON payout_requests (driver_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_payout_requests_pending ON payout_requests (created_at) WHERE status = 'PENDING';
+
+-- How the passenger pays for the trip
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS payment_method VARCHAR(20) NOT NULL DEFAULT 'CASH'; -- CASH, WALLET
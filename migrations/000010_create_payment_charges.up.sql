@@ -0,0 +1,26 @@
+-- Wallet top-ups paid through a payment gateway (QRIS or bank virtual account).
+-- reference is our order id at the gateway; a PAID charge is credited to the wallet once,
+-- keyed by the reference in the ledger.
+CREATE TABLE IF NOT EXISTS payment_charges (
+    id                    BIGSERIAL    PRIMARY KEY,
+    user_id               INT          NOT NULL REFERENCES users(id),
+    provider              VARCHAR(20)  NOT NULL,
+    reference             VARCHAR(64)  NOT NULL UNIQUE,
+    provider_ref          VARCHAR(100),
+    channel               VARCHAR(10)  NOT NULL, -- QRIS, VA
+    bank                  VARCHAR(20),
+    amount                BIGINT       NOT NULL CHECK (amount > 0),
+    status                VARCHAR(20)  NOT NULL DEFAULT 'PENDING', -- PENDING, PAID, FAILED, EXPIRED
+    qr_string             TEXT,
+    qr_image_url          VARCHAR(255),
+    va_number             VARCHAR(50),
+    expires_at            TIMESTAMP    NOT NULL,
+    paid_at               TIMESTAMP,
+    ledger_transaction_id BIGINT       REFERENCES ledger_transactions(id),
+    last_checked_at       TIMESTAMP,
+    created_at            TIMESTAMP    NOT NULL DEFAULT NOW(),
+    updated_at            TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_charges_user ON payment_charges (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_payment_charges_pending ON payment_charges (expires_at) WHERE status = 'PENDING';
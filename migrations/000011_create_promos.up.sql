@@ -0,0 +1,44 @@
+-- Promo codes. used_count counts live redemptions (reserved or redeemed) and is only
+-- changed while the promo row is locked, so the global and per-user caps cannot be raced.
+CREATE TABLE IF NOT EXISTS promos (
+    id             SERIAL       PRIMARY KEY,
+    code           VARCHAR(30)  NOT NULL UNIQUE, -- stored upper-case
+    name           VARCHAR(100) NOT NULL,
+    description    VARCHAR(255),
+    discount_type  VARCHAR(10)  NOT NULL, -- PERCENT, FLAT
+    discount_value INT          NOT NULL CHECK (discount_value > 0),
+    max_discount   INT          CHECK (max_discount > 0),
+    min_fare       INT          NOT NULL DEFAULT 0,
+    usage_limit    INT          CHECK (usage_limit > 0), -- NULL = unlimited
+    per_user_limit INT          NOT NULL DEFAULT 1 CHECK (per_user_limit > 0),
+    used_count     INT          NOT NULL DEFAULT 0 CHECK (used_count >= 0),
+    eligible_roles TEXT[]       NOT NULL DEFAULT '{}', -- empty = any role
+    new_users_only BOOLEAN      NOT NULL DEFAULT FALSE, -- passengers without a completed trip
+    email_domains  TEXT[]       NOT NULL DEFAULT '{}', -- e.g. {ui.ac.id, *.ac.id}; empty = any
+    starts_at      TIMESTAMP    NOT NULL,
+    ends_at        TIMESTAMP    NOT NULL,
+    is_active      BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_by     INT          REFERENCES users(id),
+    created_at     TIMESTAMP    NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMP    NOT NULL DEFAULT NOW(),
+    CHECK (discount_type <> 'PERCENT' OR discount_value <= 100),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE TABLE IF NOT EXISTS promo_redemptions (
+    id          BIGSERIAL   PRIMARY KEY,
+    promo_id    INT         NOT NULL REFERENCES promos(id),
+    user_id     INT         NOT NULL REFERENCES users(id),
+    order_id    INT         NOT NULL UNIQUE REFERENCES orders(id),
+    discount    INT         NOT NULL CHECK (discount > 0),
+    status      VARCHAR(20) NOT NULL DEFAULT 'RESERVED', -- RESERVED, REDEEMED, RELEASED
+    reserved_at TIMESTAMP   NOT NULL DEFAULT NOW(),
+    redeemed_at TIMESTAMP,
+    released_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_promo_redemptions_promo_user ON promo_redemptions (promo_id, user_id) WHERE status <> 'RELEASED';
+
+-- Discount granted on the order; the passenger pays fare - discount and the platform funds the rest
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS promo_code VARCHAR(30);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount   INT NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+ALTER TABLE promos DROP COLUMN IF EXISTS campus_only;
+DROP TABLE IF EXISTS campus_domains;
+ALTER TABLE users
+    DROP COLUMN IF EXISTS campus_eligible,
+    DROP COLUMN IF EXISTS email_verified;
@@ -0,0 +1,17 @@
+-- Verified email and campus eligibility. campus_eligible is derived: it is recomputed
+-- from the verified email whenever the email or the campus domain allow-list changes.
+ALTER TABLE users
+    ADD COLUMN email_verified  BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN campus_eligible BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Allow-listed campus email domains, matched exactly ("ui.ac.id") or by subdomain ("*.ac.id")
+CREATE TABLE IF NOT EXISTS campus_domains (
+    id         SERIAL       PRIMARY KEY,
+    pattern    VARCHAR(100) NOT NULL UNIQUE, -- stored lower-case
+    name       VARCHAR(100) NOT NULL,
+    created_by INT          REFERENCES users(id),
+    created_at TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE promos
+    ADD COLUMN campus_only BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,17 @@
+-- Expiry dates of driver documents, recorded by an admin at review. expires_on is the last
+-- valid day. last_reminder_days is the smallest reminder threshold already sent, so each
+-- threshold is sent once; expired_at is set once the lapse has been enforced.
+CREATE TABLE IF NOT EXISTS driver_document_expiries (
+    driver_profile_id  INT         NOT NULL REFERENCES driver_profiles(id) ON DELETE CASCADE,
+    document_type      VARCHAR(10) NOT NULL CHECK (document_type IN ('SIM', 'STNK', 'KTM')),
+    expires_on         DATE        NOT NULL,
+    last_reminder_days INT,
+    expired_at         TIMESTAMP,
+    recorded_by        INT         REFERENCES users(id),
+    recorded_at        TIMESTAMP   NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (driver_profile_id, document_type)
+);
+
+CREATE INDEX IF NOT EXISTS idx_driver_document_expiries_pending
+    ON driver_document_expiries (expires_on)
+    WHERE expired_at IS NULL;
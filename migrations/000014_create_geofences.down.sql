@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_driver_profiles_outside_service_area;
+ALTER TABLE driver_profiles
+    DROP COLUMN IF EXISTS outside_service_area_since;
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS zone_surcharge;
+DROP TABLE IF EXISTS pickup_points;
+DROP TABLE IF EXISTS zones;
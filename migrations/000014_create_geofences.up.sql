@@ -0,0 +1,53 @@
+-- Geofence zones drawn by admins. geometry is a GeoJSON MultiPolygon ([long, lat]
+-- positions); the bounding box columns prefilter containment checks before the exact
+-- polygon test. SERVICE_AREA zones bound where rides may start or end, PRICING zones add
+-- their surcharge to trips starting or ending inside them, NO_GO zones are off limits.
+CREATE TABLE IF NOT EXISTS zones (
+    id         SERIAL           PRIMARY KEY,
+    name       VARCHAR(100)     NOT NULL,
+    kind       VARCHAR(20)      NOT NULL CHECK (kind IN ('SERVICE_AREA', 'PRICING', 'NO_GO')),
+    geometry   JSONB            NOT NULL,
+    min_lat    DOUBLE PRECISION NOT NULL,
+    max_lat    DOUBLE PRECISION NOT NULL,
+    min_long   DOUBLE PRECISION NOT NULL,
+    max_long   DOUBLE PRECISION NOT NULL,
+    surcharge  INT              NOT NULL DEFAULT 0 CHECK (surcharge >= 0), -- Rupiah, PRICING zones
+    is_active  BOOLEAN          NOT NULL DEFAULT TRUE,
+    created_by INT              REFERENCES users(id),
+    created_at TIMESTAMP        NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP        NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_zones_active_bounds
+    ON zones (kind, min_lat, max_lat)
+    WHERE is_active = TRUE;
+
+-- Official pickup and drop points (gates, faculty drop points). Pickups and dropoffs close
+-- to a point are snapped onto it.
+CREATE TABLE IF NOT EXISTS pickup_points (
+    id          SERIAL           PRIMARY KEY,
+    name        VARCHAR(100)     NOT NULL,
+    kind        VARCHAR(20)      NOT NULL CHECK (kind IN ('GATE', 'FACULTY', 'OTHER')),
+    lat         DOUBLE PRECISION NOT NULL,
+    long        DOUBLE PRECISION NOT NULL,
+    description VARCHAR(255),
+    is_active   BOOLEAN          NOT NULL DEFAULT TRUE,
+    created_by  INT              REFERENCES users(id),
+    created_at  TIMESTAMP        NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP        NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pickup_points_active_location
+    ON pickup_points (lat, long)
+    WHERE is_active = TRUE;
+
+-- Pricing zone surcharge included in the fare
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS zone_surcharge INT NOT NULL DEFAULT 0;
+
+-- Set while the driver's last location is outside the service area
+ALTER TABLE driver_profiles
+    ADD COLUMN IF NOT EXISTS outside_service_area_since TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_driver_profiles_outside_service_area
+    ON driver_profiles (outside_service_area_since) WHERE outside_service_area_since IS NOT NULL;
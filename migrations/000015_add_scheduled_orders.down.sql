@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_orders_scheduled_driver;
+DROP INDEX IF EXISTS idx_orders_scheduled_passenger;
+DROP INDEX IF EXISTS idx_orders_scheduled;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS cancelled_at,
+    DROP COLUMN IF EXISTS scheduled_at;
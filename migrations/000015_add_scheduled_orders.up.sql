@@ -0,0 +1,12 @@
+-- Rides booked in advance wait in SCHEDULED until dispatch starts shortly before pickup.
+-- A driver who pre-accepts one is recorded in driver_id while it is still SCHEDULED.
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS scheduled_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_orders_scheduled
+    ON orders (scheduled_at) WHERE status = 'SCHEDULED';
+CREATE INDEX IF NOT EXISTS idx_orders_scheduled_passenger
+    ON orders (passenger_id, scheduled_at) WHERE status = 'SCHEDULED';
+CREATE INDEX IF NOT EXISTS idx_orders_scheduled_driver
+    ON orders (driver_id, scheduled_at) WHERE status = 'SCHEDULED';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS user_cooldowns;
+DROP TABLE IF EXISTS cancellations;
@@ -0,0 +1,36 @@
+-- Every time a passenger or driver backs out of a ride. charged_user_id is the user the
+-- cancellation counts against (NULL when it was free): usually the one who cancelled,
+-- but the passenger when a driver reports a no-show. A fee is always paid by the
+-- passenger to the driver, from the wallet or, when it is short, as debt.
+CREATE TABLE IF NOT EXISTS cancellations (
+    id              SERIAL       PRIMARY KEY,
+    order_id        INT          NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    cancelled_by    INT          NOT NULL REFERENCES users(id),
+    role            VARCHAR(20)  NOT NULL CHECK (role IN ('PASSENGER', 'DRIVER')),
+    reason_code     VARCHAR(30)  NOT NULL,
+    note            VARCHAR(255),
+    order_status    VARCHAR(20)  NOT NULL,
+    fee             INT          NOT NULL DEFAULT 0 CHECK (fee >= 0),
+    fee_settled_by  VARCHAR(10)  CHECK (fee_settled_by IN ('WALLET', 'DEBT')),
+    charged_user_id INT          REFERENCES users(id),
+    created_at      TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_cancellations_order ON cancellations (order_id);
+CREATE INDEX IF NOT EXISTS idx_cancellations_cancelled_by ON cancellations (cancelled_by, created_at);
+CREATE INDEX IF NOT EXISTS idx_cancellations_charged
+    ON cancellations (charged_user_id, created_at)
+    WHERE charged_user_id IS NOT NULL;
+
+-- Users whose cancellation rate crossed the threshold: passengers cannot order and
+-- drivers cannot go online until the cooldown ends
+CREATE TABLE IF NOT EXISTS user_cooldowns (
+    user_id       INT         PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    role          VARCHAR(20) NOT NULL CHECK (role IN ('PASSENGER', 'DRIVER')),
+    until         TIMESTAMP   NOT NULL,
+    cancellations INT         NOT NULL,
+    orders        INT         NOT NULL,
+    created_at    TIMESTAMP   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_cooldowns_until ON user_cooldowns (until);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS trip_routes;
+DROP TABLE IF EXISTS trip_points;
@@ -0,0 +1,27 @@
+-- Driver location pings received while an order is ON_TRIP, kept raw so the route can
+-- be rebuilt when the cleaning rules change or a fare is disputed
+CREATE TABLE IF NOT EXISTS trip_points (
+    id          BIGSERIAL        PRIMARY KEY,
+    order_id    INT              NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    lat         DOUBLE PRECISION NOT NULL,
+    long        DOUBLE PRECISION NOT NULL,
+    recorded_at TIMESTAMP        NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_trip_points_order ON trip_points (order_id, recorded_at);
+
+-- The cleaned trace of a completed trip: GPS jumps dropped, the shape simplified into an
+-- encoded polyline, and the distance, duration and fare measured from it
+CREATE TABLE IF NOT EXISTS trip_routes (
+    order_id          INT              PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+    polyline          TEXT             NOT NULL,
+    distance_km       DOUBLE PRECISION NOT NULL,
+    duration_seconds  INT              NOT NULL,
+    raw_points        INT              NOT NULL,
+    kept_points       INT              NOT NULL,
+    simplified_points INT              NOT NULL,
+    measured_fare     INT              NOT NULL,
+    started_at        TIMESTAMP        NOT NULL,
+    ended_at          TIMESTAMP        NOT NULL,
+    built_at          TIMESTAMP        NOT NULL DEFAULT NOW()
+);
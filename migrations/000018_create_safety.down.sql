@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS sos_incidents;
+DROP TABLE IF EXISTS trusted_contacts;
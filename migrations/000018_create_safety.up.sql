@@ -0,0 +1,42 @@
+-- People a user wants alerted in an emergency. verified_at is set once the contact
+-- confirms the OTP sent to their WhatsApp; unverified contacts never receive alerts.
+CREATE TABLE IF NOT EXISTS trusted_contacts (
+    id           SERIAL       PRIMARY KEY,
+    user_id      INT          NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name         VARCHAR(100) NOT NULL,
+    phone_number VARCHAR(20)  NOT NULL,
+    verified_at  TIMESTAMP,
+    created_at   TIMESTAMP    NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, phone_number)
+);
+
+-- Emergencies raised during a ride. trip is a snapshot of the participants, vehicle and
+-- route when the SOS was pressed; admins move the incident through its own workflow.
+CREATE TABLE IF NOT EXISTS sos_incidents (
+    id               SERIAL           PRIMARY KEY,
+    order_id         INT              NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    reported_by      INT              NOT NULL REFERENCES users(id),
+    role             VARCHAR(20)      NOT NULL CHECK (role IN ('PASSENGER', 'DRIVER')),
+    lat              DOUBLE PRECISION NOT NULL,
+    long             DOUBLE PRECISION NOT NULL,
+    order_status     VARCHAR(20)      NOT NULL,
+    trip             JSONB            NOT NULL,
+    note             VARCHAR(255),
+    status           VARCHAR(20)      NOT NULL DEFAULT 'OPEN'
+                     CHECK (status IN ('OPEN', 'ACKNOWLEDGED', 'RESOLVED', 'DISMISSED')),
+    contacts_alerted INT              NOT NULL DEFAULT 0,
+    acknowledged_by  INT              REFERENCES users(id),
+    acknowledged_at  TIMESTAMP,
+    resolved_by      INT              REFERENCES users(id),
+    resolved_at      TIMESTAMP,
+    resolution_note  VARCHAR(500),
+    created_at       TIMESTAMP        NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMP        NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sos_incidents_status ON sos_incidents (status, created_at);
+
+-- Pressing SOS again while an incident is being handled returns the same incident
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sos_incidents_open
+    ON sos_incidents (order_id, reported_by)
+    WHERE status IN ('OPEN', 'ACKNOWLEDGED');
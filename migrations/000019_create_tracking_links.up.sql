@@ -0,0 +1,14 @@
+-- Read-only trip tracking links a passenger shares with friends. Only the SHA-256 of the
+-- token is stored; a link stops working when it is revoked, when the order ends or at
+-- expires_at, whichever comes first.
+CREATE TABLE IF NOT EXISTS tracking_links (
+    id         SERIAL      PRIMARY KEY,
+    order_id   INT         NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    created_by INT         NOT NULL REFERENCES users(id),
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP   NOT NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_tracking_links_order ON tracking_links (order_id);
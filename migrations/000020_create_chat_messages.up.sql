@@ -0,0 +1,17 @@
+-- In-ride chat between a passenger and their driver. Messages can only be sent while a
+-- driver is assigned and the order is active; they are kept for the chat retention
+-- period after that so disputes can be looked into, then purged.
+CREATE TABLE IF NOT EXISTS chat_messages (
+    id          BIGSERIAL    PRIMARY KEY,
+    order_id    INT          NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    sender_id   INT          NOT NULL REFERENCES users(id),
+    sender_role VARCHAR(20)  NOT NULL CHECK (sender_role IN ('PASSENGER', 'DRIVER')),
+    body        VARCHAR(500) NOT NULL,
+    quick_reply VARCHAR(40),
+    masked      BOOLEAN      NOT NULL DEFAULT FALSE,
+    read_at     TIMESTAMP,
+    created_at  TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_messages_order ON chat_messages (order_id, id);
+CREATE INDEX IF NOT EXISTS idx_chat_messages_created ON chat_messages (created_at);
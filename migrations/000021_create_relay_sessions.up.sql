@@ -0,0 +1,20 @@
+-- Masked WhatsApp contact between the passenger and driver of an order. Both message our
+-- sender number and their messages are passed on to the other party, so neither sees the
+-- other's phone number. Phones are copied when the session opens so inbound messages can
+-- be matched by sender; a session stops relaying once closed_at is set or the order ends.
+CREATE TABLE IF NOT EXISTS relay_sessions (
+    id               SERIAL      PRIMARY KEY,
+    order_id         INT         NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    passenger_id     INT         NOT NULL REFERENCES users(id),
+    driver_id        INT         NOT NULL REFERENCES users(id),
+    passenger_phone  VARCHAR(20) NOT NULL,
+    driver_phone     VARCHAR(20) NOT NULL,
+    messages_relayed INT         NOT NULL DEFAULT 0,
+    created_at       TIMESTAMP   NOT NULL DEFAULT NOW(),
+    closed_at        TIMESTAMP
+);
+
+-- One open session per order; a new driver after a reassignment gets a new session
+CREATE UNIQUE INDEX IF NOT EXISTS idx_relay_sessions_open ON relay_sessions (order_id) WHERE closed_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_relay_sessions_passenger_phone ON relay_sessions (passenger_phone, created_at);
+CREATE INDEX IF NOT EXISTS idx_relay_sessions_driver_phone ON relay_sessions (driver_phone, created_at);
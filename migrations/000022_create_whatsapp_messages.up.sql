@@ -0,0 +1,25 @@
+-- WhatsApp messages we sent and how far each got. The row is written once the provider
+-- accepts the message and moves forward as its delivery webhooks arrive; purpose and
+-- reference_id tie it back to what it was sent for (the OTP, user or relay session).
+CREATE TABLE IF NOT EXISTS whatsapp_messages (
+    id                  BIGSERIAL    PRIMARY KEY,
+    provider            VARCHAR(20)  NOT NULL,
+    provider_message_id VARCHAR(100) NOT NULL,
+    phone_number        VARCHAR(20)  NOT NULL,
+    purpose             VARCHAR(20)  NOT NULL CHECK (purpose IN ('OTP', 'NOTIFICATION', 'RELAY')),
+    reference_id        INT,
+    status              VARCHAR(20)  NOT NULL DEFAULT 'SENT' CHECK (status IN ('SENT', 'DELIVERED', 'READ', 'FAILED')),
+    error               TEXT,
+    sent_at             TIMESTAMP    NOT NULL DEFAULT NOW(),
+    delivered_at        TIMESTAMP,
+    read_at             TIMESTAMP,
+    failed_at           TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_whatsapp_messages_provider_id ON whatsapp_messages (provider, provider_message_id);
+CREATE INDEX IF NOT EXISTS idx_whatsapp_messages_reference ON whatsapp_messages (purpose, reference_id, id);
+
+-- The locale an OTP was requested in, so a redelivery after a failed send reads the same
+ALTER TABLE otp_codes
+    ADD COLUMN IF NOT EXISTS locale VARCHAR(5) NOT NULL DEFAULT 'id'
+        CHECK (locale IN ('id', 'en'));
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_orders_active_driver;
+CREATE INDEX IF NOT EXISTS idx_orders_active_driver
+    ON orders (driver_id) WHERE status IN ('ACCEPTED', 'ARRIVED', 'ON_TRIP');
+
+DROP INDEX IF EXISTS idx_dispatch_offers_pending;
+CREATE INDEX IF NOT EXISTS idx_dispatch_offers_pending ON dispatch_offers (driver_id) WHERE status = 'PENDING';
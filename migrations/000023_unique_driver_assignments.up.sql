@@ -0,0 +1,18 @@
+-- A driver serves at most one order and holds at most one open offer at a time. The
+-- dispatcher and offer acceptance check this first; the unique indexes settle the races.
+-- Duplicate open offers left by such races are closed first; duplicate active orders
+-- need a manual decision, so the migration fails until they are resolved.
+UPDATE dispatch_offers o
+SET status = 'CANCELLED', responded_at = NOW()
+WHERE o.status = 'PENDING'
+  AND EXISTS (
+      SELECT 1 FROM dispatch_offers newer
+      WHERE newer.driver_id = o.driver_id AND newer.status = 'PENDING' AND newer.id > o.id
+  );
+
+DROP INDEX IF EXISTS idx_dispatch_offers_pending;
+CREATE UNIQUE INDEX idx_dispatch_offers_pending ON dispatch_offers (driver_id) WHERE status = 'PENDING';
+
+DROP INDEX IF EXISTS idx_orders_active_driver;
+CREATE UNIQUE INDEX idx_orders_active_driver
+    ON orders (driver_id) WHERE status IN ('ACCEPTED', 'ARRIVED', 'ON_TRIP');
@@ -0,0 +1,125 @@
+package apperror
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Error is a domain error that knows how it should be presented to API clients.
+// Services return these; the central HTTP error handler renders them as dto.Response.
+type Error struct {
+	Code       string            // machine readable code, e.g. PHONE_EXISTS
+	Status     int               // HTTP status code
+	MessageKey string            // i18n catalog key of the user-facing message
+	Message    string            // internal English message used in logs
+	Vars       map[string]string // placeholders for the user-facing message
+	Detail     string            // extra detail appended to the user-facing message
+	Fields     map[string]string // per-field validation messages
+	Err        error             // wrapped cause (never shown to clients)
+}
+
+// New defines an application error
+func New(status int, code, messageKey, message string) *Error {
+	return &Error{
+		Code:       code,
+		Status:     status,
+		MessageKey: messageKey,
+		Message:    message,
+	}
+}
+
+func (e *Error) Error() string {
+	message := e.Message
+	if e.Detail != "" {
+		message += ": " + e.Detail
+	}
+	if e.Err != nil {
+		message += ": " + e.Err.Error()
+	}
+	return message
+}
+
+// Unwrap exposes the cause to errors.Is / errors.As
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches application errors by code and message key, so errors.Is(err, apperror.ErrUserNotFound)
+// holds for any copy created with Wrap/WithVars/WithDetail/WithFields. Several errors share a
+// code (e.g. NOT_FOUND), so the code alone does not identify one.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code && t.MessageKey == e.MessageKey
+}
+
+// Wrap returns a copy of the error with a cause attached
+func (e *Error) Wrap(cause error) *Error {
+	clone := *e
+	clone.Err = cause
+	return &clone
+}
+
+// WithVars returns a copy of the error with message placeholders set
+func (e *Error) WithVars(vars map[string]string) *Error {
+	clone := *e
+	clone.Vars = vars
+	return &clone
+}
+
+// WithDetail returns a copy of the error with a detail appended to its message
+func (e *Error) WithDetail(detail string) *Error {
+	clone := *e
+	clone.Detail = detail
+	return &clone
+}
+
+// WithFields returns a copy of the error with per-field messages
+func (e *Error) WithFields(fields map[string]string) *Error {
+	clone := *e
+	clone.Fields = fields
+	return &clone
+}
+
+// As returns the application error in err's chain, if any
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
+
+// Internal wraps an unexpected error; clients only see a generic message
+func Internal(cause error) *Error {
+	return ErrInternal.Wrap(cause)
+}
+
+// Validation builds a validation error with per-field messages
+func Validation(fields map[string]string) *Error {
+	return ErrValidation.WithFields(fields)
+}
+
+// FromStatus converts a bare HTTP status (e.g. from echo's router) into an application error
+func FromStatus(status int) *Error {
+	switch status {
+	case http.StatusNotFound:
+		return ErrRouteNotFound
+	case http.StatusMethodNotAllowed:
+		return ErrMethodNotAllowed
+	case http.StatusRequestEntityTooLarge:
+		return ErrRequestTooLarge
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusBadRequest:
+		return ErrInvalidRequest
+	default:
+		if status >= http.StatusInternalServerError {
+			return ErrInternal
+		}
+		return New(status, "HTTP_ERROR", "error.invalid_request", http.StatusText(status))
+	}
+}
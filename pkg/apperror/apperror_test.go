@@ -0,0 +1,40 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorIs(t *testing.T) {
+	cause := errors.New("connection reset")
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{name: "same error", err: ErrOrderNotFound, target: ErrOrderNotFound, want: true},
+		{name: "wrapped copy", err: ErrOrderNotFound.Wrap(cause), target: ErrOrderNotFound, want: true},
+		{name: "copy with vars", err: ErrOrderNotFound.WithVars(map[string]string{"id": "7"}), target: ErrOrderNotFound, want: true},
+		{name: "copy with detail", err: ErrInvalidRequest.WithDetail("bad reference"), target: ErrInvalidRequest, want: true},
+		{name: "copy with fields", err: ErrValidation.WithFields(map[string]string{"phone": "required"}), target: ErrValidation, want: true},
+		{name: "inside a fmt wrapper", err: fmt.Errorf("accept offer: %w", ErrDriverBusy), target: ErrDriverBusy, want: true},
+		{name: "different not found errors", err: ErrUserNotFound, target: ErrOrderNotFound, want: false},
+		{name: "other NOT_FOUND errors do not match", err: ErrTrackingLinkNotFound, target: ErrOrderNotFound, want: false},
+		{name: "other UNAUTHORIZED errors do not match", err: ErrInvalidToken, target: ErrMissingToken, want: false},
+		{name: "other FORBIDDEN errors do not match", err: ErrDocumentAccessDenied, target: ErrForbidden, want: false},
+		{name: "other WEAK_PASSWORD errors do not match", err: ErrPasswordTooShort, target: ErrPasswordTooWeak, want: false},
+		{name: "cause is reachable", err: Internal(cause), target: cause, want: true},
+		{name: "plain error", err: cause, target: ErrInternal, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, tt.target); got != tt.want {
+				t.Errorf("errors.Is(%v, %v) = %v, want %v", tt.err, tt.target, got, tt.want)
+			}
+		})
+	}
+}
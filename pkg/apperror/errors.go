@@ -0,0 +1,208 @@
+package apperror
+
+import (
+	"net/http"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
+)
+
+// Generic errors
+var (
+	ErrInternal         = New(http.StatusInternalServerError, "INTERNAL_ERROR", "error.internal", "internal server error")
+	ErrInvalidRequest   = New(http.StatusBadRequest, "INVALID_REQUEST", "error.invalid_request", "invalid request")
+	ErrValidation       = New(http.StatusBadRequest, "VALIDATION_ERROR", "error.validation", "validation failed")
+	ErrRouteNotFound    = New(http.StatusNotFound, "NOT_FOUND", "error.route_not_found", "route not found")
+	ErrMethodNotAllowed = New(http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "error.method_not_allowed", "method not allowed")
+	ErrRequestTooLarge  = New(http.StatusRequestEntityTooLarge, "REQUEST_TOO_LARGE", "error.request_too_large", "request body too large")
+	ErrRateLimited      = New(http.StatusTooManyRequests, "RATE_LIMIT_EXCEEDED", "error.rate_limited", "too many requests")
+)
+
+// Authentication & authorization errors
+var (
+	ErrUnauthorized        = New(http.StatusUnauthorized, "UNAUTHORIZED", "error.unauthorized", "unauthorized")
+	ErrMissingToken        = New(http.StatusUnauthorized, "UNAUTHORIZED", "error.missing_token", "missing authorization token")
+	ErrInvalidAuthFormat   = New(http.StatusUnauthorized, "UNAUTHORIZED", "error.invalid_auth_format", "invalid authorization format")
+	ErrInvalidToken        = New(http.StatusUnauthorized, "UNAUTHORIZED", "error.invalid_token", "invalid or expired token")
+	ErrForbidden           = New(http.StatusForbidden, "FORBIDDEN", "error.forbidden", "insufficient permissions")
+	ErrInvalidCredentials  = New(http.StatusUnauthorized, "INVALID_CREDENTIALS", "error.invalid_credentials", constants.ErrInvalidCredentials)
+	ErrAccountSuspended    = New(http.StatusForbidden, "ACCOUNT_SUSPENDED", "error.account_suspended", constants.ErrAccountSuspended)
+	ErrInvalidRefreshToken = New(http.StatusUnauthorized, "INVALID_REFRESH_TOKEN", "error.invalid_refresh_token", constants.ErrInvalidRefreshToken)
+	ErrTokenRevoked        = New(http.StatusUnauthorized, "TOKEN_REVOKED", "error.token_revoked", constants.ErrTokenRevoked)
+	ErrTokenExpired        = New(http.StatusUnauthorized, "TOKEN_EXPIRED", "error.token_expired", constants.ErrTokenExpired)
+)
+
+// Registration & account errors
+var (
+	ErrPasswordTooShort        = New(http.StatusBadRequest, "WEAK_PASSWORD", "error.password_too_short", "password is too short")
+	ErrPasswordTooWeak         = New(http.StatusBadRequest, "WEAK_PASSWORD", "error.password_too_weak", "password must contain both letters and numbers")
+	ErrPhoneAlreadyRegistered  = New(http.StatusConflict, "PHONE_EXISTS", "error.phone_already_registered", constants.ErrPhoneAlreadyRegistered)
+	ErrEmailAlreadyRegistered  = New(http.StatusConflict, "EMAIL_EXISTS", "error.email_already_registered", constants.ErrEmailAlreadyRegistered)
+	ErrUserNotFound            = New(http.StatusNotFound, "USER_NOT_FOUND", "error.user_not_found", "user not found")
+	ErrNoProfileChanges        = New(http.StatusBadRequest, "NO_CHANGES", "error.no_profile_changes", constants.ErrNoProfileChanges)
+	ErrEmailUnchanged          = New(http.StatusBadRequest, "EMAIL_UNCHANGED", "error.email_unchanged", constants.ErrEmailUnchanged)
+	ErrNoPendingEmailChange    = New(http.StatusNotFound, "NO_PENDING_EMAIL", "error.no_pending_email_change", constants.ErrNoPendingEmailChange)
+	ErrInvalidVerificationCode = New(http.StatusBadRequest, "INVALID_CODE", "error.invalid_verification_code", constants.ErrInvalidVerificationCode)
+	ErrVerificationCodeExpired = New(http.StatusBadRequest, "CODE_EXPIRED", "error.verification_code_expired", constants.ErrVerificationCodeExpired)
+	ErrTooManyAttempts         = New(http.StatusTooManyRequests, "TOO_MANY_ATTEMPTS", "error.too_many_attempts", constants.ErrTooManyAttempts)
+	ErrEmailRequired           = New(http.StatusBadRequest, "EMAIL_REQUIRED", "error.email_required", "account has no email")
+	ErrEmailAlreadyVerified    = New(http.StatusConflict, "EMAIL_ALREADY_VERIFIED", "error.email_already_verified", "email is already verified")
+	ErrInvalidVerificationLink = New(http.StatusBadRequest, "INVALID_LINK", "error.invalid_verification_link", "invalid or expired verification link")
+	ErrOTPCooldown             = New(http.StatusTooManyRequests, "OTP_COOLDOWN", "otp.cooldown", "please wait before requesting new OTP")
+	ErrOTPSendFailed           = New(http.StatusInternalServerError, "OTP_SEND_FAILED", "otp.send_failed", "failed to send OTP")
+	ErrOTPResendFailed         = New(http.StatusInternalServerError, "OTP_SEND_FAILED", "otp.resend_failed", "failed to resend OTP")
+	ErrOTPVerifyFailed         = New(http.StatusInternalServerError, "OTP_VERIFY_FAILED", "otp.verify_failed", "failed to verify OTP")
+	ErrOTPInvalid              = New(http.StatusBadRequest, "OTP_INVALID", "otp.invalid", "invalid OTP code")
+	ErrOTPUsed                 = New(http.StatusBadRequest, "OTP_USED", "otp.used", "OTP code has already been used")
+	ErrOTPExpired              = New(http.StatusBadRequest, "OTP_EXPIRED", "otp.expired", "OTP code has expired")
+	ErrOTPTooManyAttempts      = New(http.StatusTooManyRequests, "TOO_MANY_ATTEMPTS", "otp.too_many_attempts", "too many OTP attempts")
+)
+
+// Profile & document errors
+var (
+	ErrPassengerProfileNotFound = New(http.StatusNotFound, "NOT_FOUND", "error.passenger_profile_not_found", constants.ErrPassengerProfileNotFound)
+	ErrDriverProfileNotFound    = New(http.StatusNotFound, "NOT_FOUND", "error.driver_profile_not_found", constants.ErrDriverProfileNotFound)
+	ErrVehiclePlateExists       = New(http.StatusConflict, "PLATE_EXISTS", "error.vehicle_plate_exists", constants.ErrVehiclePlateExists)
+	ErrSTNKRequired             = New(http.StatusBadRequest, "MISSING_STNK", "error.stnk_required", constants.ErrSTNKRequired)
+	ErrDriverNotVerified        = New(http.StatusForbidden, "DRIVER_NOT_VERIFIED", "error.driver_not_verified", constants.ErrDriverNotVerified)
+	ErrMissingFile              = New(http.StatusBadRequest, "MISSING_FILES", "error.missing_file", "required file is missing")
+	ErrInvalidFileType          = New(http.StatusBadRequest, "INVALID_FILE_TYPE", "error.invalid_file_type", constants.ErrInvalidFileType)
+	ErrFileTooLarge             = New(http.StatusBadRequest, "FILE_TOO_LARGE", "error.file_too_large", constants.ErrFileTooLarge)
+	ErrUploadFailed             = New(http.StatusInternalServerError, "UPLOAD_FAILED", "error.upload_failed", constants.ErrFailedToUploadFile)
+	ErrInvalidDocumentType      = New(http.StatusBadRequest, "INVALID_DOC_TYPE", "error.invalid_document_type", "invalid document type")
+	ErrInvalidFilename          = New(http.StatusBadRequest, "INVALID_FILENAME", "error.invalid_filename", "invalid filename")
+	ErrDocumentNotFound         = New(http.StatusNotFound, "NOT_FOUND", "error.document_not_found", constants.ErrDocumentNotFound)
+	ErrDocumentAccessDenied     = New(http.StatusForbidden, "FORBIDDEN", "error.unauthorized_access", constants.ErrUnauthorizedAccess)
+)
+
+// Order & dispatch errors
+var (
+	ErrOrderNotFound       = New(http.StatusNotFound, "NOT_FOUND", "error.order_not_found", "order not found")
+	ErrActiveOrderExists   = New(http.StatusConflict, "ACTIVE_ORDER_EXISTS", "error.active_order_exists", "passenger already has an active order")
+	ErrInvalidTripRoute    = New(http.StatusBadRequest, "INVALID_ROUTE", "error.invalid_trip_route", "pickup and dropoff are too close")
+	ErrOfferNotFound       = New(http.StatusNotFound, "NOT_FOUND", "error.offer_not_found", "offer not found")
+	ErrOfferClosed         = New(http.StatusConflict, "OFFER_CLOSED", "error.offer_closed", "offer is no longer available")
+	ErrDriverBusy          = New(http.StatusConflict, "DRIVER_BUSY", "error.driver_busy", "driver is already serving another order")
+	ErrInvalidTopic        = New(http.StatusBadRequest, "INVALID_TOPIC", "error.invalid_topic", "invalid realtime topic")
+	ErrDriverLocationStale = New(http.StatusBadRequest, "LOCATION_REQUIRED", "error.driver_location_required", "send a fresh location before going online")
+	ErrInvalidOrderStatus  = New(http.StatusConflict, "INVALID_ORDER_STATUS", "error.invalid_order_status", "order is not in the right status for this action")
+	ErrTripRouteNotFound   = New(http.StatusNotFound, "NOT_FOUND", "error.trip_route_not_found", "no route was recorded for this trip")
+)
+
+// Wallet errors
+var (
+	ErrInsufficientBalance = New(http.StatusConflict, "INSUFFICIENT_BALANCE", "error.insufficient_balance", "insufficient wallet balance")
+	ErrWalletUnavailable   = New(http.StatusBadRequest, "WALLET_UNAVAILABLE", "error.wallet_unavailable", "wallet is only available to passengers and drivers")
+	ErrTopUpTarget         = New(http.StatusBadRequest, "INVALID_TOPUP_TARGET", "error.invalid_topup_target", "only passenger wallets can be topped up")
+	ErrPayoutNotFound      = New(http.StatusNotFound, "NOT_FOUND", "error.payout_not_found", "payout request not found")
+	ErrPayoutClosed        = New(http.StatusConflict, "PAYOUT_CLOSED", "error.payout_closed", "payout request was already reviewed")
+)
+
+// Payment gateway errors
+var (
+	ErrPaymentChargeNotFound   = New(http.StatusNotFound, "NOT_FOUND", "error.payment_charge_not_found", "payment charge not found")
+	ErrPaymentProviderFailed   = New(http.StatusBadGateway, "PAYMENT_PROVIDER_ERROR", "error.payment_provider_failed", "payment provider is unavailable")
+	ErrInvalidPaymentSignature = New(http.StatusUnauthorized, "INVALID_SIGNATURE", "error.invalid_payment_signature", "invalid payment notification signature")
+	ErrPaymentAmountMismatch   = New(http.StatusConflict, "AMOUNT_MISMATCH", "error.payment_amount_mismatch", "paid amount does not match the charge")
+)
+
+// Promo errors
+var (
+	ErrPromoNotFound     = New(http.StatusNotFound, "PROMO_NOT_FOUND", "error.promo_not_found", "promo code not found")
+	ErrPromoNotActive    = New(http.StatusBadRequest, "PROMO_NOT_ACTIVE", "error.promo_not_active", "promo is not active")
+	ErrPromoNotEligible  = New(http.StatusForbidden, "PROMO_NOT_ELIGIBLE", "error.promo_not_eligible", "user is not eligible for the promo")
+	ErrPromoMinFare      = New(http.StatusBadRequest, "PROMO_MIN_FARE", "error.promo_min_fare", "fare is below the promo minimum")
+	ErrPromoExhausted    = New(http.StatusConflict, "PROMO_EXHAUSTED", "error.promo_exhausted", "promo usage limit reached")
+	ErrPromoUserLimit    = New(http.StatusConflict, "PROMO_USER_LIMIT", "error.promo_user_limit", "user already used the promo")
+	ErrPromoCodeExists   = New(http.StatusConflict, "PROMO_CODE_EXISTS", "error.promo_code_exists", "promo code already exists")
+	ErrInvalidPromoRules = New(http.StatusBadRequest, "INVALID_PROMO", "error.invalid_promo_rules", "invalid promo rules")
+)
+
+// Campus eligibility errors
+var (
+	ErrCampusVerificationRequired = New(http.StatusForbidden, "CAMPUS_VERIFICATION_REQUIRED", "error.campus_verification_required", "verified campus email required")
+	ErrCampusEmailRequired        = New(http.StatusBadRequest, "CAMPUS_EMAIL_REQUIRED", "error.campus_email_required", "campus email required")
+	ErrKTMRequired                = New(http.StatusForbidden, "KTM_REQUIRED", "error.ktm_required", "student ID card (KTM) required")
+	ErrCampusDomainExists         = New(http.StatusConflict, "CAMPUS_DOMAIN_EXISTS", "error.campus_domain_exists", "campus domain already exists")
+	ErrCampusDomainNotFound       = New(http.StatusNotFound, "NOT_FOUND", "error.campus_domain_not_found", "campus domain not found")
+	ErrInvalidCampusDomain        = New(http.StatusBadRequest, "INVALID_CAMPUS_DOMAIN", "error.invalid_campus_domain", "invalid campus domain")
+)
+
+// Driver review errors
+var (
+	ErrDocumentExpiryRequired  = New(http.StatusBadRequest, "DOCUMENT_EXPIRY_REQUIRED", "error.document_expiry_required", "document expiry date required")
+	ErrInvalidDocumentExpiry   = New(http.StatusBadRequest, "INVALID_DOCUMENT_EXPIRY", "error.invalid_document_expiry", "invalid document expiry date")
+	ErrRejectionReasonRequired = New(http.StatusBadRequest, "REJECTION_REASON_REQUIRED", "error.rejection_reason_required", "rejection reason required")
+)
+
+// Geofence errors
+var (
+	ErrOutsideServiceArea      = New(http.StatusBadRequest, "OUTSIDE_SERVICE_AREA", "error.outside_service_area", "trip must start or end inside the service area")
+	ErrNoGoZone                = New(http.StatusBadRequest, "NO_GO_ZONE", "error.no_go_zone", "pickup or dropoff is in a restricted zone")
+	ErrZoneNotFound            = New(http.StatusNotFound, "NOT_FOUND", "error.zone_not_found", "zone not found")
+	ErrInvalidZoneGeometry     = New(http.StatusBadRequest, "INVALID_ZONE_GEOMETRY", "error.invalid_zone_geometry", "invalid zone geometry")
+	ErrZoneSurchargeNotAllowed = New(http.StatusBadRequest, "INVALID_ZONE", "error.zone_surcharge_not_allowed", "only pricing zones can have a surcharge")
+	ErrPickupPointNotFound     = New(http.StatusNotFound, "NOT_FOUND", "error.pickup_point_not_found", "pickup point not found")
+)
+
+// Scheduled ride errors
+var (
+	ErrPickupTooSoon         = New(http.StatusBadRequest, "PICKUP_TOO_SOON", "error.pickup_too_soon", "scheduled pickup is too soon")
+	ErrPickupTooFar          = New(http.StatusBadRequest, "PICKUP_TOO_FAR", "error.pickup_too_far", "scheduled pickup is too far ahead")
+	ErrScheduleLimitReached  = New(http.StatusConflict, "SCHEDULE_LIMIT_REACHED", "error.schedule_limit_reached", "too many scheduled rides")
+	ErrScheduleConflict      = New(http.StatusConflict, "SCHEDULE_CONFLICT", "error.schedule_conflict", "another scheduled ride is too close to this pickup time")
+	ErrScheduledRideTaken    = New(http.StatusConflict, "SCHEDULED_RIDE_TAKEN", "error.scheduled_ride_taken", "scheduled ride already has a driver")
+	ErrScheduledRideNotFound = New(http.StatusNotFound, "NOT_FOUND", "error.scheduled_ride_not_found", "scheduled ride not found")
+)
+
+// Cancellation errors
+var (
+	ErrInvalidCancelReason = New(http.StatusBadRequest, "INVALID_CANCEL_REASON", "error.invalid_cancel_reason", "invalid cancellation reason")
+	ErrCancelNoteRequired  = New(http.StatusBadRequest, "CANCEL_NOTE_REQUIRED", "error.cancel_note_required", "a note is required for this cancellation reason")
+	ErrNoShowTooEarly      = New(http.StatusConflict, "NO_SHOW_TOO_EARLY", "error.no_show_too_early", "driver has not waited long enough to report a no-show")
+	ErrOrderingCooldown    = New(http.StatusForbidden, "ORDERING_COOLDOWN", "error.ordering_cooldown", "ordering is paused after too many cancellations")
+	ErrDrivingCooldown     = New(http.StatusForbidden, "DRIVING_COOLDOWN", "error.driving_cooldown", "driving is paused after too many cancellations")
+	ErrOutstandingFee      = New(http.StatusConflict, "OUTSTANDING_CANCELLATION_FEE", "error.outstanding_cancellation_fee", "outstanding cancellation fees must be paid first")
+	ErrCooldownNotFound    = New(http.StatusNotFound, "NOT_FOUND", "error.cooldown_not_found", "user has no active cooldown")
+)
+
+// Safety errors
+var (
+	ErrTrustedContactNotFound = New(http.StatusNotFound, "NOT_FOUND", "error.trusted_contact_not_found", "trusted contact not found")
+	ErrTrustedContactLimit    = New(http.StatusConflict, "TRUSTED_CONTACT_LIMIT", "error.trusted_contact_limit", "trusted contact limit reached")
+	ErrTrustedContactExists   = New(http.StatusConflict, "TRUSTED_CONTACT_EXISTS", "error.trusted_contact_exists", "this number is already a trusted contact")
+	ErrTrustedContactSelf     = New(http.StatusBadRequest, "TRUSTED_CONTACT_SELF", "error.trusted_contact_self", "you cannot add your own number as a trusted contact")
+	ErrTrustedContactVerified = New(http.StatusConflict, "TRUSTED_CONTACT_VERIFIED", "error.trusted_contact_verified", "trusted contact is already verified")
+	ErrNoTrustedContacts      = New(http.StatusConflict, "NO_TRUSTED_CONTACTS", "error.no_trusted_contacts", "add and verify a trusted contact first")
+	ErrTripNotInProgress      = New(http.StatusConflict, "TRIP_NOT_IN_PROGRESS", "error.trip_not_in_progress", "only available while a driver is assigned to the order")
+	ErrIncidentNotFound       = New(http.StatusNotFound, "NOT_FOUND", "error.incident_not_found", "incident not found")
+	ErrInvalidIncidentStatus  = New(http.StatusConflict, "INVALID_INCIDENT_STATUS", "error.invalid_incident_status", "incident is not in the right status for this action")
+	ErrTrackingLinkNotFound   = New(http.StatusNotFound, "NOT_FOUND", "error.tracking_link_not_found", "tracking link not found")
+	ErrTrackingLinkExpired    = New(http.StatusGone, "TRACKING_LINK_EXPIRED", "error.tracking_link_expired", "this trip has ended or the link has expired")
+	ErrTrackingLinkLimit      = New(http.StatusConflict, "TRACKING_LINK_LIMIT", "error.tracking_link_limit", "too many active tracking links for this order")
+)
+
+// Chat errors
+var (
+	ErrChatClosed         = New(http.StatusConflict, "CHAT_CLOSED", "error.chat_closed", "chat is only open while a driver is assigned to the order")
+	ErrInvalidChatMessage = New(http.StatusBadRequest, "INVALID_CHAT_MESSAGE", "error.invalid_chat_message", "send either a message or a quick reply")
+	ErrInvalidQuickReply  = New(http.StatusBadRequest, "INVALID_QUICK_REPLY", "error.invalid_quick_reply", "invalid quick reply")
+)
+
+// WhatsApp relay errors
+var (
+	ErrInvalidWebhookToken = New(http.StatusUnauthorized, "INVALID_WEBHOOK_TOKEN", "error.invalid_webhook_token", "invalid webhook token")
+)
+
+// Rating errors
+var (
+	ErrOrderNotCompleted  = New(http.StatusConflict, "ORDER_NOT_COMPLETED", "error.order_not_completed", "only completed orders can be rated")
+	ErrAlreadyRated       = New(http.StatusConflict, "ALREADY_RATED", "error.already_rated", "order already rated")
+	ErrRatingWindowClosed = New(http.StatusConflict, "RATING_WINDOW_CLOSED", "error.rating_window_closed", "rating window has closed")
+	ErrInvalidRatingTag   = New(http.StatusBadRequest, "INVALID_RATING_TAG", "error.invalid_rating_tag", "invalid rating tag")
+	ErrDriverNotFlagged   = New(http.StatusNotFound, "NOT_FOUND", "error.driver_not_flagged", "driver is not flagged for review")
+)
+
+// Background job errors
+var (
+	ErrJobNotFound = New(http.StatusNotFound, "NOT_FOUND", "error.job_not_found", "dead job not found")
+)
@@ -0,0 +1,150 @@
+// Package cancellation holds the rules for backing out of a ride: which cancellations
+// are free, which carry a fee or count against the user, and when a user's cancellation
+// rate earns them a cooldown.
+package cancellation
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNoShowTooEarly is returned when a driver reports a no-show before waiting long enough
+var ErrNoShowTooEarly = errors.New("driver has not waited long enough for a no-show")
+
+// Stage is how far an order got before it was cancelled
+type Stage string
+
+const (
+	StageSearching Stage = "SEARCHING" // no driver yet
+	StageAssigned  Stage = "ASSIGNED"  // a driver accepted and is on the way
+	StageArrived   Stage = "ARRIVED"   // the driver is waiting at the pickup
+)
+
+// Outcome is what a cancellation costs. Fee is paid by the passenger to the driver;
+// Counted marks whether it counts toward the cancelling user's rate.
+type Outcome struct {
+	Fee     int64
+	Counted bool
+}
+
+// Policy tunes cancellations
+type Policy struct {
+	FreeWindow  time.Duration // passengers cancel free for this long after a driver accepts
+	AssignedFee int64         // passenger fee once the free window has passed
+	ArrivedFee  int64         // passenger fee once the driver is at the pickup, also charged for a no-show
+	NoShowWait  time.Duration // drivers may report a no-show after waiting this long at the pickup
+
+	RateWindow        time.Duration // cancellation rates are measured over this rolling window
+	MinOrders         int           // rates are not enforced below this many orders in the window
+	PassengerMaxRate  float64       // share of counted cancellations that triggers a passenger cooldown
+	DriverMaxRate     float64       // share of counted cancellations that triggers a driver cooldown
+	PassengerCooldown time.Duration // how long a passenger cannot order after crossing the threshold
+	DriverCooldown    time.Duration // how long a driver cannot go online after crossing the threshold
+}
+
+// DefaultPolicy returns the production defaults
+func DefaultPolicy() Policy {
+	return Policy{
+		FreeWindow:        2 * time.Minute,
+		AssignedFee:       3000,
+		ArrivedFee:        5000,
+		NoShowWait:        5 * time.Minute,
+		RateWindow:        7 * 24 * time.Hour,
+		MinOrders:         5,
+		PassengerMaxRate:  0.4,
+		DriverMaxRate:     0.2,
+		PassengerCooldown: 2 * time.Hour,
+		DriverCooldown:    6 * time.Hour,
+	}
+}
+
+// WithDefaults fills unset fields from DefaultPolicy. Rates are shares, so thresholds
+// outside (0, 1] fall back to the default.
+func (p Policy) WithDefaults() Policy {
+	defaults := DefaultPolicy()
+	if p.FreeWindow <= 0 {
+		p.FreeWindow = defaults.FreeWindow
+	}
+	if p.AssignedFee <= 0 {
+		p.AssignedFee = defaults.AssignedFee
+	}
+	if p.ArrivedFee <= 0 {
+		p.ArrivedFee = defaults.ArrivedFee
+	}
+	if p.NoShowWait <= 0 {
+		p.NoShowWait = defaults.NoShowWait
+	}
+	if p.RateWindow <= 0 {
+		p.RateWindow = defaults.RateWindow
+	}
+	if p.MinOrders <= 0 {
+		p.MinOrders = defaults.MinOrders
+	}
+	if p.PassengerMaxRate <= 0 || p.PassengerMaxRate > 1 {
+		p.PassengerMaxRate = defaults.PassengerMaxRate
+	}
+	if p.DriverMaxRate <= 0 || p.DriverMaxRate > 1 {
+		p.DriverMaxRate = defaults.DriverMaxRate
+	}
+	if p.PassengerCooldown <= 0 {
+		p.PassengerCooldown = defaults.PassengerCooldown
+	}
+	if p.DriverCooldown <= 0 {
+		p.DriverCooldown = defaults.DriverCooldown
+	}
+	return p
+}
+
+// PassengerCancel prices a passenger cancelling at now. acceptedAt is when the driver
+// accepted and is ignored while searching.
+func (p Policy) PassengerCancel(stage Stage, acceptedAt *time.Time, now time.Time) Outcome {
+	switch stage {
+	case StageAssigned:
+		if acceptedAt != nil && now.Sub(*acceptedAt) < p.FreeWindow {
+			return Outcome{}
+		}
+		return Outcome{Fee: p.AssignedFee, Counted: true}
+	case StageArrived:
+		return Outcome{Fee: p.ArrivedFee, Counted: true}
+	}
+	return Outcome{}
+}
+
+// DriverCancel prices a driver cancelling at now. A no-show after the driver waited
+// NoShowWait at the pickup is the passenger's fault: they pay ArrivedFee and the
+// cancellation counts against them instead. Any other driver cancellation counts
+// against the driver.
+func (p Policy) DriverCancel(stage Stage, noShow bool, arrivedAt *time.Time, now time.Time) (Outcome, error) {
+	if !noShow {
+		return Outcome{Counted: true}, nil
+	}
+	if stage != StageArrived || arrivedAt == nil || now.Sub(*arrivedAt) < p.NoShowWait {
+		return Outcome{}, ErrNoShowTooEarly
+	}
+	return Outcome{Fee: p.ArrivedFee, Counted: true}, nil
+}
+
+// MaxRate returns the cancellation rate threshold for passengers or drivers
+func (p Policy) MaxRate(driver bool) float64 {
+	if driver {
+		return p.DriverMaxRate
+	}
+	return p.PassengerMaxRate
+}
+
+// Cooldown returns the cooldown length for passengers or drivers
+func (p Policy) Cooldown(driver bool) time.Duration {
+	if driver {
+		return p.DriverCooldown
+	}
+	return p.PassengerCooldown
+}
+
+// Exceeds reports whether counted cancellations out of orders in the rolling window cross
+// the threshold. Users with fewer than MinOrders orders in the window are never cut off.
+func (p Policy) Exceeds(cancelled, orders int, maxRate float64) bool {
+	if orders < p.MinOrders || orders == 0 {
+		return false
+	}
+	return float64(cancelled)/float64(orders) > maxRate
+}
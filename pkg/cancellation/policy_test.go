@@ -0,0 +1,128 @@
+package cancellation
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var acceptedAt = time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+
+func at(d time.Duration) *time.Time {
+	t := acceptedAt.Add(d)
+	return &t
+}
+
+func TestPassengerCancel(t *testing.T) {
+	policy := DefaultPolicy()
+
+	tests := []struct {
+		name       string
+		stage      Stage
+		acceptedAt *time.Time
+		elapsed    time.Duration
+		want       Outcome
+	}{
+		{name: "while searching is free", stage: StageSearching, elapsed: time.Hour, want: Outcome{}},
+		{name: "right after the driver accepts is free", stage: StageAssigned, acceptedAt: at(0), elapsed: 0, want: Outcome{}},
+		{name: "just inside the free window is free", stage: StageAssigned, acceptedAt: at(0), elapsed: 2*time.Minute - time.Second, want: Outcome{}},
+		{name: "free window exactly elapsed costs the assigned fee", stage: StageAssigned, acceptedAt: at(0), elapsed: 2 * time.Minute, want: Outcome{Fee: 3000, Counted: true}},
+		{name: "after the free window costs the assigned fee", stage: StageAssigned, acceptedAt: at(0), elapsed: 10 * time.Minute, want: Outcome{Fee: 3000, Counted: true}},
+		{name: "assigned without an accept time is charged", stage: StageAssigned, elapsed: 0, want: Outcome{Fee: 3000, Counted: true}},
+		{name: "driver at the pickup costs the arrived fee", stage: StageArrived, acceptedAt: at(0), elapsed: time.Minute, want: Outcome{Fee: 5000, Counted: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.PassengerCancel(tt.stage, tt.acceptedAt, acceptedAt.Add(tt.elapsed)); got != tt.want {
+				t.Errorf("PassengerCancel() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDriverCancel(t *testing.T) {
+	policy := DefaultPolicy()
+	arrivedAt := at(3 * time.Minute)
+
+	tests := []struct {
+		name      string
+		stage     Stage
+		noShow    bool
+		arrivedAt *time.Time
+		now       time.Time
+		want      Outcome
+		wantErr   error
+	}{
+		{name: "ordinary cancellation counts against the driver", stage: StageAssigned, now: *at(time.Minute), want: Outcome{Counted: true}},
+		{name: "cancelling at the pickup without a no-show counts against the driver", stage: StageArrived, arrivedAt: arrivedAt, now: *at(20 * time.Minute), want: Outcome{Counted: true}},
+		{name: "no-show before arriving is refused", stage: StageAssigned, noShow: true, now: *at(20 * time.Minute), wantErr: ErrNoShowTooEarly},
+		{name: "no-show without an arrival time is refused", stage: StageArrived, noShow: true, now: *at(20 * time.Minute), wantErr: ErrNoShowTooEarly},
+		{name: "no-show just before the wait is over is refused", stage: StageArrived, noShow: true, arrivedAt: arrivedAt, now: arrivedAt.Add(5*time.Minute - time.Second), wantErr: ErrNoShowTooEarly},
+		{name: "no-show once the wait exactly elapsed charges the passenger", stage: StageArrived, noShow: true, arrivedAt: arrivedAt, now: arrivedAt.Add(5 * time.Minute), want: Outcome{Fee: 5000, Counted: true}},
+		{name: "no-show after a long wait charges the passenger", stage: StageArrived, noShow: true, arrivedAt: arrivedAt, now: arrivedAt.Add(time.Hour), want: Outcome{Fee: 5000, Counted: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := policy.DriverCancel(tt.stage, tt.noShow, tt.arrivedAt, tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DriverCancel() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DriverCancel() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExceeds(t *testing.T) {
+	policy := DefaultPolicy() // MinOrders 5
+
+	tests := []struct {
+		name      string
+		cancelled int
+		orders    int
+		maxRate   float64
+		want      bool
+	}{
+		{name: "no orders", cancelled: 0, orders: 0, maxRate: 0.4, want: false},
+		{name: "below min orders is never cut off", cancelled: 4, orders: 4, maxRate: 0.4, want: false},
+		{name: "orders exactly at min orders are enforced", cancelled: 3, orders: 5, maxRate: 0.4, want: true},
+		{name: "rate exactly at the threshold is allowed", cancelled: 2, orders: 5, maxRate: 0.4, want: false},
+		{name: "rate above the threshold", cancelled: 3, orders: 10, maxRate: 0.2, want: true},
+		{name: "rate below the threshold", cancelled: 1, orders: 10, maxRate: 0.2, want: false},
+		{name: "every order cancelled", cancelled: 20, orders: 20, maxRate: 0.4, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Exceeds(tt.cancelled, tt.orders, tt.maxRate); got != tt.want {
+				t.Errorf("Exceeds(%d, %d, %v) = %v, want %v", tt.cancelled, tt.orders, tt.maxRate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithDefaults(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+		check  func(Policy) bool
+	}{
+		{name: "zero policy uses the defaults", policy: Policy{}, check: func(p Policy) bool { return p == DefaultPolicy() }},
+		{name: "rate above one falls back", policy: Policy{DriverMaxRate: 1.5}, check: func(p Policy) bool { return p.DriverMaxRate == 0.2 }},
+		{name: "rate of exactly one is kept", policy: Policy{PassengerMaxRate: 1}, check: func(p Policy) bool { return p.PassengerMaxRate == 1 }},
+		{name: "overrides are kept", policy: Policy{FreeWindow: time.Minute, MinOrders: 10}, check: func(p Policy) bool {
+			return p.FreeWindow == time.Minute && p.MinOrders == 10 && p.AssignedFee == 3000
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.WithDefaults(); !tt.check(got) {
+				t.Errorf("WithDefaults() = %+v", got)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
 )
@@ -14,6 +15,16 @@ type Config struct {
 	JWT      JWTConfig
 	Server   ServerConfig
 	WhatsApp WhatsAppConfig
+	Push     PushConfig
+	Jobs     JobsConfig
+	Dispatch DispatchConfig
+	Payment  PaymentConfig
+	Mail     MailConfig
+	Campus   CampusConfig
+	Schedule ScheduleConfig
+	Cancel   CancellationConfig
+	Safety   SafetyConfig
+	Chat     ChatConfig
 }
 
 // DatabaseConfig holds database configuration
@@ -37,6 +48,7 @@ type ServerConfig struct {
 	Port        string
 	Environment string
 	Timezone    string
+	DevRoutes   bool // mounts the admin-only /api/dev routes of the fake providers; never in production
 }
 
 // WhatsAppConfig holds WhatsApp API configuration
@@ -45,6 +57,86 @@ type WhatsAppConfig struct {
 	APIToken     string
 	BaseURL      string
 	SenderNumber string
+	Provider     string // "fake" (in-memory, requires DEV_ROUTES) or "ultramsg"; sends all WhatsApp messages
+	WebhookToken string // secret ?token= of the webhook URL (incoming messages, delivery updates)
+}
+
+// PushConfig holds push notification configuration
+type PushConfig struct {
+	FCMCredentialsFile string // empty = log notifier (development)
+}
+
+// JobsConfig holds background job queue and webhook fan-out configuration
+type JobsConfig struct {
+	Workers       int
+	WebhookURLs   []string // subscribers receiving every outbox event
+	WebhookSecret string
+}
+
+// DispatchConfig overrides the dispatch engine defaults (zero keeps the default)
+type DispatchConfig struct {
+	OfferTimeoutSeconds int
+	MaxRadiusKm         float64
+	MaxAttempts         int
+}
+
+// PaymentConfig holds payment gateway configuration
+type PaymentConfig struct {
+	Provider  string // "mock" (in-process gateway, requires DEV_ROUTES) or "midtrans"
+	ServerKey string
+	BaseURL   string
+}
+
+// MailConfig holds email delivery configuration
+type MailConfig struct {
+	Transport    string // "fake" (in-process SMTP server, requires DEV_ROUTES), "smtp" or "log" (development)
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	From         string
+	FakeSMTPAddr string // where the fake SMTP server listens
+	LinkBaseURL  string // public base URL used in emailed links
+}
+
+// CampusConfig decides what a verified campus email is required for
+type CampusConfig struct {
+	RequiredForOrders  bool
+	RequiredForDrivers bool // drivers additionally need a KTM on file
+}
+
+// ScheduleConfig overrides the scheduled ride policy defaults (zero keeps the default)
+type ScheduleConfig struct {
+	MinLeadMinutes      int
+	MaxLeadDays         int
+	DispatchLeadMinutes int
+	ReminderLeadMinutes int
+	FreeCancelMinutes   int
+	MaxPending          int
+}
+
+// CancellationConfig overrides the cancellation policy defaults (zero keeps the default)
+type CancellationConfig struct {
+	FreeWindowMinutes        int
+	AssignedFee              int
+	ArrivedFee               int
+	NoShowWaitMinutes        int
+	RateWindowDays           int
+	MinOrders                int
+	PassengerMaxRate         float64
+	DriverMaxRate            float64
+	PassengerCooldownMinutes int
+	DriverCooldownMinutes    int
+}
+
+// SafetyConfig holds who is alerted when a rider presses SOS besides their trusted contacts
+type SafetyConfig struct {
+	SecurityPhones []string // campus security WhatsApp numbers
+}
+
+// ChatConfig holds how long ride chats are kept after they are sent, for dispute handling
+type ChatConfig struct {
+	RetentionDays int
 }
 
 // LoadConfig loads configuration from environment variables
@@ -66,13 +158,87 @@ func LoadConfig() (*Config, error) {
 			Port:        getEnv("PORT", constants.DefaultPort),
 			Environment: getEnv("ENVIRONMENT", "development"),
 			Timezone:    getEnv("TZ", "Asia/Jakarta"),
+			DevRoutes:   getEnvAsBool("DEV_ROUTES", false),
 		},
 		WhatsApp: WhatsAppConfig{
 			InstanceID:   getEnv("WHATSAPP_INSTANCE_ID", ""),
 			APIToken:     getEnv("WHATSAPP_API_TOKEN", ""),
 			BaseURL:      getEnv("WHATSAPP_BASE_URL", "https://ultramsg.com/api"),
+			Provider:     getEnv("WHATSAPP_PROVIDER", ""),
+			WebhookToken: getEnv("WHATSAPP_WEBHOOK_TOKEN", ""),
 			SenderNumber: getEnv("WHATSAPP_SENDER_NUMBER", ""),
 		},
+		Push: PushConfig{
+			FCMCredentialsFile: getEnv("FCM_CREDENTIALS_FILE", ""),
+		},
+		Jobs: JobsConfig{
+			Workers:       getEnvAsInt("JOB_WORKERS", constants.DefaultJobWorkers),
+			WebhookURLs:   getEnvAsList("WEBHOOK_URLS"),
+			WebhookSecret: getEnv("WEBHOOK_SECRET", ""),
+		},
+		Dispatch: DispatchConfig{
+			OfferTimeoutSeconds: getEnvAsInt("DISPATCH_OFFER_TIMEOUT_SECONDS", 0),
+			MaxRadiusKm:         getEnvAsFloat("DISPATCH_MAX_RADIUS_KM", 0),
+			MaxAttempts:         getEnvAsInt("DISPATCH_MAX_ATTEMPTS", 0),
+		},
+		Payment: PaymentConfig{
+			Provider:  getEnv("PAYMENT_PROVIDER", ""),
+			ServerKey: getEnv("PAYMENT_SERVER_KEY", ""),
+			BaseURL:   getEnv("PAYMENT_BASE_URL", "https://api.sandbox.midtrans.com"),
+		},
+		Mail: MailConfig{
+			Transport:    getEnv("MAIL_TRANSPORT", ""),
+			SMTPHost:     getEnv("SMTP_HOST", ""),
+			SMTPPort:     getEnvAsInt("SMTP_PORT", 587),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			From:         getEnv("MAIL_FROM", "Ojek Kampus <no-reply@ojekkampus.local>"),
+			FakeSMTPAddr: getEnv("FAKE_SMTP_ADDR", "127.0.0.1:2525"),
+			LinkBaseURL:  getEnv("APP_BASE_URL", ""),
+		},
+		Campus: CampusConfig{
+			RequiredForOrders:  getEnvAsBool("CAMPUS_REQUIRED_FOR_ORDERS", false),
+			RequiredForDrivers: getEnvAsBool("CAMPUS_REQUIRED_FOR_DRIVERS", false),
+		},
+		Schedule: ScheduleConfig{
+			MinLeadMinutes:      getEnvAsInt("SCHEDULED_MIN_LEAD_MINUTES", 0),
+			MaxLeadDays:         getEnvAsInt("SCHEDULED_MAX_LEAD_DAYS", 0),
+			DispatchLeadMinutes: getEnvAsInt("SCHEDULED_DISPATCH_LEAD_MINUTES", 0),
+			ReminderLeadMinutes: getEnvAsInt("SCHEDULED_REMINDER_LEAD_MINUTES", 0),
+			FreeCancelMinutes:   getEnvAsInt("SCHEDULED_FREE_CANCEL_MINUTES", 0),
+			MaxPending:          getEnvAsInt("SCHEDULED_MAX_PENDING", 0),
+		},
+		Cancel: CancellationConfig{
+			FreeWindowMinutes:        getEnvAsInt("CANCEL_FREE_WINDOW_MINUTES", 0),
+			AssignedFee:              getEnvAsInt("CANCEL_ASSIGNED_FEE", 0),
+			ArrivedFee:               getEnvAsInt("CANCEL_ARRIVED_FEE", 0),
+			NoShowWaitMinutes:        getEnvAsInt("CANCEL_NO_SHOW_WAIT_MINUTES", 0),
+			RateWindowDays:           getEnvAsInt("CANCEL_RATE_WINDOW_DAYS", 0),
+			MinOrders:                getEnvAsInt("CANCEL_MIN_ORDERS", 0),
+			PassengerMaxRate:         getEnvAsFloat("CANCEL_PASSENGER_MAX_RATE", 0),
+			DriverMaxRate:            getEnvAsFloat("CANCEL_DRIVER_MAX_RATE", 0),
+			PassengerCooldownMinutes: getEnvAsInt("CANCEL_PASSENGER_COOLDOWN_MINUTES", 0),
+			DriverCooldownMinutes:    getEnvAsInt("CANCEL_DRIVER_COOLDOWN_MINUTES", 0),
+		},
+		Safety: SafetyConfig{
+			SecurityPhones: getEnvAsList("SOS_SECURITY_PHONES"),
+		},
+		Chat: ChatConfig{
+			RetentionDays: getEnvAsInt("CHAT_RETENTION_DAYS", constants.DefaultChatRetentionDays),
+		},
+	}
+	if config.WhatsApp.Provider == "" && config.WhatsApp.InstanceID != "" && config.WhatsApp.APIToken != "" {
+		// Deployments configured before the provider setting existed keep sending through Ultramsg
+		config.WhatsApp.Provider = "ultramsg"
+	}
+	if config.Payment.Provider == "" && config.Payment.ServerKey != "" {
+		config.Payment.Provider = "midtrans"
+	}
+	if config.Mail.Transport == "" && config.Mail.SMTPHost != "" {
+		config.Mail.Transport = "smtp"
+	}
+	if config.Mail.LinkBaseURL == "" {
+		config.Mail.LinkBaseURL = "http://localhost:" + config.Server.Port
 	}
 
 	// Validate required fields
@@ -88,6 +254,68 @@ func LoadConfig() (*Config, error) {
 	if config.JWT.Secret == "" {
 		return nil, fmt.Errorf("JWT_SECRET is required")
 	}
+	if config.Server.DevRoutes && config.Server.Environment == "production" {
+		return nil, fmt.Errorf("DEV_ROUTES is not allowed in production")
+	}
+	if len(config.Jobs.WebhookURLs) > 0 && config.Jobs.WebhookSecret == "" {
+		return nil, fmt.Errorf("WEBHOOK_SECRET is required when WEBHOOK_URLS is set")
+	}
+	switch config.Payment.Provider {
+	case "mock":
+		if config.Server.Environment == "production" {
+			return nil, fmt.Errorf("PAYMENT_PROVIDER=mock is not allowed in production")
+		}
+		if !config.Server.DevRoutes {
+			return nil, fmt.Errorf("PAYMENT_PROVIDER=mock requires DEV_ROUTES=true")
+		}
+	case "midtrans":
+		if config.Payment.ServerKey == "" {
+			return nil, fmt.Errorf("PAYMENT_SERVER_KEY is required when PAYMENT_PROVIDER=midtrans")
+		}
+	case "":
+		return nil, fmt.Errorf("PAYMENT_PROVIDER is required unless PAYMENT_SERVER_KEY is set")
+	default:
+		return nil, fmt.Errorf("PAYMENT_PROVIDER must be mock or midtrans")
+	}
+	switch config.WhatsApp.Provider {
+	case "fake":
+		if config.Server.Environment == "production" {
+			return nil, fmt.Errorf("WHATSAPP_PROVIDER=fake is not allowed in production")
+		}
+		if !config.Server.DevRoutes {
+			return nil, fmt.Errorf("WHATSAPP_PROVIDER=fake requires DEV_ROUTES=true")
+		}
+	case "ultramsg":
+		if config.WhatsApp.InstanceID == "" || config.WhatsApp.APIToken == "" {
+			return nil, fmt.Errorf("WHATSAPP_INSTANCE_ID and WHATSAPP_API_TOKEN are required when WHATSAPP_PROVIDER=ultramsg")
+		}
+	case "":
+		return nil, fmt.Errorf("WHATSAPP_PROVIDER is required unless WHATSAPP_INSTANCE_ID and WHATSAPP_API_TOKEN are set")
+	default:
+		return nil, fmt.Errorf("WHATSAPP_PROVIDER must be fake or ultramsg")
+	}
+	switch config.Mail.Transport {
+	case "fake":
+		if config.Server.Environment == "production" {
+			return nil, fmt.Errorf("MAIL_TRANSPORT=fake is not allowed in production")
+		}
+		if !config.Server.DevRoutes {
+			return nil, fmt.Errorf("MAIL_TRANSPORT=fake requires DEV_ROUTES=true")
+		}
+	case "smtp":
+		if config.Mail.SMTPHost == "" {
+			return nil, fmt.Errorf("SMTP_HOST is required when MAIL_TRANSPORT=smtp")
+		}
+	case "log":
+		// Logged emails carry verification codes and links
+		if config.Server.Environment == "production" {
+			return nil, fmt.Errorf("MAIL_TRANSPORT=log is not allowed in production")
+		}
+	case "":
+		return nil, fmt.Errorf("MAIL_TRANSPORT is required unless SMTP_HOST is set")
+	default:
+		return nil, fmt.Errorf("MAIL_TRANSPORT must be fake, smtp or log")
+	}
 
 	return config, nil
 }
@@ -111,3 +339,41 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return value
 }
+
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.ParseBool(valueStr)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvAsList(key string) []string {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return nil
+	}
+	var values []string
+	for _, value := range strings.Split(valueStr, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -29,12 +29,89 @@ const (
 	// Server
 	DefaultPort = "8080"
 
+	// Background jobs
+	DefaultJobWorkers      = 4
+	JobRetention           = 7 * 24 * time.Hour
+	OTPDeliveryMaxAttempts = 3
+	OTPMaxRedeliveries     = 2                // re-sends of an OTP after WhatsApp reports a failed delivery
+	OTPRedeliveryDelay     = 10 * time.Second // wait before re-sending a failed OTP
+
 	// File Upload
 	MaxFileSize        = 5 * 1024 * 1024  // 5MB
 	MaxTotalUploadSize = 20 * 1024 * 1024 // 20MB (4 files x 5MB)
 	UploadDirectory    = "uploads"
 	AllowedImageTypes  = "image/jpeg,image/png"
 	AllowedDocTypes    = "application/pdf"
+
+	// Orders & dispatch
+	BaseFare             = 5000 // Rupiah
+	FarePerKm            = 2500 // Rupiah
+	FareRounding         = 500  // fares are rounded up to this many Rupiah
+	MinTripDistanceKm    = 0.2
+	DriverLocationMaxAge = 2 * time.Minute
+	DispatchStatsWindow  = 30 * 24 * time.Hour // offer history used for acceptance rates
+	PickupSnapRadiusKm   = 0.15                // pickups and dropoffs this close to an official point snap onto it
+	ScheduledTimeLayout  = "02/01 15:04"       // scheduled pickup time in notifications
+
+	// Trip routes
+	TripMaxSpeedKmh         = 120.0 // pings implying a faster move from the last kept ping are GPS jumps
+	TripSimplifyToleranceKm = 0.005 // Douglas-Peucker tolerance for the stored route shape
+	RouteFormatGeoJSON      = "geojson"
+	RouteFormatPolyline     = "polyline"
+
+	// Safety
+	MaxTrustedContacts = 5
+
+	// Trip tracking links
+	TrackingTokenBytes         = 24
+	TrackingLinkTTL            = 6 * time.Hour // backstop for trips that never complete
+	MaxTrackingLinksPerOrder   = 5             // active links at a time
+	TrackingAverageSpeedKmh    = 20.0          // campus traffic speed behind tracking ETAs
+	TrackingDestinationPickup  = "PICKUP"
+	TrackingDestinationDropoff = "DROPOFF"
+
+	// Ride chat
+	MaxChatMessageLength     = 500
+	DefaultChatRetentionDays = 90
+	ChatPurgeHour            = 3 // local hour of the daily retention purge
+	ChatPurgeBatch           = 1000
+
+	// Wallet
+	PlatformCommissionPercent = 10 // platform share of wallet-paid fares
+
+	// Payment gateway top-ups
+	PaymentChargeExpiry       = 15 * time.Minute // how long the QR code / virtual account can be paid
+	PaymentStatusPollDelay    = 2 * time.Minute  // first status check, in case the webhook never arrives
+	PaymentStatusPollInterval = 5 * time.Minute
+	PaymentExpiryGrace        = 2 * time.Minute // late settlements are still picked up this long after expiry
+
+	// Ratings
+	RatingWindow                 = 72 * time.Hour // how long after completion a trip can be rated
+	RatingPriorMean              = 4.5            // Bayesian prior: new users start near this average
+	RatingPriorWeight            = 5              // how many ratings the prior is worth
+	DriverRatingReviewThreshold  = 4.0            // drivers below this smoothed average are flagged for review
+	DriverRatingReviewMinRatings = 10             // ...once they have at least this many ratings
+
+	// Realtime
+	RealtimeEventRetention = time.Hour // how far back clients can resume
+	RealtimeEventMaxRows   = 200000    // cap of the resume log regardless of age
+	SSEKeepAliveInterval   = 15 * time.Second
+
+	// Email change verification
+	EmailChangeCodeTTL     = 30 * time.Minute
+	MaxEmailChangeAttempts = 5
+	EmailChangeCodeLength  = 6
+
+	// Driver document expiry
+	DocumentExpiryScanHour   = 7  // local hour of the daily expiry scan
+	DocumentExpiryWindowDays = 30 // default look-ahead of the admin expiry view
+	DocumentExpiryDateLayout = "2006-01-02"
+)
+
+// Storage namespaces (top-level directories under UploadDirectory)
+const (
+	StorageNamespaceDrivers    = "drivers"
+	StorageNamespacePassengers = "passengers"
 )
 
 // Error messages
@@ -46,7 +123,6 @@ const (
 	ErrInvalidRefreshToken    = "invalid refresh token"
 	ErrTokenRevoked           = "token has been revoked"
 	ErrTokenExpired           = "token has expired"
-	ErrUserNotFound           = "user not found"
 	ErrFailedToHashPassword   = "failed to hash password"
 	ErrFailedToCreateUser     = "failed to create user"
 	ErrFailedToGenerateToken  = "failed to generate token"
@@ -59,6 +135,19 @@ const (
 	ErrDriverNotVerified  = "driver account is not verified yet"
 	ErrDocumentNotFound   = "document not found"
 	ErrUnauthorizedAccess = "unauthorized access to document"
+
+	// Driver profile update errors
+	ErrDriverProfileNotFound = "driver profile not found"
+	ErrNoProfileChanges      = "no profile changes provided"
+	ErrSTNKRequired          = "new STNK document is required when changing vehicle plate"
+
+	// Passenger & account errors
+	ErrPassengerProfileNotFound = "passenger profile not found"
+	ErrEmailUnchanged           = "new email is the same as current email"
+	ErrNoPendingEmailChange     = "no pending email change"
+	ErrInvalidVerificationCode  = "invalid verification code"
+	ErrVerificationCodeExpired  = "verification code has expired"
+	ErrTooManyAttempts          = "too many attempts, please request a new code"
 )
 
 // Revoke reasons
@@ -80,11 +169,158 @@ const (
 	DocumentTypeSIM  = "SIM"
 	DocumentTypeSTNK = "STNK"
 	DocumentTypeKTM  = "KTM"
+
+	// Profile picture is stored alongside documents but is not a verification document
+	DocumentTypeProfilePicture = "PROFILE"
 )
 
+// Days before expiry at which drivers are reminded, largest first
+var DocumentExpiryReminderDays = []int{30, 7, 1}
+
+// Driver documents whose expiry dates are recorded at review
+var ExpiringDocumentTypes = []string{DocumentTypeSIM, DocumentTypeSTNK, DocumentTypeKTM}
+
 // Driver verification status
 const (
 	VerificationStatusPending  = "PENDING_VERIFICATION"
 	VerificationStatusVerified = "VERIFIED"
 	VerificationStatusRejected = "REJECTED"
 )
+
+// Driver profile change history fields
+const (
+	ProfileFieldVehiclePlate   = "vehicle_plate"
+	ProfileFieldVehicleBrand   = "vehicle_brand"
+	ProfileFieldVehicleModel   = "vehicle_model"
+	ProfileFieldVehicleColor   = "vehicle_color"
+	ProfileFieldSTNKPhoto      = "stnk_photo"
+	ProfileFieldProfilePicture = "profile_picture"
+)
+
+// Background job types
+const (
+	JobTypeSendOTP        = "otp.send"
+	JobTypeRedeliverOTP   = "otp.redeliver"
+	JobTypePushFanOut     = "push.fanout"
+	JobTypePushSend       = "push.send"
+	JobTypeWebhookDeliver = "webhook.deliver"
+
+	JobTypeDispatchOrder        = "dispatch.order"
+	JobTypeDispatchOfferTimeout = "dispatch.offer_timeout"
+
+	JobTypePaymentCheckStatus = "payment.check_status"
+
+	JobTypeWhatsAppNotify     = "whatsapp.notify"
+	JobTypeWhatsAppSend       = "whatsapp.send"
+	JobTypeDocumentExpiryScan = "documents.expiry_scan"
+
+	JobTypeScheduledDispatch = "scheduled.dispatch"
+	JobTypeScheduledReminder = "scheduled.reminder"
+
+	JobTypeChatPurge = "chat.purge"
+
+	JobTypeRelayForward = "relay.forward"
+)
+
+// Outbox aggregates and event types
+const (
+	AggregateDriver        = "driver"
+	AggregateOrder         = "order"
+	AggregatePaymentCharge = "payment_charge"
+	AggregateUser          = "user"
+	AggregateSOSIncident   = "sos_incident"
+
+	EventDriverProfileUpdated    = "driver.profile_updated"
+	EventDriverVerificationReset = "driver.verification_reset"
+	EventDriverReviewed          = "driver.reviewed"
+	EventDriverDocumentExpired   = "driver.document_expired"
+	EventDriverLeftServiceArea   = "driver.left_service_area"
+
+	EventOrderCreated   = "order.created"
+	EventOrderOffered   = "order.offered"
+	EventOrderAccepted  = "order.accepted"
+	EventOrderExpired   = "order.expired"
+	EventOrderArrived   = "order.arrived"
+	EventOrderStarted   = "order.started"
+	EventOrderCompleted = "order.completed"
+
+	EventOrderPreAccepted     = "order.pre_accepted"
+	EventOrderDriverWithdrew  = "order.driver_withdrew"
+	EventOrderDispatchStarted = "order.dispatch_started"
+	EventOrderCancelled       = "order.cancelled"
+
+	EventOrderDriverCancelled = "order.driver_cancelled" // driver backed out, the order is dispatched again
+	EventUserCooldownStarted  = "user.cooldown_started"
+
+	EventOrderPaymentFallback = "order.payment_fallback" // wallet could not cover the fare at completion
+
+	EventOrderRated          = "order.rated"
+	EventDriverRatingFlagged = "driver.rating_flagged"
+
+	EventPaymentChargePaid = "payment.charge_paid"
+
+	EventSOSRaised       = "sos.raised"
+	EventSOSAcknowledged = "sos.acknowledged"
+	EventSOSClosed       = "sos.closed" // resolved or dismissed
+)
+
+// Ledger transaction references
+const (
+	LedgerRefOrder  = "order"
+	LedgerRefPayout = "payout"
+	LedgerRefTopUp  = "topup"
+
+	// Prefix of top-up references credited from gateway charges
+	TopUpRefPaymentCharge = "payment:"
+)
+
+// Rating tags a passenger can give a driver
+var DriverRatingTags = []string{
+	"SAFE_DRIVING", "FRIENDLY", "ON_TIME", "CLEAN_VEHICLE", "KNOWS_ROUTE",
+	"RECKLESS", "LATE", "RUDE", "WRONG_ROUTE",
+}
+
+// Rating tags a driver can give a passenger
+var PassengerRatingTags = []string{
+	"FRIENDLY", "ON_TIME", "CLEAR_PICKUP",
+	"LATE", "RUDE", "WRONG_PICKUP",
+}
+
+// Cancellation reason codes a passenger can give
+var PassengerCancelReasons = []string{
+	"CHANGED_PLANS", "DRIVER_TOO_FAR", "DRIVER_ASKED_TO_CANCEL", "WRONG_PICKUP", "FOUND_OTHER_RIDE",
+	CancelReasonOther,
+}
+
+// Cancellation reason codes a driver can give
+var DriverCancelReasons = []string{
+	CancelReasonNoShow, "PASSENGER_ASKED_TO_CANCEL", "VEHICLE_PROBLEM", "UNSAFE_PICKUP",
+	CancelReasonOther,
+}
+
+const (
+	CancelReasonOther  = "OTHER" // requires a note
+	CancelReasonNoShow = "PASSENGER_NO_SHOW"
+	// Recorded when a driver gives up a pre-accepted scheduled ride
+	CancelReasonScheduleWithdrawn = "SCHEDULE_WITHDRAWN"
+)
+
+// Quick replies a passenger can send in the ride chat
+var PassengerQuickReplies = []string{
+	"ON_MY_WAY", "AT_PICKUP", "WAIT_A_MOMENT", "WHERE_ARE_YOU", "OK_THANKS",
+}
+
+// Quick replies a driver can send in the ride chat
+var DriverQuickReplies = []string{
+	"ON_MY_WAY", "ARRIVED", "STUCK_IN_TRAFFIC", "WHERE_ARE_YOU", "OK_THANKS",
+}
+
+// Realtime event types pushed over WebSocket and SSE
+const (
+	RealtimeOrderStatus       = "order.status"
+	RealtimeDispatchOffer     = "dispatch.offer"
+	RealtimeDispatchOfferGone = "dispatch.offer_closed"
+	RealtimeDriverLocation    = "driver.location"
+	RealtimeChatMessage       = "chat.message"
+	RealtimeChatRead          = "chat.read"
+)
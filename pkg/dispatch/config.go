@@ -0,0 +1,78 @@
+package dispatch
+
+import "time"
+
+// MaxRating is the top of the driver rating scale
+const MaxRating = 5.0
+
+// Weights sets how much each signal contributes to a driver's score
+type Weights struct {
+	Distance   float64
+	Rating     float64
+	Acceptance float64
+}
+
+// Config tunes the dispatch engine
+type Config struct {
+	OfferTimeout    time.Duration // accept window of a single offer
+	InitialRadiusKm float64       // first search radius around the pickup point
+	RadiusStepKm    float64       // radius growth when nobody is in range
+	MaxRadiusKm     float64       // the order expires when nobody is found within this radius
+	MaxAttempts     int           // the order expires after this many offers
+
+	Weights Weights
+
+	DefaultRating   float64 // rating assumed for drivers without ratings
+	PriorAcceptance float64 // acceptance rate assumed for drivers without history
+	PriorOffers     int     // how many offers the prior is worth
+}
+
+// DefaultConfig returns the production defaults
+func DefaultConfig() Config {
+	return Config{
+		OfferTimeout:    20 * time.Second,
+		InitialRadiusKm: 1,
+		RadiusStepKm:    1,
+		MaxRadiusKm:     5,
+		MaxAttempts:     8,
+		Weights: Weights{
+			Distance:   0.6,
+			Rating:     0.25,
+			Acceptance: 0.15,
+		},
+		DefaultRating:   4.5,
+		PriorAcceptance: 0.8,
+		PriorOffers:     5,
+	}
+}
+
+func (c *Config) setDefaults() {
+	defaults := DefaultConfig()
+	if c.OfferTimeout <= 0 {
+		c.OfferTimeout = defaults.OfferTimeout
+	}
+	if c.InitialRadiusKm <= 0 {
+		c.InitialRadiusKm = defaults.InitialRadiusKm
+	}
+	if c.RadiusStepKm <= 0 {
+		c.RadiusStepKm = defaults.RadiusStepKm
+	}
+	if c.MaxRadiusKm < c.InitialRadiusKm {
+		c.MaxRadiusKm = max(defaults.MaxRadiusKm, c.InitialRadiusKm)
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaults.MaxAttempts
+	}
+	if c.Weights.Distance+c.Weights.Rating+c.Weights.Acceptance <= 0 {
+		c.Weights = defaults.Weights
+	}
+	if c.DefaultRating <= 0 {
+		c.DefaultRating = defaults.DefaultRating
+	}
+	if c.PriorAcceptance <= 0 {
+		c.PriorAcceptance = defaults.PriorAcceptance
+	}
+	if c.PriorOffers <= 0 {
+		c.PriorOffers = defaults.PriorOffers
+	}
+}
@@ -0,0 +1,174 @@
+package dispatch
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/clock"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/geo"
+)
+
+// Action is what the engine decides to do next with an order
+type Action string
+
+const (
+	ActionOffer  Action = "OFFER"  // offer the order to Decision.Driver
+	ActionExpire Action = "EXPIRE" // give up, no driver will take the order
+)
+
+// Expire reasons
+const (
+	ReasonMaxAttempts = "max_attempts"
+	ReasonNoDrivers   = "no_drivers"
+)
+
+// Candidate is an online driver that could receive an offer
+type Candidate struct {
+	DriverID       int
+	Location       geo.Point
+	Rating         float64 // 0 = not rated yet
+	OffersAccepted int     // recent offers accepted
+	OffersDecided  int     // recent offers accepted, declined or timed out
+}
+
+// Ranked is a candidate inside the search radius with its score breakdown
+type Ranked struct {
+	Candidate
+	DistanceKm     float64
+	AcceptanceRate float64
+	Score          float64
+}
+
+// State is the dispatch progress of one order, rebuilt from its recorded offers
+type State struct {
+	Pickup   geo.Point
+	Attempts int          // offers already made
+	RadiusKm float64      // radius of the latest offer (0 = first attempt)
+	Offered  map[int]bool // drivers that already had an offer for this order
+}
+
+// Decision is the outcome of Engine.Next
+type Decision struct {
+	Action    Action
+	Driver    *Ranked // set for ActionOffer
+	Attempt   int     // 1-based attempt number of the offer
+	RadiusKm  float64
+	OfferedAt time.Time
+	ExpiresAt time.Time // end of the driver's accept window
+	Reason    string    // set for ActionExpire
+}
+
+// Engine ranks nearby drivers and decides who gets the next offer. It holds no state
+// and reads time only from its clock, so the same inputs always give the same decision.
+type Engine struct {
+	cfg   Config
+	clock clock.Clock
+}
+
+// NewEngine creates an engine; zero config values fall back to DefaultConfig
+func NewEngine(cfg Config, clk clock.Clock) *Engine {
+	cfg.setDefaults()
+	return &Engine{cfg: cfg, clock: clk}
+}
+
+// Config returns the effective configuration
+func (e *Engine) Config() Config {
+	return e.cfg
+}
+
+// Next decides the next step for an order: offer it to the best-ranked driver not offered yet,
+// widening the radius step by step when nobody is in range, or expire it once the attempt
+// budget or the max radius is exhausted
+func (e *Engine) Next(state State, candidates []Candidate) Decision {
+	if state.Attempts >= e.cfg.MaxAttempts {
+		return Decision{Action: ActionExpire, RadiusKm: state.RadiusKm, Reason: ReasonMaxAttempts}
+	}
+
+	radius := state.RadiusKm
+	if radius <= 0 {
+		radius = e.cfg.InitialRadiusKm
+	}
+
+	for {
+		ranked := e.Rank(state.Pickup, radius, candidates, state.Offered)
+		if len(ranked) > 0 {
+			now := e.clock.Now()
+			best := ranked[0]
+			return Decision{
+				Action:    ActionOffer,
+				Driver:    &best,
+				Attempt:   state.Attempts + 1,
+				RadiusKm:  radius,
+				OfferedAt: now,
+				ExpiresAt: now.Add(e.cfg.OfferTimeout),
+			}
+		}
+
+		if radius >= e.cfg.MaxRadiusKm {
+			return Decision{Action: ActionExpire, RadiusKm: radius, Reason: ReasonNoDrivers}
+		}
+		radius = math.Min(radius+e.cfg.RadiusStepKm, e.cfg.MaxRadiusKm)
+	}
+}
+
+// Rank scores the candidates within radiusKm of pickup, best first. Excluded drivers are skipped.
+// Ties are broken by distance, then driver id, so the order is fully deterministic.
+func (e *Engine) Rank(pickup geo.Point, radiusKm float64, candidates []Candidate, exclude map[int]bool) []Ranked {
+	ranked := make([]Ranked, 0, len(candidates))
+	for _, candidate := range candidates {
+		if exclude[candidate.DriverID] {
+			continue
+		}
+
+		distance := geo.DistanceKm(pickup, candidate.Location)
+		if distance > radiusKm {
+			continue
+		}
+
+		acceptance := e.acceptanceRate(candidate)
+		ranked = append(ranked, Ranked{
+			Candidate:      candidate,
+			DistanceKm:     distance,
+			AcceptanceRate: acceptance,
+			Score:          e.score(distance, candidate.Rating, acceptance),
+		})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		if ranked[i].DistanceKm != ranked[j].DistanceKm {
+			return ranked[i].DistanceKm < ranked[j].DistanceKm
+		}
+		return ranked[i].DriverID < ranked[j].DriverID
+	})
+	return ranked
+}
+
+// IsExpired reports whether an offer's accept window has closed
+func (e *Engine) IsExpired(expiresAt time.Time) bool {
+	return !e.clock.Now().Before(expiresAt)
+}
+
+// score combines closeness (relative to the max radius), rating and acceptance rate into [0, 1]
+func (e *Engine) score(distanceKm, rating, acceptance float64) float64 {
+	closeness := 1 - math.Min(distanceKm/e.cfg.MaxRadiusKm, 1)
+
+	if rating <= 0 {
+		rating = e.cfg.DefaultRating
+	}
+	normalizedRating := math.Min(rating/MaxRating, 1)
+
+	w := e.cfg.Weights
+	total := w.Distance + w.Rating + w.Acceptance
+	return (w.Distance*closeness + w.Rating*normalizedRating + w.Acceptance*acceptance) / total
+}
+
+// acceptanceRate smooths the observed rate toward the prior, so one declined offer
+// does not bury a new driver and one accepted offer does not crown them
+func (e *Engine) acceptanceRate(candidate Candidate) float64 {
+	prior := e.cfg.PriorAcceptance * float64(e.cfg.PriorOffers)
+	return (float64(candidate.OffersAccepted) + prior) / float64(candidate.OffersDecided+e.cfg.PriorOffers)
+}
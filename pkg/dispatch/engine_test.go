@@ -0,0 +1,281 @@
+package dispatch
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/clock"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/geo"
+)
+
+var (
+	pickup  = geo.Point{Lat: -6.3628, Long: 106.8269}
+	startAt = time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+)
+
+// north returns the point km north of the pickup
+func north(km float64) geo.Point {
+	return geo.Point{Lat: pickup.Lat + km/(geo.EarthRadiusKm*math.Pi/180), Long: pickup.Long}
+}
+
+func driverIDs(ranked []Ranked) []int {
+	ids := make([]int, 0, len(ranked))
+	for _, r := range ranked {
+		ids = append(ids, r.DriverID)
+	}
+	return ids
+}
+
+func TestEngineRank(t *testing.T) {
+	tests := []struct {
+		name       string
+		radiusKm   float64
+		candidates []Candidate
+		exclude    map[int]bool
+		want       []int
+	}{
+		{
+			name:     "closer driver wins when rating and history match",
+			radiusKm: 5,
+			candidates: []Candidate{
+				{DriverID: 1, Location: north(2), Rating: 4.8},
+				{DriverID: 2, Location: north(0.5), Rating: 4.8},
+			},
+			want: []int{2, 1},
+		},
+		{
+			name:     "rating outweighs a small distance difference",
+			radiusKm: 5,
+			candidates: []Candidate{
+				{DriverID: 1, Location: north(1), Rating: 3},
+				{DriverID: 2, Location: north(1.2), Rating: 5},
+			},
+			want: []int{2, 1},
+		},
+		{
+			name:     "unrated driver gets the default rating and ties break by id",
+			radiusKm: 5,
+			candidates: []Candidate{
+				{DriverID: 3, Location: north(1), Rating: 0},
+				{DriverID: 1, Location: north(1), Rating: 4.5},
+				{DriverID: 2, Location: north(1), Rating: 4.6},
+			},
+			want: []int{2, 1, 3},
+		},
+		{
+			name:     "new driver ranks above one who declined every recent offer",
+			radiusKm: 5,
+			candidates: []Candidate{
+				{DriverID: 1, Location: north(1), Rating: 4.8, OffersAccepted: 0, OffersDecided: 10},
+				{DriverID: 2, Location: north(1), Rating: 4.8},
+			},
+			want: []int{2, 1},
+		},
+		{
+			name:     "drivers outside the radius or already offered are skipped",
+			radiusKm: 1,
+			candidates: []Candidate{
+				{DriverID: 1, Location: north(0.5), Rating: 4.8},
+				{DriverID: 2, Location: north(1.5), Rating: 4.8},
+				{DriverID: 3, Location: north(0.2), Rating: 4.8},
+			},
+			exclude: map[int]bool{3: true},
+			want:    []int{1},
+		},
+		{
+			name:       "no candidates",
+			radiusKm:   5,
+			candidates: nil,
+			want:       []int{},
+		},
+	}
+
+	engine := NewEngine(Config{}, clock.NewFake(startAt))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := driverIDs(engine.Rank(pickup, tt.radiusKm, tt.candidates, tt.exclude))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Rank() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEngineNext(t *testing.T) {
+	nearby := []Candidate{
+		{DriverID: 1, Location: north(0.5), Rating: 4.9},
+		{DriverID: 2, Location: north(0.8), Rating: 4.7},
+	}
+	farAway := []Candidate{{DriverID: 7, Location: north(2.5), Rating: 4.9}}
+
+	tests := []struct {
+		name       string
+		state      State
+		candidates []Candidate
+		wantAction Action
+		wantDriver int
+		wantTry    int
+		wantRadius float64
+		wantReason string
+	}{
+		{
+			name:       "first attempt offers the best driver in the initial radius",
+			state:      State{Pickup: pickup},
+			candidates: nearby,
+			wantAction: ActionOffer,
+			wantDriver: 1,
+			wantTry:    1,
+			wantRadius: 1,
+		},
+		{
+			name:       "retry skips drivers that already had an offer",
+			state:      State{Pickup: pickup, Attempts: 1, RadiusKm: 1, Offered: map[int]bool{1: true}},
+			candidates: nearby,
+			wantAction: ActionOffer,
+			wantDriver: 2,
+			wantTry:    2,
+			wantRadius: 1,
+		},
+		{
+			name:       "radius widens step by step until a driver is in range",
+			state:      State{Pickup: pickup},
+			candidates: farAway,
+			wantAction: ActionOffer,
+			wantDriver: 7,
+			wantTry:    1,
+			wantRadius: 3,
+		},
+		{
+			name:       "retry keeps the radius of the previous offer",
+			state:      State{Pickup: pickup, Attempts: 2, RadiusKm: 3, Offered: map[int]bool{7: true}},
+			candidates: nearby,
+			wantAction: ActionOffer,
+			wantDriver: 1,
+			wantTry:    3,
+			wantRadius: 3,
+		},
+		{
+			name:       "nobody within the max radius expires the order",
+			state:      State{Pickup: pickup, Attempts: 1, RadiusKm: 1, Offered: map[int]bool{1: true, 2: true}},
+			candidates: nearby,
+			wantAction: ActionExpire,
+			wantRadius: 5,
+			wantReason: ReasonNoDrivers,
+		},
+		{
+			name:       "attempt budget exhausted expires the order",
+			state:      State{Pickup: pickup, Attempts: 8, RadiusKm: 2},
+			candidates: nearby,
+			wantAction: ActionExpire,
+			wantRadius: 2,
+			wantReason: ReasonMaxAttempts,
+		},
+	}
+
+	clk := clock.NewFake(startAt)
+	engine := NewEngine(Config{}, clk)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := engine.Next(tt.state, tt.candidates)
+
+			if got.Action != tt.wantAction {
+				t.Fatalf("Action = %s, want %s", got.Action, tt.wantAction)
+			}
+			if got.RadiusKm != tt.wantRadius {
+				t.Errorf("RadiusKm = %v, want %v", got.RadiusKm, tt.wantRadius)
+			}
+			if got.Reason != tt.wantReason {
+				t.Errorf("Reason = %q, want %q", got.Reason, tt.wantReason)
+			}
+			if tt.wantAction != ActionOffer {
+				if got.Driver != nil {
+					t.Errorf("Driver = %d, want none", got.Driver.DriverID)
+				}
+				return
+			}
+
+			if got.Driver == nil || got.Driver.DriverID != tt.wantDriver {
+				t.Fatalf("Driver = %v, want %d", got.Driver, tt.wantDriver)
+			}
+			if got.Attempt != tt.wantTry {
+				t.Errorf("Attempt = %d, want %d", got.Attempt, tt.wantTry)
+			}
+			if !got.OfferedAt.Equal(clk.Now()) {
+				t.Errorf("OfferedAt = %v, want %v", got.OfferedAt, clk.Now())
+			}
+			if want := clk.Now().Add(20 * time.Second); !got.ExpiresAt.Equal(want) {
+				t.Errorf("ExpiresAt = %v, want %v", got.ExpiresAt, want)
+			}
+		})
+	}
+}
+
+func TestEngineIsExpired(t *testing.T) {
+	clk := clock.NewFake(startAt)
+	engine := NewEngine(Config{OfferTimeout: 15 * time.Second}, clk)
+	decision := engine.Next(State{Pickup: pickup}, []Candidate{{DriverID: 1, Location: north(0.3)}})
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		want    bool
+	}{
+		{name: "just offered", elapsed: 0, want: false},
+		{name: "one second before the window closes", elapsed: 14 * time.Second, want: false},
+		{name: "window exactly elapsed", elapsed: 15 * time.Second, want: true},
+		{name: "long after the window", elapsed: time.Minute, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk.Set(startAt.Add(tt.elapsed))
+			if got := engine.IsExpired(decision.ExpiresAt); got != tt.want {
+				t.Errorf("IsExpired() after %v = %v, want %v", tt.elapsed, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewEngineDefaults(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want Config
+	}{
+		{
+			name: "zero config uses the defaults",
+			cfg:  Config{},
+			want: DefaultConfig(),
+		},
+		{
+			name: "max radius below the initial radius is raised",
+			cfg:  Config{InitialRadiusKm: 8, MaxRadiusKm: 3},
+			want: func() Config {
+				c := DefaultConfig()
+				c.InitialRadiusKm = 8
+				c.MaxRadiusKm = 8
+				return c
+			}(),
+		},
+		{
+			name: "overrides are kept",
+			cfg:  Config{OfferTimeout: time.Minute, MaxAttempts: 3},
+			want: func() Config {
+				c := DefaultConfig()
+				c.OfferTimeout = time.Minute
+				c.MaxAttempts = 3
+				return c
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewEngine(tt.cfg, clock.Real{}).Config(); got != tt.want {
+				t.Errorf("Config() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
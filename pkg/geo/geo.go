@@ -0,0 +1,41 @@
+package geo
+
+import "math"
+
+// EarthRadiusKm is the mean Earth radius used for great-circle distances
+const EarthRadiusKm = 6371.0
+
+// Point is a WGS84 coordinate
+type Point struct {
+	Lat  float64 `json:"lat"`
+	Long float64 `json:"long"`
+}
+
+// DistanceKm returns the great-circle (haversine) distance between two points in kilometers
+func DistanceKm(a, b Point) float64 {
+	lat1 := toRadians(a.Lat)
+	lat2 := toRadians(b.Lat)
+	dLat := lat2 - lat1
+	dLong := toRadians(b.Long - a.Long)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLong/2)*math.Sin(dLong/2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// BoundingBox returns the lat/long box that contains every point within radiusKm of center.
+// It is a cheap index-friendly prefilter; callers still check DistanceKm for the exact radius.
+func BoundingBox(center Point, radiusKm float64) (minLat, maxLat, minLong, maxLong float64) {
+	dLat := radiusKm / EarthRadiusKm * 180 / math.Pi
+	dLong := dLat / math.Max(math.Cos(toRadians(center.Lat)), 1e-6)
+	return center.Lat - dLat, center.Lat + dLat, center.Long - dLong, center.Long + dLong
+}
+
+// Valid reports whether the point is a real coordinate
+func (p Point) Valid() bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Long >= -180 && p.Long <= 180
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
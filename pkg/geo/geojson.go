@@ -0,0 +1,164 @@
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidGeoJSON is returned for GeoJSON that is not a usable polygon area
+var ErrInvalidGeoJSON = errors.New("invalid GeoJSON")
+
+// geoJSONObject covers the GeoJSON object types ParseGeoJSON accepts
+type geoJSONObject struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+	Geometry    *geoJSONObject  `json:"geometry"`
+	Features    []geoJSONObject `json:"features"`
+}
+
+// ParseGeoJSON reads a Polygon, MultiPolygon, Feature or FeatureCollection into a
+// MultiPolygon. Positions are [longitude, latitude] as the spec requires; open rings are
+// closed implicitly and features without a polygon geometry are rejected.
+func ParseGeoJSON(data []byte) (MultiPolygon, error) {
+	var obj geoJSONObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGeoJSON, err)
+	}
+	area, err := parseGeoJSONObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	if len(area) == 0 {
+		return nil, fmt.Errorf("%w: no polygons", ErrInvalidGeoJSON)
+	}
+	return area, nil
+}
+
+// MarshalGeoJSON renders the area as a GeoJSON MultiPolygon geometry
+func (m MultiPolygon) MarshalGeoJSON() ([]byte, error) {
+	coordinates := make([][][][2]float64, 0, len(m))
+	for _, pg := range m {
+		rings := make([][][2]float64, 0, 1+len(pg.Holes))
+		rings = append(rings, ringPositions(pg.Outer))
+		for _, hole := range pg.Holes {
+			rings = append(rings, ringPositions(hole))
+		}
+		coordinates = append(coordinates, rings)
+	}
+	return json.Marshal(map[string]any{
+		"type":        "MultiPolygon",
+		"coordinates": coordinates,
+	})
+}
+
+// MarshalLineString renders points as a GeoJSON LineString geometry
+func MarshalLineString(points []Point) ([]byte, error) {
+	coordinates := make([][2]float64, 0, len(points))
+	for _, p := range points {
+		coordinates = append(coordinates, [2]float64{p.Long, p.Lat})
+	}
+	return json.Marshal(map[string]any{
+		"type":        "LineString",
+		"coordinates": coordinates,
+	})
+}
+
+func parseGeoJSONObject(obj geoJSONObject) (MultiPolygon, error) {
+	switch obj.Type {
+	case "Polygon":
+		var rings [][][]float64
+		if err := json.Unmarshal(obj.Coordinates, &rings); err != nil {
+			return nil, fmt.Errorf("%w: polygon coordinates: %v", ErrInvalidGeoJSON, err)
+		}
+		pg, err := parsePolygon(rings)
+		if err != nil {
+			return nil, err
+		}
+		return MultiPolygon{pg}, nil
+	case "MultiPolygon":
+		var polygons [][][][]float64
+		if err := json.Unmarshal(obj.Coordinates, &polygons); err != nil {
+			return nil, fmt.Errorf("%w: multipolygon coordinates: %v", ErrInvalidGeoJSON, err)
+		}
+		area := make(MultiPolygon, 0, len(polygons))
+		for _, rings := range polygons {
+			pg, err := parsePolygon(rings)
+			if err != nil {
+				return nil, err
+			}
+			area = append(area, pg)
+		}
+		return area, nil
+	case "Feature":
+		if obj.Geometry == nil {
+			return nil, fmt.Errorf("%w: feature without geometry", ErrInvalidGeoJSON)
+		}
+		return parseGeoJSONObject(*obj.Geometry)
+	case "FeatureCollection":
+		var area MultiPolygon
+		for _, feature := range obj.Features {
+			polygons, err := parseGeoJSONObject(feature)
+			if err != nil {
+				return nil, err
+			}
+			area = append(area, polygons...)
+		}
+		return area, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported type %q", ErrInvalidGeoJSON, obj.Type)
+	}
+}
+
+func parsePolygon(rings [][][]float64) (Polygon, error) {
+	if len(rings) == 0 {
+		return Polygon{}, fmt.Errorf("%w: polygon without rings", ErrInvalidGeoJSON)
+	}
+	var pg Polygon
+	for i, positions := range rings {
+		ring, err := parseRing(positions)
+		if err != nil {
+			return Polygon{}, err
+		}
+		if i == 0 {
+			pg.Outer = ring
+		} else {
+			pg.Holes = append(pg.Holes, ring)
+		}
+	}
+	return pg, nil
+}
+
+// parseRing converts [long, lat] positions, dropping the closing position
+func parseRing(positions [][]float64) (Ring, error) {
+	ring := make(Ring, 0, len(positions))
+	for _, position := range positions {
+		if len(position) < 2 {
+			return nil, fmt.Errorf("%w: position needs longitude and latitude", ErrInvalidGeoJSON)
+		}
+		p := Point{Lat: position[1], Long: position[0]}
+		if !p.Valid() {
+			return nil, fmt.Errorf("%w: position [%g, %g] out of range", ErrInvalidGeoJSON, position[0], position[1])
+		}
+		ring = append(ring, p)
+	}
+	if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+		ring = ring[:len(ring)-1]
+	}
+	if len(ring) < 3 {
+		return nil, fmt.Errorf("%w: ring needs at least 3 distinct positions", ErrInvalidGeoJSON)
+	}
+	return ring, nil
+}
+
+// ringPositions converts a ring back to closed [long, lat] positions
+func ringPositions(ring Ring) [][2]float64 {
+	positions := make([][2]float64, 0, len(ring)+1)
+	for _, p := range ring {
+		positions = append(positions, [2]float64{p.Long, p.Lat})
+	}
+	if len(ring) > 0 {
+		positions = append(positions, [2]float64{ring[0].Long, ring[0].Lat})
+	}
+	return positions
+}
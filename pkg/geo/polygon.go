@@ -0,0 +1,77 @@
+package geo
+
+import "math"
+
+// Ring is a closed chain of points; the closing edge back to the first point is implicit
+type Ring []Point
+
+// Polygon is an outer ring with optional holes
+type Polygon struct {
+	Outer Ring
+	Holes []Ring
+}
+
+// MultiPolygon is a set of polygons treated as one area, e.g. a campus split by a public road
+type MultiPolygon []Polygon
+
+// Contains reports whether p lies inside the ring (even-odd rule). Points exactly on an
+// edge may fall either way; zones are drawn far coarser than GPS precision.
+func (r Ring) Contains(p Point) bool {
+	inside := false
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		a, b := r[i], r[j]
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) &&
+			p.Long < (b.Long-a.Long)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Long {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Contains reports whether p lies inside the outer ring and outside every hole
+func (pg Polygon) Contains(p Point) bool {
+	if !pg.Outer.Contains(p) {
+		return false
+	}
+	for _, hole := range pg.Holes {
+		if hole.Contains(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// Contains reports whether p lies inside any of the polygons
+func (m MultiPolygon) Contains(p Point) bool {
+	for _, pg := range m {
+		if pg.Contains(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Bounds returns the bounding box of the outer rings, used as an index-friendly prefilter
+func (m MultiPolygon) Bounds() (minLat, maxLat, minLong, maxLong float64) {
+	minLat, minLong = math.Inf(1), math.Inf(1)
+	maxLat, maxLong = math.Inf(-1), math.Inf(-1)
+	for _, pg := range m {
+		for _, p := range pg.Outer {
+			minLat, maxLat = math.Min(minLat, p.Lat), math.Max(maxLat, p.Lat)
+			minLong, maxLong = math.Min(minLong, p.Long), math.Max(maxLong, p.Long)
+		}
+	}
+	return minLat, maxLat, minLong, maxLong
+}
+
+// Nearest returns the index of the candidate closest to p and its distance in kilometers,
+// or -1 when there are no candidates
+func Nearest(p Point, candidates []Point) (int, float64) {
+	best, bestKm := -1, math.Inf(1)
+	for i, candidate := range candidates {
+		if km := DistanceKm(p, candidate); km < bestKm {
+			best, bestKm = i, km
+		}
+	}
+	return best, bestKm
+}
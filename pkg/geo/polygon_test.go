@@ -0,0 +1,87 @@
+package geo
+
+import "testing"
+
+// rect returns the ring of the rectangle spanning the given km offsets from origin
+func rect(west, south, east, north float64) Ring {
+	return Ring{offset(west, south), offset(west, north), offset(east, north), offset(east, south)}
+}
+
+func TestPolygonContains(t *testing.T) {
+	// A 2 km campus with a 0.5 km lake in the middle, and a U-shaped annex whose notch
+	// (the public road) is outside
+	campus := Polygon{
+		Outer: rect(0, 0, 2, 2),
+		Holes: []Ring{rect(0.75, 0.75, 1.25, 1.25)},
+	}
+	annex := Polygon{Outer: Ring{
+		offset(3, 0), offset(3, 2), offset(3.4, 2), offset(3.4, 0.5),
+		offset(3.6, 0.5), offset(3.6, 2), offset(4, 2), offset(4, 0),
+	}}
+	area := MultiPolygon{campus, annex}
+
+	tests := []struct {
+		name  string
+		point Point
+		want  bool
+	}{
+		{name: "inside the outer ring", point: offset(0.3, 0.3), want: true},
+		{name: "just inside a corner", point: offset(1.99, 1.99), want: true},
+		{name: "just outside an edge", point: offset(2.01, 1), want: false},
+		{name: "south of the campus", point: offset(1, -0.5), want: false},
+		{name: "inside the hole", point: offset(1, 1), want: false},
+		{name: "between the hole and the outer ring", point: offset(1, 1.5), want: true},
+		{name: "arm of the concave annex", point: offset(3.2, 1.5), want: true},
+		{name: "notch of the concave annex", point: offset(3.5, 1.5), want: false},
+		{name: "base of the concave annex below the notch", point: offset(3.5, 0.25), want: true},
+		{name: "between the two polygons", point: offset(2.5, 1), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := area.Contains(tt.point); got != tt.want {
+				t.Errorf("Contains(%v) = %v, want %v", tt.point, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRingContainsWinding(t *testing.T) {
+	clockwise := rect(0, 0, 1, 1)
+	counterClockwise := Ring{clockwise[3], clockwise[2], clockwise[1], clockwise[0]}
+	inside, outside := offset(0.5, 0.5), offset(1.5, 0.5)
+
+	for name, ring := range map[string]Ring{"clockwise": clockwise, "counter-clockwise": counterClockwise} {
+		t.Run(name, func(t *testing.T) {
+			if !ring.Contains(inside) {
+				t.Errorf("Contains(inside) = false, want true")
+			}
+			if ring.Contains(outside) {
+				t.Errorf("Contains(outside) = true, want false")
+			}
+		})
+	}
+}
+
+func TestNearest(t *testing.T) {
+	candidates := []Point{offset(1, 0), offset(0, 0.2), offset(-0.5, 0)}
+
+	tests := []struct {
+		name       string
+		point      Point
+		candidates []Point
+		want       int
+	}{
+		{name: "closest pickup point wins", point: origin, candidates: candidates, want: 1},
+		{name: "point on a candidate", point: offset(-0.5, 0), candidates: candidates, want: 2},
+		{name: "no candidates", point: origin, candidates: nil, want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := Nearest(tt.point, tt.candidates); got != tt.want {
+				t.Errorf("Nearest() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+// ErrInvalidPolyline is returned for a string that is not an encoded polyline
+var ErrInvalidPolyline = errors.New("invalid encoded polyline")
+
+// polylinePrecision is the 1e5 scale of the encoded polyline format
+const polylinePrecision = 1e5
+
+// EncodePolyline encodes points in the encoded polyline algorithm format used by map
+// SDKs: each coordinate is a zigzag varint delta at five decimal places, lat before long
+func EncodePolyline(points []Point) string {
+	var b strings.Builder
+	var prevLat, prevLong int64
+	for _, p := range points {
+		lat := int64(math.Round(p.Lat * polylinePrecision))
+		long := int64(math.Round(p.Long * polylinePrecision))
+		encodePolylineValue(&b, lat-prevLat)
+		encodePolylineValue(&b, long-prevLong)
+		prevLat, prevLong = lat, long
+	}
+	return b.String()
+}
+
+func encodePolylineValue(b *strings.Builder, value int64) {
+	v := value << 1
+	if value < 0 {
+		v = ^v
+	}
+	for v >= 0x20 {
+		b.WriteByte(byte((0x20 | (v & 0x1f)) + 63))
+		v >>= 5
+	}
+	b.WriteByte(byte(v + 63))
+}
+
+// DecodePolyline reverses EncodePolyline
+func DecodePolyline(encoded string) ([]Point, error) {
+	var points []Point
+	var lat, long int64
+	for i := 0; i < len(encoded); {
+		dLat, next, err := decodePolylineValue(encoded, i)
+		if err != nil {
+			return nil, err
+		}
+		dLong, next, err := decodePolylineValue(encoded, next)
+		if err != nil {
+			return nil, err
+		}
+		i = next
+		lat += dLat
+		long += dLong
+		points = append(points, Point{Lat: float64(lat) / polylinePrecision, Long: float64(long) / polylinePrecision})
+	}
+	return points, nil
+}
+
+func decodePolylineValue(encoded string, i int) (int64, int, error) {
+	var result int64
+	for shift := uint(0); ; shift += 5 {
+		if i >= len(encoded) || shift > 60 {
+			return 0, 0, ErrInvalidPolyline
+		}
+		c := int64(encoded[i]) - 63
+		i++
+		if c < 0 {
+			return 0, 0, ErrInvalidPolyline
+		}
+		result |= (c & 0x1f) << shift
+		if c < 0x20 {
+			break
+		}
+	}
+	if result&1 != 0 {
+		return ^(result >> 1), i, nil
+	}
+	return result >> 1, i, nil
+}
@@ -0,0 +1,114 @@
+package geo
+
+import (
+	"math"
+	"time"
+)
+
+// TrackPoint is a location ping with the time it was taken
+type TrackPoint struct {
+	Point
+	At time.Time
+}
+
+// FilterJumps drops GPS noise from a time-ordered track: a ping that could only be
+// reached from the last kept ping faster than maxSpeedKmh is a jump and is discarded, as
+// is a ping that does not move forward in time. The first ping is always kept.
+func FilterJumps(track []TrackPoint, maxSpeedKmh float64) []TrackPoint {
+	if len(track) == 0 {
+		return nil
+	}
+
+	kept := make([]TrackPoint, 0, len(track))
+	kept = append(kept, track[0])
+	for _, p := range track[1:] {
+		last := kept[len(kept)-1]
+		elapsed := p.At.Sub(last.At).Hours()
+		if elapsed <= 0 {
+			continue
+		}
+		if DistanceKm(last.Point, p.Point)/elapsed > maxSpeedKmh {
+			continue
+		}
+		kept = append(kept, p)
+	}
+	return kept
+}
+
+// Simplify reduces a track with the Douglas-Peucker algorithm, keeping every point that
+// deviates more than toleranceKm from the simplified line. The endpoints are always kept.
+func Simplify(track []TrackPoint, toleranceKm float64) []TrackPoint {
+	if len(track) < 3 {
+		return track
+	}
+
+	keep := make([]bool, len(track))
+	keep[0], keep[len(track)-1] = true, true
+	douglasPeucker(track, 0, len(track)-1, toleranceKm, keep)
+
+	simplified := make([]TrackPoint, 0, len(track))
+	for i, p := range track {
+		if keep[i] {
+			simplified = append(simplified, p)
+		}
+	}
+	return simplified
+}
+
+// douglasPeucker marks the points between first and last that the simplified line keeps.
+// It recurses on the farthest point, so its depth is bounded by the track length.
+func douglasPeucker(track []TrackPoint, first, last int, toleranceKm float64, keep []bool) {
+	if last-first < 2 {
+		return
+	}
+
+	farthest, maxDistance := -1, toleranceKm
+	for i := first + 1; i < last; i++ {
+		if d := segmentDistanceKm(track[i].Point, track[first].Point, track[last].Point); d > maxDistance {
+			farthest, maxDistance = i, d
+		}
+	}
+	if farthest < 0 {
+		return
+	}
+
+	keep[farthest] = true
+	douglasPeucker(track, first, farthest, toleranceKm, keep)
+	douglasPeucker(track, farthest, last, toleranceKm, keep)
+}
+
+// segmentDistanceKm returns the distance from p to the segment a-b. Over the short spans
+// of a city trip an equirectangular projection around a is accurate enough.
+func segmentDistanceKm(p, a, b Point) float64 {
+	scale := math.Cos(toRadians(a.Lat))
+	project := func(q Point) (float64, float64) {
+		return toRadians(q.Long-a.Long) * scale * EarthRadiusKm, toRadians(q.Lat-a.Lat) * EarthRadiusKm
+	}
+	px, py := project(p)
+	bx, by := project(b)
+
+	lengthSq := bx*bx + by*by
+	if lengthSq == 0 {
+		return math.Hypot(px, py)
+	}
+	t := math.Max(0, math.Min(1, (px*bx+py*by)/lengthSq))
+	return math.Hypot(px-t*bx, py-t*by)
+}
+
+// PathLengthKm returns the length of the track along its points
+func PathLengthKm(track []TrackPoint) float64 {
+	total := 0.0
+	for i := 1; i < len(track); i++ {
+		total += DistanceKm(track[i-1].Point, track[i].Point)
+	}
+	return total
+}
+
+// Points returns the coordinates of a track without their times
+func Points(track []TrackPoint) []Point {
+	points := make([]Point, len(track))
+	for i, p := range track {
+		points[i] = p.Point
+	}
+	return points
+}
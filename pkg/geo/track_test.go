@@ -0,0 +1,152 @@
+package geo
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+var (
+	origin    = Point{Lat: -6.3628, Long: 106.8269}
+	tripStart = time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+)
+
+// offset returns the point eastKm east and northKm north of origin
+func offset(eastKm, northKm float64) Point {
+	kmPerDegree := EarthRadiusKm * math.Pi / 180
+	return Point{
+		Lat:  origin.Lat + northKm/kmPerDegree,
+		Long: origin.Long + eastKm/(kmPerDegree*math.Cos(toRadians(origin.Lat))),
+	}
+}
+
+// track builds a track of the given km offsets, one ping every interval
+func track(interval time.Duration, offsets ...[2]float64) []TrackPoint {
+	points := make([]TrackPoint, len(offsets))
+	for i, o := range offsets {
+		points[i] = TrackPoint{Point: offset(o[0], o[1]), At: tripStart.Add(time.Duration(i) * interval)}
+	}
+	return points
+}
+
+// kept returns the positions in original of the points in simplified
+func kept(original, simplified []TrackPoint) []int {
+	indexes := make([]int, 0, len(simplified))
+	for _, p := range simplified {
+		indexes = append(indexes, slices.IndexFunc(original, func(q TrackPoint) bool { return q.At.Equal(p.At) }))
+	}
+	return indexes
+}
+
+func TestSimplify(t *testing.T) {
+	tests := []struct {
+		name        string
+		track       []TrackPoint
+		toleranceKm float64
+		want        []int
+	}{
+		{
+			name:        "empty track",
+			track:       nil,
+			toleranceKm: 0.01,
+			want:        []int{},
+		},
+		{
+			name:        "two points are kept as they are",
+			track:       track(time.Second, [2]float64{0, 0}, [2]float64{1, 0}),
+			toleranceKm: 0.01,
+			want:        []int{0, 1},
+		},
+		{
+			name:        "points on a straight line are dropped",
+			track:       track(time.Second, [2]float64{0, 0}, [2]float64{0.25, 0}, [2]float64{0.5, 0}, [2]float64{0.75, 0}, [2]float64{1, 0}),
+			toleranceKm: 0.01,
+			want:        []int{0, 4},
+		},
+		{
+			name:        "wobble within the tolerance is dropped",
+			track:       track(time.Second, [2]float64{0, 0}, [2]float64{0.5, 0.005}, [2]float64{1, 0}),
+			toleranceKm: 0.01,
+			want:        []int{0, 2},
+		},
+		{
+			name:        "detour beyond the tolerance is kept",
+			track:       track(time.Second, [2]float64{0, 0}, [2]float64{0.5, 0.05}, [2]float64{1, 0}),
+			toleranceKm: 0.01,
+			want:        []int{0, 1, 2},
+		},
+		{
+			name:        "corner of a turn is kept and the straight legs are dropped",
+			track:       track(time.Second, [2]float64{0, 0}, [2]float64{0.5, 0}, [2]float64{1, 0}, [2]float64{1, 0.5}, [2]float64{1, 1}),
+			toleranceKm: 0.01,
+			want:        []int{0, 2, 4},
+		},
+		{
+			name:        "every bend of a zigzag is kept",
+			track:       track(time.Second, [2]float64{0, 0}, [2]float64{1, 0.5}, [2]float64{2, 0}, [2]float64{3, 0.5}, [2]float64{4, 0}),
+			toleranceKm: 0.1,
+			want:        []int{0, 1, 2, 3, 4},
+		},
+		{
+			name:        "large tolerance keeps only the endpoints",
+			track:       track(time.Second, [2]float64{0, 0}, [2]float64{1, 0.5}, [2]float64{2, 0}, [2]float64{3, 0.5}, [2]float64{4, 0}),
+			toleranceKm: 1,
+			want:        []int{0, 4},
+		},
+		{
+			name:        "round trip keeps the turning point",
+			track:       track(time.Second, [2]float64{0, 0}, [2]float64{1, 0}, [2]float64{2, 0}, [2]float64{1, 0.001}, [2]float64{0, 0.002}),
+			toleranceKm: 0.01,
+			want:        []int{0, 2, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := kept(tt.track, Simplify(tt.track, tt.toleranceKm)); !slices.Equal(got, tt.want) {
+				t.Errorf("Simplify() kept %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterJumps(t *testing.T) {
+	tests := []struct {
+		name        string
+		track       []TrackPoint
+		maxSpeedKmh float64
+		want        []int
+	}{
+		{
+			name:        "steady ride is kept",
+			track:       track(time.Minute, [2]float64{0, 0}, [2]float64{0.5, 0}, [2]float64{1, 0}),
+			maxSpeedKmh: 120,
+			want:        []int{0, 1, 2},
+		},
+		{
+			name:        "single jump is dropped and the ride resumes from the last good ping",
+			track:       track(time.Minute, [2]float64{0, 0}, [2]float64{10, 0}, [2]float64{0.5, 0}, [2]float64{1, 0}),
+			maxSpeedKmh: 120,
+			want:        []int{0, 2, 3},
+		},
+		{
+			name: "ping that does not move forward in time is dropped",
+			track: []TrackPoint{
+				{Point: offset(0, 0), At: tripStart},
+				{Point: offset(0.1, 0), At: tripStart},
+				{Point: offset(0.5, 0), At: tripStart.Add(time.Minute)},
+			},
+			maxSpeedKmh: 120,
+			want:        []int{0, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := kept(tt.track, FilterJumps(tt.track, tt.maxSpeedKmh)); !slices.Equal(got, tt.want) {
+				t.Errorf("FilterJumps() kept %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package httpclient
+
+import (
+	"sync"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/clock"
+)
+
+// circuitState is the state of a provider's circuit breaker
+type circuitState string
+
+const (
+	stateClosed   circuitState = "closed"    // calls go through
+	stateOpen     circuitState = "open"      // calls fail fast until the open timeout passes
+	stateHalfOpen circuitState = "half_open" // one probe call decides between closed and open
+)
+
+// breaker is a consecutive-failure circuit breaker. After threshold failed attempts in a
+// row it opens and rejects calls for openTimeout; the first call after that is let
+// through as a probe, closing the circuit on success and reopening it on failure. Other
+// calls are rejected while the probe is out.
+type breaker struct {
+	threshold   int
+	openTimeout time.Duration
+	clock       clock.Clock
+
+	mu       sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newBreaker(threshold int, openTimeout time.Duration, clk clock.Clock) *breaker {
+	return &breaker{
+		threshold:   threshold,
+		openTimeout: openTimeout,
+		clock:       clk,
+		state:       stateClosed,
+	}
+}
+
+// allow reports whether a call may go out. Every allowed call must be followed by record
+// or, when its outcome says nothing about the provider, release.
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case stateOpen:
+		if b.clock.Now().Sub(b.openedAt) < b.openTimeout {
+			return false
+		}
+		b.state = stateHalfOpen
+		b.probing = true
+		return true
+	case stateHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	}
+	return true
+}
+
+// release returns an allowed call without an outcome, freeing the probe slot
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// record feeds the outcome of an allowed call and returns the state before and after
+func (b *breaker) record(success bool) (from, to circuitState) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	from = b.state
+	b.probing = false
+	switch {
+	case success:
+		b.failures = 0
+		b.state = stateClosed
+	case b.state == stateHalfOpen:
+		b.state = stateOpen
+		b.openedAt = b.clock.Now()
+	default:
+		b.failures++
+		if b.state == stateClosed && b.failures >= b.threshold {
+			b.state = stateOpen
+			b.openedAt = b.clock.Now()
+		}
+	}
+	return from, b.state
+}
+
+// current returns the state for metrics
+func (b *breaker) current() circuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
@@ -0,0 +1,136 @@
+package httpclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/clock"
+)
+
+var startAt = time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+
+// breakerStep is one call on the breaker after moving the clock by advance
+type breakerStep struct {
+	advance time.Duration
+	call    string // allow, success, failure or release
+	allowed bool   // expected result of allow
+	state   circuitState
+}
+
+func allow(allowed bool, state circuitState) breakerStep {
+	return breakerStep{call: "allow", allowed: allowed, state: state}
+}
+
+func allowAfter(advance time.Duration, allowed bool, state circuitState) breakerStep {
+	return breakerStep{advance: advance, call: "allow", allowed: allowed, state: state}
+}
+
+func success(state circuitState) breakerStep { return breakerStep{call: "success", state: state} }
+func failure(state circuitState) breakerStep { return breakerStep{call: "failure", state: state} }
+func release(state circuitState) breakerStep { return breakerStep{call: "release", state: state} }
+
+func TestBreaker(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []breakerStep
+	}{
+		{
+			name: "failures below the threshold keep the circuit closed",
+			steps: []breakerStep{
+				allow(true, stateClosed), failure(stateClosed),
+				allow(true, stateClosed), failure(stateClosed),
+				allow(true, stateClosed),
+			},
+		},
+		{
+			name: "a success resets the failure count",
+			steps: []breakerStep{
+				allow(true, stateClosed), failure(stateClosed),
+				allow(true, stateClosed), failure(stateClosed),
+				allow(true, stateClosed), success(stateClosed),
+				allow(true, stateClosed), failure(stateClosed),
+				allow(true, stateClosed), failure(stateClosed),
+				allow(true, stateClosed),
+			},
+		},
+		{
+			name: "consecutive failures open the circuit and calls fail fast",
+			steps: []breakerStep{
+				allow(true, stateClosed), failure(stateClosed),
+				allow(true, stateClosed), failure(stateClosed),
+				allow(true, stateClosed), failure(stateOpen),
+				allow(false, stateOpen),
+				allowAfter(30*time.Second-time.Millisecond, false, stateOpen),
+			},
+		},
+		{
+			name: "open timeout lets a single probe through",
+			steps: []breakerStep{
+				allow(true, stateClosed), failure(stateClosed),
+				allow(true, stateClosed), failure(stateClosed),
+				allow(true, stateClosed), failure(stateOpen),
+				allowAfter(30*time.Second, true, stateHalfOpen),
+				allow(false, stateHalfOpen),
+				allowAfter(time.Minute, false, stateHalfOpen),
+			},
+		},
+		{
+			name: "successful probe closes the circuit",
+			steps: []breakerStep{
+				allow(true, stateClosed), failure(stateClosed),
+				allow(true, stateClosed), failure(stateClosed),
+				allow(true, stateClosed), failure(stateOpen),
+				allowAfter(30*time.Second, true, stateHalfOpen), success(stateClosed),
+				allow(true, stateClosed), failure(stateClosed),
+				allow(true, stateClosed),
+			},
+		},
+		{
+			name: "failed probe reopens the circuit for another timeout",
+			steps: []breakerStep{
+				allow(true, stateClosed), failure(stateClosed),
+				allow(true, stateClosed), failure(stateClosed),
+				allow(true, stateClosed), failure(stateOpen),
+				allowAfter(30*time.Second, true, stateHalfOpen), failure(stateOpen),
+				allowAfter(30*time.Second-time.Millisecond, false, stateOpen),
+				allowAfter(time.Millisecond, true, stateHalfOpen),
+			},
+		},
+		{
+			name: "released probe frees the slot for the next call",
+			steps: []breakerStep{
+				allow(true, stateClosed), failure(stateClosed),
+				allow(true, stateClosed), failure(stateClosed),
+				allow(true, stateClosed), failure(stateOpen),
+				allowAfter(30*time.Second, true, stateHalfOpen), release(stateHalfOpen),
+				allow(true, stateHalfOpen), success(stateClosed),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := clock.NewFake(startAt)
+			b := newBreaker(3, 30*time.Second, clk)
+
+			for i, step := range tt.steps {
+				clk.Advance(step.advance)
+				switch step.call {
+				case "allow":
+					if got := b.allow(); got != step.allowed {
+						t.Fatalf("step %d: allow() = %v, want %v", i, got, step.allowed)
+					}
+				case "success":
+					b.record(true)
+				case "failure":
+					b.record(false)
+				case "release":
+					b.release()
+				}
+				if got := b.current(); got != step.state {
+					t.Fatalf("step %d (%s): state = %s, want %s", i, step.call, got, step.state)
+				}
+			}
+		})
+	}
+}
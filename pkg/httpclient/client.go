@@ -0,0 +1,295 @@
+// Package httpclient is the outbound HTTP client shared by the external providers
+// (WhatsApp, FCM, payment gateway). Each provider gets its own Client, which bounds every
+// attempt with a timeout, retries transient failures with jittered backoff, stops calling
+// a provider that keeps failing (circuit breaker) and records latency and error metrics.
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/clock"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+)
+
+// ErrCircuitOpen is returned without calling the provider while its circuit is open
+var ErrCircuitOpen = errors.New("provider circuit open")
+
+// Config tunes a provider client
+type Config struct {
+	Name             string        // provider name in logs and metrics
+	AttemptTimeout   time.Duration // bounds each attempt, including reading the response
+	MaxAttempts      int           // attempts per request, the first one included
+	BaseBackoff      time.Duration // delay before the first retry, doubled per retry
+	MaxBackoff       time.Duration // cap on the retry delay
+	FailureThreshold int           // consecutive failed attempts that open the circuit
+	OpenTimeout      time.Duration // how long the circuit stays open before a probe
+	MaxResponseBytes int64         // responses are cut off beyond this size
+	RedactFields     []string      // form, query and JSON fields masked in logs and errors
+}
+
+// DefaultConfig returns the defaults for the named provider
+func DefaultConfig(name string) Config {
+	return Config{
+		Name:             name,
+		AttemptTimeout:   10 * time.Second,
+		MaxAttempts:      3,
+		BaseBackoff:      200 * time.Millisecond,
+		MaxBackoff:       2 * time.Second,
+		FailureThreshold: 5,
+		OpenTimeout:      30 * time.Second,
+		MaxResponseBytes: 1 << 20,
+		RedactFields:     []string{"token", "access_token", "assertion", "private_key", "server_key", "password"},
+	}
+}
+
+// WithDefaults fills unset fields from DefaultConfig
+func (c Config) WithDefaults() Config {
+	defaults := DefaultConfig(c.Name)
+	if c.AttemptTimeout <= 0 {
+		c.AttemptTimeout = defaults.AttemptTimeout
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaults.MaxAttempts
+	}
+	if c.BaseBackoff <= 0 {
+		c.BaseBackoff = defaults.BaseBackoff
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = defaults.MaxBackoff
+	}
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = defaults.FailureThreshold
+	}
+	if c.OpenTimeout <= 0 {
+		c.OpenTimeout = defaults.OpenTimeout
+	}
+	if c.MaxResponseBytes <= 0 {
+		c.MaxResponseBytes = defaults.MaxResponseBytes
+	}
+	if c.RedactFields == nil {
+		c.RedactFields = defaults.RedactFields
+	}
+	return c
+}
+
+// Response is a provider response, read in full within the attempt timeout
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Client calls one provider
+type Client struct {
+	config     Config
+	httpClient *http.Client
+	breaker    *breaker
+	metrics    *metrics
+}
+
+// New creates a client for a provider and registers its metrics
+func New(config Config) *Client {
+	config = config.WithDefaults()
+	c := &Client{
+		config:     config,
+		httpClient: &http.Client{},
+		breaker:    newBreaker(config.FailureThreshold, config.OpenTimeout, clock.Real{}),
+		metrics:    newMetrics(),
+	}
+	register(config.Name, c)
+	return c
+}
+
+// Do sends req, retrying transient failures. Requests that are idempotent (GET, HEAD,
+// OPTIONS, PUT, DELETE, requests carrying an Idempotency-Key header and those marked with
+// Idempotent) are retried on
+// network errors, timeouts, 5xx and 429; others only when the connection failed before
+// anything was sent, so a message is never delivered twice. The request body must be
+// replayable (http.NewRequestWithContext sets GetBody for in-memory readers).
+// A non-2xx response is returned as a Response, not an error, once retries are used up.
+func (c *Client) Do(req *http.Request) (*Response, error) {
+	ctx := req.Context()
+	idempotent := isIdempotent(req)
+
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		resp, err := c.attempt(req, attempt)
+		if err == nil && !retryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+
+		retry := attempt < c.config.MaxAttempts && ctx.Err() == nil && !errors.Is(err, ErrCircuitOpen) &&
+			(idempotent || (err != nil && notSent(err)))
+		if !retry {
+			if err != nil {
+				return nil, err
+			}
+			return resp, nil
+		}
+
+		lastErr = err
+		if err == nil {
+			lastErr = fmt.Errorf("status %d", resp.StatusCode)
+		}
+
+		c.metrics.retry()
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%s: %w (last error: %v)", c.config.Name, ctx.Err(), lastErr)
+		case <-time.After(c.backoff(attempt)):
+		}
+	}
+}
+
+// attempt makes one call under the attempt timeout and feeds its outcome to the circuit
+// breaker and metrics
+func (c *Client) attempt(req *http.Request, attempt int) (*Response, error) {
+	if !c.breaker.allow() {
+		c.metrics.reject()
+		return nil, fmt.Errorf("%s: %w", c.config.Name, ErrCircuitOpen)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), c.config.AttemptTimeout)
+	defer cancel()
+
+	attemptReq := req.Clone(ctx)
+	if req.GetBody != nil && attempt > 1 {
+		body, err := req.GetBody()
+		if err != nil {
+			c.breaker.release()
+			return nil, fmt.Errorf("%s: failed to replay request body: %w", c.config.Name, err)
+		}
+		attemptReq.Body = body
+	}
+
+	start := time.Now()
+	resp, err := c.send(attemptReq)
+	elapsed := time.Since(start)
+
+	// The caller giving up says nothing about the provider
+	if err != nil && req.Context().Err() != nil {
+		c.breaker.release()
+		return nil, err
+	}
+
+	failed := err != nil || retryableStatus(resp.StatusCode)
+	c.metrics.observe(elapsed, err, resp)
+	c.record(failed)
+
+	if failed {
+		event := logger.Log.Warn().
+			Str("provider", c.config.Name).
+			Str("method", req.Method).
+			Str("url", redactURL(req.URL, c.config.RedactFields)).
+			Int("attempt", attempt).
+			Dur("elapsed", elapsed)
+		if err != nil {
+			event = event.Err(err)
+		} else {
+			event = event.Int("status", resp.StatusCode).Str("response", excerpt(redactBody(resp.Body, c.config.RedactFields)))
+		}
+		event.Msg("Provider request failed")
+	}
+	return resp, err
+}
+
+// send performs the request and reads the response body
+func (c *Client) send(req *http.Request) (*Response, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, c.redactError(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.config.MaxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to read response: %w", c.config.Name, err)
+	}
+	return &Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: body}, nil
+}
+
+// record moves the circuit breaker and logs its transitions
+func (c *Client) record(failed bool) {
+	from, to := c.breaker.record(!failed)
+	if from == to {
+		return
+	}
+	if to == stateOpen {
+		c.metrics.open()
+		logger.Log.Error().Str("provider", c.config.Name).Dur("open_for", c.config.OpenTimeout).Msg("Provider circuit opened")
+		return
+	}
+	logger.Log.Info().Str("provider", c.config.Name).Str("state", string(to)).Msg("Provider circuit state changed")
+}
+
+// redactError masks secrets in the URL of a transport error
+func (c *Client) redactError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		if parsed, parseErr := url.Parse(urlErr.URL); parseErr == nil {
+			urlErr.URL = redactURL(parsed, c.config.RedactFields)
+		}
+	}
+	return fmt.Errorf("%s request failed: %w", c.config.Name, err)
+}
+
+// backoff returns the delay before retry n: exponential from BaseBackoff, capped at
+// MaxBackoff, randomized between half and all of it so retries from many goroutines
+// spread out
+func (c *Client) backoff(retry int) time.Duration {
+	delay := c.config.MaxBackoff
+	if retry < 20 {
+		delay = c.config.BaseBackoff << (retry - 1)
+		if delay > c.config.MaxBackoff {
+			delay = c.config.MaxBackoff
+		}
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	return delay/2 + jitter
+}
+
+// idempotentKey marks requests that callers declared safe to repeat
+type idempotentKey struct{}
+
+// Idempotent marks a request as safe to send more than once, for POSTs without an
+// Idempotency-Key header such as fetching an access token
+func Idempotent(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), idempotentKey{}, true))
+}
+
+// isIdempotent reports whether a request may be sent more than once
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	if marked, _ := req.Context().Value(idempotentKey{}).(bool); marked {
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// retryableStatus reports whether a status means the provider may succeed later
+func retryableStatus(status int) bool {
+	return status >= 500 || status == http.StatusTooManyRequests
+}
+
+// notSent reports whether a transport error happened before the request went out: the
+// connection could not be made, so no request reached the provider
+func notSent(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
@@ -0,0 +1,263 @@
+package httpclient
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/clock"
+)
+
+// provider is an httptest server that answers with the given statuses in turn (the
+// last one repeats) and records the bodies it received
+type provider struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	bodies   []string
+}
+
+func newProvider(t *testing.T, statuses ...int) *provider {
+	t.Helper()
+	p := &provider{statuses: statuses}
+	p.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		p.mu.Lock()
+		p.bodies = append(p.bodies, string(body))
+		status := p.statuses[min(len(p.bodies), len(p.statuses))-1]
+		p.mu.Unlock()
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(p.Close)
+	return p
+}
+
+func (p *provider) calls() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.bodies...)
+}
+
+// testClient returns a client that retries without a noticeable delay
+func testClient(t *testing.T, clk clock.Clock) *Client {
+	c := New(Config{
+		Name:             t.Name(),
+		MaxAttempts:      3,
+		BaseBackoff:      time.Millisecond,
+		MaxBackoff:       time.Millisecond,
+		FailureThreshold: 100,
+		OpenTimeout:      30 * time.Second,
+	})
+	c.breaker = newBreaker(c.config.FailureThreshold, c.config.OpenTimeout, clk)
+	return c
+}
+
+func TestClientRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		header     http.Header
+		idempotent bool
+		statuses   []int
+		wantCalls  int
+		wantStatus int
+	}{
+		{name: "GET is retried on a 5xx until it succeeds", method: http.MethodGet, statuses: []int{503, 200}, wantCalls: 2, wantStatus: 200},
+		{name: "GET is retried on 429", method: http.MethodGet, statuses: []int{429, 429, 200}, wantCalls: 3, wantStatus: 200},
+		{name: "GET stops after max attempts and returns the last response", method: http.MethodGet, statuses: []int{502}, wantCalls: 3, wantStatus: 502},
+		{name: "GET is not retried on a client error", method: http.MethodGet, statuses: []int{404}, wantCalls: 1, wantStatus: 404},
+		{name: "POST is not retried on a 5xx", method: http.MethodPost, statuses: []int{500, 200}, wantCalls: 1, wantStatus: 500},
+		{name: "POST with an Idempotency-Key is retried", method: http.MethodPost, header: http.Header{"Idempotency-Key": {"charge-7"}}, statuses: []int{500, 200}, wantCalls: 2, wantStatus: 200},
+		{name: "POST marked idempotent is retried", method: http.MethodPost, idempotent: true, statuses: []int{503, 503, 201}, wantCalls: 3, wantStatus: 201},
+		{name: "PUT is retried", method: http.MethodPut, statuses: []int{500, 204}, wantCalls: 2, wantStatus: 204},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newProvider(t, tt.statuses...)
+			c := testClient(t, clock.NewFake(startAt))
+
+			req, err := http.NewRequest(tt.method, p.URL, strings.NewReader("to=628123&body=hello"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for key, values := range tt.header {
+				req.Header[key] = values
+			}
+			if tt.idempotent {
+				req = Idempotent(req)
+			}
+
+			resp, err := c.Do(req)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			calls := p.calls()
+			if len(calls) != tt.wantCalls {
+				t.Fatalf("provider called %d times, want %d", len(calls), tt.wantCalls)
+			}
+			for i, body := range calls {
+				if body != "to=628123&body=hello" {
+					t.Errorf("attempt %d sent body %q, want the original body", i+1, body)
+				}
+			}
+		})
+	}
+}
+
+func TestClientRetriesUnsentRequests(t *testing.T) {
+	// A closed server refuses the connection, so nothing was sent and even a POST is retried
+	p := newProvider(t, 200)
+	p.Close()
+	c := testClient(t, clock.NewFake(startAt))
+
+	req, err := http.NewRequest(http.MethodPost, p.URL, strings.NewReader("body=hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Do(req); err == nil {
+		t.Fatal("Do() error = nil, want a connection error")
+	}
+	if got := c.metrics.snapshot("", stateClosed).Attempts; got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+}
+
+func TestClientCircuit(t *testing.T) {
+	p := newProvider(t, 500, 500, 200)
+	clk := clock.NewFake(startAt)
+	c := New(Config{Name: t.Name(), MaxAttempts: 1, FailureThreshold: 2, OpenTimeout: 30 * time.Second})
+	c.breaker = newBreaker(c.config.FailureThreshold, c.config.OpenTimeout, clk)
+
+	call := func() (*Response, error) {
+		req, err := http.NewRequest(http.MethodGet, p.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c.Do(req)
+	}
+
+	tests := []struct {
+		name        string
+		advance     time.Duration
+		wantErr     error
+		wantStatus  int
+		wantCalls   int
+		wantCircuit circuitState
+	}{
+		{name: "first failure keeps the circuit closed", wantStatus: 500, wantCalls: 1, wantCircuit: stateClosed},
+		{name: "second failure opens the circuit", wantStatus: 500, wantCalls: 2, wantCircuit: stateOpen},
+		{name: "open circuit rejects without calling the provider", wantErr: ErrCircuitOpen, wantCalls: 2, wantCircuit: stateOpen},
+		{name: "still rejected just before the open timeout", advance: 30*time.Second - time.Millisecond, wantErr: ErrCircuitOpen, wantCalls: 2, wantCircuit: stateOpen},
+		{name: "probe after the open timeout succeeds and closes the circuit", advance: time.Millisecond, wantStatus: 200, wantCalls: 3, wantCircuit: stateClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk.Advance(tt.advance)
+			resp, err := call()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := len(p.calls()); got != tt.wantCalls {
+				t.Errorf("provider called %d times, want %d", got, tt.wantCalls)
+			}
+			if got := c.breaker.current(); got != tt.wantCircuit {
+				t.Errorf("circuit = %s, want %s", got, tt.wantCircuit)
+			}
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	fields := DefaultConfig("test").RedactFields
+
+	tests := []struct {
+		name string
+		got  func() string
+		want string
+	}{
+		{
+			name: "query token",
+			got: func() string {
+				u, _ := url.Parse("https://api.ultramsg.com/instance1/messages/chat?token=s3cret&to=628123")
+				return redactURL(u, fields)
+			},
+			want: "https://api.ultramsg.com/instance1/messages/chat?token=[REDACTED]&to=628123",
+		},
+		{
+			name: "query field names are matched case-insensitively",
+			got: func() string {
+				u, _ := url.Parse("https://example.com/status?Access_Token=s3cret")
+				return redactURL(u, fields)
+			},
+			want: "https://example.com/status?Access_Token=[REDACTED]",
+		},
+		{
+			name: "url without a query is unchanged",
+			got: func() string {
+				u, _ := url.Parse("https://example.com/v2/charge")
+				return redactURL(u, fields)
+			},
+			want: "https://example.com/v2/charge",
+		},
+		{
+			name: "form body keeps the other fields as sent",
+			got:  func() string { return redactBody([]byte("token=s3cret&to=628123&body=hi%20there"), fields) },
+			want: "token=[REDACTED]&to=628123&body=hi%20there",
+		},
+		{
+			name: "json body masks nested keys",
+			got: func() string {
+				return redactBody([]byte(`{"message":{"token":"device-s3cret","notification":{"title":"Hi"}},"access_token":"ya29.s\"3cret"}`), fields)
+			},
+			want: `{"message":{"token":"[REDACTED]","notification":{"title":"Hi"}},"access_token":"[REDACTED]"}`,
+		},
+		{
+			name: "plain text is left alone",
+			got:  func() string { return redactBody([]byte("upstream error: token expired"), fields) },
+			want: "upstream error: token expired",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientRedactsTransportErrors(t *testing.T) {
+	p := newProvider(t, 200)
+	p.Close()
+	c := testClient(t, clock.NewFake(startAt))
+
+	req, err := http.NewRequest(http.MethodGet, p.URL+"/messages?token=s3cret", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.Do(req)
+	if err == nil {
+		t.Fatal("Do() error = nil, want a connection error")
+	}
+	if strings.Contains(err.Error(), "s3cret") || !strings.Contains(err.Error(), "token=[REDACTED]") {
+		t.Errorf("error = %q, want the token redacted", err)
+	}
+}
@@ -0,0 +1,171 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// latencyBucketsMs are the upper bounds of the latency histogram, in milliseconds; the
+// last bucket counts everything slower
+var latencyBucketsMs = []int64{50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+// ProviderMetrics is a snapshot of one provider client's counters since startup
+type ProviderMetrics struct {
+	Provider      string          `json:"provider"`
+	Circuit       string          `json:"circuit"`
+	Attempts      int64           `json:"attempts"`       // calls made, retries included
+	Retries       int64           `json:"retries"`        // attempts that were retries
+	Rejected      int64           `json:"rejected"`       // calls refused by the open circuit
+	CircuitOpens  int64           `json:"circuit_opens"`  // times the circuit opened
+	Timeouts      int64           `json:"timeouts"`       // attempts cut off by the attempt timeout
+	NetworkErrors int64           `json:"network_errors"` // attempts without a response
+	ServerErrors  int64           `json:"server_errors"`  // 5xx and 429 responses
+	ClientErrors  int64           `json:"client_errors"`  // other 4xx responses
+	Latency       LatencyMetrics  `json:"latency"`
+	Buckets       []LatencyBucket `json:"latency_buckets"`
+}
+
+// LatencyMetrics summarizes attempt latency
+type LatencyMetrics struct {
+	AvgMs float64 `json:"avg_ms"`
+	MaxMs int64   `json:"max_ms"`
+}
+
+// LatencyBucket counts attempts that took at most LeMs milliseconds and more than the
+// previous bucket; LeMs is 0 for the overflow bucket
+type LatencyBucket struct {
+	LeMs  int64 `json:"le_ms"`
+	Count int64 `json:"count"`
+}
+
+type metrics struct {
+	mu            sync.Mutex
+	attempts      int64
+	retries       int64
+	rejected      int64
+	opens         int64
+	timeouts      int64
+	networkErrors int64
+	serverErrors  int64
+	clientErrors  int64
+	totalLatency  time.Duration
+	maxLatency    time.Duration
+	buckets       []int64
+}
+
+func newMetrics() *metrics {
+	return &metrics{buckets: make([]int64, len(latencyBucketsMs)+1)}
+}
+
+// observe records one attempt
+func (m *metrics) observe(elapsed time.Duration, err error, resp *Response) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.attempts++
+	m.totalLatency += elapsed
+	if elapsed > m.maxLatency {
+		m.maxLatency = elapsed
+	}
+	bucket := len(latencyBucketsMs)
+	for i, le := range latencyBucketsMs {
+		if elapsed.Milliseconds() <= le {
+			bucket = i
+			break
+		}
+	}
+	m.buckets[bucket]++
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		m.timeouts++
+	case err != nil:
+		m.networkErrors++
+	case retryableStatus(resp.StatusCode):
+		m.serverErrors++
+	case resp.StatusCode >= 400:
+		m.clientErrors++
+	}
+}
+
+func (m *metrics) retry() {
+	m.mu.Lock()
+	m.retries++
+	m.mu.Unlock()
+}
+
+func (m *metrics) reject() {
+	m.mu.Lock()
+	m.rejected++
+	m.mu.Unlock()
+}
+
+func (m *metrics) open() {
+	m.mu.Lock()
+	m.opens++
+	m.mu.Unlock()
+}
+
+func (m *metrics) snapshot(provider string, circuit circuitState) ProviderMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := ProviderMetrics{
+		Provider:      provider,
+		Circuit:       string(circuit),
+		Attempts:      m.attempts,
+		Retries:       m.retries,
+		Rejected:      m.rejected,
+		CircuitOpens:  m.opens,
+		Timeouts:      m.timeouts,
+		NetworkErrors: m.networkErrors,
+		ServerErrors:  m.serverErrors,
+		ClientErrors:  m.clientErrors,
+		Latency:       LatencyMetrics{MaxMs: m.maxLatency.Milliseconds()},
+		Buckets:       make([]LatencyBucket, len(m.buckets)),
+	}
+	if m.attempts > 0 {
+		snapshot.Latency.AvgMs = float64(m.totalLatency.Milliseconds()) / float64(m.attempts)
+	}
+	for i, count := range m.buckets {
+		var le int64
+		if i < len(latencyBucketsMs) {
+			le = latencyBucketsMs[i]
+		}
+		snapshot.Buckets[i] = LatencyBucket{LeMs: le, Count: count}
+	}
+	return snapshot
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]*Client{}
+)
+
+// register makes a client's metrics visible to Metrics; a later client with the same
+// name replaces the earlier one
+func register(name string, c *Client) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = c
+}
+
+// Metrics returns a snapshot of every provider client, ordered by provider name
+func Metrics() []ProviderMetrics {
+	registryMu.Lock()
+	clients := make([]*Client, 0, len(registry))
+	for _, c := range registry {
+		clients = append(clients, c)
+	}
+	registryMu.Unlock()
+
+	snapshots := make([]ProviderMetrics, 0, len(clients))
+	for _, c := range clients {
+		snapshots = append(snapshots, c.metrics.snapshot(c.config.Name, c.breaker.current()))
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Provider < snapshots[j].Provider })
+	return snapshots
+}
@@ -0,0 +1,85 @@
+package httpclient
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// redacted replaces secret values in logs and errors
+const redacted = "[REDACTED]"
+
+// maxExcerpt bounds response bodies quoted in logs
+const maxExcerpt = 512
+
+// redactURL renders u with secret query parameters masked
+func redactURL(u *url.URL, fields []string) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+	masked := *u
+	masked.RawQuery = redactForm(u.RawQuery, fields)
+	return masked.String()
+}
+
+// redactBody masks secret fields in a form-encoded or JSON body
+func redactBody(body []byte, fields []string) string {
+	text := string(body)
+	trimmed := strings.TrimSpace(text)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		return redactJSON(text, fields)
+	}
+	if strings.Contains(text, "=") && !strings.ContainsAny(trimmed, " \n<") {
+		return redactForm(text, fields)
+	}
+	return text
+}
+
+// redactForm masks secret fields of a query string or form body, keeping the field
+// order and every other value as sent
+func redactForm(form string, fields []string) string {
+	pairs := strings.Split(form, "&")
+	for i, pair := range pairs {
+		key, _, found := strings.Cut(pair, "=")
+		name, err := url.QueryUnescape(key)
+		if err != nil {
+			name = key
+		}
+		if found && isSecret(name, fields) {
+			pairs[i] = key + "=" + redacted
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// redactJSON masks string values of secret keys anywhere in a JSON document
+func redactJSON(text string, fields []string) string {
+	for _, field := range fields {
+		pattern := regexp.MustCompile(`("` + regexp.QuoteMeta(field) + `"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+		text = pattern.ReplaceAllString(text, `$1"`+redacted+`"`)
+	}
+	return text
+}
+
+func isSecret(name string, fields []string) bool {
+	for _, field := range fields {
+		if strings.EqualFold(name, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// excerpt shortens text for a log line
+func excerpt(text string) string {
+	if len(text) <= maxExcerpt {
+		return text
+	}
+	return text[:maxExcerpt] + "..."
+}
+
+// Redact masks the client's secret fields in a form-encoded or JSON body, for providers
+// that log bodies themselves
+func (c *Client) Redact(body []byte) string {
+	return excerpt(redactBody(body, c.config.RedactFields))
+}
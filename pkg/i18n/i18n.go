@@ -0,0 +1,132 @@
+package i18n
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale is a supported language code
+type Locale string
+
+const (
+	Indonesian Locale = "id"
+	English    Locale = "en"
+
+	// DefaultLocale is used when neither the request nor the user picks a language
+	DefaultLocale = Indonesian
+)
+
+// catalogs holds the messages of every supported locale, keyed by message key
+var catalogs = map[Locale]map[string]string{
+	Indonesian: messagesID,
+	English:    messagesEN,
+}
+
+// Parse returns the supported locale for a language tag such as "en", "en-US" or "id_ID"
+func Parse(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	// "in" is the legacy ISO 639 code for Indonesian still sent by older Android versions
+	if tag == "in" {
+		tag = string(Indonesian)
+	}
+	locale := Locale(tag)
+	if _, ok := catalogs[locale]; !ok {
+		return "", false
+	}
+	return locale, true
+}
+
+// ParseAcceptLanguage picks the best supported locale from an Accept-Language header.
+// ok is false when the header names no supported language.
+func ParseAcceptLanguage(header string) (Locale, bool) {
+	type candidate struct {
+		locale Locale
+		q      float64
+		order  int
+	}
+
+	var candidates []candidate
+	for i, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		locale, ok := Parse(fields[0])
+		if !ok {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if value, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = value
+				}
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{locale: locale, q: q, order: i})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", false
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+	return candidates[0].locale, true
+}
+
+// OrDefault returns the stored locale when it is supported, DefaultLocale otherwise
+func OrDefault(tag string) Locale {
+	if locale, ok := Parse(tag); ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+type contextKey struct{}
+
+// WithLocale stores the locale in the context for services and background jobs
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, contextKey{}, locale)
+}
+
+// FromContext returns the locale stored by WithLocale, or DefaultLocale
+func FromContext(ctx context.Context) Locale {
+	if locale, ok := ctx.Value(contextKey{}).(Locale); ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// T renders a message with {placeholder} variables.
+// Missing translations fall back to DefaultLocale, then to the key itself.
+func T(locale Locale, key string, vars map[string]string) string {
+	message, ok := catalogs[locale][key]
+	if !ok {
+		message, ok = catalogs[DefaultLocale][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(vars) == 0 {
+		return message
+	}
+
+	pairs := make([]string, 0, len(vars)*2)
+	for name, value := range vars {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(message)
+}
+
+// Has reports whether a message key exists in the default catalog
+func Has(key string) bool {
+	_, ok := catalogs[DefaultLocale][key]
+	return ok
+}
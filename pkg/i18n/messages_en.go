@@ -0,0 +1,247 @@
+package i18n
+
+// messagesEN is the English catalog
+var messagesEN = map[string]string{
+	// OTP
+	"otp.sent":              "The OTP code has been sent to your WhatsApp",
+	"otp.verified":          "Verification successful",
+	"otp.invalid":           "Invalid OTP code",
+	"otp.used":              "This OTP code has already been used",
+	"otp.expired":           "This OTP code has expired",
+	"otp.too_many_attempts": "Too many attempts. Please request a new code",
+	"otp.cooldown":          "Please wait {seconds} seconds before requesting a new OTP code",
+	"otp.send_failed":       "Failed to send the OTP code. Please try again.",
+	"otp.resend_failed":     "Failed to resend the OTP code. Please try again.",
+	"otp.verify_failed":     "Failed to verify the OTP code. Please try again.",
+
+	// WhatsApp bodies
+	"whatsapp.otp": "🔐 *Ojek Kampus - OTP Code*\n\n" +
+		"Your OTP code: *{code}*\n\n" +
+		"Valid for {minutes} minutes.\n" +
+		"Never share this code with anyone!",
+	"whatsapp.document_expiring": "⚠️ *Ojek Kampus - Document Expiring*\n\n" +
+		"Your {document} is valid until *{date}* ({days} days left).\n" +
+		"Renew it and contact an admin for re-verification to keep your driver account active.",
+	"whatsapp.document_expired": "⛔ *Ojek Kampus - Document Expired*\n\n" +
+		"Your {document} expired on *{date}*.\n" +
+		"Your driver account is suspended until an admin verifies a renewed document.",
+	"whatsapp.otp_trusted_contact": "🔐 *Ojek Kampus - Trusted Contact*\n\n" +
+		"Someone added this number as a trusted contact on Ojek Kampus.\n" +
+		"If you agree, give them this code: *{code}*\n\n" +
+		"Valid for {minutes} minutes. Ignore this message if you do not recognise them.",
+	"whatsapp.sos_alert": "🚨 *Ojek Kampus - SOS*\n\n" +
+		"*{name}* pressed the emergency button during a ride at {time}.\n\n" +
+		"Passenger: {passenger_name}\n" +
+		"Driver: {driver_name} ({vehicle_plate}, {vehicle})\n" +
+		"Route: {pickup} → {dropoff}\n" +
+		"Last location: {location}\n" +
+		"Note: {note}\n\n" +
+		"Report #{incident_id} has been passed to our team. Please contact {name} or the authorities right away.",
+	"whatsapp.trip_shared": "📍 *Ojek Kampus - Trip Shared*\n\n" +
+		"{name} shared their ride with you.\n\n" +
+		"Driver: {driver_name} ({vehicle_plate}, {vehicle})\n" +
+		"Route: {pickup} → {dropoff}\n" +
+		"Driver position: {location}",
+	"whatsapp.relay_from_driver": "💬 *Ojek Kampus - Message from your driver*\n\n" +
+		"*{name}* (order #{order_id}):\n{body}\n\n" +
+		"Reply to this message to answer. Your number is not shown to the driver.",
+	"whatsapp.relay_from_passenger": "💬 *Ojek Kampus - Message from your passenger*\n\n" +
+		"*{name}* (order #{order_id}):\n{body}\n\n" +
+		"Reply to this message to answer. Your number is not shown to the passenger.",
+	"whatsapp.relay_closed": "ℹ️ *Ojek Kampus*\n\n" +
+		"The trip for order #{order_id} has ended, so your message was not passed on.",
+
+	// Email bodies
+	"email.change.subject": "Ojek Kampus Email Verification",
+	"email.change.body":    "Hi {name},\n\nYour Ojek Kampus email verification code: {code}\n\nOr open this link: {link}\n\nValid for {minutes} minutes.",
+
+	// Push notifications
+	"push.order_accepted.title":            "Driver found",
+	"push.order_accepted.body":             "{driver_name} ({vehicle_plate}) is on the way to your pickup point.",
+	"push.driver_arrived.title":            "Your driver has arrived",
+	"push.driver_arrived.body":             "{driver_name} is waiting at the pickup point.",
+	"push.trip_started.title":              "Trip started",
+	"push.trip_started.body":               "Have a safe ride! Your trip to {destination} has started.",
+	"push.trip_completed.title":            "Trip completed",
+	"push.trip_completed.body":             "Thank you for riding with Ojek Kampus. Total fare: Rp{fare}.",
+	"push.order_cancelled.title":           "Order cancelled",
+	"push.order_cancelled.body":            "Order #{order_id} has been cancelled.",
+	"push.order_expired.title":             "No driver found",
+	"push.order_expired.body":              "Sorry, no driver is available for order #{order_id}. Please try again.",
+	"push.new_order_offer.title":           "New order",
+	"push.new_order_offer.body":            "Pickup at {pickup} ({distance} km). Accept within {timeout} seconds.",
+	"push.driver_verified.title":           "Driver account verified",
+	"push.driver_verified.body":            "Congratulations! Your documents have been approved. You can start accepting orders.",
+	"push.driver_rejected.title":           "Driver verification rejected",
+	"push.driver_rejected.body":            "Your documents could not be approved: {reason}",
+	"push.promotion.title":                 "{title}",
+	"push.promotion.body":                  "{body}",
+	"push.wallet_topped_up.title":          "Wallet topped up",
+	"push.wallet_topped_up.body":           "Your Rp{amount} top-up was successful. Your balance is now Rp{balance}.",
+	"push.document_expiring.title":         "Your {document} expires soon",
+	"push.document_expiring.body":          "Your {document} is valid until {date} ({days} days left). Renew it and contact an admin to keep your driver account active.",
+	"push.document_expired.title":          "Your {document} has expired",
+	"push.document_expired.body":           "Your {document} expired on {date}. Your driver account is suspended until a renewed document is verified.",
+	"push.scheduled_ride_confirmed.title":  "Scheduled ride confirmed",
+	"push.scheduled_ride_confirmed.body":   "{driver_name} ({vehicle_plate}) will pick you up at {time}.",
+	"push.scheduled_driver_withdrew.title": "Your driver can't make it",
+	"push.scheduled_driver_withdrew.body":  "The driver for your {time} ride withdrew. We will find you another driver.",
+	"push.scheduled_ride_reminder.title":   "Ride reminder",
+	"push.scheduled_ride_reminder.body":    "Your ride from {pickup} is booked for {time}. Please be at the pickup point on time.",
+	"push.scheduled_job_reminder.title":    "Upcoming pickup",
+	"push.scheduled_job_reminder.body":     "You have a pickup at {pickup} at {time}. Make sure you are online and nearby.",
+	"push.scheduled_job_starting.title":    "Time to pick up",
+	"push.scheduled_job_starting.body":     "Head to {pickup} now, your passenger is expecting you at {time}.",
+	"push.scheduled_job_released.title":    "Scheduled ride reassigned",
+	"push.scheduled_job_released.body":     "Your {time} ride went to another driver because you were not available at dispatch time.",
+	"push.driver_cancelled.title":          "Your driver cancelled",
+	"push.driver_cancelled.body":           "The driver for order #{order_id} cancelled. We are finding you another driver.",
+	"push.passenger_no_show.title":         "Missed pickup",
+	"push.passenger_no_show.body":          "Order #{order_id} was cancelled because you did not come to the pickup point. A cancellation fee of Rp{fee} was charged.",
+	"push.ordering_cooldown.title":         "Ordering paused",
+	"push.ordering_cooldown.body":          "You cancelled {cancelled} of your last {orders} rides. You can order again after {until}.",
+	"push.driving_cooldown.title":          "Driving paused",
+	"push.driving_cooldown.body":           "You cancelled {cancelled} of your last {orders} jobs. You can go online again after {until}.",
+	"push.sos_acknowledged.title":          "Help is on the way",
+	"push.sos_acknowledged.body":           "Our team is handling your emergency report for order #{order_id}.",
+
+	// Chat quick replies
+	"chat.quick_reply.on_my_way":        "I'm on my way",
+	"chat.quick_reply.at_pickup":        "I'm at the pickup point",
+	"chat.quick_reply.wait_a_moment":    "Please wait a moment",
+	"chat.quick_reply.where_are_you":    "Where are you?",
+	"chat.quick_reply.ok_thanks":        "OK, thanks",
+	"chat.quick_reply.arrived":          "I've arrived at the pickup point",
+	"chat.quick_reply.stuck_in_traffic": "Sorry, I'm stuck in traffic",
+
+	// API errors
+	"error.internal":                     "Something went wrong on our side. Please try again.",
+	"error.invalid_request":              "Invalid request",
+	"error.validation":                   "Invalid input data",
+	"error.route_not_found":              "Endpoint not found",
+	"error.method_not_allowed":           "Method not allowed",
+	"error.request_too_large":            "Request body is too large",
+	"error.rate_limited":                 "Too many requests. Please try again later.",
+	"error.unauthorized":                 "You need to log in first",
+	"error.missing_token":                "Missing authorization token",
+	"error.invalid_auth_format":          "Invalid authorization format",
+	"error.invalid_token":                "Invalid or expired token",
+	"error.forbidden":                    "You do not have permission to do this",
+	"error.password_too_short":           "Password must be at least {min} characters",
+	"error.password_too_weak":            "Password must contain both letters and numbers",
+	"error.missing_file":                 "The {field} file is required",
+	"error.job_not_found":                "Dead job not found",
+	"error.order_not_found":              "Order not found",
+	"error.active_order_exists":          "You already have an order in progress",
+	"error.invalid_trip_route":           "Pickup and destination are too close",
+	"error.offer_not_found":              "Order offer not found",
+	"error.offer_closed":                 "This order offer is no longer available",
+	"error.driver_busy":                  "Finish your current order before accepting another one",
+	"error.driver_location_required":     "Send your current location before going online",
+	"error.invalid_topic":                "Invalid realtime topic",
+	"error.invalid_order_status":         "The order cannot be updated at this stage",
+	"error.trip_route_not_found":         "No route was recorded for this trip",
+	"error.order_not_completed":          "Only completed trips can be rated",
+	"error.already_rated":                "You have already rated this trip",
+	"error.rating_window_closed":         "The rating period for this trip has ended",
+	"error.invalid_rating_tag":           "Invalid rating tag: {tag}",
+	"error.driver_not_flagged":           "Driver is not flagged for review",
+	"error.insufficient_balance":         "Your balance is not enough",
+	"error.wallet_unavailable":           "Wallets are only available to passengers and drivers",
+	"error.invalid_topup_target":         "Only passenger wallets can be topped up",
+	"error.payout_not_found":             "Payout request not found",
+	"error.payout_closed":                "This payout request has already been reviewed",
+	"error.payment_charge_not_found":     "Payment charge not found",
+	"error.payment_provider_failed":      "The payment service is unavailable. Please try again.",
+	"error.invalid_payment_signature":    "Invalid payment notification signature",
+	"error.payment_amount_mismatch":      "The paid amount does not match the charge",
+	"error.promo_not_found":              "Promo code not found",
+	"error.promo_not_active":             "This promo is not currently valid",
+	"error.promo_not_eligible":           "You are not eligible for this promo",
+	"error.promo_min_fare":               "This promo requires a minimum fare of Rp{min_fare}",
+	"error.promo_exhausted":              "This promo has run out",
+	"error.promo_user_limit":             "You have already used this promo",
+	"error.promo_code_exists":            "Promo code already exists",
+	"error.invalid_promo_rules":          "Invalid promo rules: {reason}",
+	"error.phone_already_registered":     "Phone number is already registered",
+	"error.email_already_registered":     "Email is already registered",
+	"error.invalid_credentials":          "Invalid phone number or password",
+	"error.account_suspended":            "Your account is suspended",
+	"error.invalid_refresh_token":        "Invalid refresh token",
+	"error.token_revoked":                "Token has been revoked",
+	"error.token_expired":                "Token has expired",
+	"error.user_not_found":               "User not found",
+	"error.vehicle_plate_exists":         "Vehicle plate is already registered",
+	"error.invalid_file_type":            "Invalid file type: {type} (allowed: {allowed})",
+	"error.file_too_large":               "File size exceeds the maximum limit ({max} MB)",
+	"error.upload_failed":                "Failed to upload file",
+	"error.driver_not_verified":          "Your driver account is not verified yet",
+	"error.invalid_document_type":        "Invalid document type",
+	"error.invalid_filename":             "Invalid filename",
+	"error.document_not_found":           "Document not found",
+	"error.unauthorized_access":          "You do not have access to this document",
+	"error.driver_profile_not_found":     "Driver profile not found",
+	"error.no_profile_changes":           "No profile changes provided",
+	"error.stnk_required":                "A new STNK document is required when changing the vehicle plate",
+	"error.passenger_profile_not_found":  "Passenger profile not found",
+	"error.email_unchanged":              "New email is the same as the current email",
+	"error.no_pending_email_change":      "No pending email change",
+	"error.invalid_verification_code":    "Invalid verification code",
+	"error.verification_code_expired":    "Verification code has expired",
+	"error.too_many_attempts":            "Too many attempts. Please request a new code",
+	"error.email_required":               "Add an email to your account first",
+	"error.email_already_verified":       "Your email is already verified",
+	"error.invalid_verification_link":    "The verification link is invalid or has expired",
+	"error.campus_verification_required": "Verify your campus email first",
+	"error.campus_email_required":        "Use a registered campus email",
+	"error.ktm_required":                 "Upload a valid student ID card (KTM)",
+	"error.campus_domain_exists":         "Campus domain is already registered",
+	"error.campus_domain_not_found":      "Campus domain not found",
+	"error.invalid_campus_domain":        "Invalid campus domain: {pattern}",
+	"error.document_expiry_required":     "{document} expiry date is required to approve the driver",
+	"error.invalid_document_expiry":      "Invalid {document} expiry date",
+	"error.rejection_reason_required":    "Rejection reason is required",
+	"error.outside_service_area":         "Pickup or destination must be inside the campus service area",
+	"error.no_go_zone":                   "Pickups and dropoffs are not available in {zone}",
+	"error.zone_not_found":               "Zone not found",
+	"error.invalid_zone_geometry":        "Invalid zone geometry: {reason}",
+	"error.zone_surcharge_not_allowed":   "Only pricing zones can have a surcharge",
+	"error.pickup_point_not_found":       "Pickup point not found",
+	"error.pickup_too_soon":              "Pickup must be at least {minutes} minutes from now",
+	"error.pickup_too_far":               "Pickup can be booked at most {days} days ahead",
+	"error.schedule_limit_reached":       "You already have {max} scheduled rides",
+	"error.schedule_conflict":            "Another scheduled ride is too close to this time",
+	"error.scheduled_ride_taken":         "This ride already has a driver",
+	"error.scheduled_ride_not_found":     "Scheduled ride not found",
+	"error.invalid_cancel_reason":        "Invalid cancellation reason",
+	"error.cancel_note_required":         "Please describe why you are cancelling",
+	"error.no_show_too_early":            "Wait at least {minutes} minutes at the pickup point before reporting a no-show",
+	"error.ordering_cooldown":            "You have cancelled too often. You can order again after {until}",
+	"error.driving_cooldown":             "You have cancelled too often. You can go online again after {until}",
+	"error.outstanding_cancellation_fee": "Top up to pay your Rp{amount} in cancellation fees before ordering",
+	"error.cooldown_not_found":           "User has no active cooldown",
+	"error.trusted_contact_not_found":    "Trusted contact not found",
+	"error.trusted_contact_limit":        "You can save up to {max} trusted contacts",
+	"error.trusted_contact_exists":       "This number is already one of your trusted contacts",
+	"error.trusted_contact_self":         "You cannot add your own number as a trusted contact",
+	"error.trusted_contact_verified":     "This trusted contact is already verified",
+	"error.no_trusted_contacts":          "Add and verify a trusted contact first",
+	"error.trip_not_in_progress":         "This is only available while a driver is assigned to the order",
+	"error.incident_not_found":           "Incident not found",
+	"error.invalid_incident_status":      "The incident cannot be updated at this stage",
+	"error.tracking_link_not_found":      "Tracking link not found",
+	"error.tracking_link_expired":        "This trip has ended or the link has expired",
+	"error.tracking_link_limit":          "You can have up to {max} active tracking links for an order",
+	"error.chat_closed":                  "Chat is only open while a driver is assigned to the order",
+	"error.invalid_chat_message":         "Send either a message or a quick reply",
+	"error.invalid_quick_reply":          "Invalid quick reply",
+	"error.invalid_webhook_token":        "Invalid webhook token",
+
+	// Validation messages ({field} is the request field name)
+	"validation.required": "{field} is required",
+	"validation.email":    "{field} must be a valid email",
+	"validation.min":      "{field} is too short",
+	"validation.max":      "{field} is too long",
+	"validation.oneof":    "{field} must be one of: {param}",
+	"validation.invalid":  "{field} is invalid",
+}
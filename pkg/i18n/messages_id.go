@@ -0,0 +1,247 @@
+package i18n
+
+// messagesID is the Indonesian catalog (default locale)
+var messagesID = map[string]string{
+	// OTP
+	"otp.sent":              "Kode OTP telah dikirim ke WhatsApp Anda",
+	"otp.verified":          "Verifikasi berhasil",
+	"otp.invalid":           "Kode OTP tidak valid",
+	"otp.used":              "Kode OTP sudah pernah digunakan",
+	"otp.expired":           "Kode OTP telah kadaluarsa",
+	"otp.too_many_attempts": "Terlalu banyak percobaan. Silakan minta kode baru",
+	"otp.cooldown":          "Silakan tunggu {seconds} detik sebelum meminta kode OTP baru",
+	"otp.send_failed":       "Gagal mengirim kode OTP. Silakan coba lagi.",
+	"otp.resend_failed":     "Gagal mengirim ulang kode OTP. Silakan coba lagi.",
+	"otp.verify_failed":     "Gagal memverifikasi kode OTP. Silakan coba lagi.",
+
+	// WhatsApp bodies
+	"whatsapp.otp": "🔐 *Ojek Kampus - Kode OTP*\n\n" +
+		"Kode OTP Anda: *{code}*\n\n" +
+		"Berlaku selama {minutes} menit.\n" +
+		"Jangan bagikan kode ini kepada siapapun!",
+	"whatsapp.document_expiring": "⚠️ *Ojek Kampus - Dokumen Segera Kedaluwarsa*\n\n" +
+		"{document} Anda berlaku hingga *{date}* ({days} hari lagi).\n" +
+		"Perbarui dokumen dan hubungi admin untuk verifikasi ulang agar akun driver tetap aktif.",
+	"whatsapp.document_expired": "⛔ *Ojek Kampus - Dokumen Kedaluwarsa*\n\n" +
+		"{document} Anda habis masa berlakunya pada *{date}*.\n" +
+		"Akun driver Anda dinonaktifkan sampai dokumen baru diverifikasi admin.",
+	"whatsapp.otp_trusted_contact": "🔐 *Ojek Kampus - Kontak Darurat*\n\n" +
+		"Seseorang menambahkan nomor ini sebagai kontak darurat di Ojek Kampus.\n" +
+		"Jika Anda bersedia, berikan kode ini kepadanya: *{code}*\n\n" +
+		"Berlaku selama {minutes} menit. Abaikan pesan ini jika Anda tidak mengenalinya.",
+	"whatsapp.sos_alert": "🚨 *Ojek Kampus - SOS*\n\n" +
+		"*{name}* menekan tombol darurat saat perjalanan pada {time}.\n\n" +
+		"Penumpang: {passenger_name}\n" +
+		"Driver: {driver_name} ({vehicle_plate}, {vehicle})\n" +
+		"Rute: {pickup} → {dropoff}\n" +
+		"Lokasi terakhir: {location}\n" +
+		"Catatan: {note}\n\n" +
+		"Laporan #{incident_id} telah diteruskan ke tim kami. Segera hubungi {name} atau pihak berwenang.",
+	"whatsapp.trip_shared": "📍 *Ojek Kampus - Perjalanan Dibagikan*\n\n" +
+		"{name} membagikan perjalanannya dengan Anda.\n\n" +
+		"Driver: {driver_name} ({vehicle_plate}, {vehicle})\n" +
+		"Rute: {pickup} → {dropoff}\n" +
+		"Posisi driver: {location}",
+	"whatsapp.relay_from_driver": "💬 *Ojek Kampus - Pesan dari Driver*\n\n" +
+		"*{name}* (pesanan #{order_id}):\n{body}\n\n" +
+		"Balas pesan ini untuk menjawab. Nomor Anda tidak ditampilkan kepada driver.",
+	"whatsapp.relay_from_passenger": "💬 *Ojek Kampus - Pesan dari Penumpang*\n\n" +
+		"*{name}* (pesanan #{order_id}):\n{body}\n\n" +
+		"Balas pesan ini untuk menjawab. Nomor Anda tidak ditampilkan kepada penumpang.",
+	"whatsapp.relay_closed": "ℹ️ *Ojek Kampus*\n\n" +
+		"Perjalanan untuk pesanan #{order_id} sudah berakhir, jadi pesan Anda tidak diteruskan.",
+
+	// Email bodies
+	"email.change.subject": "Verifikasi Email Ojek Kampus",
+	"email.change.body":    "Halo {name},\n\nKode verifikasi email Ojek Kampus Anda: {code}\n\nAtau buka tautan ini: {link}\n\nBerlaku selama {minutes} menit.",
+
+	// Push notifications
+	"push.order_accepted.title":            "Driver ditemukan",
+	"push.order_accepted.body":             "{driver_name} ({vehicle_plate}) sedang menuju lokasi jemput Anda.",
+	"push.driver_arrived.title":            "Driver sudah tiba",
+	"push.driver_arrived.body":             "{driver_name} sudah menunggu di titik jemput.",
+	"push.trip_started.title":              "Perjalanan dimulai",
+	"push.trip_started.body":               "Selamat jalan! Perjalanan Anda menuju {destination} telah dimulai.",
+	"push.trip_completed.title":            "Perjalanan selesai",
+	"push.trip_completed.body":             "Terima kasih telah menggunakan Ojek Kampus. Total tarif: Rp{fare}.",
+	"push.order_cancelled.title":           "Pesanan dibatalkan",
+	"push.order_cancelled.body":            "Pesanan #{order_id} telah dibatalkan.",
+	"push.order_expired.title":             "Driver tidak ditemukan",
+	"push.order_expired.body":              "Maaf, belum ada driver yang tersedia untuk pesanan #{order_id}. Silakan coba lagi.",
+	"push.new_order_offer.title":           "Pesanan baru",
+	"push.new_order_offer.body":            "Penjemputan di {pickup} ({distance} km). Terima dalam {timeout} detik.",
+	"push.driver_verified.title":           "Akun driver terverifikasi",
+	"push.driver_verified.body":            "Selamat! Dokumen Anda telah disetujui. Anda sudah bisa mulai menerima pesanan.",
+	"push.driver_rejected.title":           "Verifikasi driver ditolak",
+	"push.driver_rejected.body":            "Dokumen Anda belum dapat disetujui: {reason}",
+	"push.promotion.title":                 "{title}",
+	"push.promotion.body":                  "{body}",
+	"push.wallet_topped_up.title":          "Saldo berhasil diisi",
+	"push.wallet_topped_up.body":           "Top-up Rp{amount} berhasil. Saldo Anda sekarang Rp{balance}.",
+	"push.document_expiring.title":         "{document} Anda segera habis masa berlakunya",
+	"push.document_expiring.body":          "{document} Anda berlaku hingga {date} ({days} hari lagi). Perbarui dan hubungi admin agar akun driver tetap aktif.",
+	"push.document_expired.title":          "{document} Anda sudah habis masa berlakunya",
+	"push.document_expired.body":           "{document} Anda habis masa berlakunya pada {date}. Akun driver dinonaktifkan sampai dokumen baru diverifikasi.",
+	"push.scheduled_ride_confirmed.title":  "Perjalanan terjadwal dikonfirmasi",
+	"push.scheduled_ride_confirmed.body":   "{driver_name} ({vehicle_plate}) akan menjemput Anda pada {time}.",
+	"push.scheduled_driver_withdrew.title": "Driver berhalangan",
+	"push.scheduled_driver_withdrew.body":  "Driver untuk perjalanan {time} membatalkan. Kami akan mencarikan driver lain.",
+	"push.scheduled_ride_reminder.title":   "Pengingat perjalanan",
+	"push.scheduled_ride_reminder.body":    "Perjalanan Anda dari {pickup} dijadwalkan pada {time}. Pastikan Anda sudah di titik jemput tepat waktu.",
+	"push.scheduled_job_reminder.title":    "Jadwal jemput",
+	"push.scheduled_job_reminder.body":     "Anda menjemput penumpang di {pickup} pada {time}. Pastikan Anda online dan berada di sekitar lokasi.",
+	"push.scheduled_job_starting.title":    "Saatnya menjemput",
+	"push.scheduled_job_starting.body":     "Menuju {pickup} sekarang, penumpang menunggu pada {time}.",
+	"push.scheduled_job_released.title":    "Perjalanan terjadwal dialihkan",
+	"push.scheduled_job_released.body":     "Perjalanan pukul {time} dialihkan ke driver lain karena Anda tidak tersedia saat waktu penjemputan.",
+	"push.driver_cancelled.title":          "Driver membatalkan pesanan",
+	"push.driver_cancelled.body":           "Driver untuk pesanan #{order_id} membatalkan. Kami sedang mencarikan driver lain.",
+	"push.passenger_no_show.title":         "Penjemputan terlewat",
+	"push.passenger_no_show.body":          "Pesanan #{order_id} dibatalkan karena Anda tidak datang ke titik jemput. Biaya pembatalan Rp{fee} dikenakan.",
+	"push.ordering_cooldown.title":         "Pemesanan dijeda sementara",
+	"push.ordering_cooldown.body":          "Anda membatalkan {cancelled} dari {orders} perjalanan terakhir. Anda dapat memesan lagi setelah {until}.",
+	"push.driving_cooldown.title":          "Akun driver dijeda sementara",
+	"push.driving_cooldown.body":           "Anda membatalkan {cancelled} dari {orders} pesanan terakhir. Anda dapat online lagi setelah {until}.",
+	"push.sos_acknowledged.title":          "Bantuan sedang diproses",
+	"push.sos_acknowledged.body":           "Tim kami sedang menangani laporan darurat Anda untuk pesanan #{order_id}.",
+
+	// Chat quick replies
+	"chat.quick_reply.on_my_way":        "Saya dalam perjalanan",
+	"chat.quick_reply.at_pickup":        "Saya sudah di titik jemput",
+	"chat.quick_reply.wait_a_moment":    "Mohon tunggu sebentar",
+	"chat.quick_reply.where_are_you":    "Posisi Anda di mana?",
+	"chat.quick_reply.ok_thanks":        "Oke, terima kasih",
+	"chat.quick_reply.arrived":          "Saya sudah sampai di titik jemput",
+	"chat.quick_reply.stuck_in_traffic": "Maaf, saya terjebak macet",
+
+	// API errors
+	"error.internal":                     "Terjadi kesalahan pada server. Silakan coba lagi.",
+	"error.invalid_request":              "Permintaan tidak valid",
+	"error.validation":                   "Data yang dikirim tidak valid",
+	"error.route_not_found":              "Endpoint tidak ditemukan",
+	"error.method_not_allowed":           "Metode tidak diizinkan",
+	"error.request_too_large":            "Ukuran permintaan terlalu besar",
+	"error.rate_limited":                 "Terlalu banyak permintaan. Silakan coba lagi nanti.",
+	"error.unauthorized":                 "Anda harus login terlebih dahulu",
+	"error.missing_token":                "Token otorisasi tidak ditemukan",
+	"error.invalid_auth_format":          "Format otorisasi tidak valid",
+	"error.invalid_token":                "Token tidak valid atau sudah kadaluarsa",
+	"error.forbidden":                    "Anda tidak memiliki izin untuk aksi ini",
+	"error.password_too_short":           "Kata sandi minimal {min} karakter",
+	"error.password_too_weak":            "Kata sandi harus mengandung huruf dan angka",
+	"error.missing_file":                 "File {field} wajib diunggah",
+	"error.job_not_found":                "Job gagal tidak ditemukan",
+	"error.order_not_found":              "Pesanan tidak ditemukan",
+	"error.active_order_exists":          "Anda masih memiliki pesanan yang sedang berjalan",
+	"error.invalid_trip_route":           "Titik jemput dan tujuan terlalu dekat",
+	"error.offer_not_found":              "Tawaran pesanan tidak ditemukan",
+	"error.offer_closed":                 "Tawaran pesanan sudah tidak berlaku",
+	"error.driver_busy":                  "Selesaikan pesanan Anda saat ini sebelum menerima pesanan lain",
+	"error.driver_location_required":     "Kirim lokasi terbaru Anda sebelum mulai menerima pesanan",
+	"error.invalid_topic":                "Topik realtime tidak valid",
+	"error.invalid_order_status":         "Pesanan tidak dapat diperbarui pada tahap ini",
+	"error.trip_route_not_found":         "Rute perjalanan ini tidak terekam",
+	"error.order_not_completed":          "Hanya perjalanan yang sudah selesai yang dapat dinilai",
+	"error.already_rated":                "Anda sudah memberi penilaian untuk perjalanan ini",
+	"error.rating_window_closed":         "Batas waktu penilaian perjalanan ini sudah berakhir",
+	"error.invalid_rating_tag":           "Tag penilaian tidak valid: {tag}",
+	"error.driver_not_flagged":           "Driver tidak sedang ditandai untuk ditinjau",
+	"error.insufficient_balance":         "Saldo Anda tidak mencukupi",
+	"error.wallet_unavailable":           "Dompet hanya tersedia untuk penumpang dan driver",
+	"error.invalid_topup_target":         "Hanya dompet penumpang yang dapat diisi saldo",
+	"error.payout_not_found":             "Permintaan pencairan tidak ditemukan",
+	"error.payout_closed":                "Permintaan pencairan ini sudah ditinjau",
+	"error.payment_charge_not_found":     "Tagihan pembayaran tidak ditemukan",
+	"error.payment_provider_failed":      "Layanan pembayaran sedang tidak tersedia. Silakan coba lagi.",
+	"error.invalid_payment_signature":    "Tanda tangan notifikasi pembayaran tidak valid",
+	"error.payment_amount_mismatch":      "Jumlah pembayaran tidak sesuai dengan tagihan",
+	"error.promo_not_found":              "Kode promo tidak ditemukan",
+	"error.promo_not_active":             "Promo ini sedang tidak berlaku",
+	"error.promo_not_eligible":           "Anda tidak memenuhi syarat untuk promo ini",
+	"error.promo_min_fare":               "Promo ini berlaku untuk tarif minimal Rp{min_fare}",
+	"error.promo_exhausted":              "Kuota promo ini sudah habis",
+	"error.promo_user_limit":             "Anda sudah menggunakan promo ini",
+	"error.promo_code_exists":            "Kode promo sudah digunakan",
+	"error.invalid_promo_rules":          "Aturan promo tidak valid: {reason}",
+	"error.phone_already_registered":     "Nomor telepon sudah terdaftar",
+	"error.email_already_registered":     "Email sudah terdaftar",
+	"error.invalid_credentials":          "Nomor telepon atau kata sandi salah",
+	"error.account_suspended":            "Akun Anda sedang ditangguhkan",
+	"error.invalid_refresh_token":        "Refresh token tidak valid",
+	"error.token_revoked":                "Token telah dicabut",
+	"error.token_expired":                "Token telah kadaluarsa",
+	"error.user_not_found":               "Pengguna tidak ditemukan",
+	"error.vehicle_plate_exists":         "Plat nomor kendaraan sudah terdaftar",
+	"error.invalid_file_type":            "Tipe file tidak valid: {type} (diperbolehkan: {allowed})",
+	"error.file_too_large":               "Ukuran file melebihi batas maksimum ({max} MB)",
+	"error.upload_failed":                "Gagal mengunggah file",
+	"error.driver_not_verified":          "Akun driver Anda belum terverifikasi",
+	"error.invalid_document_type":        "Jenis dokumen tidak valid",
+	"error.invalid_filename":             "Nama file tidak valid",
+	"error.document_not_found":           "Dokumen tidak ditemukan",
+	"error.unauthorized_access":          "Anda tidak memiliki akses ke dokumen ini",
+	"error.driver_profile_not_found":     "Profil driver tidak ditemukan",
+	"error.no_profile_changes":           "Tidak ada perubahan profil",
+	"error.stnk_required":                "Dokumen STNK baru wajib diunggah saat mengganti plat nomor",
+	"error.passenger_profile_not_found":  "Profil penumpang tidak ditemukan",
+	"error.email_unchanged":              "Email baru sama dengan email saat ini",
+	"error.no_pending_email_change":      "Tidak ada perubahan email yang menunggu verifikasi",
+	"error.invalid_verification_code":    "Kode verifikasi tidak valid",
+	"error.verification_code_expired":    "Kode verifikasi telah kadaluarsa",
+	"error.too_many_attempts":            "Terlalu banyak percobaan. Silakan minta kode baru",
+	"error.email_required":               "Tambahkan email ke akun Anda terlebih dahulu",
+	"error.email_already_verified":       "Email Anda sudah terverifikasi",
+	"error.invalid_verification_link":    "Tautan verifikasi tidak valid atau sudah kadaluarsa",
+	"error.campus_verification_required": "Verifikasi email kampus Anda terlebih dahulu",
+	"error.campus_email_required":        "Gunakan email kampus yang terdaftar",
+	"error.ktm_required":                 "Unggah Kartu Tanda Mahasiswa (KTM) yang masih berlaku",
+	"error.campus_domain_exists":         "Domain kampus sudah terdaftar",
+	"error.campus_domain_not_found":      "Domain kampus tidak ditemukan",
+	"error.invalid_campus_domain":        "Domain kampus tidak valid: {pattern}",
+	"error.document_expiry_required":     "Tanggal kedaluwarsa {document} wajib diisi untuk menyetujui driver",
+	"error.invalid_document_expiry":      "Tanggal kedaluwarsa {document} tidak valid",
+	"error.rejection_reason_required":    "Alasan penolakan wajib diisi",
+	"error.outside_service_area":         "Titik jemput atau tujuan harus berada di area layanan kampus",
+	"error.no_go_zone":                   "Penjemputan dan pengantaran tidak tersedia di {zone}",
+	"error.zone_not_found":               "Zona tidak ditemukan",
+	"error.invalid_zone_geometry":        "Geometri zona tidak valid: {reason}",
+	"error.zone_surcharge_not_allowed":   "Biaya tambahan hanya untuk zona tarif",
+	"error.pickup_point_not_found":       "Titik jemput tidak ditemukan",
+	"error.pickup_too_soon":              "Waktu jemput minimal {minutes} menit dari sekarang",
+	"error.pickup_too_far":               "Waktu jemput maksimal {days} hari dari sekarang",
+	"error.schedule_limit_reached":       "Anda sudah memiliki {max} perjalanan terjadwal",
+	"error.schedule_conflict":            "Ada perjalanan terjadwal lain yang terlalu dekat dengan waktu ini",
+	"error.scheduled_ride_taken":         "Perjalanan ini sudah diambil driver lain",
+	"error.scheduled_ride_not_found":     "Perjalanan terjadwal tidak ditemukan",
+	"error.invalid_cancel_reason":        "Alasan pembatalan tidak valid",
+	"error.cancel_note_required":         "Mohon jelaskan alasan pembatalan",
+	"error.no_show_too_early":            "Tunggu penumpang setidaknya {minutes} menit di titik jemput sebelum melaporkan tidak datang",
+	"error.ordering_cooldown":            "Anda terlalu sering membatalkan. Anda dapat memesan lagi setelah {until}",
+	"error.driving_cooldown":             "Anda terlalu sering membatalkan. Anda dapat online lagi setelah {until}",
+	"error.outstanding_cancellation_fee": "Lunasi biaya pembatalan Rp{amount} dengan top-up sebelum memesan",
+	"error.cooldown_not_found":           "Pengguna tidak sedang dibatasi",
+	"error.trusted_contact_not_found":    "Kontak darurat tidak ditemukan",
+	"error.trusted_contact_limit":        "Anda hanya dapat menyimpan hingga {max} kontak darurat",
+	"error.trusted_contact_exists":       "Nomor ini sudah menjadi kontak darurat Anda",
+	"error.trusted_contact_self":         "Anda tidak dapat menambahkan nomor Anda sendiri sebagai kontak darurat",
+	"error.trusted_contact_verified":     "Kontak darurat ini sudah terverifikasi",
+	"error.no_trusted_contacts":          "Tambahkan dan verifikasi kontak darurat terlebih dahulu",
+	"error.trip_not_in_progress":         "Fitur ini hanya tersedia selama pesanan memiliki driver",
+	"error.incident_not_found":           "Laporan darurat tidak ditemukan",
+	"error.invalid_incident_status":      "Laporan darurat tidak dapat diperbarui pada tahap ini",
+	"error.tracking_link_not_found":      "Tautan pelacakan tidak ditemukan",
+	"error.tracking_link_expired":        "Perjalanan sudah selesai atau tautan sudah tidak berlaku",
+	"error.tracking_link_limit":          "Maksimal {max} tautan pelacakan aktif untuk satu pesanan",
+	"error.chat_closed":                  "Chat hanya tersedia selama pesanan memiliki driver",
+	"error.invalid_chat_message":         "Kirim pesan atau balasan cepat, salah satu saja",
+	"error.invalid_quick_reply":          "Balasan cepat tidak valid",
+	"error.invalid_webhook_token":        "Token webhook tidak valid",
+
+	// Validation messages ({field} is the request field name)
+	"validation.required": "{field} wajib diisi",
+	"validation.email":    "{field} harus berupa email yang valid",
+	"validation.min":      "{field} terlalu pendek",
+	"validation.max":      "{field} terlalu panjang",
+	"validation.oneof":    "{field} harus salah satu dari: {param}",
+	"validation.invalid":  "{field} tidak valid",
+}
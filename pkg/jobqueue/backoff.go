@@ -0,0 +1,30 @@
+package jobqueue
+
+import (
+	"math/rand"
+	"time"
+)
+
+const (
+	baseBackoff = 5 * time.Second
+	maxBackoff  = 1 * time.Hour
+)
+
+// Backoff returns the delay before the next attempt: exponential (5s, 10s, 20s, ...)
+// capped at one hour, with up to 20% random jitter so retries don't stampede
+func Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := maxBackoff
+	if attempt < 20 {
+		delay = baseBackoff << (attempt - 1)
+		if delay > maxBackoff {
+			delay = maxBackoff
+		}
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(delay)/5 + 1))
+	return delay + jitter
+}
@@ -0,0 +1,139 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrJobNotFound is returned by Retry when no dead job has the given id
+var ErrJobNotFound = errors.New("dead job not found")
+
+const jobColumns = `id, type, payload, status, attempts, max_attempts, run_at, locked_at, locked_by,
+	last_error, idempotency_key, created_at, updated_at, completed_at`
+
+// Enqueuer queues jobs. Services that only queue work depend on it instead of calling
+// Enqueue, so tests can record the jobs without a database.
+type Enqueuer interface {
+	Enqueue(ctx context.Context, db database.DBTX, job NewJob) (int64, error)
+}
+
+// PostgresEnqueuer is the Enqueuer backed by the jobs table
+type PostgresEnqueuer struct{}
+
+// Enqueue calls the package-level Enqueue
+func (PostgresEnqueuer) Enqueue(ctx context.Context, db database.DBTX, job NewJob) (int64, error) {
+	return Enqueue(ctx, db, job)
+}
+
+// Enqueue inserts a job. Pass a pgx.Tx as db to enqueue atomically with domain changes.
+// When a job with the same idempotency key already exists, its id is returned and nothing is inserted.
+//
+// run_at, locked_at and completed_at are TIMESTAMP columns without a zone, so the queue always
+// writes and compares them with the app's time, never the database's NOW(); mixing the two would
+// shift every delayed job by the difference between the app's and the database session's time zone.
+func Enqueue(ctx context.Context, db database.DBTX, job NewJob) (int64, error) {
+	payload, err := json.Marshal(job.Payload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode job payload: %w", err)
+	}
+
+	maxAttempts := job.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+
+	var idempotencyKey *string
+	if job.IdempotencyKey != "" {
+		idempotencyKey = &job.IdempotencyKey
+	}
+
+	runAt := job.RunAt
+	if runAt.IsZero() {
+		runAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO jobs (type, payload, max_attempts, run_at, idempotency_key)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (idempotency_key) DO NOTHING
+		RETURNING id
+	`
+
+	var id int64
+	err = db.QueryRow(ctx, query, job.Type, payload, maxAttempts, runAt, idempotencyKey).Scan(&id)
+	if err == pgx.ErrNoRows && idempotencyKey != nil {
+		// Duplicate: return the existing job
+		err = db.QueryRow(ctx, `SELECT id FROM jobs WHERE idempotency_key = $1`, *idempotencyKey).Scan(&id)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue job %s: %w", job.Type, err)
+	}
+	return id, nil
+}
+
+// ListDead returns dead-lettered jobs, newest first
+func ListDead(ctx context.Context, db database.DBTX, limit, offset int) ([]*Job, error) {
+	query := `
+		SELECT ` + jobColumns + `
+		FROM jobs
+		WHERE status = 'DEAD'
+		ORDER BY updated_at DESC
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := db.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanJobs(rows)
+}
+
+// Retry moves a dead-lettered job back to the queue with a fresh attempt budget
+func Retry(ctx context.Context, db database.DBTX, id int64) error {
+	query := `
+		UPDATE jobs
+		SET status = 'PENDING', attempts = 0, run_at = $2, locked_at = NULL, locked_by = NULL, updated_at = NOW()
+		WHERE id = $1 AND status = 'DEAD'
+	`
+	result, err := db.Exec(ctx, query, id, time.Now())
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrJobNotFound
+	}
+	return nil
+}
+
+func scanJobs(rows pgx.Rows) ([]*Job, error) {
+	jobs := []*Job{}
+	for rows.Next() {
+		var job Job
+		if err := rows.Scan(
+			&job.ID,
+			&job.Type,
+			&job.Payload,
+			&job.Status,
+			&job.Attempts,
+			&job.MaxAttempts,
+			&job.RunAt,
+			&job.LockedAt,
+			&job.LockedBy,
+			&job.LastError,
+			&job.IdempotencyKey,
+			&job.CreatedAt,
+			&job.UpdatedAt,
+			&job.CompletedAt,
+		); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, &job)
+	}
+	return jobs, rows.Err()
+}
@@ -0,0 +1,76 @@
+package jobqueue
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Status of a job row
+type Status string
+
+const (
+	StatusPending Status = "PENDING"
+	StatusRunning Status = "RUNNING"
+	StatusDone    Status = "DONE"
+	StatusDead    Status = "DEAD" // dead-lettered: exhausted retries or permanent failure
+)
+
+// DefaultMaxAttempts is used when NewJob.MaxAttempts is zero
+const DefaultMaxAttempts = 5
+
+// Job represents a row of the jobs table
+type Job struct {
+	ID             int64           `json:"id" db:"id"`
+	Type           string          `json:"type" db:"type"`
+	Payload        json.RawMessage `json:"payload" db:"payload"`
+	Status         Status          `json:"status" db:"status"`
+	Attempts       int             `json:"attempts" db:"attempts"`
+	MaxAttempts    int             `json:"max_attempts" db:"max_attempts"`
+	RunAt          time.Time       `json:"run_at" db:"run_at"`
+	LockedAt       *time.Time      `json:"locked_at,omitempty" db:"locked_at"`
+	LockedBy       *string         `json:"locked_by,omitempty" db:"locked_by"`
+	LastError      *string         `json:"last_error,omitempty" db:"last_error"`
+	IdempotencyKey *string         `json:"idempotency_key,omitempty" db:"idempotency_key"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at" db:"updated_at"`
+	CompletedAt    *time.Time      `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// Decode unmarshals the job payload into v
+func (j *Job) Decode(v any) error {
+	return json.Unmarshal(j.Payload, v)
+}
+
+// NewJob describes a job to enqueue
+type NewJob struct {
+	Type    string
+	Payload any
+	// IdempotencyKey deduplicates enqueues: a second job with the same key is ignored
+	IdempotencyKey string
+	MaxAttempts    int
+	// RunAt delays the first attempt (zero = now)
+	RunAt time.Time
+}
+
+// permanentError marks a failure that must not be retried
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so the worker dead-letters the job immediately instead of retrying
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was wrapped with Permanent
+func IsPermanent(err error) bool {
+	var pe *permanentError
+	return errors.As(err, &pe)
+}
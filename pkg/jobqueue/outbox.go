@@ -0,0 +1,196 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Event is a domain event stored in the outbox_events table
+type Event struct {
+	ID            int64           `json:"id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	EventType     string          `json:"event_type"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// WriteEvent stores an event in the outbox. Call it with the same pgx.Tx as the domain
+// change so the event exists if and only if the change is committed.
+func WriteEvent(ctx context.Context, tx database.DBTX, aggregateType, aggregateID, eventType string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode outbox payload: %w", err)
+	}
+
+	query := `
+		INSERT INTO outbox_events (aggregate_type, aggregate_id, event_type, payload)
+		VALUES ($1, $2, $3, $4)
+	`
+	if _, err := tx.Exec(ctx, query, aggregateType, aggregateID, eventType, data); err != nil {
+		return fmt.Errorf("failed to write outbox event %s: %w", eventType, err)
+	}
+	return nil
+}
+
+// Route turns an outbox event into jobs
+type Route func(event *Event) ([]NewJob, error)
+
+// Relay moves outbox events into the job queue. Each event is published exactly once:
+// the resulting jobs are enqueued and the event is marked published in one transaction.
+type Relay struct {
+	db           *pgxpool.Pool
+	pollInterval time.Duration
+	batchSize    int
+
+	routes    map[string][]Route
+	catchAlls []Route
+
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+}
+
+// NewRelay creates an outbox relay
+func NewRelay(db *pgxpool.Pool, pollInterval time.Duration, batchSize int) *Relay {
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	return &Relay{
+		db:           db,
+		pollInterval: pollInterval,
+		batchSize:    batchSize,
+		routes:       make(map[string][]Route),
+	}
+}
+
+// On registers a route for an event type
+func (r *Relay) On(eventType string, route Route) {
+	r.routes[eventType] = append(r.routes[eventType], route)
+}
+
+// OnAll registers a route for every event (e.g. webhook fan-out)
+func (r *Relay) OnAll(route Route) {
+	r.catchAlls = append(r.catchAlls, route)
+}
+
+// Start launches the relay loop
+func (r *Relay) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+	r.wg.Add(1)
+	go r.loop(ctx)
+	logger.Log.Info().Msg("Outbox relay started")
+}
+
+// Stop stops the relay loop
+func (r *Relay) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	logger.Log.Info().Msg("Outbox relay stopped")
+}
+
+func (r *Relay) loop(ctx context.Context) {
+	defer r.wg.Done()
+
+	for {
+		published, err := r.publishBatch(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.Log.Error().Err(err).Msg("Failed to publish outbox events")
+		}
+
+		// Keep draining while there is a backlog
+		if published == r.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.pollInterval):
+		}
+	}
+}
+
+func (r *Relay) publishBatch(ctx context.Context) (int, error) {
+	published := 0
+
+	err := database.WithTx(ctx, r.db, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, `
+			SELECT id, aggregate_type, aggregate_id, event_type, payload, created_at
+			FROM outbox_events
+			WHERE published_at IS NULL
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		`, r.batchSize)
+		if err != nil {
+			return err
+		}
+
+		var events []*Event
+		for rows.Next() {
+			var event Event
+			if err := rows.Scan(
+				&event.ID,
+				&event.AggregateType,
+				&event.AggregateID,
+				&event.EventType,
+				&event.Payload,
+				&event.CreatedAt,
+			); err != nil {
+				rows.Close()
+				return err
+			}
+			events = append(events, &event)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			jobs, err := r.route(event)
+			if err != nil {
+				return fmt.Errorf("failed to route outbox event %d: %w", event.ID, err)
+			}
+			for _, job := range jobs {
+				if _, err := Enqueue(ctx, tx, job); err != nil {
+					return err
+				}
+			}
+			if _, err := tx.Exec(ctx, `UPDATE outbox_events SET published_at = NOW() WHERE id = $1`, event.ID); err != nil {
+				return err
+			}
+		}
+
+		published = len(events)
+		return nil
+	})
+
+	return published, err
+}
+
+func (r *Relay) route(event *Event) ([]NewJob, error) {
+	var jobs []NewJob
+	routes := append(append([]Route{}, r.routes[event.EventType]...), r.catchAlls...)
+	for _, route := range routes {
+		routed, err := route(event)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, routed...)
+	}
+	return jobs, nil
+}
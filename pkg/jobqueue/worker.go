@@ -0,0 +1,260 @@
+package jobqueue
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Handler processes a job. Returning an error schedules a retry with backoff;
+// wrap it with Permanent to dead-letter the job immediately.
+type Handler func(ctx context.Context, job *Job) error
+
+// WorkerConfig configures a Worker
+type WorkerConfig struct {
+	Concurrency  int           // jobs processed in parallel
+	PollInterval time.Duration // idle wait between polls
+	JobTimeout   time.Duration // per-job context timeout
+	// StaleAfter returns RUNNING jobs to the queue when their worker died mid-job
+	StaleAfter time.Duration
+	// Retention deletes DONE jobs older than this (0 keeps them)
+	Retention time.Duration
+}
+
+func (c *WorkerConfig) setDefaults() {
+	if c.Concurrency <= 0 {
+		c.Concurrency = 4
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+	if c.JobTimeout <= 0 {
+		c.JobTimeout = time.Minute
+	}
+	if c.StaleAfter <= 0 {
+		c.StaleAfter = 5 * time.Minute
+	}
+}
+
+// Worker claims jobs with SELECT ... FOR UPDATE SKIP LOCKED, so any number of
+// replicas can process the same table without double-processing
+type Worker struct {
+	db       *pgxpool.Pool
+	cfg      WorkerConfig
+	id       string
+	handlers map[string]Handler
+
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+}
+
+// NewWorker creates a worker; register handlers before Start
+func NewWorker(db *pgxpool.Pool, cfg WorkerConfig) *Worker {
+	cfg.setDefaults()
+
+	hostname, _ := os.Hostname()
+	return &Worker{
+		db:       db,
+		cfg:      cfg,
+		id:       fmt.Sprintf("%s-%s", hostname, uuid.New().String()[:8]),
+		handlers: make(map[string]Handler),
+	}
+}
+
+// Register binds a handler to a job type
+func (w *Worker) Register(jobType string, handler Handler) {
+	w.handlers[jobType] = handler
+}
+
+// Start launches the polling loops
+func (w *Worker) Start(ctx context.Context) {
+	ctx, w.cancel = context.WithCancel(ctx)
+
+	for i := 0; i < w.cfg.Concurrency; i++ {
+		w.wg.Add(1)
+		go w.loop(ctx)
+	}
+
+	w.wg.Add(1)
+	go w.maintenance(ctx)
+
+	logger.Log.Info().
+		Str("worker_id", w.id).
+		Int("concurrency", w.cfg.Concurrency).
+		Int("job_types", len(w.handlers)).
+		Msg("Job worker started")
+}
+
+// Stop signals loops to exit and waits for in-flight jobs to finish
+func (w *Worker) Stop() {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.wg.Wait()
+	logger.Log.Info().Str("worker_id", w.id).Msg("Job worker stopped")
+}
+
+func (w *Worker) loop(ctx context.Context) {
+	defer w.wg.Done()
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		job, err := w.claim(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.Log.Error().Err(err).Msg("Failed to claim job")
+		}
+
+		if job == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(w.cfg.PollInterval):
+			}
+			continue
+		}
+
+		w.process(ctx, job)
+	}
+}
+
+// claim locks the next due job of a registered type
+func (w *Worker) claim(ctx context.Context) (*Job, error) {
+	types := make([]string, 0, len(w.handlers))
+	for jobType := range w.handlers {
+		types = append(types, jobType)
+	}
+
+	query := `
+		UPDATE jobs
+		SET status = 'RUNNING', attempts = attempts + 1, locked_at = $3, locked_by = $1, updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM jobs
+			WHERE status = 'PENDING' AND run_at <= $3 AND type = ANY($2)
+			ORDER BY run_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns
+
+	rows, err := w.db.Query(ctx, query, w.id, types, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs, err := scanJobs(rows)
+	if err != nil || len(jobs) == 0 {
+		return nil, err
+	}
+	return jobs[0], nil
+}
+
+func (w *Worker) process(ctx context.Context, job *Job) {
+	handler := w.handlers[job.Type]
+
+	jobCtx, cancel := context.WithTimeout(ctx, w.cfg.JobTimeout)
+	err := runHandler(jobCtx, handler, job)
+	cancel()
+
+	// Use a fresh context so results are recorded even during shutdown
+	recordCtx, recordCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer recordCancel()
+
+	if err == nil {
+		if _, dbErr := w.db.Exec(recordCtx, `
+			UPDATE jobs
+			SET status = 'DONE', completed_at = $2, locked_at = NULL, locked_by = NULL, last_error = NULL, updated_at = NOW()
+			WHERE id = $1
+		`, job.ID, time.Now()); dbErr != nil {
+			logger.Log.Error().Err(dbErr).Int64("job_id", job.ID).Msg("Failed to mark job as done")
+		}
+		return
+	}
+
+	if IsPermanent(err) || job.Attempts >= job.MaxAttempts {
+		logger.Log.Error().
+			Err(err).
+			Int64("job_id", job.ID).
+			Str("type", job.Type).
+			Int("attempts", job.Attempts).
+			Msg("Job dead-lettered")
+		if _, dbErr := w.db.Exec(recordCtx, `
+			UPDATE jobs
+			SET status = 'DEAD', last_error = $2, locked_at = NULL, locked_by = NULL, updated_at = NOW()
+			WHERE id = $1
+		`, job.ID, err.Error()); dbErr != nil {
+			logger.Log.Error().Err(dbErr).Int64("job_id", job.ID).Msg("Failed to dead-letter job")
+		}
+		return
+	}
+
+	delay := Backoff(job.Attempts)
+	logger.Log.Warn().
+		Err(err).
+		Int64("job_id", job.ID).
+		Str("type", job.Type).
+		Int("attempts", job.Attempts).
+		Dur("retry_in", delay).
+		Msg("Job failed, scheduling retry")
+	if _, dbErr := w.db.Exec(recordCtx, `
+		UPDATE jobs
+		SET status = 'PENDING', last_error = $2, run_at = $3, locked_at = NULL, locked_by = NULL, updated_at = NOW()
+		WHERE id = $1
+	`, job.ID, err.Error(), time.Now().Add(delay)); dbErr != nil {
+		logger.Log.Error().Err(dbErr).Int64("job_id", job.ID).Msg("Failed to reschedule job")
+	}
+}
+
+// runHandler converts handler panics into errors so the job is retried
+func runHandler(ctx context.Context, handler Handler, job *Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job handler panic: %v", r)
+		}
+	}()
+	return handler(ctx, job)
+}
+
+// maintenance requeues jobs abandoned by crashed workers and purges old completed jobs
+func (w *Worker) maintenance(ctx context.Context) {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		result, err := w.db.Exec(ctx, `
+			UPDATE jobs
+			SET status = 'PENDING', locked_at = NULL, locked_by = NULL, updated_at = NOW()
+			WHERE status = 'RUNNING' AND locked_at < $1
+		`, time.Now().Add(-w.cfg.StaleAfter))
+		if err != nil {
+			logger.Log.Error().Err(err).Msg("Failed to requeue stale jobs")
+		} else if result.RowsAffected() > 0 {
+			logger.Log.Warn().Int64("count", result.RowsAffected()).Msg("Requeued stale jobs")
+		}
+
+		if w.cfg.Retention > 0 {
+			if _, err := w.db.Exec(ctx, `
+				DELETE FROM jobs WHERE status = 'DONE' AND completed_at < $1
+			`, time.Now().Add(-w.cfg.Retention)); err != nil {
+				logger.Log.Error().Err(err).Msg("Failed to purge completed jobs")
+			}
+		}
+	}
+}
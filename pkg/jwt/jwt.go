@@ -13,10 +13,11 @@ type Claims struct {
 	UserID   int             `json:"user_id"`
 	Role     entity.UserRole `json:"role"`
 	UserType string          `json:"user_type"` // PASSENGER, DRIVER, ADMIN
+	Locale   string          `json:"locale,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func GenerateAccessToken(userID int, role entity.UserRole, userType string) (string, error) {
+func GenerateAccessToken(userID int, role entity.UserRole, userType, locale string) (string, error) {
 	secret := os.Getenv("JWT_SECRET")
 	if secret == "" {
 		secret = "your-secret-key-change-in-production"
@@ -26,6 +27,7 @@ func GenerateAccessToken(userID int, role entity.UserRole, userType string) (str
 		UserID:   userID,
 		Role:     role,
 		UserType: userType,
+		Locale:   locale,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(15 * time.Minute)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -0,0 +1,75 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/jackc/pgx/v5"
+)
+
+const accountColumns = `id, type, owner_id, balance, allow_negative, created_at, updated_at`
+
+// EnsureAccount returns the account of the type and owner, creating it on first use.
+// System accounts are looked up with a nil owner.
+func EnsureAccount(ctx context.Context, db database.DBTX, accountType AccountType, ownerID *int) (*Account, error) {
+	query := `
+		INSERT INTO ledger_accounts (type, owner_id, allow_negative)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (type, COALESCE(owner_id, 0)) DO NOTHING
+	`
+	if _, err := db.Exec(ctx, query, accountType, ownerID, accountType.allowsNegative()); err != nil {
+		return nil, fmt.Errorf("failed to create %s account: %w", accountType, err)
+	}
+
+	account, err := FindAccount(ctx, db, accountType, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrAccountNotFound
+	}
+	return account, nil
+}
+
+// FindAccount returns nil when the account has not been created yet
+func FindAccount(ctx context.Context, db database.DBTX, accountType AccountType, ownerID *int) (*Account, error) {
+	query := `SELECT ` + accountColumns + ` FROM ledger_accounts WHERE type = $1 AND COALESCE(owner_id, 0) = COALESCE($2, 0)`
+
+	var account Account
+	err := db.QueryRow(ctx, query, accountType, ownerID).Scan(
+		&account.ID, &account.Type, &account.OwnerID, &account.Balance,
+		&account.AllowNegative, &account.CreatedAt, &account.UpdatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// lockAccounts locks the accounts in id order, so concurrent postings over the same
+// accounts queue up instead of deadlocking
+func lockAccounts(ctx context.Context, tx pgx.Tx, ids []int64) (map[int64]*Account, error) {
+	query := `SELECT ` + accountColumns + ` FROM ledger_accounts WHERE id = ANY($1) ORDER BY id FOR UPDATE`
+	rows, err := tx.Query(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts := make(map[int64]*Account, len(ids))
+	for rows.Next() {
+		var account Account
+		if err := rows.Scan(
+			&account.ID, &account.Type, &account.OwnerID, &account.Balance,
+			&account.AllowNegative, &account.CreatedAt, &account.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		accounts[account.ID] = &account
+	}
+	return accounts, rows.Err()
+}
@@ -0,0 +1,114 @@
+// Package ledger is a double-entry ledger on Postgres. Money moves only by posting a
+// transaction whose entries sum to zero; entries are append-only and each posting
+// locks the accounts it touches, so balances can never be raced below zero.
+package ledger
+
+import (
+	"errors"
+	"time"
+)
+
+// AccountType identifies what an account holds
+type AccountType string
+
+const (
+	AccountPassengerWallet    AccountType = "PASSENGER_WALLET" // passenger's spendable balance
+	AccountDriverEarnings     AccountType = "DRIVER_EARNINGS"  // driver's share of wallet fares
+	AccountPlatformCommission AccountType = "PLATFORM_COMMISSION"
+	AccountTopUpFunding       AccountType = "TOPUP_FUNDING"  // money that entered the system; goes negative
+	AccountPayoutHold         AccountType = "PAYOUT_HOLD"    // driver's earnings reserved by pending payout requests
+	AccountPayoutSettled      AccountType = "PAYOUT_SETTLED" // money that left the system to drivers' banks
+	AccountPromoFunding       AccountType = "PROMO_FUNDING"  // promo discounts the platform paid for; goes negative
+	AccountPassengerDebt      AccountType = "PASSENGER_DEBT" // fees a passenger could not cover yet; goes negative
+)
+
+// allowsNegative reports whether an account of the type may have a negative balance.
+// The funding accounts, which mirror money brought in from outside, do, and so does a
+// passenger's debt, which is what they owe.
+func (t AccountType) allowsNegative() bool {
+	return t == AccountTopUpFunding || t == AccountPromoFunding || t == AccountPassengerDebt
+}
+
+// Transaction kinds
+const (
+	KindTopUp         = "TOPUP"
+	KindTripFare      = "TRIP_FARE"
+	KindPayoutHold    = "PAYOUT_HOLD"
+	KindPayoutPaid    = "PAYOUT_PAID"
+	KindPayoutRelease = "PAYOUT_RELEASE"
+	KindPromoSubsidy  = "PROMO_SUBSIDY"
+	KindCancelFee     = "CANCELLATION_FEE"
+	KindDebtRepayment = "DEBT_REPAYMENT"
+)
+
+var (
+	// ErrInsufficientFunds is returned when a posting would take a non-negative account below zero
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrUnbalanced is returned when a posting's lines do not sum to zero
+	ErrUnbalanced = errors.New("transaction lines do not balance")
+	// ErrInvalidPosting is returned for postings with too few lines or zero amounts
+	ErrInvalidPosting = errors.New("invalid posting")
+	// ErrAccountNotFound is returned when a posting references an unknown account
+	ErrAccountNotFound = errors.New("ledger account not found")
+)
+
+// Account is a row of ledger_accounts
+type Account struct {
+	ID            int64
+	Type          AccountType
+	OwnerID       *int
+	Balance       int64 // cached sum of the account's entries
+	AllowNegative bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Transaction is a row of ledger_transactions with its entries
+type Transaction struct {
+	ID             int64
+	Kind           string
+	IdempotencyKey string
+	ReferenceType  *string
+	ReferenceID    *string
+	Description    *string
+	CreatedBy      *int
+	CreatedAt      time.Time
+	Entries        []*Entry
+}
+
+// Entry is one leg of a transaction
+type Entry struct {
+	ID            int64
+	TransactionID int64
+	AccountID     int64
+	Amount        int64 // positive increases the account balance
+	BalanceAfter  int64
+	CreatedAt     time.Time
+}
+
+// StatementEntry is an entry with the transaction it belongs to, for account history
+type StatementEntry struct {
+	Entry
+	Kind          string
+	ReferenceType *string
+	ReferenceID   *string
+	Description   *string
+}
+
+// Line moves Amount into (positive) or out of (negative) an account
+type Line struct {
+	AccountID int64
+	Amount    int64
+}
+
+// Posting describes a transaction to record. The idempotency key makes retries safe:
+// posting the same key again returns the original transaction.
+type Posting struct {
+	Kind           string
+	IdempotencyKey string
+	ReferenceType  string
+	ReferenceID    string
+	Description    string
+	CreatedBy      *int
+	Lines          []Line
+}
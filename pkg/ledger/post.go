@@ -0,0 +1,188 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/jackc/pgx/v5"
+)
+
+// Post records a balanced transaction inside tx. It locks the accounts involved, rejects
+// the posting with ErrInsufficientFunds before writing anything if a non-negative account
+// would go below zero, and returns the existing transaction when the idempotency key was
+// already posted. Because nothing is written on rejection, the caller's tx stays usable.
+func Post(ctx context.Context, tx pgx.Tx, posting Posting) (*Transaction, error) {
+	if err := validate(posting); err != nil {
+		return nil, err
+	}
+
+	ids := accountIDs(posting.Lines)
+	accounts, err := lockAccounts(ctx, tx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock ledger accounts: %w", err)
+	}
+	if len(accounts) != len(ids) {
+		return nil, ErrAccountNotFound
+	}
+
+	// Checked after locking: a concurrent post with the same key has committed by now
+	existing, err := FindTransaction(ctx, tx, posting.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	balances, err := applyLines(accounts, posting.Lines)
+	if err != nil {
+		return nil, err
+	}
+
+	txn := &Transaction{
+		Kind:           posting.Kind,
+		IdempotencyKey: posting.IdempotencyKey,
+		ReferenceType:  nullable(posting.ReferenceType),
+		ReferenceID:    nullable(posting.ReferenceID),
+		Description:    nullable(posting.Description),
+		CreatedBy:      posting.CreatedBy,
+	}
+	query := `
+		INSERT INTO ledger_transactions (kind, idempotency_key, reference_type, reference_id, description, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+	if err := tx.QueryRow(ctx, query,
+		txn.Kind, txn.IdempotencyKey, txn.ReferenceType, txn.ReferenceID, txn.Description, txn.CreatedBy,
+	).Scan(&txn.ID, &txn.CreatedAt); err != nil {
+		return nil, fmt.Errorf("failed to insert ledger transaction: %w", err)
+	}
+
+	running := make(map[int64]int64, len(accounts))
+	for id, account := range accounts {
+		running[id] = account.Balance
+	}
+	for _, line := range posting.Lines {
+		running[line.AccountID] += line.Amount
+		entry := &Entry{
+			TransactionID: txn.ID,
+			AccountID:     line.AccountID,
+			Amount:        line.Amount,
+			BalanceAfter:  running[line.AccountID],
+		}
+		query := `
+			INSERT INTO ledger_entries (transaction_id, account_id, amount, balance_after)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, created_at
+		`
+		if err := tx.QueryRow(ctx, query, entry.TransactionID, entry.AccountID, entry.Amount, entry.BalanceAfter).
+			Scan(&entry.ID, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to insert ledger entry: %w", err)
+		}
+		txn.Entries = append(txn.Entries, entry)
+	}
+
+	for _, id := range ids {
+		query := `UPDATE ledger_accounts SET balance = $1, updated_at = NOW() WHERE id = $2`
+		if _, err := tx.Exec(ctx, query, balances[id], id); err != nil {
+			return nil, fmt.Errorf("failed to update ledger balance: %w", err)
+		}
+	}
+
+	return txn, nil
+}
+
+// FindTransaction returns the transaction posted with the idempotency key, or nil
+func FindTransaction(ctx context.Context, db database.DBTX, idempotencyKey string) (*Transaction, error) {
+	query := `
+		SELECT id, kind, idempotency_key, reference_type, reference_id, description, created_by, created_at
+		FROM ledger_transactions
+		WHERE idempotency_key = $1
+	`
+	var txn Transaction
+	err := db.QueryRow(ctx, query, idempotencyKey).Scan(
+		&txn.ID, &txn.Kind, &txn.IdempotencyKey, &txn.ReferenceType, &txn.ReferenceID,
+		&txn.Description, &txn.CreatedBy, &txn.CreatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query(ctx, `
+		SELECT id, transaction_id, account_id, amount, balance_after, created_at
+		FROM ledger_entries
+		WHERE transaction_id = $1
+		ORDER BY id
+	`, txn.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var entry Entry
+		if err := rows.Scan(&entry.ID, &entry.TransactionID, &entry.AccountID, &entry.Amount, &entry.BalanceAfter, &entry.CreatedAt); err != nil {
+			return nil, err
+		}
+		txn.Entries = append(txn.Entries, &entry)
+	}
+	return &txn, rows.Err()
+}
+
+func validate(posting Posting) error {
+	if posting.Kind == "" || posting.IdempotencyKey == "" || len(posting.Lines) < 2 {
+		return ErrInvalidPosting
+	}
+	var sum int64
+	for _, line := range posting.Lines {
+		if line.Amount == 0 {
+			return ErrInvalidPosting
+		}
+		sum += line.Amount
+	}
+	if sum != 0 {
+		return ErrUnbalanced
+	}
+	return nil
+}
+
+// accountIDs returns the accounts a posting touches, in the order they are locked
+func accountIDs(lines []Line) []int64 {
+	ids := make([]int64, 0, len(lines))
+	for _, line := range lines {
+		if !slices.Contains(ids, line.AccountID) {
+			ids = append(ids, line.AccountID)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// applyLines returns the balances the accounts would have after the lines, or
+// ErrInsufficientFunds when a non-negative account would go below zero
+func applyLines(accounts map[int64]*Account, lines []Line) (map[int64]int64, error) {
+	balances := make(map[int64]int64, len(accounts))
+	for id, account := range accounts {
+		balances[id] = account.Balance
+	}
+	for _, line := range lines {
+		balances[line.AccountID] += line.Amount
+	}
+	for id, balance := range balances {
+		if balance < 0 && !accounts[id].AllowNegative {
+			return nil, ErrInsufficientFunds
+		}
+	}
+	return balances, nil
+}
+
+func nullable(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
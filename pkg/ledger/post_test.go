@@ -0,0 +1,185 @@
+package ledger
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+)
+
+// Accounts of a trip paid from the wallet with a promo discount
+const (
+	wallet   int64 = 1
+	earnings int64 = 2
+	platform int64 = 3
+	promo    int64 = 4
+	funding  int64 = 5
+)
+
+func ledgerAccounts(balances map[int64]int64) map[int64]*Account {
+	types := map[int64]AccountType{
+		wallet:   AccountPassengerWallet,
+		earnings: AccountDriverEarnings,
+		platform: AccountPlatformCommission,
+		promo:    AccountPromoFunding,
+		funding:  AccountTopUpFunding,
+	}
+	accounts := make(map[int64]*Account, len(balances))
+	for id, balance := range balances {
+		accounts[id] = &Account{ID: id, Type: types[id], Balance: balance, AllowNegative: types[id].allowsNegative()}
+	}
+	return accounts
+}
+
+// reversal returns the lines that undo lines
+func reversal(lines []Line) []Line {
+	reversed := make([]Line, len(lines))
+	for i, line := range lines {
+		reversed[i] = Line{AccountID: line.AccountID, Amount: -line.Amount}
+	}
+	return reversed
+}
+
+// tripFare is a 20000 fare with a 5000 promo discount and 10% commission
+var tripFare = []Line{
+	{AccountID: wallet, Amount: -15000},
+	{AccountID: earnings, Amount: 18000},
+	{AccountID: platform, Amount: 2000},
+	{AccountID: promo, Amount: -5000},
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		posting Posting
+		want    error
+	}{
+		{
+			name:    "balanced two-line posting",
+			posting: Posting{Kind: KindTopUp, IdempotencyKey: "topup:1", Lines: []Line{{AccountID: funding, Amount: -50000}, {AccountID: wallet, Amount: 50000}}},
+		},
+		{
+			name:    "balanced split across several accounts",
+			posting: Posting{Kind: KindTripFare, IdempotencyKey: "trip-fare:1", Lines: tripFare},
+		},
+		{
+			name:    "reversal of a balanced posting is balanced",
+			posting: Posting{Kind: KindTripFare, IdempotencyKey: "trip-fare:1:reversal", Lines: reversal(tripFare)},
+		},
+		{
+			name:    "lines that do not sum to zero",
+			posting: Posting{Kind: KindTopUp, IdempotencyKey: "topup:2", Lines: []Line{{AccountID: funding, Amount: -50000}, {AccountID: wallet, Amount: 49999}}},
+			want:    ErrUnbalanced,
+		},
+		{
+			name:    "one-sided posting",
+			posting: Posting{Kind: KindTopUp, IdempotencyKey: "topup:3", Lines: []Line{{AccountID: wallet, Amount: 50000}}},
+			want:    ErrInvalidPosting,
+		},
+		{
+			name:    "no lines",
+			posting: Posting{Kind: KindTopUp, IdempotencyKey: "topup:4"},
+			want:    ErrInvalidPosting,
+		},
+		{
+			name:    "zero amount line",
+			posting: Posting{Kind: KindTopUp, IdempotencyKey: "topup:5", Lines: []Line{{AccountID: funding, Amount: 0}, {AccountID: wallet, Amount: 0}}},
+			want:    ErrInvalidPosting,
+		},
+		{
+			name:    "zero amount line next to balanced ones",
+			posting: Posting{Kind: KindTopUp, IdempotencyKey: "topup:6", Lines: []Line{{AccountID: funding, Amount: -100}, {AccountID: wallet, Amount: 100}, {AccountID: platform, Amount: 0}}},
+			want:    ErrInvalidPosting,
+		},
+		{
+			name:    "missing kind",
+			posting: Posting{IdempotencyKey: "topup:7", Lines: []Line{{AccountID: funding, Amount: -100}, {AccountID: wallet, Amount: 100}}},
+			want:    ErrInvalidPosting,
+		},
+		{
+			name:    "missing idempotency key",
+			posting: Posting{Kind: KindTopUp, Lines: []Line{{AccountID: funding, Amount: -100}, {AccountID: wallet, Amount: 100}}},
+			want:    ErrInvalidPosting,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validate(tt.posting); !errors.Is(err, tt.want) {
+				t.Errorf("validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		balances map[int64]int64
+		lines    []Line
+		want     map[int64]int64
+		wantErr  error
+	}{
+		{
+			name:     "top-up takes the funding account negative",
+			balances: map[int64]int64{funding: 0, wallet: 0},
+			lines:    []Line{{AccountID: funding, Amount: -50000}, {AccountID: wallet, Amount: 50000}},
+			want:     map[int64]int64{funding: -50000, wallet: 50000},
+		},
+		{
+			name:     "wallet covering the fare exactly ends at zero",
+			balances: map[int64]int64{wallet: 15000, earnings: 0, platform: 0, promo: 0},
+			lines:    tripFare,
+			want:     map[int64]int64{wallet: 0, earnings: 18000, platform: 2000, promo: -5000},
+		},
+		{
+			name:     "wallet one rupiah short is refused",
+			balances: map[int64]int64{wallet: 14999, earnings: 0, platform: 0, promo: 0},
+			lines:    tripFare,
+			wantErr:  ErrInsufficientFunds,
+		},
+		{
+			name:     "lines on the same account net out before the check",
+			balances: map[int64]int64{wallet: 1000, earnings: 0},
+			lines:    []Line{{AccountID: wallet, Amount: -3000}, {AccountID: wallet, Amount: 2500}, {AccountID: earnings, Amount: 500}},
+			want:     map[int64]int64{wallet: 500, earnings: 500},
+		},
+		{
+			name:     "reversal restores the balances before the posting",
+			balances: map[int64]int64{wallet: 0, earnings: 18000, platform: 2000, promo: -5000},
+			lines:    reversal(tripFare),
+			want:     map[int64]int64{wallet: 15000, earnings: 0, platform: 0, promo: 0},
+		},
+		{
+			name:     "reversal refused once the driver has spent the earnings",
+			balances: map[int64]int64{wallet: 0, earnings: 10000, platform: 2000, promo: -5000},
+			lines:    reversal(tripFare),
+			wantErr:  ErrInsufficientFunds,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accounts := ledgerAccounts(tt.balances)
+			got, err := applyLines(accounts, tt.lines)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("applyLines() error = %v, want %v", err, tt.wantErr)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("applyLines() = %v, want %v", got, tt.want)
+			}
+			for id, account := range accounts {
+				if account.Balance != tt.balances[id] {
+					t.Errorf("account %d balance changed to %d", id, account.Balance)
+				}
+			}
+		})
+	}
+}
+
+func TestAccountIDs(t *testing.T) {
+	lines := []Line{{AccountID: promo, Amount: -5000}, {AccountID: wallet, Amount: -1}, {AccountID: promo, Amount: 1}, {AccountID: earnings, Amount: 5000}}
+	if got, want := accountIDs(lines), []int64{wallet, earnings, promo}; !slices.Equal(got, want) {
+		t.Errorf("accountIDs() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,133 @@
+package ledger
+
+import (
+	"context"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+)
+
+// Report is the result of a reconciliation run
+type Report struct {
+	GeneratedAt            time.Time
+	Accounts               int
+	Transactions           int
+	TotalsByType           []TypeTotal
+	BalanceMismatches      []BalanceMismatch // cached balance differs from the sum of entries
+	NegativeAccounts       []BalanceMismatch // non-negative accounts below zero
+	UnbalancedTransactions []int64           // transactions whose entries do not sum to zero
+	LedgerSum              int64             // sum of all entries; zero in a healthy ledger
+}
+
+// Healthy reports whether the run found no problems
+func (r *Report) Healthy() bool {
+	return len(r.BalanceMismatches) == 0 && len(r.NegativeAccounts) == 0 &&
+		len(r.UnbalancedTransactions) == 0 && r.LedgerSum == 0
+}
+
+// TypeTotal sums the balances of all accounts of a type
+type TypeTotal struct {
+	Type     AccountType
+	Accounts int
+	Balance  int64
+}
+
+// BalanceMismatch describes an account that failed a check
+type BalanceMismatch struct {
+	AccountID int64
+	Type      AccountType
+	OwnerID   *int
+	Cached    int64
+	Derived   int64
+}
+
+// Reconcile checks the ledger's invariants. Run it inside a REPEATABLE READ transaction
+// (or on a replica) to get a consistent snapshot while postings continue.
+func Reconcile(ctx context.Context, db database.DBTX, now time.Time) (*Report, error) {
+	report := &Report{
+		GeneratedAt:            now,
+		TotalsByType:           []TypeTotal{},
+		BalanceMismatches:      []BalanceMismatch{},
+		NegativeAccounts:       []BalanceMismatch{},
+		UnbalancedTransactions: []int64{},
+	}
+
+	if err := db.QueryRow(ctx, `SELECT (SELECT COUNT(*) FROM ledger_accounts), (SELECT COUNT(*) FROM ledger_transactions),
+		(SELECT COALESCE(SUM(amount), 0) FROM ledger_entries)`).
+		Scan(&report.Accounts, &report.Transactions, &report.LedgerSum); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query(ctx, `
+		SELECT type, COUNT(*), COALESCE(SUM(balance), 0)
+		FROM ledger_accounts
+		GROUP BY type
+		ORDER BY type
+	`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var total TypeTotal
+		if err := rows.Scan(&total.Type, &total.Accounts, &total.Balance); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		report.TotalsByType = append(report.TotalsByType, total)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = db.Query(ctx, `
+		SELECT a.id, a.type, a.owner_id, a.balance, COALESCE(e.total, 0), a.allow_negative
+		FROM ledger_accounts a
+		LEFT JOIN (SELECT account_id, SUM(amount) AS total FROM ledger_entries GROUP BY account_id) e
+		       ON e.account_id = a.id
+		WHERE a.balance <> COALESCE(e.total, 0) OR (NOT a.allow_negative AND LEAST(a.balance, COALESCE(e.total, 0)) < 0)
+		ORDER BY a.id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var mismatch BalanceMismatch
+		var allowNegative bool
+		if err := rows.Scan(&mismatch.AccountID, &mismatch.Type, &mismatch.OwnerID, &mismatch.Cached, &mismatch.Derived, &allowNegative); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if mismatch.Cached != mismatch.Derived {
+			report.BalanceMismatches = append(report.BalanceMismatches, mismatch)
+		}
+		if !allowNegative && min(mismatch.Cached, mismatch.Derived) < 0 {
+			report.NegativeAccounts = append(report.NegativeAccounts, mismatch)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = db.Query(ctx, `
+		SELECT t.id
+		FROM ledger_transactions t
+		LEFT JOIN ledger_entries e ON e.transaction_id = t.id
+		GROUP BY t.id
+		HAVING COALESCE(SUM(e.amount), 0) <> 0 OR COUNT(e.id) < 2
+		ORDER BY t.id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		report.UnbalancedTransactions = append(report.UnbalancedTransactions, id)
+	}
+	return report, rows.Err()
+}
@@ -0,0 +1,46 @@
+package ledger
+
+import (
+	"context"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+)
+
+// Statement returns an account's entries, newest first
+func Statement(ctx context.Context, db database.DBTX, accountID int64, limit, offset int) ([]*StatementEntry, error) {
+	query := `
+		SELECT e.id, e.transaction_id, e.account_id, e.amount, e.balance_after, e.created_at,
+		       t.kind, t.reference_type, t.reference_id, t.description
+		FROM ledger_entries e
+		JOIN ledger_transactions t ON t.id = e.transaction_id
+		WHERE e.account_id = $1
+		ORDER BY e.id DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := db.Query(ctx, query, accountID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []*StatementEntry{}
+	for rows.Next() {
+		var entry StatementEntry
+		if err := rows.Scan(
+			&entry.ID, &entry.TransactionID, &entry.AccountID, &entry.Amount, &entry.BalanceAfter, &entry.CreatedAt,
+			&entry.Kind, &entry.ReferenceType, &entry.ReferenceID, &entry.Description,
+		); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, rows.Err()
+}
+
+// DerivedBalance sums the account's entries; it equals Account.Balance unless the
+// ledger is corrupt (see Reconcile)
+func DerivedBalance(ctx context.Context, db database.DBTX, accountID int64) (int64, error) {
+	var balance int64
+	err := db.QueryRow(ctx, `SELECT COALESCE(SUM(amount), 0) FROM ledger_entries WHERE account_id = $1`, accountID).Scan(&balance)
+	return balance, err
+}
@@ -0,0 +1,226 @@
+package mailer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+)
+
+const (
+	fakeHostname       = "fake-smtp.local"
+	fakeSessionTimeout = time.Minute
+	fakeMailboxSize    = 100
+)
+
+// FakeMessage is an email accepted by the FakeServer
+type FakeMessage struct {
+	From       string    `json:"from"`
+	To         []string  `json:"to"`
+	Subject    string    `json:"subject"`
+	Body       string    `json:"body"`
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+// FakeServer is a minimal in-process SMTP server for local development: point an
+// SMTPMailer at it and every message is accepted, logged and kept in memory, so
+// verification codes and links can be read back over HTTP instead of from a real inbox
+type FakeServer struct {
+	mu       sync.Mutex
+	messages []FakeMessage
+	listener net.Listener
+	wg       sync.WaitGroup
+}
+
+// NewFakeServer creates a fake SMTP server; call Start to accept connections
+func NewFakeServer() *FakeServer {
+	return &FakeServer{}
+}
+
+// Start listens on addr ("127.0.0.1:2525", or port 0 for any free port)
+func (s *FakeServer) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				if !errors.Is(err, net.ErrClosed) {
+					logger.Log.Error().Err(err).Msg("Fake SMTP server stopped accepting")
+				}
+				return
+			}
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				s.serve(conn)
+			}()
+		}
+	}()
+	return nil
+}
+
+// Addr returns the address the server listens on
+func (s *FakeServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Stop closes the listener and waits for open sessions to finish
+func (s *FakeServer) Stop() {
+	if s.listener != nil {
+		_ = s.listener.Close()
+	}
+	s.wg.Wait()
+}
+
+// Messages returns the received messages, newest first
+func (s *FakeServer) Messages() []FakeMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages := make([]FakeMessage, len(s.messages))
+	for i, message := range s.messages {
+		messages[len(s.messages)-1-i] = message
+	}
+	return messages
+}
+
+// ServeHTTP lists the received messages as JSON, optionally filtered by ?to=address
+func (s *FakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	messages := s.Messages()
+	if to := strings.ToLower(r.URL.Query().Get("to")); to != "" {
+		filtered := messages[:0]
+		for _, message := range messages {
+			for _, rcpt := range message.To {
+				if strings.ToLower(rcpt) == to {
+					filtered = append(filtered, message)
+					break
+				}
+			}
+		}
+		messages = filtered
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"messages": messages})
+}
+
+// serve runs one SMTP session; only the commands net/smtp needs are implemented
+func (s *FakeServer) serve(conn net.Conn) {
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(fakeSessionTimeout))
+
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 %s ESMTP ready", fakeHostname)
+
+	var from string
+	var to []string
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			_ = tp.PrintfLine("250 %s", fakeHostname)
+		case "MAIL":
+			from, to = smtpPath(arg), nil
+			_ = tp.PrintfLine("250 2.1.0 OK")
+		case "RCPT":
+			if from == "" {
+				_ = tp.PrintfLine("503 5.5.1 MAIL first")
+				continue
+			}
+			to = append(to, smtpPath(arg))
+			_ = tp.PrintfLine("250 2.1.5 OK")
+		case "DATA":
+			if len(to) == 0 {
+				_ = tp.PrintfLine("503 5.5.1 RCPT first")
+				continue
+			}
+			_ = tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.store(from, to, data)
+			from, to = "", nil
+			_ = tp.PrintfLine("250 2.0.0 OK")
+		case "RSET":
+			from, to = "", nil
+			_ = tp.PrintfLine("250 2.0.0 OK")
+		case "NOOP":
+			_ = tp.PrintfLine("250 2.0.0 OK")
+		case "QUIT":
+			_ = tp.PrintfLine("221 2.0.0 Bye")
+			return
+		default:
+			_ = tp.PrintfLine("502 5.5.2 Command not implemented")
+		}
+	}
+}
+
+// store decodes the message and keeps it, dropping the oldest beyond fakeMailboxSize
+func (s *FakeServer) store(from string, to []string, data []byte) {
+	message := FakeMessage{From: from, To: to, ReceivedAt: time.Now()}
+
+	if parsed, err := mail.ReadMessage(bytes.NewReader(data)); err == nil {
+		subject := parsed.Header.Get("Subject")
+		if decoded, err := new(mime.WordDecoder).DecodeHeader(subject); err == nil {
+			subject = decoded
+		}
+		message.Subject = subject
+
+		var body io.Reader = parsed.Body
+		if strings.EqualFold(parsed.Header.Get("Content-Transfer-Encoding"), "quoted-printable") {
+			body = quotedprintable.NewReader(body)
+		}
+		if raw, err := io.ReadAll(body); err == nil {
+			message.Body = string(raw)
+		}
+	} else {
+		message.Body = string(data)
+	}
+
+	s.mu.Lock()
+	s.messages = append(s.messages, message)
+	if len(s.messages) > fakeMailboxSize {
+		s.messages = s.messages[len(s.messages)-fakeMailboxSize:]
+	}
+	s.mu.Unlock()
+
+	logger.Log.Info().
+		Strs("to", to).
+		Str("subject", message.Subject).
+		Str("body", message.Body).
+		Msg("Email (fake SMTP)")
+}
+
+// smtpPath extracts the address from "FROM:<a@b>" or "TO:<a@b> PARAM=..."
+func smtpPath(arg string) string {
+	start := strings.Index(arg, "<")
+	end := strings.Index(arg, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return arg[start+1 : end]
+}
@@ -0,0 +1,31 @@
+package mailer
+
+import (
+	"context"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+)
+
+// Mailer sends plain-text emails
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// LogMailer writes emails to the application log instead of sending them.
+// Used in development until a real transport is configured.
+type LogMailer struct{}
+
+// NewLogMailer creates a new log-only mailer
+func NewLogMailer() Mailer {
+	return &LogMailer{}
+}
+
+// Send logs the email
+func (m *LogMailer) Send(ctx context.Context, to, subject, body string) error {
+	logger.Log.Info().
+		Str("to", to).
+		Str("subject", subject).
+		Str("body", body).
+		Msg("Email (log mailer)")
+	return nil
+}
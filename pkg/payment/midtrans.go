@@ -0,0 +1,275 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/httpclient"
+)
+
+// midtransTimeLayout is the layout of Midtrans timestamps, in Asia/Jakarta time
+const midtransTimeLayout = "2006-01-02 15:04:05"
+
+var jakarta = time.FixedZone("WIB", 7*60*60)
+
+// MidtransProvider implements Provider with the Midtrans Core API (Xendit-style gateways
+// follow the same charge / status / signed notification shape)
+type MidtransProvider struct {
+	serverKey  string
+	baseURL    string
+	httpClient *httpclient.Client
+}
+
+// NewMidtransProvider creates a provider; baseURL is e.g. https://api.sandbox.midtrans.com
+func NewMidtransProvider(serverKey, baseURL string) *MidtransProvider {
+	return &MidtransProvider{
+		serverKey: serverKey,
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		httpClient: httpclient.New(httpclient.Config{
+			Name:           "midtrans",
+			AttemptTimeout: 15 * time.Second,
+		}),
+	}
+}
+
+// Name identifies the provider in stored charges
+func (p *MidtransProvider) Name() string {
+	return "midtrans"
+}
+
+// midtransTransaction is the transaction object shared by charge, status and notification bodies
+type midtransTransaction struct {
+	StatusCode        string             `json:"status_code"`
+	StatusMessage     string             `json:"status_message,omitempty"`
+	TransactionID     string             `json:"transaction_id"`
+	OrderID           string             `json:"order_id"`
+	GrossAmount       string             `json:"gross_amount"`
+	PaymentType       string             `json:"payment_type"`
+	TransactionStatus string             `json:"transaction_status"`
+	FraudStatus       string             `json:"fraud_status,omitempty"`
+	SettlementTime    string             `json:"settlement_time,omitempty"`
+	ExpiryTime        string             `json:"expiry_time,omitempty"`
+	SignatureKey      string             `json:"signature_key,omitempty"`
+	QRString          string             `json:"qr_string,omitempty"`
+	Actions           []midtransAction   `json:"actions,omitempty"`
+	VANumbers         []midtransVANumber `json:"va_numbers,omitempty"`
+}
+
+type midtransAction struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type midtransVANumber struct {
+	Bank     string `json:"bank"`
+	VANumber string `json:"va_number"`
+}
+
+// CreateCharge creates a QRIS or bank transfer charge
+func (p *MidtransProvider) CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error) {
+	body := map[string]any{
+		"transaction_details": map[string]any{
+			"order_id":     req.Reference,
+			"gross_amount": req.Amount,
+		},
+		"customer_details": map[string]any{
+			"first_name": req.CustomerName,
+			"phone":      req.CustomerPhone,
+		},
+	}
+	if req.ExpiresIn > 0 {
+		body["custom_expiry"] = map[string]any{
+			"expiry_duration": int(math.Ceil(req.ExpiresIn.Minutes())),
+			"unit":            "minute",
+		}
+	}
+
+	switch req.Channel {
+	case ChannelQRIS:
+		body["payment_type"] = "qris"
+	case ChannelVA:
+		body["payment_type"] = "bank_transfer"
+		body["bank_transfer"] = map[string]any{"bank": strings.ToLower(req.Bank)}
+	default:
+		return nil, fmt.Errorf("unsupported payment channel %q", req.Channel)
+	}
+
+	var txn midtransTransaction
+	if err := p.do(ctx, http.MethodPost, "/v2/charge", "charge-"+req.Reference, body, &txn); err != nil {
+		return nil, err
+	}
+	if txn.StatusCode != "201" && txn.StatusCode != "200" {
+		return nil, fmt.Errorf("midtrans charge failed: %s %s", txn.StatusCode, txn.StatusMessage)
+	}
+
+	charge := &Charge{
+		Reference:   txn.OrderID,
+		ProviderRef: txn.TransactionID,
+		Status:      mapMidtransStatus(txn.TransactionStatus, txn.FraudStatus),
+		QRString:    txn.QRString,
+		ExpiresAt:   parseMidtransTime(txn.ExpiryTime),
+	}
+	for _, action := range txn.Actions {
+		if action.Name == "generate-qr-code" {
+			charge.QRImageURL = action.URL
+		}
+	}
+	if len(txn.VANumbers) > 0 {
+		charge.Bank = txn.VANumbers[0].Bank
+		charge.VANumber = txn.VANumbers[0].VANumber
+	}
+	if charge.ExpiresAt.IsZero() && req.ExpiresIn > 0 {
+		charge.ExpiresAt = time.Now().Add(req.ExpiresIn)
+	}
+	return charge, nil
+}
+
+// GetStatus fetches the charge's current status
+func (p *MidtransProvider) GetStatus(ctx context.Context, reference string) (*StatusResult, error) {
+	var txn midtransTransaction
+	if err := p.do(ctx, http.MethodGet, "/v2/"+reference+"/status", "", nil, &txn); err != nil {
+		return nil, err
+	}
+	if txn.StatusCode == "404" {
+		return nil, ErrChargeNotFound
+	}
+	return toStatusResult(&txn)
+}
+
+// Expire cancels a pending charge
+func (p *MidtransProvider) Expire(ctx context.Context, reference string) error {
+	var txn midtransTransaction
+	if err := p.do(ctx, http.MethodPost, "/v2/"+reference+"/expire", "expire-"+reference, nil, &txn); err != nil {
+		return err
+	}
+	switch txn.StatusCode {
+	case "200", "407", "412": // 407: already expired, 412: already final (e.g. settled)
+		return nil
+	case "404":
+		return ErrChargeNotFound
+	}
+	return fmt.Errorf("midtrans expire failed: %s %s", txn.StatusCode, txn.StatusMessage)
+}
+
+// ParseNotification verifies signature_key = SHA512(order_id + status_code + gross_amount + server_key)
+func (p *MidtransProvider) ParseNotification(header http.Header, body []byte) (*Notification, error) {
+	var txn midtransTransaction
+	if err := json.Unmarshal(body, &txn); err != nil {
+		return nil, fmt.Errorf("invalid notification body: %w", err)
+	}
+
+	expected := MidtransSignature(txn.OrderID, txn.StatusCode, txn.GrossAmount, p.serverKey)
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(strings.ToLower(txn.SignatureKey))) != 1 {
+		return nil, ErrInvalidSignature
+	}
+
+	result, err := toStatusResult(&txn)
+	if err != nil {
+		return nil, err
+	}
+	return &Notification{StatusResult: *result, Raw: body}, nil
+}
+
+// MidtransSignature computes the notification signature key
+func MidtransSignature(orderID, statusCode, grossAmount, serverKey string) string {
+	sum := sha512.Sum512([]byte(orderID + statusCode + grossAmount + serverKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// do calls the Core API. POSTs carry an Idempotency-Key, which Midtrans uses to answer a
+// repeated request with the first response, so they are safe to retry.
+func (p *MidtransProvider) do(ctx context.Context, method, path, idempotencyKey string, body any, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(p.serverKey, "")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("midtrans request failed: %w", err)
+	}
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("midtrans error: status %d", resp.StatusCode)
+	}
+	// Midtrans reports most errors in the body's status_code with HTTP 200
+	if err := json.Unmarshal(resp.Body, out); err != nil {
+		return fmt.Errorf("invalid midtrans response (status %d): %w", resp.StatusCode, err)
+	}
+	return nil
+}
+
+func toStatusResult(txn *midtransTransaction) (*StatusResult, error) {
+	amount, err := strconv.ParseFloat(txn.GrossAmount, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gross_amount %q: %w", txn.GrossAmount, err)
+	}
+
+	result := &StatusResult{
+		Reference:   txn.OrderID,
+		ProviderRef: txn.TransactionID,
+		Status:      mapMidtransStatus(txn.TransactionStatus, txn.FraudStatus),
+		Amount:      int64(math.Round(amount)),
+	}
+	if result.Status == StatusPaid {
+		paidAt := parseMidtransTime(txn.SettlementTime)
+		if paidAt.IsZero() {
+			paidAt = time.Now()
+		}
+		result.PaidAt = &paidAt
+	}
+	return result, nil
+}
+
+func mapMidtransStatus(transactionStatus, fraudStatus string) Status {
+	switch transactionStatus {
+	case "settlement":
+		return StatusPaid
+	case "capture":
+		if fraudStatus == "" || fraudStatus == "accept" {
+			return StatusPaid
+		}
+		return StatusPending
+	case "expire":
+		return StatusExpired
+	case "cancel", "deny", "failure", "refund", "partial_refund":
+		return StatusFailed
+	}
+	return StatusPending
+}
+
+func parseMidtransTime(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	t, err := time.ParseInLocation(midtransTimeLayout, value, jakarta)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,253 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MockServer emulates the Midtrans Core API in memory for local development and tests:
+// point a MidtransProvider at it, then Pay or Expire charges to trigger signed notifications.
+// Paying is not part of the HTTP API, so holding the server key is not enough to settle a charge.
+type MockServer struct {
+	serverKey  string
+	notifyURL  string
+	httpClient *http.Client
+
+	mu                sync.Mutex
+	charges           map[string]*midtransTransaction
+	skipNotifications bool
+}
+
+// NewMockServer creates a mock gateway; notifyURL receives the webhook notifications
+// (empty = never notify, so only status polling sees the payment)
+func NewMockServer(serverKey, notifyURL string) *MockServer {
+	return &MockServer{
+		serverKey:  serverKey,
+		notifyURL:  notifyURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		charges:    make(map[string]*midtransTransaction),
+	}
+}
+
+// SkipNotifications simulates webhooks that never arrive
+func (m *MockServer) SkipNotifications(skip bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.skipNotifications = skip
+}
+
+// ServeHTTP handles POST /v2/charge, GET /v2/{order_id}/status and POST /v2/{order_id}/expire
+func (m *MockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if key, _, ok := r.BasicAuth(); !ok || key != m.serverKey {
+		writeMockJSON(w, map[string]string{"status_code": "401", "status_message": "Access denied"})
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v2/")
+	if r.Method == http.MethodPost && path == "charge" {
+		m.handleCharge(w, r)
+		return
+	}
+
+	orderID, action, ok := strings.Cut(path, "/")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && action == "status":
+		m.mu.Lock()
+		txn, found := m.charges[orderID]
+		var snapshot midtransTransaction
+		if found {
+			snapshot = *txn
+			snapshot.StatusCode = statusCodeFor(txn.TransactionStatus)
+		}
+		m.mu.Unlock()
+		if !found {
+			writeMockJSON(w, map[string]string{"status_code": "404", "status_message": "Transaction doesn't exist."})
+			return
+		}
+		writeMockJSON(w, snapshot)
+	case r.Method == http.MethodPost && action == "expire":
+		if err := m.Expire(r.Context(), orderID); err != nil {
+			writeMockJSON(w, map[string]string{"status_code": mockErrorCode(err), "status_message": err.Error()})
+			return
+		}
+		writeMockJSON(w, map[string]string{"status_code": "200", "order_id": orderID, "transaction_status": "expire"})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (m *MockServer) handleCharge(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PaymentType        string `json:"payment_type"`
+		TransactionDetails struct {
+			OrderID     string `json:"order_id"`
+			GrossAmount int64  `json:"gross_amount"`
+		} `json:"transaction_details"`
+		BankTransfer struct {
+			Bank string `json:"bank"`
+		} `json:"bank_transfer"`
+		CustomExpiry struct {
+			ExpiryDuration int `json:"expiry_duration"`
+		} `json:"custom_expiry"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeMockJSON(w, map[string]string{"status_code": "400", "status_message": "Invalid JSON"})
+		return
+	}
+
+	orderID := req.TransactionDetails.OrderID
+	expiry := time.Duration(req.CustomExpiry.ExpiryDuration) * time.Minute
+	if expiry <= 0 {
+		expiry = 24 * time.Hour
+	}
+
+	txn := &midtransTransaction{
+		StatusCode:        "201",
+		StatusMessage:     "Success, transaction is created",
+		TransactionID:     randomID(),
+		OrderID:           orderID,
+		GrossAmount:       fmt.Sprintf("%d.00", req.TransactionDetails.GrossAmount),
+		PaymentType:       req.PaymentType,
+		TransactionStatus: "pending",
+		ExpiryTime:        time.Now().Add(expiry).In(jakarta).Format(midtransTimeLayout),
+	}
+	switch req.PaymentType {
+	case "qris":
+		txn.QRString = "00020101021226610014COM.MOCK.WWW0118" + orderID + "5204599953033605802ID6304MOCK"
+		txn.Actions = []midtransAction{{Name: "generate-qr-code", URL: "https://mock.payment.local/qris/" + txn.TransactionID}}
+	case "bank_transfer":
+		txn.VANumbers = []midtransVANumber{{Bank: req.BankTransfer.Bank, VANumber: "8808" + txn.TransactionID[:12]}}
+	default:
+		writeMockJSON(w, map[string]string{"status_code": "400", "status_message": "Unsupported payment type"})
+		return
+	}
+
+	m.mu.Lock()
+	if _, exists := m.charges[orderID]; exists {
+		m.mu.Unlock()
+		writeMockJSON(w, map[string]string{"status_code": "406", "status_message": "The request could not be completed due to a conflict with the current state of the target resource, please try again"})
+		return
+	}
+	m.charges[orderID] = txn
+	m.mu.Unlock()
+
+	writeMockJSON(w, txn)
+}
+
+// Pay settles a pending charge and sends the signed settlement notification
+func (m *MockServer) Pay(ctx context.Context, orderID string) error {
+	return m.transition(ctx, orderID, "settlement")
+}
+
+// Expire expires a pending charge and sends the signed expiry notification
+func (m *MockServer) Expire(ctx context.Context, orderID string) error {
+	return m.transition(ctx, orderID, "expire")
+}
+
+func (m *MockServer) transition(ctx context.Context, orderID, status string) error {
+	m.mu.Lock()
+	txn, ok := m.charges[orderID]
+	if !ok {
+		m.mu.Unlock()
+		return ErrChargeNotFound
+	}
+	if txn.TransactionStatus != "pending" {
+		m.mu.Unlock()
+		return fmt.Errorf("charge %s is %s", orderID, txn.TransactionStatus)
+	}
+	txn.TransactionStatus = status
+	if status == "settlement" {
+		txn.SettlementTime = time.Now().In(jakarta).Format(midtransTimeLayout)
+	}
+	body, err := m.notification(txn)
+	skip := m.skipNotifications
+	m.mu.Unlock()
+
+	if err != nil || skip || m.notifyURL == "" {
+		return err
+	}
+	return m.notify(ctx, body)
+}
+
+// Notification returns the signed notification body for the charge's current state
+func (m *MockServer) Notification(orderID string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	txn, ok := m.charges[orderID]
+	if !ok {
+		return nil, ErrChargeNotFound
+	}
+	return m.notification(txn)
+}
+
+func (m *MockServer) notification(txn *midtransTransaction) ([]byte, error) {
+	notification := *txn
+	notification.StatusCode = statusCodeFor(txn.TransactionStatus)
+	notification.StatusMessage = "midtrans payment notification"
+	notification.SignatureKey = MidtransSignature(notification.OrderID, notification.StatusCode, notification.GrossAmount, m.serverKey)
+	return json.Marshal(notification)
+}
+
+func (m *MockServer) notify(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.notifyURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("mock notification failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("mock notification rejected: status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// statusCodeFor mirrors the status_code Midtrans sends with each transaction status
+func statusCodeFor(transactionStatus string) string {
+	switch transactionStatus {
+	case "settlement", "capture":
+		return "200"
+	case "pending":
+		return "201"
+	}
+	return "202"
+}
+
+// mockErrorCode maps a transition error to the Midtrans status_code
+func mockErrorCode(err error) string {
+	if errors.Is(err, ErrChargeNotFound) {
+		return "404"
+	}
+	return "412" // merchant cannot modify the status of the transaction
+}
+
+func randomID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func writeMockJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,89 @@
+// Package payment talks to payment gateways: creating QRIS / virtual account charges,
+// checking their status and verifying the gateway's webhook notifications.
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Channel is how the customer pays
+type Channel string
+
+const (
+	ChannelQRIS Channel = "QRIS"
+	ChannelVA   Channel = "VA" // bank virtual account; ChargeRequest.Bank picks the bank
+)
+
+// Status is the provider-independent state of a charge
+type Status string
+
+const (
+	StatusPending Status = "PENDING"
+	StatusPaid    Status = "PAID"
+	StatusFailed  Status = "FAILED"
+	StatusExpired Status = "EXPIRED"
+)
+
+// IsFinal reports whether the charge can no longer change
+func (s Status) IsFinal() bool {
+	return s == StatusPaid || s == StatusFailed || s == StatusExpired
+}
+
+var (
+	// ErrInvalidSignature is returned for notifications that fail signature verification
+	ErrInvalidSignature = errors.New("invalid payment notification signature")
+	// ErrChargeNotFound is returned when the provider does not know the reference
+	ErrChargeNotFound = errors.New("payment charge not found at provider")
+)
+
+// ChargeRequest asks the provider for a new charge
+type ChargeRequest struct {
+	Reference     string // our unique id for the charge (the provider's order_id)
+	Amount        int64
+	Channel       Channel
+	Bank          string // for ChannelVA: bca, bni, bri, ...
+	CustomerName  string
+	CustomerPhone string
+	ExpiresIn     time.Duration
+}
+
+// Charge is a created charge with the payment instructions for the customer
+type Charge struct {
+	Reference   string
+	ProviderRef string
+	Status      Status
+	QRString    string // QRIS payload to render as a QR code
+	QRImageURL  string
+	VANumber    string
+	Bank        string
+	ExpiresAt   time.Time
+}
+
+// StatusResult is the provider's current view of a charge
+type StatusResult struct {
+	Reference   string
+	ProviderRef string
+	Status      Status
+	Amount      int64
+	PaidAt      *time.Time
+}
+
+// Notification is a verified webhook notification
+type Notification struct {
+	StatusResult
+	Raw []byte
+}
+
+// Provider is a payment gateway
+type Provider interface {
+	Name() string
+	CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error)
+	GetStatus(ctx context.Context, reference string) (*StatusResult, error)
+	// Expire cancels a pending charge so it can no longer be paid; final charges are left as they are
+	Expire(ctx context.Context, reference string) error
+	// ParseNotification verifies and decodes a webhook request body
+	ParseNotification(header http.Header, body []byte) (*Notification, error)
+}
@@ -0,0 +1,91 @@
+// Package profanity masks swear words in user-written text such as ride chat messages.
+// Words are matched whole and case-insensitively after undoing common disguises:
+// digits or symbols standing in for letters ("4nj1ng") and stretched letters ("fuuuck").
+package profanity
+
+import (
+	"strings"
+	"unicode"
+)
+
+// words are the Indonesian, Javanese and English swear words that are masked
+var words = []string{
+	// Indonesian and regional
+	"anjing", "anjir", "anjay", "bangsat", "bajingan", "brengsek", "keparat", "kampret",
+	"goblok", "goblog", "tolol", "bego", "idiot", "kontol", "memek", "ngentot", "entot",
+	"jancok", "jancuk", "cok", "asu", "bacot", "tai", "taik", "perek", "pelacur", "lonte",
+	// English
+	"fuck", "fucking", "fucker", "shit", "bitch", "bastard", "asshole", "dick", "cunt",
+	"motherfucker", "slut", "whore",
+}
+
+// blocked holds the normalized form of every word
+var blocked = func() map[string]struct{} {
+	set := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		set[normalize(word)] = struct{}{}
+	}
+	return set
+}()
+
+// leet maps characters commonly typed in place of letters
+var leet = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '@': 'a', '$': 's',
+}
+
+// Mask replaces every letter of a swear word after the first with '*', e.g. "b******n",
+// and reports whether anything was masked. Everything else is returned unchanged.
+func Mask(text string) (string, bool) {
+	runes := []rune(text)
+	masked := false
+
+	for start := 0; start < len(runes); {
+		if !isWordRune(runes[start]) {
+			start++
+			continue
+		}
+		end := start
+		for end < len(runes) && isWordRune(runes[end]) {
+			end++
+		}
+		if _, ok := blocked[normalize(string(runes[start:end]))]; ok {
+			for i := start + 1; i < end; i++ {
+				runes[i] = '*'
+			}
+			masked = true
+		}
+		start = end
+	}
+
+	if !masked {
+		return text, false
+	}
+	return string(runes), true
+}
+
+// normalize lowercases a word, undoes look-alike characters and collapses repeated
+// letters, so "FuUuCk", "4nj1ng" and "b4ngs4t" compare equal to the listed words
+func normalize(word string) string {
+	var b strings.Builder
+	var last rune
+	for _, r := range strings.ToLower(word) {
+		if mapped, ok := leet[r]; ok {
+			r = mapped
+		}
+		if r == last {
+			continue
+		}
+		b.WriteRune(r)
+		last = r
+	}
+	return b.String()
+}
+
+// isWordRune reports whether r can be part of a word, including the look-alikes in leet
+func isWordRune(r rune) bool {
+	if unicode.IsLetter(r) || unicode.IsDigit(r) {
+		return true
+	}
+	_, ok := leet[r]
+	return ok
+}
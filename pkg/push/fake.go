@@ -0,0 +1,70 @@
+package push
+
+import (
+	"context"
+	"sync"
+)
+
+// SentMessage is a message recorded by FakeNotifier
+type SentMessage struct {
+	Token   string
+	Message Message
+}
+
+// FakeNotifier is an in-memory Notifier for tests and local development
+type FakeNotifier struct {
+	mu           sync.Mutex
+	sent         []SentMessage
+	unregistered map[string]bool
+	err          error
+}
+
+// NewFakeNotifier creates a new in-memory notifier
+func NewFakeNotifier() *FakeNotifier {
+	return &FakeNotifier{unregistered: make(map[string]bool)}
+}
+
+// Send records the message, or returns ErrUnregistered for tokens marked via Unregister
+func (f *FakeNotifier) Send(ctx context.Context, token string, msg Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.unregistered[token] {
+		return ErrUnregistered
+	}
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, SentMessage{Token: token, Message: msg})
+	return nil
+}
+
+// Unregister makes subsequent sends to token fail with ErrUnregistered
+func (f *FakeNotifier) Unregister(token string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.unregistered[token] = true
+}
+
+// FailWith makes subsequent sends fail with err (nil to reset)
+func (f *FakeNotifier) FailWith(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+// Sent returns a copy of all recorded messages
+func (f *FakeNotifier) Sent() []SentMessage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]SentMessage(nil), f.sent...)
+}
+
+// Reset clears recorded messages and unregistered tokens
+func (f *FakeNotifier) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = nil
+	f.unregistered = make(map[string]bool)
+	f.err = nil
+}
@@ -0,0 +1,244 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/httpclient"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	fcmScope          = "https://www.googleapis.com/auth/firebase.messaging"
+	fcmSendURLFormat  = "https://fcm.googleapis.com/v1/projects/%s/messages:send"
+	defaultTokenURI   = "https://oauth2.googleapis.com/token"
+	accessTokenLeeway = 1 * time.Minute
+)
+
+// serviceAccount holds the fields we need from a Google service account JSON key
+type serviceAccount struct {
+	ProjectID   string `json:"project_id"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// FCMNotifier sends push notifications through the FCM HTTP v1 API
+type FCMNotifier struct {
+	projectID   string
+	clientEmail string
+	tokenURI    string
+	privateKey  *rsa.PrivateKey
+	sendURL     string
+	httpClient  *httpclient.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// NewFCMNotifierFromFile creates an FCM notifier from a service account JSON key file
+func NewFCMNotifierFromFile(credentialsFile string) (*FCMNotifier, error) {
+	data, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read FCM credentials: %w", err)
+	}
+	return NewFCMNotifier(data)
+}
+
+// NewFCMNotifier creates an FCM notifier from service account JSON key content
+func NewFCMNotifier(credentialsJSON []byte) (*FCMNotifier, error) {
+	var sa serviceAccount
+	if err := json.Unmarshal(credentialsJSON, &sa); err != nil {
+		return nil, fmt.Errorf("invalid FCM credentials: %w", err)
+	}
+	if sa.ProjectID == "" || sa.ClientEmail == "" || sa.PrivateKey == "" {
+		return nil, fmt.Errorf("invalid FCM credentials: project_id, client_email and private_key are required")
+	}
+
+	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(sa.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid FCM private key: %w", err)
+	}
+
+	tokenURI := sa.TokenURI
+	if tokenURI == "" {
+		tokenURI = defaultTokenURI
+	}
+
+	return &FCMNotifier{
+		projectID:   sa.ProjectID,
+		clientEmail: sa.ClientEmail,
+		tokenURI:    tokenURI,
+		privateKey:  privateKey,
+		sendURL:     fmt.Sprintf(fcmSendURLFormat, sa.ProjectID),
+		httpClient:  httpclient.New(httpclient.DefaultConfig("fcm")),
+	}, nil
+}
+
+type fcmRequest struct {
+	Message fcmMessage `json:"message"`
+}
+
+type fcmMessage struct {
+	Token        string            `json:"token"`
+	Notification fcmNotification   `json:"notification"`
+	Data         map[string]string `json:"data,omitempty"`
+	Android      fcmAndroid        `json:"android"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+type fcmAndroid struct {
+	Priority string `json:"priority"`
+}
+
+type fcmErrorResponse struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+		Details []struct {
+			Type      string `json:"@type"`
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// Send delivers msg to a single device token
+func (n *FCMNotifier) Send(ctx context.Context, token string, msg Message) error {
+	accessToken, err := n.getAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(fcmRequest{
+		Message: fcmMessage{
+			Token:        token,
+			Notification: fcmNotification{Title: msg.Title, Body: msg.Body},
+			Data:         msg.Data,
+			Android:      fcmAndroid{Priority: "high"},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode FCM message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.sendURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create FCM request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send FCM message: %w", err)
+	}
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var fcmErr fcmErrorResponse
+	_ = json.Unmarshal(resp.Body, &fcmErr)
+
+	if isUnregistered(resp.StatusCode, &fcmErr) {
+		return ErrUnregistered
+	}
+
+	// Force a fresh access token on the next send
+	if resp.StatusCode == http.StatusUnauthorized {
+		n.mu.Lock()
+		n.accessToken = ""
+		n.mu.Unlock()
+	}
+
+	logger.Log.Error().
+		Int("status", resp.StatusCode).
+		Str("fcm_status", fcmErr.Error.Status).
+		Str("fcm_message", fcmErr.Error.Message).
+		Msg("FCM API error")
+	return fmt.Errorf("FCM API error: status %d, %s", resp.StatusCode, fcmErr.Error.Status)
+}
+
+// isUnregistered reports whether the FCM error means the token must be discarded
+func isUnregistered(statusCode int, fcmErr *fcmErrorResponse) bool {
+	for _, detail := range fcmErr.Error.Details {
+		if detail.ErrorCode == "UNREGISTERED" {
+			return true
+		}
+		// INVALID_ARGUMENT on the token field means the token is malformed
+		if detail.ErrorCode == "INVALID_ARGUMENT" && strings.Contains(fcmErr.Error.Message, "registration token") {
+			return true
+		}
+	}
+	return statusCode == http.StatusNotFound
+}
+
+// getAccessToken returns a cached OAuth2 access token, refreshing it via the JWT bearer flow
+func (n *FCMNotifier) getAccessToken(ctx context.Context) (string, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.accessToken != "" && time.Now().Add(accessTokenLeeway).Before(n.expiresAt) {
+		return n.accessToken, nil
+	}
+
+	now := time.Now()
+	assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   n.clientEmail,
+		"scope": fcmScope,
+		"aud":   n.tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}).SignedString(n.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign FCM assertion: %w", err)
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	form.Set("assertion", assertion)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	// Fetching a token twice is harmless, so the request may be retried
+	resp, err := n.httpClient.Do(httpclient.Idempotent(req))
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch FCM access token: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("FCM token endpoint error: status %d, response: %s", resp.StatusCode, n.httpClient.Redact(resp.Body))
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.Unmarshal(resp.Body, &tokenResp); err != nil {
+		return "", fmt.Errorf("failed to decode FCM access token: %w", err)
+	}
+
+	n.accessToken = tokenResp.AccessToken
+	n.expiresAt = now.Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+
+	return n.accessToken, nil
+}
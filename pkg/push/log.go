@@ -0,0 +1,35 @@
+package push
+
+import (
+	"context"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+)
+
+// LogNotifier writes push messages to the application log.
+// Used in development when FCM credentials are not configured.
+type LogNotifier struct{}
+
+// NewLogNotifier creates a new log-only notifier
+func NewLogNotifier() Notifier {
+	return &LogNotifier{}
+}
+
+// Send logs the message
+func (n *LogNotifier) Send(ctx context.Context, token string, msg Message) error {
+	logger.Log.Info().
+		Str("token", maskToken(token)).
+		Str("title", msg.Title).
+		Str("body", msg.Body).
+		Interface("data", msg.Data).
+		Msg("Push notification (log notifier)")
+	return nil
+}
+
+// maskToken keeps only the tail of a device token for logging
+func maskToken(token string) string {
+	if len(token) <= 8 {
+		return "****"
+	}
+	return "****" + token[len(token)-8:]
+}
@@ -0,0 +1,22 @@
+package push
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrUnregistered is returned by a Notifier when the device token is no longer valid
+// (app uninstalled, token rotated). Callers should delete the token.
+var ErrUnregistered = errors.New("push token is unregistered")
+
+// Message represents a push notification payload
+type Message struct {
+	Title string            `json:"title"`
+	Body  string            `json:"body"`
+	Data  map[string]string `json:"data,omitempty"`
+}
+
+// Notifier delivers a push message to a single device token
+type Notifier interface {
+	Send(ctx context.Context, token string, msg Message) error
+}
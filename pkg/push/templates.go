@@ -0,0 +1,81 @@
+package push
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/i18n"
+)
+
+// TemplateID identifies a push message template
+type TemplateID string
+
+// Order event templates
+const (
+	TemplateOrderAccepted  TemplateID = "ORDER_ACCEPTED"
+	TemplateDriverArrived  TemplateID = "DRIVER_ARRIVED"
+	TemplateTripStarted    TemplateID = "TRIP_STARTED"
+	TemplateTripCompleted  TemplateID = "TRIP_COMPLETED"
+	TemplateOrderCancelled TemplateID = "ORDER_CANCELLED"
+	TemplateOrderExpired   TemplateID = "ORDER_EXPIRED"
+	TemplateNewOrderOffer  TemplateID = "NEW_ORDER_OFFER"
+	TemplateDriverVerified TemplateID = "DRIVER_VERIFIED"
+	TemplateDriverRejected TemplateID = "DRIVER_REJECTED"
+	TemplatePromotion      TemplateID = "PROMOTION"
+	TemplateWalletToppedUp TemplateID = "WALLET_TOPPED_UP"
+
+	TemplateDocumentExpiring TemplateID = "DOCUMENT_EXPIRING"
+	TemplateDocumentExpired  TemplateID = "DOCUMENT_EXPIRED"
+
+	TemplateScheduledRideConfirmed  TemplateID = "SCHEDULED_RIDE_CONFIRMED"
+	TemplateScheduledDriverWithdrew TemplateID = "SCHEDULED_DRIVER_WITHDREW"
+	TemplateScheduledRideReminder   TemplateID = "SCHEDULED_RIDE_REMINDER"
+	TemplateScheduledJobReminder    TemplateID = "SCHEDULED_JOB_REMINDER"
+	TemplateScheduledJobStarting    TemplateID = "SCHEDULED_JOB_STARTING"
+	TemplateScheduledJobReleased    TemplateID = "SCHEDULED_JOB_RELEASED"
+
+	TemplateDriverCancelled  TemplateID = "DRIVER_CANCELLED"
+	TemplatePassengerNoShow  TemplateID = "PASSENGER_NO_SHOW"
+	TemplateOrderingCooldown TemplateID = "ORDERING_COOLDOWN"
+	TemplateDrivingCooldown  TemplateID = "DRIVING_COOLDOWN"
+)
+
+// Safety templates. SOS alerts go over WhatsApp to trusted contacts and campus security,
+// shared trips to trusted contacts only.
+const (
+	TemplateSOSAlert   TemplateID = "SOS_ALERT"
+	TemplateTripShared TemplateID = "TRIP_SHARED"
+
+	TemplateSOSAcknowledged TemplateID = "SOS_ACKNOWLEDGED" // push to the reporter
+)
+
+// catalogKey returns the i18n key prefix of a template, e.g. "push.order_accepted"
+func catalogKey(id TemplateID) string {
+	return "push." + strings.ToLower(string(id))
+}
+
+// HasTemplate reports whether a template exists in the message catalog
+func HasTemplate(id TemplateID) bool {
+	return i18n.Has(catalogKey(id) + ".title")
+}
+
+// Render builds a localized Message from a template and variables.
+// Variables are also copied into Message.Data along with the template id for client-side routing.
+func Render(locale i18n.Locale, id TemplateID, vars map[string]string) (Message, error) {
+	if !HasTemplate(id) {
+		return Message{}, fmt.Errorf("unknown push template: %s", id)
+	}
+
+	data := make(map[string]string, len(vars)+1)
+	for key, value := range vars {
+		data[key] = value
+	}
+	data["type"] = string(id)
+
+	key := catalogKey(id)
+	return Message{
+		Title: i18n.T(locale, key+".title", vars),
+		Body:  i18n.T(locale, key+".body", vars),
+		Data:  data,
+	}, nil
+}
@@ -0,0 +1,14 @@
+package realtime
+
+// cursor remembers the last event id sent per topic. A stream subscribes before it
+// replays, so live events that the replay already covered are skipped with it.
+type cursor map[string]int64
+
+// advance records the event and reports whether it is new for the stream
+func (c cursor) advance(event *Event) bool {
+	if event.ID <= c[event.Topic] {
+		return false
+	}
+	c[event.Topic] = event.ID
+	return true
+}
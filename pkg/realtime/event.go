@@ -0,0 +1,65 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+)
+
+// Topic kinds
+const (
+	TopicUser  = "user"
+	TopicOrder = "order"
+)
+
+// Event is a message delivered to the subscribers of a topic.
+// IDs increase monotonically, so clients resume by sending the last id they saw.
+type Event struct {
+	ID    int64           `json:"id"`
+	Topic string          `json:"topic"`
+	Type  string          `json:"type"`
+	Data  json.RawMessage `json:"data,omitempty"`
+}
+
+// Publisher publishes events. Pass a pgx.Tx as db to publish only if the transaction commits.
+type Publisher interface {
+	Publish(ctx context.Context, db database.DBTX, topic, eventType string, data any) error
+}
+
+// Broker publishes events to every replica and delivers them to local subscribers
+type Broker interface {
+	Publisher
+	Subscribe(topics ...string) *Subscription
+	Replay(ctx context.Context, topics []string, afterID int64, limit int) ([]*Event, error)
+}
+
+// UserTopic is the private topic of a user (offers, their orders' status)
+func UserTopic(userID int) string {
+	return TopicUser + ":" + strconv.Itoa(userID)
+}
+
+// OrderTopic is the topic of an order (status changes, driver location)
+func OrderTopic(orderID int) string {
+	return TopicOrder + ":" + strconv.Itoa(orderID)
+}
+
+// ParseTopic splits "kind:id"
+func ParseTopic(topic string) (kind string, id int, err error) {
+	kind, idStr, found := strings.Cut(topic, ":")
+	if !found {
+		return "", 0, fmt.Errorf("invalid topic %q", topic)
+	}
+	id, err = strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		return "", 0, fmt.Errorf("invalid topic %q", topic)
+	}
+	switch kind {
+	case TopicUser, TopicOrder:
+		return kind, id, nil
+	}
+	return "", 0, fmt.Errorf("unknown topic kind %q", kind)
+}
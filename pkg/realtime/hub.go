@@ -0,0 +1,132 @@
+package realtime
+
+import "sync"
+
+// DefaultSubscriptionBuffer is the number of undelivered events a subscriber may queue
+const DefaultSubscriptionBuffer = 64
+
+// Hub fans events out to the subscribers of this process
+type Hub struct {
+	mu     sync.Mutex
+	topics map[string]map[*Subscription]struct{}
+}
+
+// NewHub creates an empty hub
+func NewHub() *Hub {
+	return &Hub{topics: make(map[string]map[*Subscription]struct{})}
+}
+
+// Subscribe creates a subscription to the given topics
+func (h *Hub) Subscribe(topics ...string) *Subscription {
+	sub := &Subscription{
+		hub:    h,
+		topics: make(map[string]bool),
+		events: make(chan *Event, DefaultSubscriptionBuffer),
+	}
+	for _, topic := range topics {
+		sub.Add(topic)
+	}
+	return sub
+}
+
+// Broadcast delivers an event to every subscriber of its topic. A subscriber whose buffer
+// is full is closed instead of blocking the others; it is expected to reconnect and resume.
+func (h *Hub) Broadcast(event *Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.topics[event.Topic] {
+		select {
+		case sub.events <- event:
+		default:
+			sub.overflowed = true
+			h.closeLocked(sub)
+		}
+	}
+}
+
+func (h *Hub) closeLocked(sub *Subscription) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	for topic := range sub.topics {
+		h.removeLocked(topic, sub)
+	}
+	close(sub.events)
+}
+
+func (h *Hub) removeLocked(topic string, sub *Subscription) {
+	subs := h.topics[topic]
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(h.topics, topic)
+	}
+}
+
+// Subscription receives the events of its topics on Events
+type Subscription struct {
+	hub        *Hub
+	topics     map[string]bool
+	events     chan *Event
+	closed     bool
+	overflowed bool
+}
+
+// Events is closed when the subscription is closed or overflowed
+func (s *Subscription) Events() <-chan *Event {
+	return s.events
+}
+
+// Add subscribes to another topic
+func (s *Subscription) Add(topic string) {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	if s.closed || s.topics[topic] {
+		return
+	}
+	s.topics[topic] = true
+	if s.hub.topics[topic] == nil {
+		s.hub.topics[topic] = make(map[*Subscription]struct{})
+	}
+	s.hub.topics[topic][s] = struct{}{}
+}
+
+// Remove unsubscribes from a topic
+func (s *Subscription) Remove(topic string) {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	if !s.topics[topic] {
+		return
+	}
+	delete(s.topics, topic)
+	s.hub.removeLocked(topic, s)
+}
+
+// Topics returns the subscribed topics
+func (s *Subscription) Topics() []string {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	topics := make([]string, 0, len(s.topics))
+	for topic := range s.topics {
+		topics = append(topics, topic)
+	}
+	return topics
+}
+
+// Overflowed reports whether the subscription was dropped for falling behind
+func (s *Subscription) Overflowed() bool {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	return s.overflowed
+}
+
+// Close stops delivery and closes Events
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.closeLocked(s)
+}
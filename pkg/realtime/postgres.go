@@ -0,0 +1,259 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/database"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// notifyChannel is the LISTEN/NOTIFY channel shared by all replicas
+	notifyChannel = "realtime_events"
+
+	// maxNotifyData keeps NOTIFY payloads under Postgres' 8000 byte limit;
+	// larger events are sent by id and loaded from realtime_events by each replica
+	maxNotifyData = 7000
+
+	reconnectDelay = 2 * time.Second
+	catchUpBatch   = 500
+	pruneInterval  = 10 * time.Minute
+)
+
+// PostgresBroker stores every event in realtime_events (for resume after reconnect) and
+// fans it out with NOTIFY. Each replica LISTENs and broadcasts to its own Hub, so a client
+// connected to any replica receives events published by any other.
+type PostgresBroker struct {
+	db        *pgxpool.Pool
+	hub       *Hub
+	retention time.Duration
+	maxEvents int64
+
+	lastID int64 // highest event id broadcast, owned by the listen loop
+
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+}
+
+// NewPostgresBroker creates a broker. Events older than retention are pruned, and the
+// log is capped at maxEvents rows so a burst cannot grow it without bound (0 = no cap).
+func NewPostgresBroker(db *pgxpool.Pool, hub *Hub, retention time.Duration, maxEvents int64) *PostgresBroker {
+	return &PostgresBroker{
+		db:        db,
+		hub:       hub,
+		retention: retention,
+		maxEvents: maxEvents,
+		lastID:    -1,
+	}
+}
+
+// Publish stores the event and notifies all replicas. Inside a transaction the
+// notification is only delivered on commit.
+func (b *PostgresBroker) Publish(ctx context.Context, db database.DBTX, topic, eventType string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode realtime event: %w", err)
+	}
+
+	event := &Event{Topic: topic, Type: eventType, Data: payload}
+	query := `INSERT INTO realtime_events (topic, event_type, payload) VALUES ($1, $2, $3) RETURNING id`
+	if err := db.QueryRow(ctx, query, topic, eventType, payload).Scan(&event.ID); err != nil {
+		return fmt.Errorf("failed to store realtime event %s: %w", eventType, err)
+	}
+
+	if len(payload) > maxNotifyData {
+		event.Data = nil
+	}
+	notification, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if _, err := db.Exec(ctx, `SELECT pg_notify($1, $2)`, notifyChannel, string(notification)); err != nil {
+		return fmt.Errorf("failed to notify realtime event %s: %w", eventType, err)
+	}
+	return nil
+}
+
+// Subscribe subscribes to live events of this replica's hub
+func (b *PostgresBroker) Subscribe(topics ...string) *Subscription {
+	return b.hub.Subscribe(topics...)
+}
+
+// Replay returns stored events of the topics with an id greater than afterID, oldest first
+func (b *PostgresBroker) Replay(ctx context.Context, topics []string, afterID int64, limit int) ([]*Event, error) {
+	query := `
+		SELECT id, topic, event_type, payload
+		FROM realtime_events
+		WHERE topic = ANY($1) AND id > $2
+		ORDER BY id
+		LIMIT $3
+	`
+	return b.queryEvents(ctx, query, topics, afterID, limit)
+}
+
+// Start launches the LISTEN loop and the retention pruning
+func (b *PostgresBroker) Start(ctx context.Context) {
+	ctx, b.cancel = context.WithCancel(ctx)
+
+	b.wg.Add(2)
+	go b.listen(ctx)
+	go b.prune(ctx)
+
+	logger.Log.Info().Msg("Realtime broker started")
+}
+
+// Stop stops listening
+func (b *PostgresBroker) Stop() {
+	if b.cancel != nil {
+		b.cancel()
+	}
+	b.wg.Wait()
+	logger.Log.Info().Msg("Realtime broker stopped")
+}
+
+func (b *PostgresBroker) listen(ctx context.Context) {
+	defer b.wg.Done()
+
+	for {
+		err := b.listenOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Log.Error().Err(err).Msg("Realtime listener disconnected, reconnecting")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// listenOnce holds a dedicated connection for LISTEN until it fails
+func (b *PostgresBroker) listenOnce(ctx context.Context) error {
+	pooled, err := b.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// The connection keeps LISTEN state, so it must not go back to the pool
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+notifyChannel); err != nil {
+		return err
+	}
+
+	// Broadcast whatever was published while this replica was not listening
+	if err := b.catchUp(ctx); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var event Event
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			logger.Log.Warn().Err(err).Msg("Ignoring malformed realtime notification")
+			continue
+		}
+		if event.Data == nil {
+			if err := b.loadData(ctx, &event); err != nil {
+				logger.Log.Error().Err(err).Int64("event_id", event.ID).Msg("Failed to load realtime event")
+				continue
+			}
+		}
+		b.broadcast(&event)
+	}
+}
+
+func (b *PostgresBroker) catchUp(ctx context.Context) error {
+	if b.lastID < 0 {
+		return b.db.QueryRow(ctx, `SELECT COALESCE(MAX(id), 0) FROM realtime_events`).Scan(&b.lastID)
+	}
+
+	for {
+		query := `SELECT id, topic, event_type, payload FROM realtime_events WHERE id > $1 ORDER BY id LIMIT $2`
+		events, err := b.queryEvents(ctx, query, b.lastID, catchUpBatch)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			b.broadcast(event)
+		}
+		if len(events) < catchUpBatch {
+			return nil
+		}
+	}
+}
+
+func (b *PostgresBroker) broadcast(event *Event) {
+	b.hub.Broadcast(event)
+	if event.ID > b.lastID {
+		b.lastID = event.ID
+	}
+}
+
+func (b *PostgresBroker) loadData(ctx context.Context, event *Event) error {
+	return b.db.QueryRow(ctx, `SELECT payload FROM realtime_events WHERE id = $1`, event.ID).Scan(&event.Data)
+}
+
+func (b *PostgresBroker) prune(ctx context.Context) {
+	defer b.wg.Done()
+
+	if b.retention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := b.pruneOnce(ctx); err != nil && ctx.Err() == nil {
+				logger.Log.Error().Err(err).Msg("Failed to prune realtime events")
+			}
+		}
+	}
+}
+
+func (b *PostgresBroker) pruneOnce(ctx context.Context) error {
+	cutoff := time.Now().Add(-b.retention)
+	if _, err := b.db.Exec(ctx, `DELETE FROM realtime_events WHERE created_at < $1`, cutoff); err != nil {
+		return err
+	}
+	if b.maxEvents <= 0 {
+		return nil
+	}
+	// Ids are sequential, so keeping the newest maxEvents is a range delete
+	_, err := b.db.Exec(ctx, `DELETE FROM realtime_events WHERE id <= (SELECT MAX(id) FROM realtime_events) - $1`, b.maxEvents)
+	return err
+}
+
+func (b *PostgresBroker) queryEvents(ctx context.Context, query string, args ...any) ([]*Event, error) {
+	rows, err := b.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*Event{}
+	for rows.Next() {
+		var event Event
+		if err := rows.Scan(&event.ID, &event.Topic, &event.Type, &event.Data); err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+	return events, rows.Err()
+}
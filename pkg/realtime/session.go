@@ -0,0 +1,260 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+	"github.com/gorilla/websocket"
+)
+
+// Client message types
+const (
+	MessageSubscribe   = "subscribe"
+	MessageUnsubscribe = "unsubscribe"
+	MessagePing        = "ping"
+)
+
+// Server control message types (event messages carry the event's own dotted type)
+const (
+	MessageSubscribed   = "subscribed"
+	MessageUnsubscribed = "unsubscribed"
+	MessageRevoked      = "revoked" // the client lost access to a topic and was unsubscribed
+	MessagePong         = "pong"
+	MessageError        = "error"
+)
+
+// ClientMessage is a command sent by the client
+type ClientMessage struct {
+	Type        string `json:"type"`
+	Topic       string `json:"topic,omitempty"`
+	LastEventID int64  `json:"last_event_id,omitempty"` // replay missed events of the topic
+}
+
+// ControlMessage is a non-event message sent to the client
+type ControlMessage struct {
+	Type    string `json:"type"`
+	Topic   string `json:"topic,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// SessionConfig configures a WebSocket session
+type SessionConfig struct {
+	Topics      []string // subscribed on connect without authorization
+	LastEventID int64    // replay events of Topics after this id
+	// Authorize checks a topic the client asks to subscribe to
+	Authorize func(ctx context.Context, topic string) error
+	// RecheckOn lists event types after which Authorize runs again for the event's topic
+	// (e.g. a driver being taken off an order); clients that fail are unsubscribed
+	RecheckOn []string
+	// DescribeError turns an Authorize error into a code and a client-facing message
+	DescribeError func(err error) (code, message string)
+
+	PingInterval time.Duration
+	PongWait     time.Duration
+	WriteWait    time.Duration
+	ReplayLimit  int
+}
+
+func (c *SessionConfig) setDefaults() {
+	if c.PingInterval <= 0 {
+		c.PingInterval = 25 * time.Second
+	}
+	if c.PongWait <= c.PingInterval {
+		c.PongWait = c.PingInterval * 2
+	}
+	if c.WriteWait <= 0 {
+		c.WriteWait = 10 * time.Second
+	}
+	if c.ReplayLimit <= 0 {
+		c.ReplayLimit = 500
+	}
+	if c.DescribeError == nil {
+		c.DescribeError = func(err error) (string, string) { return "FORBIDDEN", err.Error() }
+	}
+}
+
+// session owns one connection. The reader goroutine only parses client messages;
+// everything that writes (events, replays, control replies, pings) happens in Serve's loop.
+type session struct {
+	ctx    context.Context
+	conn   *websocket.Conn
+	broker Broker
+	cfg    SessionConfig
+	sub    *Subscription
+	sent   cursor
+}
+
+// Serve runs a WebSocket session until the client disconnects or ctx is done
+func Serve(ctx context.Context, conn *websocket.Conn, broker Broker, cfg SessionConfig) {
+	cfg.setDefaults()
+	defer conn.Close()
+
+	s := &session{
+		ctx:    ctx,
+		conn:   conn,
+		broker: broker,
+		cfg:    cfg,
+		sub:    broker.Subscribe(cfg.Topics...),
+		sent:   make(cursor),
+	}
+	defer s.sub.Close()
+
+	if cfg.LastEventID > 0 {
+		if err := s.replay(cfg.Topics, cfg.LastEventID); err != nil {
+			return
+		}
+	}
+
+	messages := make(chan ClientMessage)
+	readDone := make(chan struct{})
+	go s.read(messages, readDone)
+
+	ticker := time.NewTicker(cfg.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			_ = s.conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"),
+				time.Now().Add(cfg.WriteWait))
+			return
+		case <-readDone:
+			return
+		case event, ok := <-s.sub.Events():
+			if !ok {
+				// Fell behind: the client reconnects with its last event id and resumes
+				_ = s.conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "subscriber too slow"),
+					time.Now().Add(cfg.WriteWait))
+				return
+			}
+			if !s.sent.advance(event) {
+				continue
+			}
+			if authErr := recheck(s.ctx, s.cfg.Authorize, s.cfg.RecheckOn, s.cfg.Topics, event); authErr != nil {
+				s.sub.Remove(event.Topic)
+				code, message := s.cfg.DescribeError(authErr)
+				err = s.write(ControlMessage{Type: MessageRevoked, Topic: event.Topic, Code: code, Message: message})
+			} else {
+				err = s.write(event)
+			}
+		case msg := <-messages:
+			err = s.handle(msg)
+		case <-ticker.C:
+			s.conn.SetWriteDeadline(time.Now().Add(cfg.WriteWait))
+			err = s.conn.WriteMessage(websocket.PingMessage, nil)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (s *session) read(messages chan<- ClientMessage, done chan<- struct{}) {
+	defer close(done)
+
+	s.conn.SetReadLimit(4096)
+	s.conn.SetReadDeadline(time.Now().Add(s.cfg.PongWait))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(s.cfg.PongWait))
+	})
+
+	for {
+		var msg ClientMessage
+		if err := s.conn.ReadJSON(&msg); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				logger.Log.Debug().Err(err).Msg("WebSocket read failed")
+			}
+			return
+		}
+		// Any client message proves the connection is alive
+		s.conn.SetReadDeadline(time.Now().Add(s.cfg.PongWait))
+
+		select {
+		case messages <- msg:
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *session) handle(msg ClientMessage) error {
+	switch msg.Type {
+	case MessagePing:
+		return s.write(ControlMessage{Type: MessagePong})
+
+	case MessageSubscribe:
+		if s.cfg.Authorize != nil {
+			if err := s.cfg.Authorize(s.ctx, msg.Topic); err != nil {
+				code, message := s.cfg.DescribeError(err)
+				return s.write(ControlMessage{Type: MessageError, Topic: msg.Topic, Code: code, Message: message})
+			}
+		}
+		s.sub.Add(msg.Topic)
+		if err := s.write(ControlMessage{Type: MessageSubscribed, Topic: msg.Topic}); err != nil {
+			return err
+		}
+		if msg.LastEventID > 0 {
+			return s.replay([]string{msg.Topic}, msg.LastEventID)
+		}
+		return nil
+
+	case MessageUnsubscribe:
+		s.sub.Remove(msg.Topic)
+		return s.write(ControlMessage{Type: MessageUnsubscribed, Topic: msg.Topic})
+	}
+
+	return s.write(ControlMessage{Type: MessageError, Code: "UNKNOWN_MESSAGE", Message: "unknown message type: " + msg.Type})
+}
+
+// recheck re-runs authorize for the event's topic when the event type is one that can
+// change who may see the topic. Topics the session was opened with are never rechecked.
+func recheck(ctx context.Context, authorize func(context.Context, string) error, recheckOn, fixed []string, event *Event) error {
+	if authorize == nil || !slices.Contains(recheckOn, event.Type) || slices.Contains(fixed, event.Topic) {
+		return nil
+	}
+	err := authorize(ctx, event.Topic)
+	if err != nil {
+		logger.Log.Info().Err(err).Str("topic", event.Topic).Msg("Realtime subscriber lost access to topic")
+	}
+	return err
+}
+
+// replay sends stored events after lastEventID. The subscription is already active,
+// so events published meanwhile are queued and deduplicated by the cursor.
+func (s *session) replay(topics []string, lastEventID int64) error {
+	if len(topics) == 0 {
+		return nil
+	}
+
+	events, err := s.broker.Replay(s.ctx, topics, lastEventID, s.cfg.ReplayLimit)
+	if err != nil {
+		logger.Log.Error().Err(err).Msg("Failed to replay realtime events")
+		return s.write(ControlMessage{Type: MessageError, Code: "REPLAY_FAILED", Message: "failed to replay missed events"})
+	}
+
+	for _, event := range events {
+		if !s.sent.advance(event) {
+			continue
+		}
+		if err := s.write(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *session) write(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	s.conn.SetWriteDeadline(time.Now().Add(s.cfg.WriteWait))
+	return s.conn.WriteMessage(websocket.TextMessage, data)
+}
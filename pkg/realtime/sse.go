@@ -0,0 +1,124 @@
+package realtime
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+)
+
+// StreamConfig configures a Server-Sent Events stream
+type StreamConfig struct {
+	Topics      []string // already authorized by the caller
+	LastEventID int64    // replay events of Topics after this id
+	// Authorize re-checks a topic after events of the RecheckOn types; the stream ends
+	// when it fails, and the client's reconnect is then refused by the caller
+	Authorize func(ctx context.Context, topic string) error
+	RecheckOn []string
+
+	KeepAlive   time.Duration // interval of comment lines that keep proxies from closing the stream
+	Retry       time.Duration // reconnect delay suggested to EventSource clients
+	ReplayLimit int
+}
+
+func (c *StreamConfig) setDefaults() {
+	if c.KeepAlive <= 0 {
+		c.KeepAlive = 15 * time.Second
+	}
+	if c.Retry <= 0 {
+		c.Retry = 3 * time.Second
+	}
+	if c.ReplayLimit <= 0 {
+		c.ReplayLimit = 500
+	}
+}
+
+// ServeSSE streams events of the topics as text/event-stream until the client
+// disconnects or ctx is done. It reads from the same broker as the WebSocket
+// sessions, so event ids are interchangeable between the two transports.
+func ServeSSE(ctx context.Context, w http.ResponseWriter, broker Broker, cfg StreamConfig) error {
+	cfg.setDefaults()
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return fmt.Errorf("response writer does not support streaming")
+	}
+
+	sub := broker.Subscribe(cfg.Topics...)
+	defer sub.Close()
+
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no") // disable nginx response buffering
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", cfg.Retry.Milliseconds()); err != nil {
+		return nil
+	}
+
+	sent := make(cursor)
+	if cfg.LastEventID > 0 {
+		events, err := broker.Replay(ctx, cfg.Topics, cfg.LastEventID, cfg.ReplayLimit)
+		if err != nil {
+			// Live events still flow; the client sees the gap through the event ids
+			logger.Log.Error().Err(err).Msg("Failed to replay realtime events")
+		}
+		for _, event := range events {
+			if !sent.advance(event) {
+				continue
+			}
+			if err := writeSSEEvent(w, event); err != nil {
+				return nil
+			}
+		}
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(cfg.KeepAlive)
+	defer ticker.Stop()
+
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.Events():
+			if !ok {
+				// Fell behind: ending the stream makes EventSource reconnect with Last-Event-ID
+				return nil
+			}
+			if !sent.advance(event) {
+				continue
+			}
+			if recheck(ctx, cfg.Authorize, cfg.RecheckOn, nil, event) != nil {
+				return nil
+			}
+			err = writeSSEEvent(w, event)
+		case <-ticker.C:
+			_, err = fmt.Fprint(w, ": keep-alive\n\n")
+		}
+		if err != nil {
+			return nil
+		}
+		flusher.Flush()
+	}
+}
+
+// writeSSEEvent writes one event frame. Data is JSON, which may contain newlines
+// only when pretty-printed, so each line gets its own data field to be safe.
+func writeSSEEvent(w http.ResponseWriter, event *Event) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "id: %d\nevent: %s\n", event.ID, event.Type)
+	for _, line := range strings.Split(string(event.Data), "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+
+	_, err := fmt.Fprint(w, b.String())
+	return err
+}
@@ -0,0 +1,115 @@
+// Package schedule holds the rules for rides booked in advance: how far ahead a pickup
+// may be booked, when dispatch and reminders run, and which cancellations are late.
+package schedule
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrPickupTooSoon is returned for a pickup closer than the minimum lead time
+	ErrPickupTooSoon = errors.New("pickup time is too soon")
+	// ErrPickupTooFar is returned for a pickup beyond the maximum lead time
+	ErrPickupTooFar = errors.New("pickup time is too far ahead")
+)
+
+// Policy tunes scheduled rides
+type Policy struct {
+	MinLead      time.Duration // earliest pickup, counted from booking
+	MaxLead      time.Duration // latest pickup, counted from booking
+	DispatchLead time.Duration // dispatch starts this long before pickup
+	ReminderLead time.Duration // both parties are reminded this long before pickup
+	MinGap       time.Duration // a passenger or driver cannot hold two rides closer than this
+	MaxPending   int           // scheduled rides a passenger may hold at once
+
+	FreeCancelLead   time.Duration // passengers cancel without it counting until this long before pickup
+	FreeWithdrawLead time.Duration // pre-accepted drivers withdraw without it counting until this long before pickup
+}
+
+// DefaultPolicy returns the production defaults
+func DefaultPolicy() Policy {
+	return Policy{
+		MinLead:          time.Hour,
+		MaxLead:          7 * 24 * time.Hour,
+		DispatchLead:     15 * time.Minute,
+		ReminderLead:     time.Hour,
+		MinGap:           time.Hour,
+		MaxPending:       3,
+		FreeCancelLead:   time.Hour,
+		FreeWithdrawLead: 2 * time.Hour,
+	}
+}
+
+// WithDefaults fills unset fields from DefaultPolicy. Dispatch must start after booking
+// closes, so DispatchLead is capped below MinLead.
+func (p Policy) WithDefaults() Policy {
+	defaults := DefaultPolicy()
+	if p.MinLead <= 0 {
+		p.MinLead = defaults.MinLead
+	}
+	if p.MaxLead <= p.MinLead {
+		p.MaxLead = max(defaults.MaxLead, p.MinLead)
+	}
+	if p.DispatchLead <= 0 || p.DispatchLead >= p.MinLead {
+		p.DispatchLead = min(defaults.DispatchLead, p.MinLead/2)
+	}
+	if p.ReminderLead <= 0 {
+		p.ReminderLead = defaults.ReminderLead
+	}
+	if p.MinGap <= 0 {
+		p.MinGap = defaults.MinGap
+	}
+	if p.MaxPending <= 0 {
+		p.MaxPending = defaults.MaxPending
+	}
+	if p.FreeCancelLead <= 0 {
+		p.FreeCancelLead = defaults.FreeCancelLead
+	}
+	if p.FreeWithdrawLead <= 0 {
+		p.FreeWithdrawLead = defaults.FreeWithdrawLead
+	}
+	return p
+}
+
+// CheckPickup validates a pickup time booked at now
+func (p Policy) CheckPickup(now, pickupAt time.Time) error {
+	lead := pickupAt.Sub(now)
+	if lead < p.MinLead {
+		return ErrPickupTooSoon
+	}
+	if lead > p.MaxLead {
+		return ErrPickupTooFar
+	}
+	return nil
+}
+
+// DispatchAt is when the ride leaves the schedule and goes to a driver
+func (p Policy) DispatchAt(pickupAt time.Time) time.Time {
+	return pickupAt.Add(-p.DispatchLead)
+}
+
+// ReminderAt is when both parties are reminded of the ride
+func (p Policy) ReminderAt(pickupAt time.Time) time.Time {
+	return pickupAt.Add(-p.ReminderLead)
+}
+
+// Overlaps reports whether two pickups are closer than MinGap
+func (p Policy) Overlaps(a, b time.Time) bool {
+	gap := a.Sub(b)
+	if gap < 0 {
+		gap = -gap
+	}
+	return gap < p.MinGap
+}
+
+// IsLateCancel reports whether a passenger cancelling at now counts against them
+func (p Policy) IsLateCancel(now, pickupAt time.Time) bool {
+	return pickupAt.Sub(now) < p.FreeCancelLead
+}
+
+// IsLateWithdrawal reports whether a driver giving up a pre-accepted ride at now counts
+// against them
+func (p Policy) IsLateWithdrawal(now, pickupAt time.Time) bool {
+	return pickupAt.Sub(now) < p.FreeWithdrawLead
+}
@@ -7,9 +7,11 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
 	"github.com/google/uuid"
@@ -17,7 +19,7 @@ import (
 
 // FileStorage defines interface for file storage operations
 type FileStorage interface {
-	Upload(file *multipart.FileHeader, userID int, docType string) (string, error)
+	Upload(file *multipart.FileHeader, namespace string, ownerID int, docType string) (string, error)
 	Delete(filePath string) error
 	GetFullPath(relativePath string) string
 	FileExists(relativePath string) bool
@@ -35,8 +37,8 @@ func NewLocalStorage(baseDir string) FileStorage {
 	}
 }
 
-// Upload uploads a file to local storage
-func (s *LocalStorage) Upload(file *multipart.FileHeader, userID int, docType string) (string, error) {
+// Upload uploads a file to local storage under {namespace}/{ownerID}/{docType}/
+func (s *LocalStorage) Upload(file *multipart.FileHeader, namespace string, ownerID int, docType string) (string, error) {
 	// Validate file
 	if err := validateFile(file); err != nil {
 		return "", err
@@ -45,14 +47,14 @@ func (s *LocalStorage) Upload(file *multipart.FileHeader, userID int, docType st
 	// Generate secure filename
 	filename := generateSecureFilename(file.Filename)
 
-	// Create directory structure: uploads/drivers/{userID}/{docType}/
-	relativeDir := filepath.Join("drivers", fmt.Sprintf("%d", userID), strings.ToLower(docType))
+	// Create directory structure: uploads/{namespace}/{ownerID}/{docType}/
+	relativeDir := filepath.Join(namespace, fmt.Sprintf("%d", ownerID), strings.ToLower(docType))
 	fullDir := filepath.Join(s.baseDir, relativeDir)
 
 	// Create directory if not exists
 	if err := os.MkdirAll(fullDir, 0755); err != nil {
 		logger.Log.Error().Err(err).Str("dir", fullDir).Msg("Failed to create directory")
-		return "", fmt.Errorf(constants.ErrFailedToUploadFile + ": cannot create directory")
+		return "", apperror.ErrUploadFailed.Wrap(err)
 	}
 
 	// Full file path
@@ -63,7 +65,7 @@ func (s *LocalStorage) Upload(file *multipart.FileHeader, userID int, docType st
 	src, err := file.Open()
 	if err != nil {
 		logger.Log.Error().Err(err).Str("file", file.Filename).Msg("Failed to open uploaded file")
-		return "", fmt.Errorf(constants.ErrFailedToUploadFile + ": cannot open file")
+		return "", apperror.ErrUploadFailed.Wrap(err)
 	}
 	defer src.Close()
 
@@ -71,17 +73,17 @@ func (s *LocalStorage) Upload(file *multipart.FileHeader, userID int, docType st
 	dst, err := os.Create(fullPath)
 	if err != nil {
 		logger.Log.Error().Err(err).Str("path", fullPath).Msg("Failed to create file")
-		return "", fmt.Errorf(constants.ErrFailedToUploadFile + ": cannot create file")
+		return "", apperror.ErrUploadFailed.Wrap(err)
 	}
 	defer dst.Close()
 
 	// Copy file content
 	if _, err := io.Copy(dst, src); err != nil {
 		logger.Log.Error().Err(err).Str("path", fullPath).Msg("Failed to write file")
-		return "", fmt.Errorf(constants.ErrFailedToUploadFile + ": cannot write file")
+		return "", apperror.ErrUploadFailed.Wrap(err)
 	}
 
-	logger.Log.Info().Str("path", relativePath).Int("user_id", userID).Str("doc_type", docType).Msg("File uploaded successfully")
+	logger.Log.Info().Str("path", relativePath).Str("namespace", namespace).Int("owner_id", ownerID).Str("doc_type", docType).Msg("File uploaded successfully")
 
 	// Return relative path (for database storage)
 	return relativePath, nil
@@ -114,15 +116,25 @@ func (s *LocalStorage) FileExists(relativePath string) bool {
 
 // validateFile validates uploaded file
 func validateFile(file *multipart.FileHeader) error {
+	return validateFileType(file, []string{"image/jpeg", "image/png", "application/pdf"}, "JPG, PNG, PDF")
+}
+
+// ValidateImage validates that uploaded file is a JPG or PNG image within size limit
+func ValidateImage(file *multipart.FileHeader) error {
+	return validateFileType(file, []string{"image/jpeg", "image/png"}, "JPG, PNG")
+}
+
+// validateFileType validates file size and MIME type against the allowed list
+func validateFileType(file *multipart.FileHeader, allowedTypes []string, allowedLabel string) error {
 	// Check file size
 	if file.Size > constants.MaxFileSize {
-		return fmt.Errorf(constants.ErrFileTooLarge+" (max %d MB)", constants.MaxFileSize/(1024*1024))
+		return apperror.ErrFileTooLarge.WithVars(map[string]string{"max": strconv.Itoa(constants.MaxFileSize / (1024 * 1024))})
 	}
 
 	// Open file to check MIME type
 	src, err := file.Open()
 	if err != nil {
-		return fmt.Errorf("cannot open file")
+		return apperror.ErrUploadFailed.Wrap(err)
 	}
 	defer src.Close()
 
@@ -130,14 +142,13 @@ func validateFile(file *multipart.FileHeader) error {
 	buffer := make([]byte, 512)
 	_, err = src.Read(buffer)
 	if err != nil && err != io.EOF {
-		return fmt.Errorf("cannot read file")
+		return apperror.ErrUploadFailed.Wrap(err)
 	}
 
 	// Detect MIME type using magic bytes
 	mimeType := http.DetectContentType(buffer)
 
 	// Check if MIME type is allowed
-	allowedTypes := []string{"image/jpeg", "image/png", "application/pdf"}
 	isAllowed := false
 	for _, allowed := range allowedTypes {
 		if mimeType == allowed {
@@ -147,7 +158,7 @@ func validateFile(file *multipart.FileHeader) error {
 	}
 
 	if !isAllowed {
-		return fmt.Errorf(constants.ErrInvalidFileType+": %s (allowed: JPG, PNG, PDF)", mimeType)
+		return apperror.ErrInvalidFileType.WithVars(map[string]string{"type": mimeType, "allowed": allowedLabel})
 	}
 
 	return nil
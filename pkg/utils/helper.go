@@ -1,8 +1,12 @@
 package utils
 
 import (
-	"fmt"
+	"math"
+	"strconv"
 	"strings"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/apperror"
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/constants"
 )
 
 // NormalizePhoneNumber converts Indonesian phone format to E.164
@@ -29,8 +33,8 @@ func NormalizePhoneNumber(phone string) string {
 
 // ValidatePassword checks password requirements
 func ValidatePassword(password string) error {
-	if len(password) < 8 {
-		return fmt.Errorf("password must be at least 8 characters")
+	if len(password) < constants.MinPasswordLength {
+		return apperror.ErrPasswordTooShort.WithVars(map[string]string{"min": strconv.Itoa(constants.MinPasswordLength)})
 	}
 
 	hasLetter := false
@@ -45,8 +49,15 @@ func ValidatePassword(password string) error {
 	}
 
 	if !hasLetter || !hasNumber {
-		return fmt.Errorf("password must contain both letters and numbers")
+		return apperror.ErrPasswordTooWeak
 	}
 
 	return nil
 }
+
+// CalculateFare returns the ride fare in Rupiah for a trip distance,
+// rounded up to constants.FareRounding
+func CalculateFare(distanceKm float64) int {
+	fare := float64(constants.BaseFare) + distanceKm*constants.FarePerKm
+	return int(math.Ceil(fare/constants.FareRounding)) * constants.FareRounding
+}
@@ -1,13 +1,14 @@
 package whatsapp
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"strings"
-	"time"
 
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/httpclient"
 	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
 )
 
@@ -17,7 +18,7 @@ type WhatsAppClient struct {
 	APIToken     string
 	BaseURL      string
 	SenderNumber string
-	httpClient   *http.Client
+	httpClient   *httpclient.Client
 }
 
 // NewWhatsAppClient creates a new WhatsApp client
@@ -27,27 +28,29 @@ func NewWhatsAppClient(instanceID, apiToken, baseURL, senderNumber string) *What
 		APIToken:     apiToken,
 		BaseURL:      baseURL,
 		SenderNumber: senderNumber,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		httpClient:   httpclient.New(httpclient.DefaultConfig("ultramsg")),
 	}
 }
 
-// SendOTP sends OTP code via WhatsApp
-func (w *WhatsAppClient) SendOTP(phoneNumber, otpCode string) error {
-	message := fmt.Sprintf(
-		"🔐 *Ojek Kampus - Kode OTP*\n\n"+
-			"Kode OTP Anda: *%s*\n\n"+
-			"Berlaku selama 5 menit.\n"+
-			"Jangan bagikan kode ini kepada siapapun!",
-		otpCode,
-	)
-
-	return w.SendMessage(phoneNumber, message)
+// SendResponse is Ultramsg's answer to a send request
+type SendResponse struct {
+	ID      string // Ultramsg message id, echoed as the id of the message's webhook events; may be empty
+	Message string
 }
 
-// SendMessage sends a WhatsApp message
-func (w *WhatsAppClient) SendMessage(phoneNumber, message string) error {
+// ultramsgSendResponse is the raw body of a send response. Ultramsg answers 200 even for
+// rejected messages, carrying an error string (or a list of field errors) instead of
+// "sent": "true"; id comes back as a number.
+type ultramsgSendResponse struct {
+	Sent    json.RawMessage `json:"sent"`
+	Message string          `json:"message"`
+	ID      json.RawMessage `json:"id"`
+	Error   json.RawMessage `json:"error"`
+}
+
+// SendMessage sends a WhatsApp message and returns the id Ultramsg assigned to it. Sends
+// are not idempotent, so they are retried only when Ultramsg could not be reached at all.
+func (w *WhatsAppClient) SendMessage(ctx context.Context, phoneNumber, message string) (*SendResponse, error) {
 	// Format phone number (remove leading 0, add 62)
 	formattedPhone := w.formatPhoneNumber(phoneNumber)
 
@@ -61,10 +64,10 @@ func (w *WhatsAppClient) SendMessage(phoneNumber, message string) error {
 	data.Set("body", message)
 
 	// Create request
-	req, err := http.NewRequest("POST", apiURL, strings.NewReader(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, strings.NewReader(data.Encode()))
 	if err != nil {
 		logger.Log.Error().Err(err).Msg("Failed to create WhatsApp request")
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
@@ -73,32 +76,61 @@ func (w *WhatsAppClient) SendMessage(phoneNumber, message string) error {
 	resp, err := w.httpClient.Do(req)
 	if err != nil {
 		logger.Log.Error().Err(err).Msg("Failed to send WhatsApp message")
-		return fmt.Errorf("failed to send message: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		logger.Log.Error().Err(err).Msg("Failed to read WhatsApp response")
-		return fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to send message: %w", err)
 	}
 
 	// Check status code
 	if resp.StatusCode != http.StatusOK {
+		response := w.httpClient.Redact(resp.Body)
 		logger.Log.Error().
 			Int("status", resp.StatusCode).
-			Str("response", string(body)).
+			Str("response", response).
 			Msg("WhatsApp API error")
-		return fmt.Errorf("WhatsApp API error: status %d, response: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("WhatsApp API error: status %d, response: %s", resp.StatusCode, response)
+	}
+
+	result, err := parseSendResponse(resp.Body)
+	if err != nil {
+		logger.Log.Error().
+			Err(err).
+			Str("response", w.httpClient.Redact(resp.Body)).
+			Msg("WhatsApp API rejected message")
+		return nil, err
 	}
 
 	logger.Log.Info().
 		Str("phone", formattedPhone).
-		Str("response", string(body)).
+		Str("message_id", result.ID).
 		Msg("WhatsApp message sent successfully")
 
-	return nil
+	return result, nil
+}
+
+// parseSendResponse decodes a 200 send response, failing unless the message was accepted.
+// An accepted message without an id is not an error: sending it again would duplicate it.
+func parseSendResponse(body []byte) (*SendResponse, error) {
+	var raw ultramsgSendResponse
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("invalid WhatsApp API response: %w", err)
+	}
+	if len(raw.Error) > 0 && string(raw.Error) != "null" {
+		return nil, fmt.Errorf("WhatsApp API error: %s", jsonText(raw.Error))
+	}
+	if jsonText(raw.Sent) != "true" {
+		return nil, fmt.Errorf("WhatsApp API did not accept the message: %s", raw.Message)
+	}
+
+	return &SendResponse{ID: jsonText(raw.ID), Message: raw.Message}, nil
+}
+
+// jsonText renders a JSON string, number or boolean as plain text; other values are
+// returned as raw JSON
+func jsonText(raw json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+	return strings.TrimSpace(string(raw))
 }
 
 // formatPhoneNumber converts Indonesian phone format to international format
@@ -0,0 +1,144 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AnggaKay/ojek-kampus-backend/pkg/logger"
+)
+
+// fakeOutboxSize bounds the messages the fake provider keeps
+const fakeOutboxSize = 100
+
+// FakeMessage is a message sent through the FakeProvider
+type FakeMessage struct {
+	ID     string    `json:"id"`
+	To     string    `json:"to"`
+	Body   string    `json:"body"`
+	SentAt time.Time `json:"sent_at"`
+}
+
+// fakeWebhook is the webhook body the fake provider accepts: an incoming message, or a
+// delivery update when status is set
+type fakeWebhook struct {
+	ID     string `json:"id"`
+	From   string `json:"from"`
+	Body   string `json:"body"`
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+// FakeProvider is an in-memory Provider for local development. Sent messages are logged
+// and can be read back over HTTP. Webhook events are simulated by posting to the webhook
+// with the token: {"id": "...", "from": "0812...", "body": "..."} for an incoming message,
+// {"id": "fake-1", "status": "DELIVERED"} for a delivery update of a sent message.
+type FakeProvider struct {
+	number       string
+	webhookToken string
+
+	mu     sync.Mutex
+	sent   int
+	outbox []FakeMessage
+}
+
+// NewFakeProvider creates a fake provider answering as number
+func NewFakeProvider(number, webhookToken string) *FakeProvider {
+	return &FakeProvider{
+		number:       number,
+		webhookToken: webhookToken,
+	}
+}
+
+// Name identifies the provider in logs
+func (p *FakeProvider) Name() string {
+	return "fake"
+}
+
+// Number returns the configured sender number
+func (p *FakeProvider) Number() string {
+	return p.number
+}
+
+// Send logs the message and keeps it in the outbox under a fake-<n> id
+func (p *FakeProvider) Send(ctx context.Context, to, body string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.sent++
+	id := fmt.Sprintf("fake-%d", p.sent)
+	logger.Log.Info().Str("id", id).Str("to", to).Str("body", body).Msg("WhatsApp message (fake provider)")
+
+	p.outbox = append(p.outbox, FakeMessage{ID: id, To: to, Body: body, SentAt: time.Now()})
+	if len(p.outbox) > fakeOutboxSize {
+		p.outbox = p.outbox[len(p.outbox)-fakeOutboxSize:]
+	}
+	return id, nil
+}
+
+// ParseWebhook decodes a simulated incoming message or delivery update
+func (p *FakeProvider) ParseWebhook(token string, body []byte) (*WebhookEvent, error) {
+	if err := verifyToken(p.webhookToken, token); err != nil {
+		return nil, err
+	}
+
+	var webhook fakeWebhook
+	if err := json.Unmarshal(body, &webhook); err != nil {
+		return nil, fmt.Errorf("invalid fake webhook: %w", err)
+	}
+	if webhook.ID == "" {
+		return nil, fmt.Errorf("invalid fake webhook: id is required")
+	}
+
+	if webhook.Status != "" {
+		status := DeliveryStatus(strings.ToUpper(webhook.Status))
+		switch status {
+		case StatusSent, StatusDelivered, StatusRead, StatusFailed:
+		default:
+			return nil, fmt.Errorf("invalid fake webhook: unknown status %q", webhook.Status)
+		}
+		return &WebhookEvent{Status: &StatusUpdate{MessageID: webhook.ID, Status: status, Error: webhook.Error}}, nil
+	}
+
+	if webhook.From == "" {
+		return nil, fmt.Errorf("invalid fake webhook: from is required")
+	}
+	return &WebhookEvent{Message: &InboundMessage{
+		ID:   webhook.ID,
+		From: senderNumber(webhook.From),
+		Body: webhook.Body,
+	}}, nil
+}
+
+// Messages returns the sent messages, newest first
+func (p *FakeProvider) Messages() []FakeMessage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	messages := make([]FakeMessage, len(p.outbox))
+	for i, message := range p.outbox {
+		messages[len(p.outbox)-1-i] = message
+	}
+	return messages
+}
+
+// ServeHTTP lists the sent messages as JSON, optionally filtered by ?to=number
+func (p *FakeProvider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	messages := p.Messages()
+	if to := strings.TrimPrefix(strings.TrimSpace(r.URL.Query().Get("to")), "+"); to != "" {
+		filtered := messages[:0]
+		for _, message := range messages {
+			if strings.TrimPrefix(message.To, "+") == to {
+				filtered = append(filtered, message)
+			}
+		}
+		messages = filtered
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"messages": messages})
+}
@@ -0,0 +1,70 @@
+package whatsapp
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+)
+
+// ErrInvalidWebhookToken is returned for inbound webhook calls without the configured token
+var ErrInvalidWebhookToken = errors.New("invalid WhatsApp webhook token")
+
+// Provider sends WhatsApp messages from our sender number and decodes the events it posts
+// to our webhook: messages people send to the number and the delivery status of ours
+type Provider interface {
+	Name() string
+	// Number is the sender number people message to reach us
+	Number() string
+	// Send sends a text message and returns the provider's id for it, empty when the
+	// provider did not report one
+	Send(ctx context.Context, to, body string) (string, error)
+	// ParseWebhook verifies the webhook token and decodes an event. Events other than
+	// incoming text messages and delivery updates decode to nil without an error.
+	ParseWebhook(token string, body []byte) (*WebhookEvent, error)
+}
+
+// WebhookEvent is a decoded webhook call; exactly one of its fields is set
+type WebhookEvent struct {
+	Message *InboundMessage
+	Status  *StatusUpdate
+}
+
+// InboundMessage is a message someone sent to our number
+type InboundMessage struct {
+	ID   string // provider message id, repeated when the provider retries the webhook
+	From string // sender number in 62... form
+	Body string
+}
+
+// DeliveryStatus is how far a sent message got
+type DeliveryStatus string
+
+const (
+	StatusSent      DeliveryStatus = "SENT"      // accepted by the provider
+	StatusDelivered DeliveryStatus = "DELIVERED" // reached the recipient's phone
+	StatusRead      DeliveryStatus = "READ"      // opened by the recipient
+	StatusFailed    DeliveryStatus = "FAILED"    // will not be delivered
+)
+
+// StatusUpdate reports the delivery status of a message we sent
+type StatusUpdate struct {
+	MessageID string // id returned by Send
+	Status    DeliveryStatus
+	Error     string // provider reason, for failures
+}
+
+// verifyToken compares a webhook token with the configured one in constant time; an
+// empty configured token rejects every call
+func verifyToken(expected, got string) error {
+	if expected == "" || subtle.ConstantTimeCompare([]byte(expected), []byte(got)) != 1 {
+		return ErrInvalidWebhookToken
+	}
+	return nil
+}
+
+// senderNumber strips the chat suffix from a WhatsApp id, e.g. 6281234567890@c.us
+func senderNumber(id string) string {
+	number, _, _ := strings.Cut(id, "@")
+	return number
+}
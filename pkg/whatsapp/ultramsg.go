@@ -0,0 +1,120 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Ultramsg webhook events we handle
+const (
+	ultramsgEventMessageReceived = "message_received" // a message sent to our number
+	ultramsgEventMessageAck      = "message_ack"      // a delivery update of a message we sent
+)
+
+// ultramsgAcks maps Ultramsg acknowledgement levels to delivery statuses; "pending" and
+// unknown levels carry no news and are ignored
+var ultramsgAcks = map[string]DeliveryStatus{
+	"server": StatusSent,
+	"device": StatusDelivered,
+	"read":   StatusRead,
+	"played": StatusRead,
+	"failed": StatusFailed,
+	"error":  StatusFailed,
+}
+
+// UltramsgProvider implements Provider with Ultramsg. Ultramsg does not sign webhooks, so
+// the webhook URL configured in the Ultramsg dashboard carries a secret ?token= instead.
+type UltramsgProvider struct {
+	client       *WhatsAppClient
+	webhookToken string
+}
+
+// NewUltramsgProvider creates a provider sending through client
+func NewUltramsgProvider(client *WhatsAppClient, webhookToken string) *UltramsgProvider {
+	return &UltramsgProvider{
+		client:       client,
+		webhookToken: webhookToken,
+	}
+}
+
+// Name identifies the provider in logs
+func (p *UltramsgProvider) Name() string {
+	return "ultramsg"
+}
+
+// Number returns the number of the Ultramsg instance
+func (p *UltramsgProvider) Number() string {
+	return p.client.SenderNumber
+}
+
+// Send sends a text message and returns its Ultramsg id
+func (p *UltramsgProvider) Send(ctx context.Context, to, body string) (string, error) {
+	response, err := p.client.SendMessage(ctx, to, body)
+	if err != nil {
+		return "", err
+	}
+	return response.ID, nil
+}
+
+// ultramsgWebhook is the body of an Ultramsg webhook call. For message_ack events the
+// top-level id is the Ultramsg id returned when the message was sent; data.id is
+// WhatsApp's own id.
+type ultramsgWebhook struct {
+	EventType string          `json:"event_type"`
+	ID        json.RawMessage `json:"id"`
+	Data      struct {
+		ID     string `json:"id"`
+		From   string `json:"from"`
+		Body   string `json:"body"`
+		Type   string `json:"type"`
+		Ack    string `json:"ack"`
+		FromMe bool   `json:"fromMe"`
+	} `json:"data"`
+}
+
+// ParseWebhook decodes message_received and message_ack webhooks. Messages we sent
+// ourselves, group chats and media are ignored.
+func (p *UltramsgProvider) ParseWebhook(token string, body []byte) (*WebhookEvent, error) {
+	if err := verifyToken(p.webhookToken, token); err != nil {
+		return nil, err
+	}
+
+	var webhook ultramsgWebhook
+	if err := json.Unmarshal(body, &webhook); err != nil {
+		return nil, fmt.Errorf("invalid Ultramsg webhook: %w", err)
+	}
+	data := webhook.Data
+
+	switch webhook.EventType {
+	case ultramsgEventMessageReceived:
+		if data.FromMe || data.Type != "chat" || !strings.HasSuffix(data.From, "@c.us") {
+			return nil, nil
+		}
+		if data.ID == "" {
+			return nil, fmt.Errorf("invalid Ultramsg webhook: missing message id")
+		}
+		return &WebhookEvent{Message: &InboundMessage{
+			ID:   data.ID,
+			From: senderNumber(data.From),
+			Body: data.Body,
+		}}, nil
+
+	case ultramsgEventMessageAck:
+		status, ok := ultramsgAcks[data.Ack]
+		if !ok {
+			return nil, nil
+		}
+		id := jsonText(webhook.ID)
+		if id == "" {
+			return nil, fmt.Errorf("invalid Ultramsg webhook: missing message id")
+		}
+		update := &StatusUpdate{MessageID: id, Status: status}
+		if status == StatusFailed {
+			update.Error = "ack " + data.Ack
+		}
+		return &WebhookEvent{Status: update}, nil
+	}
+	return nil, nil
+}